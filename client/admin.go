@@ -0,0 +1,51 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+)
+
+const (
+	routeConfigReload = "admin/config/reload"
+	routeLogLevels    = "admin/log/levels"
+)
+
+// ReloadConfig makes the node read its configuration again and apply the changed reloadable parameters.
+func (api *GoShimmerAPI) ReloadConfig(ctx context.Context) (*jsonmodels.ConfigReloadResponse, error) {
+	res := &jsonmodels.ConfigReloadResponse{}
+	if err := api.do(ctx, http.MethodPost, routeConfigReload, nil, res); err != nil {
+		return nil, err
+	}
+
+	if res.Error != "" {
+		return res, errors.New(res.Error)
+	}
+	return res, nil
+}
+
+// GetLogLevels returns the default log level and the log levels of the plugins that override it.
+func (api *GoShimmerAPI) GetLogLevels(ctx context.Context) (*jsonmodels.LogLevelsResponse, error) {
+	res := &jsonmodels.LogLevelsResponse{}
+	if err := api.do(ctx, http.MethodGet, routeLogLevels, nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SetLogLevel changes the log level of the given plugin. An empty plugin name changes the default log level, an empty
+// level resets the log level of the plugin to the default one.
+func (api *GoShimmerAPI) SetLogLevel(ctx context.Context, pluginName, level string) (*jsonmodels.LogLevelsResponse, error) {
+	res := &jsonmodels.LogLevelsResponse{}
+	if err := api.do(ctx, http.MethodPost, routeLogLevels, &jsonmodels.LogLevelRequest{Plugin: pluginName, Level: level}, res); err != nil {
+		return nil, err
+	}
+
+	if res.Error != "" {
+		return res, errors.New(res.Error)
+	}
+	return res, nil
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -13,9 +14,9 @@ const (
 
 // GetAutopeeringNeighbors gets the chosen/accepted neighbors.
 // If knownPeers is set, also all known peers to the node are returned additionally.
-func (api *GoShimmerAPI) GetAutopeeringNeighbors(knownPeers bool) (*jsonmodels.GetNeighborsResponse, error) {
+func (api *GoShimmerAPI) GetAutopeeringNeighbors(ctx context.Context, knownPeers bool) (*jsonmodels.GetNeighborsResponse, error) {
 	res := &jsonmodels.GetNeighborsResponse{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		if !knownPeers {
 			return routeGetAutopeeringNeighbors
 		}
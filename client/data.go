@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/iotaledger/goshimmer/packages/jsonmodels"
@@ -11,9 +12,9 @@ const (
 )
 
 // Data sends the given data (payload) by creating a message in the backend.
-func (api *GoShimmerAPI) Data(data []byte) (string, error) {
+func (api *GoShimmerAPI) Data(ctx context.Context, data []byte) (string, error) {
 	res := &jsonmodels.DataResponse{}
-	if err := api.do(http.MethodPost, routeData,
+	if err := api.do(ctx, http.MethodPost, routeData,
 		&jsonmodels.DataRequest{Data: data}, res); err != nil {
 		return "", err
 	}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"encoding/csv"
 	"fmt"
 	"net/http"
@@ -34,8 +35,8 @@ const (
 //	ID IssuerID IssuerPublicKey IssuanceTime ArrivalTime SolidTime ScheduledTime BookedTime GradeOfFinality
 //	GradeOfFinalityTime StrongParents WeakParents DislikeParents LikeParents StrongApprovers WeakApprovers BranchID InclusionState Scheduled Booked
 //	Invalid Finalized Rank IsPastMarker PastMarkers PMHI PMLI FutureMarkers FMHI FMLI PayloadType TransactionID
-func (api *GoShimmerAPI) GetDiagnosticsMessages() (*csv.Reader, error) {
-	return api.diagnose(RouteDiagnosticMessages)
+func (api *GoShimmerAPI) GetDiagnosticsMessages(ctx context.Context) (*csv.Reader, error) {
+	return api.diagnose(ctx, RouteDiagnosticMessages)
 }
 
 // GetDiagnosticsFirstWeakMessageReferences runs diagnostics over weak references only.
@@ -44,8 +45,8 @@ func (api *GoShimmerAPI) GetDiagnosticsMessages() (*csv.Reader, error) {
 //	ID IssuerID IssuerPublicKey IssuanceTime ArrivalTime SolidTime ScheduledTime BookedTime GradeOfFinality
 //	GradeOfFinalityTime StrongParents WeakParents DislikeParents LikeParents StrongApprovers WeakApprovers BranchID InclusionState Scheduled Booked
 //  Invalid Finalized Rank IsPastMarker PastMarkers PMHI PMLI FutureMarkers FMHI FMLI PayloadType TransactionID
-func (api *GoShimmerAPI) GetDiagnosticsFirstWeakMessageReferences() (*csv.Reader, error) {
-	return api.diagnose(RouteDiagnosticsFirstWeakMessageReferences)
+func (api *GoShimmerAPI) GetDiagnosticsFirstWeakMessageReferences(ctx context.Context) (*csv.Reader, error) {
+	return api.diagnose(ctx, RouteDiagnosticsFirstWeakMessageReferences)
 }
 
 // GetDiagnosticsMessagesByRank run diagnostics for messages whose markers are equal or above a certain rank
@@ -54,8 +55,8 @@ func (api *GoShimmerAPI) GetDiagnosticsFirstWeakMessageReferences() (*csv.Reader
 //	ID IssuerID IssuerPublicKey IssuanceTime ArrivalTime SolidTime ScheduledTime BookedTime GradeOfFinality
 //	GradeOfFinalityTime StrongParents WeakParents DislikeParents LikeParents StrongApprovers WeakApprovers BranchID InclusionState Scheduled Booked
 //	Invalid Finalized Rank IsPastMarker PastMarkers PMHI PMLI FutureMarkers FMHI FMLI PayloadType TransactionID
-func (api *GoShimmerAPI) GetDiagnosticsMessagesByRank(rank uint64) (*csv.Reader, error) {
-	return api.diagnose(fmt.Sprintf("%s?rank=%d", RouteDiagnosticMessages, rank))
+func (api *GoShimmerAPI) GetDiagnosticsMessagesByRank(ctx context.Context, rank uint64) (*csv.Reader, error) {
+	return api.diagnose(ctx, fmt.Sprintf("%s?rank=%d", RouteDiagnosticMessages, rank))
 }
 
 // GetDiagnosticsUtxoDag runs diagnostics over utxo dag.
@@ -63,32 +64,32 @@ func (api *GoShimmerAPI) GetDiagnosticsMessagesByRank(rank uint64) (*csv.Reader,
 //
 //	ID,IssuanceTime,SolidTime,AccessManaPledgeID,ConsensusManaPledgeID,Inputs,Outputs,Attachments,
 //	BranchID,Conflicting,LazyBooked,GradeOfFinality,GradeOfFinalityTime
-func (api *GoShimmerAPI) GetDiagnosticsUtxoDag() (*csv.Reader, error) {
-	return api.diagnose(RouteDiagnosticsUtxoDag)
+func (api *GoShimmerAPI) GetDiagnosticsUtxoDag(ctx context.Context) (*csv.Reader, error) {
+	return api.diagnose(ctx, RouteDiagnosticsUtxoDag)
 }
 
 // GetDiagnosticsBranches runs diagnostics over branches.
 // Returns csv with the following fields:
 //
 //	ID,ConflictSet,IssuanceTime,SolidTime,LazyBooked,GradeOfFinality
-func (api *GoShimmerAPI) GetDiagnosticsBranches() (*csv.Reader, error) {
-	return api.diagnose(RouteDiagnosticsBranches)
+func (api *GoShimmerAPI) GetDiagnosticsBranches(ctx context.Context) (*csv.Reader, error) {
+	return api.diagnose(ctx, RouteDiagnosticsBranches)
 }
 
 // GetDiagnosticsLazyBookedBranches runs diagnostics over lazy booked branches.
 // Returns csv with the following fields:
 //
 //	ID,ConflictSet,IssuanceTime,SolidTime,LazyBooked,GradeOfFinality
-func (api *GoShimmerAPI) GetDiagnosticsLazyBookedBranches() (*csv.Reader, error) {
-	return api.diagnose(RouteDiagnosticsLazyBookedBranches)
+func (api *GoShimmerAPI) GetDiagnosticsLazyBookedBranches(ctx context.Context) (*csv.Reader, error) {
+	return api.diagnose(ctx, RouteDiagnosticsLazyBookedBranches)
 }
 
 // GetDiagnosticsInvalidBranches runs diagnostics over invalid branches.
 // Returns csv with the following fields:
 //
 //	ID,ConflictSet,IssuanceTime,SolidTime,LazyBooked,GradeOfFinality
-func (api *GoShimmerAPI) GetDiagnosticsInvalidBranches() (*csv.Reader, error) {
-	return api.diagnose(RouteDiagnosticsInvalidBranches)
+func (api *GoShimmerAPI) GetDiagnosticsInvalidBranches(ctx context.Context) (*csv.Reader, error) {
+	return api.diagnose(ctx, RouteDiagnosticsInvalidBranches)
 }
 
 // GetDiagnosticsTips runs diagnostics over tips
@@ -97,8 +98,8 @@ func (api *GoShimmerAPI) GetDiagnosticsInvalidBranches() (*csv.Reader, error) {
 //	tipType ID IssuerID IssuerPublicKey IssuanceTime ArrivalTime SolidTime ScheduledTime BookedTime GradeOfFinality
 //	GradeOfFinalityTime StrongParents WeakParents DislikeParents LikeParents StrongApprovers WeakApprovers BranchID InclusionState Scheduled Booked
 //	Invalid Finalized Rank IsPastMarker PastMarkers PMHI PMLI FutureMarkers FMHI FMLI PayloadType TransactionID
-func (api *GoShimmerAPI) GetDiagnosticsTips() (*csv.Reader, error) {
-	return api.diagnose(RouteDiagnosticsTips)
+func (api *GoShimmerAPI) GetDiagnosticsTips(ctx context.Context) (*csv.Reader, error) {
+	return api.diagnose(ctx, RouteDiagnosticsTips)
 }
 
 // GetDiagnosticsDRNG runs diagnostics for DRNG
@@ -106,14 +107,14 @@ func (api *GoShimmerAPI) GetDiagnosticsTips() (*csv.Reader, error) {
 //
 // 	ID,IssuerID,IssuerPublicKey,IssuanceTime,ArrivalTime,SolidTime,ScheduledTime,BookedTime,
 //	dRNGPayloadType,InstanceID,Round,PreviousSignature,Signature,DistributedPK
-func (api *GoShimmerAPI) GetDiagnosticsDRNG() (*csv.Reader, error) {
-	return api.diagnose(RouteDiagnosticsDRNG)
+func (api *GoShimmerAPI) GetDiagnosticsDRNG(ctx context.Context) (*csv.Reader, error) {
+	return api.diagnose(ctx, RouteDiagnosticsDRNG)
 }
 
 // run an api call on a certain route and return a csv.
-func (api *GoShimmerAPI) diagnose(route string) (*csv.Reader, error) {
+func (api *GoShimmerAPI) diagnose(ctx context.Context, route string) (*csv.Reader, error) {
 	reader := &csv.Reader{}
-	if err := api.do(http.MethodGet, route, nil, reader); err != nil {
+	if err := api.do(ctx, http.MethodGet, route, nil, reader); err != nil {
 		return nil, err
 	}
 	return reader, nil
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/iotaledger/goshimmer/packages/jsonmodels"
@@ -13,9 +14,9 @@ const (
 )
 
 // BroadcastCollectiveBeacon sends the given collective beacon (payload) by creating a message in the backend.
-func (api *GoShimmerAPI) BroadcastCollectiveBeacon(payload []byte) (string, error) {
+func (api *GoShimmerAPI) BroadcastCollectiveBeacon(ctx context.Context, payload []byte) (string, error) {
 	res := &jsonmodels.CollectiveBeaconResponse{}
-	if err := api.do(http.MethodPost, routeCollectiveBeacon,
+	if err := api.do(ctx, http.MethodPost, routeCollectiveBeacon,
 		&jsonmodels.CollectiveBeaconRequest{Payload: payload}, res); err != nil {
 		return "", err
 	}
@@ -24,9 +25,9 @@ func (api *GoShimmerAPI) BroadcastCollectiveBeacon(payload []byte) (string, erro
 }
 
 // GetRandomness gets the current randomness.
-func (api *GoShimmerAPI) GetRandomness() (*jsonmodels.RandomnessResponse, error) {
+func (api *GoShimmerAPI) GetRandomness(ctx context.Context) (*jsonmodels.RandomnessResponse, error) {
 	res := &jsonmodels.RandomnessResponse{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		return routeRandomness
 	}(), nil, res); err != nil {
 		return nil, err
@@ -35,9 +36,9 @@ func (api *GoShimmerAPI) GetRandomness() (*jsonmodels.RandomnessResponse, error)
 }
 
 // GetCommittee gets the current committee.
-func (api *GoShimmerAPI) GetCommittee() (*jsonmodels.CommitteeResponse, error) {
+func (api *GoShimmerAPI) GetCommittee(ctx context.Context) (*jsonmodels.CommitteeResponse, error) {
 	res := &jsonmodels.CommitteeResponse{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		return routeCommittee
 	}(), nil, res); err != nil {
 		return nil, err
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// endpoint represents a node that the API can send its requests to.
+type endpoint struct {
+	baseURL     string
+	healthy     bool
+	lastChecked time.Time
+	checking    bool
+}
+
+// endpointPool keeps track of the health of the nodes that the API can talk to and selects the node for the next
+// request.
+type endpointPool struct {
+	endpoints           []*endpoint
+	currentIndex        int
+	healthCheckInterval time.Duration
+	healthCheckTimeout  time.Duration
+	mutex               sync.Mutex
+}
+
+// newEndpointPool creates a new endpointPool for the nodes with the given baseURLs.
+func newEndpointPool(baseURLs []string, healthCheckInterval, healthCheckTimeout time.Duration) *endpointPool {
+	endpoints := make([]*endpoint, len(baseURLs))
+	for i, baseURL := range baseURLs {
+		endpoints[i] = &endpoint{
+			baseURL: baseURL,
+			healthy: true,
+		}
+	}
+
+	return &endpointPool{
+		endpoints:           endpoints,
+		healthCheckInterval: healthCheckInterval,
+		healthCheckTimeout:  healthCheckTimeout,
+	}
+}
+
+// endpoint returns the node that the next request should be sent to. If there is more than one node, the current node
+// is kept as long as it is healthy and nodes are health-checked with the given function once their last check is older
+// than the health check interval. The checks run outside of the lock and are bounded by the health check timeout, so
+// a slow node does not block concurrent requests, which use the result of the previous check in the meantime. If no
+// node is healthy, the current one is returned anyway.
+func (e *endpointPool) endpoint(ctx context.Context, checkHealth func(ctx context.Context, baseURL string) bool) *endpoint {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if len(e.endpoints) == 1 {
+		return e.endpoints[0]
+	}
+
+	startIndex := e.currentIndex
+	for i := 0; i < len(e.endpoints); i++ {
+		candidateIndex := (startIndex + i) % len(e.endpoints)
+		candidate := e.endpoints[candidateIndex]
+		if !candidate.checking && time.Since(candidate.lastChecked) >= e.healthCheckInterval {
+			candidate.checking = true
+			e.mutex.Unlock()
+			healthy := e.checkHealth(ctx, candidate.baseURL, checkHealth)
+			e.mutex.Lock()
+
+			candidate.checking = false
+			candidate.healthy = healthy
+			candidate.lastChecked = time.Now()
+		}
+
+		if candidate.healthy {
+			e.currentIndex = candidateIndex
+			return candidate
+		}
+	}
+
+	return e.endpoints[e.currentIndex]
+}
+
+// checkHealth checks the health of the node with the given baseURL with the given function, which is canceled after
+// the health check timeout.
+func (e *endpointPool) checkHealth(ctx context.Context, baseURL string, checkHealth func(ctx context.Context, baseURL string) bool) bool {
+	if e.healthCheckTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.healthCheckTimeout)
+		defer cancel()
+	}
+
+	return checkHealth(ctx, baseURL)
+}
+
+// markUnhealthy marks the given node as unhealthy after a failed request, so that the next request fails over to
+// another node.
+func (e *endpointPool) markUnhealthy(failedEndpoint *endpoint) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	failedEndpoint.healthy = false
+	failedEndpoint.lastChecked = time.Now()
+	if e.endpoints[e.currentIndex] == failedEndpoint {
+		e.currentIndex = (e.currentIndex + 1) % len(e.endpoints)
+	}
+}
+
+// current returns the node that the API currently talks to.
+func (e *endpointPool) current() *endpoint {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	return e.endpoints[e.currentIndex]
+}
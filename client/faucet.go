@@ -25,7 +25,7 @@ var (
 )
 
 // SendFaucetRequest requests funds from faucet nodes by sending a faucet request payload message.
-func (api *GoShimmerAPI) SendFaucetRequest(base58EncodedAddr string, powTarget int, pledgeIDs ...string) (*jsonmodels.FaucetResponse, error) {
+func (api *GoShimmerAPI) SendFaucetRequest(ctx context.Context, base58EncodedAddr string, powTarget int, pledgeIDs ...string) (*jsonmodels.FaucetResponse, error) {
 	var aManaPledgeID identity.ID
 	var cManaPledgeID identity.ID
 	if len(pledgeIDs) > 1 {
@@ -44,13 +44,13 @@ func (api *GoShimmerAPI) SendFaucetRequest(base58EncodedAddr string, powTarget i
 		return nil, errors.Errorf("could not decode address from string: %w", err)
 	}
 
-	nonce, err := computeFaucetPoW(address, aManaPledgeID, cManaPledgeID, powTarget)
+	nonce, err := api.computeFaucetPoW(ctx, address, aManaPledgeID, cManaPledgeID, powTarget)
 	if err != nil {
 		return nil, errors.Errorf("could not compute faucet PoW: %w", err)
 	}
 
 	res := &jsonmodels.FaucetResponse{}
-	if err := api.do(http.MethodPost, routeFaucet,
+	if err := api.do(ctx, http.MethodPost, routeFaucet,
 		&jsonmodels.FaucetRequest{
 			Address:               base58EncodedAddr,
 			AccessManaPledgeID:    base58.Encode(aManaPledgeID.Bytes()),
@@ -63,7 +63,7 @@ func (api *GoShimmerAPI) SendFaucetRequest(base58EncodedAddr string, powTarget i
 	return res, nil
 }
 
-func computeFaucetPoW(address ledgerstate.Address, aManaPledgeID, cManaPledgeID identity.ID, powTarget int) (nonce uint64, err error) {
+func (api *GoShimmerAPI) computeFaucetPoW(ctx context.Context, address ledgerstate.Address, aManaPledgeID, cManaPledgeID identity.ID, powTarget int) (nonce uint64, err error) {
 	if powTarget < 0 {
 		powTarget = defaultPOWTarget
 	}
@@ -73,5 +73,5 @@ func computeFaucetPoW(address ledgerstate.Address, aManaPledgeID, cManaPledgeID
 	objectBytes := faucetRequest.Bytes()
 	powRelevantBytes := objectBytes[:len(objectBytes)-pow.NonceBytes]
 
-	return powWorker.Mine(context.Background(), powRelevantBytes, powTarget)
+	return api.mine(ctx, powRelevantBytes, powTarget)
 }
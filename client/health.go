@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"net/http"
 )
 
@@ -9,6 +10,6 @@ const (
 )
 
 // HealthCheck checks whether the node is running and healthy.
-func (api *GoShimmerAPI) HealthCheck() error {
-	return api.do(http.MethodGet, routeHealth, nil, nil)
+func (api *GoShimmerAPI) HealthCheck(ctx context.Context) error {
+	return api.do(ctx, http.MethodGet, routeHealth, nil, nil)
 }
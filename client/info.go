@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/iotaledger/goshimmer/packages/jsonmodels"
@@ -11,9 +12,9 @@ const (
 )
 
 // Info gets the info of the node.
-func (api *GoShimmerAPI) Info() (*jsonmodels.InfoResponse, error) {
+func (api *GoShimmerAPI) Info(ctx context.Context) (*jsonmodels.InfoResponse, error) {
 	res := &jsonmodels.InfoResponse{}
-	if err := api.do(http.MethodGet, routeInfo, nil, res); err != nil {
+	if err := api.do(ctx, http.MethodGet, routeInfo, nil, res); err != nil {
 		return nil, err
 	}
 	return res, nil
@@ -1,15 +1,24 @@
 package client
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/iotaledger/goshimmer/packages/consensus/gof"
 	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 )
 
 const (
 	// basic routes.
 	routeGetAddresses     = "ledgerstate/addresses/"
+	routeGetAliases       = "ledgerstate/aliases/"
+	routeGetAssets        = "ledgerstate/assets/"
 	routeGetBranches      = "ledgerstate/branches/"
 	routeGetOutputs       = "ledgerstate/outputs/"
 	routeGetTransactions  = "ledgerstate/transactions/"
@@ -17,40 +26,121 @@ const (
 
 	// route path modifiers.
 	pathUnspentOutputs = "/unspentOutputs"
+	pathBalance        = "/balance"
+	pathHistory        = "/history"
 	pathChildren       = "/children"
 	pathConflicts      = "/conflicts"
 	pathConsumers      = "/consumers"
 	pathMetadata       = "/metadata"
+	pathPreimage       = "/preimage"
 	pathSupporters     = "/supporters"
 	pathAttachments    = "/attachments"
 )
 
-// GetAddressOutputs gets the spent and unspent outputs of an address.
-func (api *GoShimmerAPI) GetAddressOutputs(base58EncodedAddress string) (*jsonmodels.GetAddressResponse, error) {
+// AddressOutputsOption is a function that sets a query parameter of the address output endpoints.
+type AddressOutputsOption func(query url.Values)
+
+// WithOutputsCursor requests the page of outputs that follows the given cursor (the nextCursor of the previous page).
+func WithOutputsCursor(cursor string) AddressOutputsOption {
+	return func(query url.Values) {
+		query.Set("cursor", cursor)
+	}
+}
+
+// WithOutputsLimit requests pages containing at most the given amount of outputs.
+func WithOutputsLimit(limit int) AddressOutputsOption {
+	return func(query url.Values) {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+}
+
+// WithOutputType requests only outputs of the given type.
+func WithOutputType(outputType ledgerstate.OutputType) AddressOutputsOption {
+	return func(query url.Values) {
+		query.Set("type", outputType.String())
+	}
+}
+
+// WithOutputColor requests only outputs holding tokens of the given color.
+func WithOutputColor(color ledgerstate.Color) AddressOutputsOption {
+	return func(query url.Values) {
+		query.Set("color", color.Base58())
+	}
+}
+
+// WithOutputMinBalance requests only outputs holding at least the given balance of the requested color (IOTA if no
+// color is requested).
+func WithOutputMinBalance(minBalance uint64) AddressOutputsOption {
+	return func(query url.Values) {
+		query.Set("minBalance", strconv.FormatUint(minBalance, 10))
+	}
+}
+
+// WithOutputMinGradeOfFinality requests only outputs that reached at least the given grade of finality.
+func WithOutputMinGradeOfFinality(minGradeOfFinality gof.GradeOfFinality) AddressOutputsOption {
+	return func(query url.Values) {
+		query.Set("minGoF", strconv.Itoa(int(minGradeOfFinality)))
+	}
+}
+
+// WithOutputTimeRange requests only outputs that were created by a transaction issued within the given (inclusive)
+// time range. A zero time leaves the corresponding end of the range open.
+func WithOutputTimeRange(from, to time.Time) AddressOutputsOption {
+	return func(query url.Values) {
+		if !from.IsZero() {
+			query.Set("fromTimestamp", strconv.FormatInt(from.Unix(), 10))
+		}
+		if !to.IsZero() {
+			query.Set("toTimestamp", strconv.FormatInt(to.Unix(), 10))
+		}
+	}
+}
+
+// GetAddressOutputs gets a page of the spent and unspent outputs of an address that match the given options.
+func (api *GoShimmerAPI) GetAddressOutputs(ctx context.Context, base58EncodedAddress string, options ...AddressOutputsOption) (*jsonmodels.GetAddressResponse, error) {
 	res := &jsonmodels.GetAddressResponse{}
-	if err := api.do(http.MethodGet, func() string {
-		return strings.Join([]string{routeGetAddresses, base58EncodedAddress}, "")
-	}(), nil, res); err != nil {
+	if err := api.do(ctx, http.MethodGet, addressOutputsRoute(strings.Join([]string{routeGetAddresses, base58EncodedAddress}, ""), options), nil, res); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-// GetAddressUnspentOutputs gets the unspent outputs of an address.
-func (api *GoShimmerAPI) GetAddressUnspentOutputs(base58EncodedAddress string) (*jsonmodels.GetAddressResponse, error) {
+// GetAddressUnspentOutputs gets a page of the unspent outputs of an address that match the given options.
+func (api *GoShimmerAPI) GetAddressUnspentOutputs(ctx context.Context, base58EncodedAddress string, options ...AddressOutputsOption) (*jsonmodels.GetAddressResponse, error) {
 	res := &jsonmodels.GetAddressResponse{}
-	if err := api.do(http.MethodGet, func() string {
-		return strings.Join([]string{routeGetAddresses, base58EncodedAddress, pathUnspentOutputs}, "")
-	}(), nil, res); err != nil {
+	if err := api.do(ctx, http.MethodGet, addressOutputsRoute(strings.Join([]string{routeGetAddresses, base58EncodedAddress, pathUnspentOutputs}, ""), options), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetAddressBalance gets the balances of the unspent outputs of an address that match the given options summed up by
+// their color. The cursor and limit options are ignored.
+func (api *GoShimmerAPI) GetAddressBalance(ctx context.Context, base58EncodedAddress string, options ...AddressOutputsOption) (*jsonmodels.GetAddressBalanceResponse, error) {
+	res := &jsonmodels.GetAddressBalanceResponse{}
+	if err := api.do(ctx, http.MethodGet, addressOutputsRoute(strings.Join([]string{routeGetAddresses, base58EncodedAddress, pathBalance}, ""), options), nil, res); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
+// addressOutputsRoute appends the query parameters of the given options to the route.
+func addressOutputsRoute(route string, options []AddressOutputsOption) string {
+	query := url.Values{}
+	for _, option := range options {
+		option(query)
+	}
+	if len(query) == 0 {
+		return route
+	}
+
+	return route + "?" + query.Encode()
+}
+
 // PostAddressUnspentOutputs gets the unspent outputs of several addresses.
-func (api *GoShimmerAPI) PostAddressUnspentOutputs(base58EncodedAddresses []string) (*jsonmodels.PostAddressesUnspentOutputsResponse, error) {
+func (api *GoShimmerAPI) PostAddressUnspentOutputs(ctx context.Context, base58EncodedAddresses []string) (*jsonmodels.PostAddressesUnspentOutputsResponse, error) {
 	res := &jsonmodels.PostAddressesUnspentOutputsResponse{}
-	if err := api.do(http.MethodPost, func() string {
+	if err := api.doIdempotent(ctx, http.MethodPost, func() string {
 		return strings.Join([]string{routeGetAddresses, "unspentOutputs"}, "")
 	}(), &jsonmodels.PostAddressesUnspentOutputsRequest{Addresses: base58EncodedAddresses}, res); err != nil {
 		return nil, err
@@ -58,10 +148,35 @@ func (api *GoShimmerAPI) PostAddressUnspentOutputs(base58EncodedAddresses []stri
 	return res, nil
 }
 
+// GetAliasHistory gets the outputs of the chain of an alias whose state index lies within the given (inclusive) range.
+// The result is paginated by the given limit, the NextStateIndex of the response is the fromStateIndex of the next
+// page.
+func (api *GoShimmerAPI) GetAliasHistory(ctx context.Context, base58EncodedAliasID string, fromStateIndex, toStateIndex uint32, limit int) (*jsonmodels.GetAliasHistoryResponse, error) {
+	res := &jsonmodels.GetAliasHistoryResponse{}
+	if err := api.do(ctx, http.MethodGet, func() string {
+		return fmt.Sprintf("%s?fromStateIndex=%d&toStateIndex=%d&limit=%d",
+			strings.Join([]string{routeGetAliases, base58EncodedAliasID, pathHistory}, ""), fromStateIndex, toStateIndex, limit)
+	}(), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetAsset gets the metadata that the minter of a color registered on the tangle.
+func (api *GoShimmerAPI) GetAsset(ctx context.Context, base58EncodedColor string) (*jsonmodels.GetAssetResponse, error) {
+	res := &jsonmodels.GetAssetResponse{}
+	if err := api.do(ctx, http.MethodGet, func() string {
+		return strings.Join([]string{routeGetAssets, base58EncodedColor}, "")
+	}(), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 // GetBranch gets the branch information.
-func (api *GoShimmerAPI) GetBranch(base58EncodedBranchID string) (*jsonmodels.Branch, error) {
+func (api *GoShimmerAPI) GetBranch(ctx context.Context, base58EncodedBranchID string) (*jsonmodels.Branch, error) {
 	res := &jsonmodels.Branch{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		return strings.Join([]string{routeGetBranches, base58EncodedBranchID}, "")
 	}(), nil, res); err != nil {
 		return nil, err
@@ -70,9 +185,9 @@ func (api *GoShimmerAPI) GetBranch(base58EncodedBranchID string) (*jsonmodels.Br
 }
 
 // GetBranchChildren gets the children of a branch.
-func (api *GoShimmerAPI) GetBranchChildren(base58EncodedBranchID string) (*jsonmodels.GetBranchChildrenResponse, error) {
+func (api *GoShimmerAPI) GetBranchChildren(ctx context.Context, base58EncodedBranchID string) (*jsonmodels.GetBranchChildrenResponse, error) {
 	res := &jsonmodels.GetBranchChildrenResponse{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		return strings.Join([]string{routeGetBranches, base58EncodedBranchID, pathChildren}, "")
 	}(), nil, res); err != nil {
 		return nil, err
@@ -81,9 +196,9 @@ func (api *GoShimmerAPI) GetBranchChildren(base58EncodedBranchID string) (*jsonm
 }
 
 // GetBranchConflicts gets the conflict branches of a branch.
-func (api *GoShimmerAPI) GetBranchConflicts(base58EncodedBranchID string) (*jsonmodels.GetBranchConflictsResponse, error) {
+func (api *GoShimmerAPI) GetBranchConflicts(ctx context.Context, base58EncodedBranchID string) (*jsonmodels.GetBranchConflictsResponse, error) {
 	res := &jsonmodels.GetBranchConflictsResponse{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		return strings.Join([]string{routeGetBranches, base58EncodedBranchID, pathConflicts}, "")
 	}(), nil, res); err != nil {
 		return nil, err
@@ -92,9 +207,9 @@ func (api *GoShimmerAPI) GetBranchConflicts(base58EncodedBranchID string) (*json
 }
 
 // GetBranchSupporters gets the supporters of a branch.
-func (api *GoShimmerAPI) GetBranchSupporters(base58EncodedBranchID string) (*jsonmodels.GetBranchSupportersResponse, error) {
+func (api *GoShimmerAPI) GetBranchSupporters(ctx context.Context, base58EncodedBranchID string) (*jsonmodels.GetBranchSupportersResponse, error) {
 	res := &jsonmodels.GetBranchSupportersResponse{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		return strings.Join([]string{routeGetBranches, base58EncodedBranchID, pathSupporters}, "")
 	}(), nil, res); err != nil {
 		return nil, err
@@ -103,9 +218,9 @@ func (api *GoShimmerAPI) GetBranchSupporters(base58EncodedBranchID string) (*jso
 }
 
 // GetOutput gets the output corresponding to OutputID.
-func (api *GoShimmerAPI) GetOutput(base58EncodedOutputID string) (*jsonmodels.Output, error) {
+func (api *GoShimmerAPI) GetOutput(ctx context.Context, base58EncodedOutputID string) (*jsonmodels.Output, error) {
 	res := &jsonmodels.Output{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		return strings.Join([]string{routeGetOutputs, base58EncodedOutputID}, "")
 	}(), nil, res); err != nil {
 		return nil, err
@@ -114,9 +229,9 @@ func (api *GoShimmerAPI) GetOutput(base58EncodedOutputID string) (*jsonmodels.Ou
 }
 
 // GetOutputConsumers gets the consumers of the output corresponding to OutputID.
-func (api *GoShimmerAPI) GetOutputConsumers(base58EncodedOutputID string) (*jsonmodels.GetOutputConsumersResponse, error) {
+func (api *GoShimmerAPI) GetOutputConsumers(ctx context.Context, base58EncodedOutputID string) (*jsonmodels.GetOutputConsumersResponse, error) {
 	res := &jsonmodels.GetOutputConsumersResponse{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		return strings.Join([]string{routeGetOutputs, base58EncodedOutputID, pathConsumers}, "")
 	}(), nil, res); err != nil {
 		return nil, err
@@ -125,9 +240,9 @@ func (api *GoShimmerAPI) GetOutputConsumers(base58EncodedOutputID string) (*json
 }
 
 // GetOutputMetadata gets the metadata of the output corresponding to OutputID.
-func (api *GoShimmerAPI) GetOutputMetadata(base58EncodedOutputID string) (*jsonmodels.OutputMetadata, error) {
+func (api *GoShimmerAPI) GetOutputMetadata(ctx context.Context, base58EncodedOutputID string) (*jsonmodels.OutputMetadata, error) {
 	res := &jsonmodels.OutputMetadata{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		return strings.Join([]string{routeGetOutputs, base58EncodedOutputID, pathMetadata}, "")
 	}(), nil, res); err != nil {
 		return nil, err
@@ -135,10 +250,21 @@ func (api *GoShimmerAPI) GetOutputMetadata(base58EncodedOutputID string) (*jsonm
 	return res, nil
 }
 
+// GetOutputPreimage gets the preimage that was revealed to claim the hash time-locked output corresponding to OutputID.
+func (api *GoShimmerAPI) GetOutputPreimage(ctx context.Context, base58EncodedOutputID string) (*jsonmodels.GetOutputPreimageResponse, error) {
+	res := &jsonmodels.GetOutputPreimageResponse{}
+	if err := api.do(ctx, http.MethodGet, func() string {
+		return strings.Join([]string{routeGetOutputs, base58EncodedOutputID, pathPreimage}, "")
+	}(), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 // GetTransaction gets the transaction of the corresponding to TransactionID.
-func (api *GoShimmerAPI) GetTransaction(base58EncodedTransactionID string) (*jsonmodels.Transaction, error) {
+func (api *GoShimmerAPI) GetTransaction(ctx context.Context, base58EncodedTransactionID string) (*jsonmodels.Transaction, error) {
 	res := &jsonmodels.Transaction{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		return strings.Join([]string{routeGetTransactions, base58EncodedTransactionID}, "")
 	}(), nil, res); err != nil {
 		return nil, err
@@ -147,9 +273,9 @@ func (api *GoShimmerAPI) GetTransaction(base58EncodedTransactionID string) (*jso
 }
 
 // GetTransactionMetadata gets metadata of the transaction corresponding to TransactionID.
-func (api *GoShimmerAPI) GetTransactionMetadata(base58EncodedTransactionID string) (*jsonmodels.TransactionMetadata, error) {
+func (api *GoShimmerAPI) GetTransactionMetadata(ctx context.Context, base58EncodedTransactionID string) (*jsonmodels.TransactionMetadata, error) {
 	res := &jsonmodels.TransactionMetadata{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		return strings.Join([]string{routeGetTransactions, base58EncodedTransactionID, pathMetadata}, "")
 	}(), nil, res); err != nil {
 		return nil, err
@@ -158,9 +284,9 @@ func (api *GoShimmerAPI) GetTransactionMetadata(base58EncodedTransactionID strin
 }
 
 // GetTransactionAttachments gets the attachments (messageIDs) of the transaction corresponding to TransactionID.
-func (api *GoShimmerAPI) GetTransactionAttachments(base58EncodedTransactionID string) (*jsonmodels.GetTransactionAttachmentsResponse, error) {
+func (api *GoShimmerAPI) GetTransactionAttachments(ctx context.Context, base58EncodedTransactionID string) (*jsonmodels.GetTransactionAttachmentsResponse, error) {
 	res := &jsonmodels.GetTransactionAttachmentsResponse{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		return strings.Join([]string{routeGetTransactions, base58EncodedTransactionID, pathAttachments}, "")
 	}(), nil, res); err != nil {
 		return nil, err
@@ -169,9 +295,9 @@ func (api *GoShimmerAPI) GetTransactionAttachments(base58EncodedTransactionID st
 }
 
 // PostTransaction sends the transaction(bytes) to the Tangle and returns its transaction ID.
-func (api *GoShimmerAPI) PostTransaction(transactionBytes []byte) (*jsonmodels.PostTransactionResponse, error) {
+func (api *GoShimmerAPI) PostTransaction(ctx context.Context, transactionBytes []byte) (*jsonmodels.PostTransactionResponse, error) {
 	res := &jsonmodels.PostTransactionResponse{}
-	if err := api.do(http.MethodPost, routePostTransactions,
+	if err := api.do(ctx, http.MethodPost, routePostTransactions,
 		&jsonmodels.PostTransactionRequest{TransactionBytes: transactionBytes}, res); err != nil {
 		return nil, err
 	}
@@ -11,8 +11,11 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
 )
 
 var (
@@ -30,6 +33,17 @@ var (
 	ErrNotImplemented = errors.New("operation not implemented/supported/available")
 )
 
+const (
+	// DefaultRetryInitialBackoff defines the waiting time before the first retry of a failed request.
+	DefaultRetryInitialBackoff = 250 * time.Millisecond
+	// DefaultRetryMaxBackoff defines the maximum waiting time between two retries of a failed request.
+	DefaultRetryMaxBackoff = 5 * time.Second
+	// DefaultHealthCheckInterval defines how often the health of the nodes is checked in multi-endpoint mode.
+	DefaultHealthCheckInterval = 10 * time.Second
+	// DefaultHealthCheckTimeout defines how long a health check of a node may take in multi-endpoint mode.
+	DefaultHealthCheckTimeout = 5 * time.Second
+)
+
 const (
 	contentType     = "Content-Type"
 	contentTypeJSON = "application/json"
@@ -64,6 +78,50 @@ func WithHTTPClient(c http.Client) Option {
 	}
 }
 
+// WithRetry configures how often idempotent requests (GET requests and read-only queries) are attempted in total
+// before their error is returned. The waiting time between two attempts starts at initialBackoff and doubles with every
+// attempt up to maxBackoff. Requests that change the state of the node are never retried.
+func WithRetry(maxAttempts int, initialBackoff, maxBackoff time.Duration) Option {
+	return func(g *GoShimmerAPI) {
+		g.retryPolicy = retryPolicy{
+			maxAttempts:    maxAttempts,
+			initialBackoff: initialBackoff,
+			maxBackoff:     maxBackoff,
+		}
+	}
+}
+
+// WithEndpoints adds further nodes that the API fails over to if the current node is unreachable, unhealthy or not
+// synced. The nodes are health-checked via their healthz and info endpoints.
+func WithEndpoints(baseURLs ...string) Option {
+	return func(g *GoShimmerAPI) {
+		g.baseURLs = append(g.baseURLs, baseURLs...)
+	}
+}
+
+// WithHealthCheckInterval defines how often the health of the nodes is checked in multi-endpoint mode.
+func WithHealthCheckInterval(interval time.Duration) Option {
+	return func(g *GoShimmerAPI) {
+		g.healthCheckInterval = interval
+	}
+}
+
+// WithHealthCheckTimeout defines how long a health check of a node may take before the node is considered unhealthy in
+// multi-endpoint mode.
+func WithHealthCheckTimeout(timeout time.Duration) Option {
+	return func(g *GoShimmerAPI) {
+		g.healthCheckTimeout = timeout
+	}
+}
+
+// WithRemotePoW lets the node perform the PoW that would otherwise be performed locally, e.g. for faucet requests. The
+// node needs to have the remote PoW endpoint enabled.
+func WithRemotePoW() Option {
+	return func(g *GoShimmerAPI) {
+		g.remotePoW = true
+	}
+}
+
 // IsEnabled returns the enabled state of a given BasicAuth.
 func (b BasicAuth) IsEnabled() bool {
 	return b.Enabled
@@ -77,19 +135,44 @@ func (b BasicAuth) Credentials() (username, password string) {
 // NewGoShimmerAPI returns a new *GoShimmerAPI with the given baseURL and options.
 func NewGoShimmerAPI(baseURL string, setters ...Option) *GoShimmerAPI {
 	g := &GoShimmerAPI{
-		baseURL: baseURL,
+		baseURLs:            []string{baseURL},
+		healthCheckInterval: DefaultHealthCheckInterval,
+		healthCheckTimeout:  DefaultHealthCheckTimeout,
 	}
 	for _, setter := range setters {
 		setter(g)
 	}
+
+	// by default every node is tried once
+	if g.retryPolicy.maxAttempts <= 0 {
+		g.retryPolicy = retryPolicy{
+			maxAttempts:    len(g.baseURLs),
+			initialBackoff: DefaultRetryInitialBackoff,
+			maxBackoff:     DefaultRetryMaxBackoff,
+		}
+	}
+	g.endpoints = newEndpointPool(g.baseURLs, g.healthCheckInterval, g.healthCheckTimeout)
+
 	return g
 }
 
 // GoShimmerAPI is an API wrapper over the web API of GoShimmer.
 type GoShimmerAPI struct {
-	baseURL    string
-	httpClient http.Client
-	basicAuth  BasicAuth
+	baseURLs            []string
+	endpoints           *endpointPool
+	httpClient          http.Client
+	basicAuth           BasicAuth
+	retryPolicy         retryPolicy
+	healthCheckInterval time.Duration
+	healthCheckTimeout  time.Duration
+	remotePoW           bool
+}
+
+// retryPolicy defines how often and how fast idempotent requests are retried.
+type retryPolicy struct {
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
 }
 
 type errorresponse struct {
@@ -103,7 +186,11 @@ func interpretBody(res *http.Response, decodeTo interface{}) error {
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == http.StatusOK || res.StatusCode == http.StatusCreated {
+	if res.StatusCode == http.StatusOK || res.StatusCode == http.StatusCreated || res.StatusCode == http.StatusNoContent {
+		if decodeTo == nil {
+			return nil
+		}
+
 		switch contType := res.Header.Get(contentType); {
 		case strings.HasPrefix(contType, contentTypeJSON):
 			return json.Unmarshal(resBody, decodeTo)
@@ -115,7 +202,9 @@ func interpretBody(res *http.Response, decodeTo interface{}) error {
 		}
 	}
 	errRes := &errorresponse{}
-	if err := json.Unmarshal(resBody, errRes); err != nil {
+	if len(resBody) == 0 {
+		errRes.Error = res.Status
+	} else if err := json.Unmarshal(resBody, errRes); err != nil {
 		return fmt.Errorf("unable to read error from response body: %w repsonseBody: %s", err, resBody)
 	}
 
@@ -135,26 +224,68 @@ func interpretBody(res *http.Response, decodeTo interface{}) error {
 	return fmt.Errorf("%w: %s", ErrUnknownError, errRes.Error)
 }
 
-func (api *GoShimmerAPI) do(method string, route string, reqObj interface{}, resObj interface{}) error {
+func (api *GoShimmerAPI) do(ctx context.Context, method string, route string, reqObj interface{}, resObj interface{}) error {
+	return api.request(ctx, method, route, reqObj, resObj, method == http.MethodGet)
+}
+
+// doIdempotent executes a request that does not change the state of the node (even though it might not be a GET
+// request), so it can be retried.
+func (api *GoShimmerAPI) doIdempotent(ctx context.Context, method string, route string, reqObj interface{}, resObj interface{}) error {
+	return api.request(ctx, method, route, reqObj, resObj, true)
+}
+
+// request sends the request to the current node and retries idempotent requests according to the retryPolicy. Nodes
+// that fail to answer are marked as unhealthy, so that the next attempt fails over to another node.
+func (api *GoShimmerAPI) request(ctx context.Context, method string, route string, reqObj interface{}, resObj interface{}, idempotent bool) (err error) {
 	// marshal request object
 	var data []byte
 	if reqObj != nil {
-		var err error
-		data, err = json.Marshal(reqObj)
-		if err != nil {
+		if data, err = json.Marshal(reqObj); err != nil {
+			return err
+		}
+	}
+
+	maxAttempts := 1
+	if idempotent {
+		maxAttempts = api.retryPolicy.maxAttempts
+	}
+
+	backoff := api.retryPolicy.initialBackoff
+	for attempt := 1; ; attempt++ {
+		currentEndpoint := api.endpoints.endpoint(ctx, api.checkHealth)
+
+		var retryable bool
+		if retryable, err = api.send(ctx, currentEndpoint.baseURL, method, route, data, resObj); err == nil || !retryable {
 			return err
 		}
+		api.endpoints.markUnhealthy(currentEndpoint)
+
+		if attempt >= maxAttempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Errorf("%v: %w", err, ctx.Err())
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > api.retryPolicy.maxBackoff {
+			backoff = api.retryPolicy.maxBackoff
+		}
 	}
-	ctx := context.TODO()
+}
+
+// send sends a single request to the node with the given baseURL. It returns if a failed request can be retried.
+func (api *GoShimmerAPI) send(ctx context.Context, baseURL string, method string, route string, data []byte, resObj interface{}) (retryable bool, err error) {
 	// construct request
-	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/%s", api.baseURL, route), func() io.Reader {
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/%s", baseURL, route), func() io.Reader {
 		if data == nil {
 			return nil
 		}
 		return bytes.NewReader(data)
 	}())
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	if data != nil {
@@ -169,21 +300,33 @@ func (api *GoShimmerAPI) do(method string, route string, reqObj interface{}, res
 	// make the request
 	res, err := api.httpClient.Do(req)
 	if err != nil {
-		return err
+		// requests that were canceled by the caller are not retried
+		return ctx.Err() == nil, err
 	}
 
-	if resObj == nil {
-		return nil
+	// write response into response object
+	if err = interpretBody(res, resObj); err != nil {
+		return res.StatusCode >= http.StatusInternalServerError || res.StatusCode == http.StatusTooManyRequests, err
 	}
 
-	// write response into response object
-	if err := interpretBody(res, resObj); err != nil {
-		return err
+	return false, nil
+}
+
+// checkHealth returns true if the node with the given baseURL is healthy and synced.
+func (api *GoShimmerAPI) checkHealth(ctx context.Context, baseURL string) bool {
+	if _, err := api.send(ctx, baseURL, http.MethodGet, routeHealth, nil, nil); err != nil {
+		return false
 	}
-	return nil
+
+	info := &jsonmodels.InfoResponse{}
+	if _, err := api.send(ctx, baseURL, http.MethodGet, routeInfo, nil, info); err != nil {
+		return false
+	}
+
+	return info.TangleTime.Synced
 }
 
-// BaseURL returns the baseURL of the API.
+// BaseURL returns the baseURL of the node that the API currently talks to.
 func (api *GoShimmerAPI) BaseURL() string {
-	return api.baseURL
+	return api.endpoints.current().baseURL
 }
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+)
+
+func TestGoShimmerAPI_Retry(t *testing.T) {
+	var requests int32
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		writeJSON(w, &jsonmodels.InfoResponse{Version: "v1.0.0"})
+	}))
+	defer node.Close()
+
+	// without retries the first error is returned
+	_, err := NewGoShimmerAPI(node.URL).Info(context.Background())
+	assert.Error(t, err)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&requests))
+
+	api := NewGoShimmerAPI(node.URL, WithRetry(3, time.Millisecond, time.Millisecond))
+	info, err := api.Info(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "v1.0.0", info.Version)
+	assert.EqualValues(t, 3, atomic.LoadInt32(&requests))
+
+	// requests that change the state of the node are not retried
+	atomic.StoreInt32(&requests, 0)
+	_, err = api.Data(context.Background(), []byte("data"))
+	assert.Error(t, err)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&requests))
+
+	// canceled requests are not retried
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	atomic.StoreInt32(&requests, 0)
+	_, err = api.Info(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.EqualValues(t, 0, atomic.LoadInt32(&requests))
+}
+
+func TestGoShimmerAPI_Failover(t *testing.T) {
+	unsyncedNode := httptest.NewServer(nodeHandler(false))
+	defer unsyncedNode.Close()
+	syncedNode := httptest.NewServer(nodeHandler(true))
+	defer syncedNode.Close()
+	unreachableNode := httptest.NewServer(nodeHandler(true))
+	unreachableNode.Close()
+
+	api := NewGoShimmerAPI(unreachableNode.URL, WithEndpoints(unsyncedNode.URL, syncedNode.URL))
+	info, err := api.Info(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, syncedNode.URL, info.Version)
+	assert.Equal(t, syncedNode.URL, api.BaseURL())
+
+	// the current node is kept as long as it is healthy
+	info, err = api.Info(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, syncedNode.URL, info.Version)
+
+	// a failed request fails over to the next node
+	syncedNode.Close()
+	_, err = api.Info(context.Background())
+	require.NoError(t, err)
+	assert.NotEqual(t, syncedNode.URL, api.BaseURL())
+}
+
+func TestGoShimmerAPI_HealthCheckTimeout(t *testing.T) {
+	release := make(chan struct{})
+	slowNode := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer slowNode.Close()
+	defer close(release)
+	syncedNode := httptest.NewServer(nodeHandler(true))
+	defer syncedNode.Close()
+
+	api := NewGoShimmerAPI(slowNode.URL, WithEndpoints(syncedNode.URL), WithHealthCheckTimeout(100*time.Millisecond))
+	infoDone := make(chan error, 1)
+	go func() {
+		_, err := api.Info(context.Background())
+		infoDone <- err
+	}()
+
+	// the pool is not locked while the slow node is checked
+	baseURLDone := make(chan string, 1)
+	go func() {
+		baseURLDone <- api.BaseURL()
+	}()
+	select {
+	case baseURL := <-baseURLDone:
+		assert.Equal(t, slowNode.URL, baseURL)
+	case <-time.After(50 * time.Millisecond):
+		t.Fatal("BaseURL blocked by the health check")
+	}
+
+	// the health check times out and the request fails over to the synced node
+	select {
+	case err := <-infoDone:
+		require.NoError(t, err)
+		assert.Equal(t, syncedNode.URL, api.BaseURL())
+	case <-time.After(5 * time.Second):
+		t.Fatal("health check did not time out")
+	}
+}
+
+// nodeHandler returns a handler that mocks the healthz and info endpoints of a node, which reports its own URL as its
+// version.
+func nodeHandler(synced bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/" + routeHealth:
+			w.WriteHeader(http.StatusOK)
+		case "/" + routeInfo:
+			info := &jsonmodels.InfoResponse{Version: "http://" + r.Host}
+			info.TangleTime.Synced = synced
+			writeJSON(w, info)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, obj interface{}) {
+	w.Header().Set(contentType, contentTypeJSON)
+	_ = json.NewEncoder(w).Encode(obj)
+}
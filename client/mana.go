@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -8,23 +9,25 @@ import (
 )
 
 const (
-	routeGetMana                  = "mana"
-	routeGetAllMana               = "mana/all"
-	routeGetManaPercentile        = "mana/percentile"
-	routeGetOnlineAccessMana      = "mana/access/online"
-	routeGetOnlineConsensusMana   = "mana/consensus/online"
-	routeGetNHighestAccessMana    = "mana/access/nhighest"
-	routeGetNHighestConsensusMana = "mana/consensus/nhighest"
-	routePending                  = "mana/pending"
-	routePastConsensusVector      = "mana/consensus/past"
-	routePastConsensusEventLogs   = "mana/consensus/logs"
-	routeAllowedPledgeNodeIDs     = "mana/allowedManaPledge"
+	routeGetMana                     = "mana"
+	routeGetAllMana                  = "mana/all"
+	routeGetManaPercentile           = "mana/percentile"
+	routeGetOnlineAccessMana         = "mana/access/online"
+	routeGetOnlineConsensusMana      = "mana/consensus/online"
+	routeGetNHighestAccessMana       = "mana/access/nhighest"
+	routeGetNHighestConsensusMana    = "mana/consensus/nhighest"
+	routePending                     = "mana/pending"
+	routePastConsensusVector         = "mana/consensus/past"
+	routePastConsensusEventLogs      = "mana/consensus/logs"
+	routePastConsensusVectorMetadata = "mana/consensus/metadata"
+	routeAllowedPledgeNodeIDs        = "mana/allowedManaPledge"
+	routeManaEvents                  = "mana/events"
 )
 
 // GetOwnMana returns the access and consensus mana of the node this api client is communicating with.
-func (api *GoShimmerAPI) GetOwnMana() (*jsonmodels.GetManaResponse, error) {
+func (api *GoShimmerAPI) GetOwnMana(ctx context.Context) (*jsonmodels.GetManaResponse, error) {
 	res := &jsonmodels.GetManaResponse{}
-	if err := api.do(http.MethodGet, routeGetMana,
+	if err := api.do(ctx, http.MethodGet, routeGetMana,
 		&jsonmodels.GetManaRequest{NodeID: ""}, res); err != nil {
 		return nil, err
 	}
@@ -33,9 +36,9 @@ func (api *GoShimmerAPI) GetOwnMana() (*jsonmodels.GetManaResponse, error) {
 
 // GetManaFullNodeID returns the access and consensus mana of the node specified in the argument.
 // Note, that for the node to understand which nodeID we are referring to, short node ID is not sufficient.
-func (api *GoShimmerAPI) GetManaFullNodeID(fullNodeID string) (*jsonmodels.GetManaResponse, error) {
+func (api *GoShimmerAPI) GetManaFullNodeID(ctx context.Context, fullNodeID string) (*jsonmodels.GetManaResponse, error) {
 	res := &jsonmodels.GetManaResponse{}
-	if err := api.do(http.MethodGet, routeGetMana,
+	if err := api.do(ctx, http.MethodGet, routeGetMana,
 		&jsonmodels.GetManaRequest{NodeID: fullNodeID}, res); err != nil {
 		return nil, err
 	}
@@ -43,10 +46,10 @@ func (api *GoShimmerAPI) GetManaFullNodeID(fullNodeID string) (*jsonmodels.GetMa
 }
 
 // GetMana returns the access and consensus mana a node has based on its shortNodeID.
-func (api *GoShimmerAPI) GetMana(shortNodeID string) (*jsonmodels.GetManaResponse, error) {
+func (api *GoShimmerAPI) GetMana(ctx context.Context, shortNodeID string) (*jsonmodels.GetManaResponse, error) {
 	// ask the node about the full mana map and filter out based on shortID
 	allManaRes := &jsonmodels.GetAllManaResponse{}
-	if err := api.do(http.MethodGet, routeGetAllMana,
+	if err := api.do(ctx, http.MethodGet, routeGetAllMana,
 		nil, allManaRes); err != nil {
 		return nil, err
 	}
@@ -68,9 +71,9 @@ func (api *GoShimmerAPI) GetMana(shortNodeID string) (*jsonmodels.GetManaRespons
 }
 
 // GetAllMana returns the mana perception of the node in the network.
-func (api *GoShimmerAPI) GetAllMana() (*jsonmodels.GetAllManaResponse, error) {
+func (api *GoShimmerAPI) GetAllMana(ctx context.Context) (*jsonmodels.GetAllManaResponse, error) {
 	res := &jsonmodels.GetAllManaResponse{}
-	if err := api.do(http.MethodGet, routeGetAllMana,
+	if err := api.do(ctx, http.MethodGet, routeGetAllMana,
 		nil, res); err != nil {
 		return nil, err
 	}
@@ -78,9 +81,9 @@ func (api *GoShimmerAPI) GetAllMana() (*jsonmodels.GetAllManaResponse, error) {
 }
 
 // GetManaPercentile returns the mana percentile for access and consensus mana of a node.
-func (api *GoShimmerAPI) GetManaPercentile(fullNodeID string) (*jsonmodels.GetPercentileResponse, error) {
+func (api *GoShimmerAPI) GetManaPercentile(ctx context.Context, fullNodeID string) (*jsonmodels.GetPercentileResponse, error) {
 	res := &jsonmodels.GetPercentileResponse{}
-	if err := api.do(http.MethodGet, routeGetManaPercentile,
+	if err := api.do(ctx, http.MethodGet, routeGetManaPercentile,
 		&jsonmodels.GetPercentileRequest{NodeID: fullNodeID}, res); err != nil {
 		return nil, err
 	}
@@ -88,9 +91,9 @@ func (api *GoShimmerAPI) GetManaPercentile(fullNodeID string) (*jsonmodels.GetPe
 }
 
 // GetOnlineAccessMana returns the sorted list of online access mana of nodes.
-func (api *GoShimmerAPI) GetOnlineAccessMana() (*jsonmodels.GetOnlineResponse, error) {
+func (api *GoShimmerAPI) GetOnlineAccessMana(ctx context.Context) (*jsonmodels.GetOnlineResponse, error) {
 	res := &jsonmodels.GetOnlineResponse{}
-	if err := api.do(http.MethodGet, routeGetOnlineAccessMana,
+	if err := api.do(ctx, http.MethodGet, routeGetOnlineAccessMana,
 		nil, res); err != nil {
 		return nil, err
 	}
@@ -98,9 +101,9 @@ func (api *GoShimmerAPI) GetOnlineAccessMana() (*jsonmodels.GetOnlineResponse, e
 }
 
 // GetOnlineConsensusMana returns the sorted list of online consensus mana of nodes.
-func (api *GoShimmerAPI) GetOnlineConsensusMana() (*jsonmodels.GetOnlineResponse, error) {
+func (api *GoShimmerAPI) GetOnlineConsensusMana(ctx context.Context) (*jsonmodels.GetOnlineResponse, error) {
 	res := &jsonmodels.GetOnlineResponse{}
-	if err := api.do(http.MethodGet, routeGetOnlineConsensusMana,
+	if err := api.do(ctx, http.MethodGet, routeGetOnlineConsensusMana,
 		nil, res); err != nil {
 		return nil, err
 	}
@@ -108,9 +111,9 @@ func (api *GoShimmerAPI) GetOnlineConsensusMana() (*jsonmodels.GetOnlineResponse
 }
 
 // GetNHighestAccessMana returns the N highest access mana holders in the network, sorted in descending order.
-func (api *GoShimmerAPI) GetNHighestAccessMana(n int) (*jsonmodels.GetNHighestResponse, error) {
+func (api *GoShimmerAPI) GetNHighestAccessMana(ctx context.Context, n int) (*jsonmodels.GetNHighestResponse, error) {
 	res := &jsonmodels.GetNHighestResponse{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		return fmt.Sprintf("%s?number=%d", routeGetNHighestAccessMana, n)
 	}(), nil, res); err != nil {
 		return nil, err
@@ -119,9 +122,9 @@ func (api *GoShimmerAPI) GetNHighestAccessMana(n int) (*jsonmodels.GetNHighestRe
 }
 
 // GetNHighestConsensusMana returns the N highest consensus mana holders in the network, sorted in descending order.
-func (api *GoShimmerAPI) GetNHighestConsensusMana(n int) (*jsonmodels.GetNHighestResponse, error) {
+func (api *GoShimmerAPI) GetNHighestConsensusMana(ctx context.Context, n int) (*jsonmodels.GetNHighestResponse, error) {
 	res := &jsonmodels.GetNHighestResponse{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		return fmt.Sprintf("%s?number=%d", routeGetNHighestConsensusMana, n)
 	}(), nil, res); err != nil {
 		return nil, err
@@ -130,9 +133,9 @@ func (api *GoShimmerAPI) GetNHighestConsensusMana(n int) (*jsonmodels.GetNHighes
 }
 
 // GetPending returns the mana (bm2) that will be pledged by spending the output specified.
-func (api *GoShimmerAPI) GetPending(outputID string) (*jsonmodels.PendingResponse, error) {
+func (api *GoShimmerAPI) GetPending(ctx context.Context, outputID string) (*jsonmodels.PendingResponse, error) {
 	res := &jsonmodels.PendingResponse{}
-	if err := api.do(http.MethodGet, routePending,
+	if err := api.do(ctx, http.MethodGet, routePending,
 		&jsonmodels.PendingRequest{OutputID: outputID}, res); err != nil {
 		return nil, err
 	}
@@ -140,38 +143,67 @@ func (api *GoShimmerAPI) GetPending(outputID string) (*jsonmodels.PendingRespons
 }
 
 // GetPastConsensusManaVector returns the consensus base mana vector of a time in the past.
-func (api *GoShimmerAPI) GetPastConsensusManaVector(t int64) (*jsonmodels.PastConsensusManaVectorResponse, error) {
+func (api *GoShimmerAPI) GetPastConsensusManaVector(ctx context.Context, t int64) (*jsonmodels.PastConsensusManaVectorResponse, error) {
 	res := &jsonmodels.PastConsensusManaVectorResponse{}
-	if err := api.do(http.MethodGet, routePastConsensusVector,
+	if err := api.do(ctx, http.MethodGet, routePastConsensusVector,
 		&jsonmodels.PastConsensusManaVectorRequest{Timestamp: t}, res); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
+// GetPastConsensusManaOfNode returns the consensus mana of the node specified at a time in the past.
+func (api *GoShimmerAPI) GetPastConsensusManaOfNode(ctx context.Context, fullNodeID string, t int64) (*jsonmodels.PastConsensusManaVectorResponse, error) {
+	res := &jsonmodels.PastConsensusManaVectorResponse{}
+	if err := api.do(ctx, http.MethodGet, routePastConsensusVector,
+		&jsonmodels.PastConsensusManaVectorRequest{Timestamp: t, NodeID: fullNodeID}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetPastNHighestConsensusMana returns the n highest consensus mana nodes at a time in the past.
+func (api *GoShimmerAPI) GetPastNHighestConsensusMana(ctx context.Context, n uint, t int64) (*jsonmodels.PastConsensusManaVectorResponse, error) {
+	res := &jsonmodels.PastConsensusManaVectorResponse{}
+	if err := api.do(ctx, http.MethodGet, routePastConsensusVector,
+		&jsonmodels.PastConsensusManaVectorRequest{Timestamp: t, Number: n}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 // GetPastConsensusVectorMetadata returns the consensus base mana vector metadata of a time in the past.
-func (api *GoShimmerAPI) GetPastConsensusVectorMetadata() (*jsonmodels.PastConsensusVectorMetadataResponse, error) {
+func (api *GoShimmerAPI) GetPastConsensusVectorMetadata(ctx context.Context) (*jsonmodels.PastConsensusVectorMetadataResponse, error) {
 	res := &jsonmodels.PastConsensusVectorMetadataResponse{}
-	if err := api.do(http.MethodGet, routePastConsensusVector, nil, res); err != nil {
+	if err := api.do(ctx, http.MethodGet, routePastConsensusVectorMetadata, nil, res); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
 // GetConsensusEventLogs returns the consensus event logs or the nodeIDs specified.
-func (api *GoShimmerAPI) GetConsensusEventLogs(nodeIDs []string) (*jsonmodels.GetEventLogsResponse, error) {
+func (api *GoShimmerAPI) GetConsensusEventLogs(ctx context.Context, nodeIDs []string) (*jsonmodels.GetEventLogsResponse, error) {
 	res := &jsonmodels.GetEventLogsResponse{}
-	if err := api.do(http.MethodGet, routePastConsensusEventLogs,
+	if err := api.do(ctx, http.MethodGet, routePastConsensusEventLogs,
 		&jsonmodels.GetEventLogsRequest{NodeIDs: nodeIDs}, res); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
+// GetManaEvents returns the recent mana events recorded by the mana event logger that match the given filters.
+func (api *GoShimmerAPI) GetManaEvents(ctx context.Context, req *jsonmodels.GetManaEventsRequest) (*jsonmodels.GetManaEventsResponse, error) {
+	res := &jsonmodels.GetManaEventsResponse{}
+	if err := api.do(ctx, http.MethodGet, routeManaEvents, req, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 // GetAllowedManaPledgeNodeIDs returns the list of allowed mana pledge IDs.
-func (api *GoShimmerAPI) GetAllowedManaPledgeNodeIDs() (*jsonmodels.AllowedManaPledgeResponse, error) {
+func (api *GoShimmerAPI) GetAllowedManaPledgeNodeIDs(ctx context.Context) (*jsonmodels.AllowedManaPledgeResponse, error) {
 	res := &jsonmodels.AllowedManaPledgeResponse{}
-	if err := api.do(http.MethodGet, routeAllowedPledgeNodeIDs, nil, res); err != nil {
+	if err := api.do(ctx, http.MethodGet, routeAllowedPledgeNodeIDs, nil, res); err != nil {
 		return nil, err
 	}
 
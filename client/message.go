@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/iotaledger/goshimmer/packages/jsonmodels"
@@ -13,10 +14,11 @@ const (
 )
 
 // GetMessage is the handler for the /messages/:messageID endpoint.
-func (api *GoShimmerAPI) GetMessage(base58EncodedID string) (*jsonmodels.Message, error) {
+func (api *GoShimmerAPI) GetMessage(ctx context.Context, base58EncodedID string) (*jsonmodels.Message, error) {
 	res := &jsonmodels.Message{}
 
 	if err := api.do(
+		ctx,
 		http.MethodGet,
 		routeMessage+base58EncodedID,
 		nil,
@@ -29,10 +31,11 @@ func (api *GoShimmerAPI) GetMessage(base58EncodedID string) (*jsonmodels.Message
 }
 
 // GetMessageMetadata is the handler for the /messages/:messageID/metadata endpoint.
-func (api *GoShimmerAPI) GetMessageMetadata(base58EncodedID string) (*jsonmodels.MessageMetadata, error) {
+func (api *GoShimmerAPI) GetMessageMetadata(ctx context.Context, base58EncodedID string) (*jsonmodels.MessageMetadata, error) {
 	res := &jsonmodels.MessageMetadata{}
 
 	if err := api.do(
+		ctx,
 		http.MethodGet,
 		routeMessage+base58EncodedID+routeMessageMetadata,
 		nil,
@@ -45,9 +48,9 @@ func (api *GoShimmerAPI) GetMessageMetadata(base58EncodedID string) (*jsonmodels
 }
 
 // SendPayload send a message with the given payload.
-func (api *GoShimmerAPI) SendPayload(payload []byte) (string, error) {
+func (api *GoShimmerAPI) SendPayload(ctx context.Context, payload []byte) (string, error) {
 	res := &jsonmodels.PostPayloadResponse{}
-	if err := api.do(http.MethodPost, routeSendPayload,
+	if err := api.do(ctx, http.MethodPost, routeSendPayload,
 		&jsonmodels.PostPayloadRequest{Payload: payload}, res); err != nil {
 		return "", err
 	}
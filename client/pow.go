@@ -0,0 +1,30 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+)
+
+const (
+	routePoW = "tools/pow"
+)
+
+// RemotePoW lets the node perform the PoW for the given data, i.e. the bytes the nonce is appended to, and returns the
+// nonce.
+func (api *GoShimmerAPI) RemotePoW(ctx context.Context, data []byte, difficulty int) (uint64, error) {
+	res := &jsonmodels.PowResponse{}
+	if err := api.do(ctx, http.MethodPost, routePoW, &jsonmodels.PowRequest{Data: data, Difficulty: difficulty}, res); err != nil {
+		return 0, err
+	}
+	return res.Nonce, nil
+}
+
+// mine performs the PoW for the given data either locally or on the node, depending on the WithRemotePoW option.
+func (api *GoShimmerAPI) mine(ctx context.Context, data []byte, difficulty int) (uint64, error) {
+	if api.remotePoW {
+		return api.RemotePoW(ctx, data, difficulty)
+	}
+	return powWorker.Mine(ctx, data, difficulty)
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -12,7 +13,7 @@ const (
 )
 
 // ToggleSpammer toggles the node internal spammer.
-func (api *GoShimmerAPI) ToggleSpammer(enable bool, rate int, unit, imif string) (*jsonmodels.SpammerResponse, error) {
+func (api *GoShimmerAPI) ToggleSpammer(ctx context.Context, enable bool, rate int, unit, imif string) (*jsonmodels.SpammerResponse, error) {
 	// set default imif in case of incorrect imif value
 	if imif != "poisson" {
 		imif = "uniform"
@@ -22,7 +23,7 @@ func (api *GoShimmerAPI) ToggleSpammer(enable bool, rate int, unit, imif string)
 		unit = "mps"
 	}
 	res := &jsonmodels.SpammerResponse{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		if enable {
 			return fmt.Sprintf("%s?cmd=start&rate=%d&imif=%s&unit=%s", routeSpammer, rate, imif, unit)
 		}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/cockroachdb/errors"
@@ -17,10 +18,11 @@ const (
 
 // PastConeExist checks that all of the messages in the past cone of a message are existing on the node
 // down to the genesis. Returns the number of messages in the past cone as well.
-func (api *GoShimmerAPI) PastConeExist(base58EncodedMessageID string) (*jsonmodels.PastconeResponse, error) {
+func (api *GoShimmerAPI) PastConeExist(ctx context.Context, base58EncodedMessageID string) (*jsonmodels.PastconeResponse, error) {
 	res := &jsonmodels.PastconeResponse{}
 
 	if err := api.do(
+		ctx,
 		http.MethodGet,
 		routePastCone,
 		&jsonmodels.PastconeRequest{ID: base58EncodedMessageID},
@@ -36,9 +38,9 @@ func (api *GoShimmerAPI) PastConeExist(base58EncodedMessageID string) (*jsonmode
 }
 
 // Missing returns all the missing messages and their count.
-func (api *GoShimmerAPI) Missing() (*jsonmodels.MissingResponse, error) {
+func (api *GoShimmerAPI) Missing(ctx context.Context) (*jsonmodels.MissingResponse, error) {
 	res := &jsonmodels.MissingResponse{}
-	if err := api.do(http.MethodGet, routeMissing, nil, res); err != nil {
+	if err := api.do(ctx, http.MethodGet, routeMissing, nil, res); err != nil {
 		return nil, err
 	}
 	return res, nil
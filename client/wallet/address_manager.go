@@ -16,6 +16,10 @@ type AddressManager struct {
 	lastAddressIndex uint64
 	spentAddresses   []bitmask.BitMask
 
+	// state of the remainder addresses on the change chain of hierarchical seeds
+	changeAddressCount   uint64
+	spentChangeAddresses []bitmask.BitMask
+
 	// internal variables for faster access
 	firstUnspentAddressIndex uint64
 	lastUnspentAddressIndex  uint64
@@ -44,14 +48,14 @@ func (addressManager *AddressManager) Address(addressIndex uint64) address.Addre
 	return addressManager.seed.Address(addressIndex)
 }
 
-// Addresses returns a list of all addresses of the wallet.
+// Addresses returns a list of all addresses of the wallet including its remainder addresses.
 func (addressManager *AddressManager) Addresses() (addresses []address.Address) {
-	addresses = make([]address.Address, addressManager.lastAddressIndex+1)
+	addresses = make([]address.Address, addressManager.lastAddressIndex+1, addressManager.lastAddressIndex+1+addressManager.changeAddressCount)
 	for i := uint64(0); i <= addressManager.lastAddressIndex; i++ {
 		addresses[i] = addressManager.Address(i)
 	}
 
-	return
+	return append(addresses, addressManager.ChangeAddresses()...)
 }
 
 // UnspentAddresses returns a list of all unspent addresses of the wallet.
@@ -62,6 +66,11 @@ func (addressManager *AddressManager) UnspentAddresses() (addresses []address.Ad
 			addresses = append(addresses, addressManager.Address(i))
 		}
 	}
+	for i := uint64(0); i < addressManager.changeAddressCount; i++ {
+		if !addressManager.IsChangeAddressSpent(i) {
+			addresses = append(addresses, addressManager.seed.ChangeAddress(i))
+		}
+	}
 
 	return
 }
@@ -74,10 +83,58 @@ func (addressManager *AddressManager) SpentAddresses() (addresses []address.Addr
 			addresses = append(addresses, addressManager.Address(i))
 		}
 	}
+	for i := uint64(0); i < addressManager.changeAddressCount; i++ {
+		if addressManager.IsChangeAddressSpent(i) {
+			addresses = append(addresses, addressManager.seed.ChangeAddress(i))
+		}
+	}
+
+	return
+}
+
+// ChangeAddresses returns a list of all remainder addresses that were generated on the change chain.
+func (addressManager *AddressManager) ChangeAddresses() (addresses []address.Address) {
+	addresses = make([]address.Address, addressManager.changeAddressCount)
+	for i := range addresses {
+		addresses[i] = addressManager.seed.ChangeAddress(uint64(i))
+	}
 
 	return
 }
 
+// NewChangeAddress generates and returns a new unused remainder address on the change chain. It must only be used
+// with hierarchical seeds.
+func (addressManager *AddressManager) NewChangeAddress() address.Address {
+	addressIndex := addressManager.changeAddressCount
+	addressManager.changeAddressCount++
+	addressManager.spentChangeAddressIndexes(addressIndex)
+
+	return addressManager.seed.ChangeAddress(addressIndex)
+}
+
+// MarkChangeAddressSpent marks the remainder address with the given index as spent.
+func (addressManager *AddressManager) MarkChangeAddressSpent(addressIndex uint64) {
+	sliceIndex, bitIndex := addressManager.spentChangeAddressIndexes(addressIndex)
+
+	addressManager.spentChangeAddresses[sliceIndex] = addressManager.spentChangeAddresses[sliceIndex].SetBit(uint(bitIndex))
+}
+
+// IsSpent returns true if the given address of the wallet was spent already.
+func (addressManager *AddressManager) IsSpent(addr address.Address) bool {
+	if addr.Change {
+		return addressManager.IsChangeAddressSpent(addr.Index)
+	}
+
+	return addressManager.IsAddressSpent(addr.Index)
+}
+
+// IsChangeAddressSpent returns true if the remainder address with the given index was spent already.
+func (addressManager *AddressManager) IsChangeAddressSpent(addressIndex uint64) bool {
+	sliceIndex, bitIndex := addressManager.spentChangeAddressIndexes(addressIndex)
+
+	return addressManager.spentChangeAddresses[sliceIndex].HasBit(uint(bitIndex))
+}
+
 // FirstUnspentAddress returns the first unspent address that we know.
 func (addressManager *AddressManager) FirstUnspentAddress() address.Address {
 	return addressManager.Address(addressManager.firstUnspentAddressIndex)
@@ -110,6 +167,29 @@ func (addressManager *AddressManager) MarkAddressSpent(addressIndex uint64) {
 	}
 }
 
+// Recover rebuilds the state of the AddressManager from the result of a recovery scan. It marks the given addresses as
+// spent and generates all addresses up to the first one after the last used address, which becomes the receive
+// address.
+func (addressManager *AddressManager) Recover(lastUsedAddressIndex uint64, spentAddressIndexes []uint64) {
+	for _, addressIndex := range spentAddressIndexes {
+		addressManager.MarkAddressSpent(addressIndex)
+	}
+
+	addressManager.Address(lastUsedAddressIndex + 1)
+}
+
+// RecoverChange rebuilds the state of the remainder addresses from the result of a recovery scan of the change chain.
+// It marks the given remainder addresses as spent and generates all remainder addresses up to the last used one.
+func (addressManager *AddressManager) RecoverChange(lastUsedAddressIndex uint64, spentAddressIndexes []uint64) {
+	if lastUsedAddressIndex >= addressManager.changeAddressCount {
+		addressManager.changeAddressCount = lastUsedAddressIndex + 1
+	}
+
+	for _, addressIndex := range spentAddressIndexes {
+		addressManager.MarkChangeAddressSpent(addressIndex)
+	}
+}
+
 // IsAddressSpent returns true if the address given by the address index was spent already.
 func (addressManager *AddressManager) IsAddressSpent(addressIndex uint64) bool {
 	sliceIndex, bitIndex := addressManager.spentAddressIndexes(addressIndex)
@@ -144,6 +224,20 @@ func (addressManager *AddressManager) spentAddressIndexes(addressIndex uint64) (
 	return
 }
 
+// spentChangeAddressIndexes retrieves the indexes for the internal representation of the spent remainder addresses
+// bitmask slice that belongs to the given address index. It automatically increases the capacity of the slice.
+func (addressManager *AddressManager) spentChangeAddressIndexes(addressIndex uint64) (sliceIndex uint64, bitIndex uint64) {
+	spentAddressesCapacity := uint64(len(addressManager.spentChangeAddresses))
+	sliceIndex = addressIndex / 8
+	bitIndex = addressIndex % 8
+
+	if sliceIndex+1 > spentAddressesCapacity {
+		addressManager.spentChangeAddresses = append(addressManager.spentChangeAddresses, make([]bitmask.BitMask, sliceIndex-spentAddressesCapacity+1)...)
+	}
+
+	return
+}
+
 // updateFirstUnspentAddressIndex searches for the first unspent address and updates the firstUnspentAddressIndex.
 func (addressManager *AddressManager) updateFirstUnspentAddressIndex() {
 	for i := addressManager.firstUnspentAddressIndex; true; i++ {
@@ -1,9 +1,6 @@
 package wallet
 
 import (
-	"github.com/capossele/asset-registry/pkg/registry"
-	"github.com/cockroachdb/errors"
-
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 )
 
@@ -27,34 +24,3 @@ type Asset struct {
 	// TransactionID that created the asset
 	TransactionID ledgerstate.TransactionID
 }
-
-// ToRegistry creates a ergistry asset from a wallet asset.
-func (a *Asset) ToRegistry() *registry.Asset {
-	return &registry.Asset{
-		ID:            a.Color.Base58(),
-		Name:          a.Name,
-		Symbol:        a.Symbol,
-		Supply:        a.Supply,
-		TransactionID: a.TransactionID.Base58(),
-	}
-}
-
-// AssetFromRegistryEntry creates a wallet asset from a registry asset.
-func AssetFromRegistryEntry(regAsset *registry.Asset) (*Asset, error) {
-	color, err := ledgerstate.ColorFromBase58EncodedString(regAsset.ID)
-	if err != nil {
-		return nil, errors.Errorf("failed to parse color(ID) of asset from registry response: %w, err")
-	}
-	var txID ledgerstate.TransactionID
-	txID, err = ledgerstate.TransactionIDFromBase58(regAsset.TransactionID)
-	if err != nil {
-		return nil, errors.Errorf("failed to parse TransactionID of asset from registry response: %w, err")
-	}
-	return &Asset{
-		Color:         color,
-		Name:          regAsset.Name,
-		Symbol:        regAsset.Symbol,
-		Supply:        regAsset.Supply,
-		TransactionID: txID,
-	}, nil
-}
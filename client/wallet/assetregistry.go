@@ -1,43 +1,30 @@
 package wallet
 
 import (
-	"context"
 	"strconv"
 
-	"github.com/capossele/asset-registry/pkg/registryclient"
 	"github.com/capossele/asset-registry/pkg/registryservice"
 	"github.com/cockroachdb/errors"
-	"github.com/go-resty/resty/v2"
 	"github.com/iotaledger/hive.go/marshalutil"
 	"github.com/iotaledger/hive.go/typeutils"
 
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 )
 
-const (
-	// RegistryHostURL is the host url of the central registry.
-	RegistryHostURL = "http://asset-registry.tokenizedassetsdemo.iota.cafe"
-)
-
-// AssetRegistry represents a registry for colored coins, that stores the relevant metadata in a dictionary.
+// AssetRegistry represents a registry for colored coins, that stores the relevant metadata in a dictionary. Assets
+// that are not known locally are looked up in the on-tangle registry of the connected node.
 type AssetRegistry struct {
 	assets map[ledgerstate.Color]Asset
-	// client communicates with the central registry
-	client  *registryclient.HTTPClient
-	network string
+	// connector retrieves the metadata that was registered on the tangle
+	connector Connector
+	network   string
 }
 
 // NewAssetRegistry is the constructor for the AssetRegistry.
-func NewAssetRegistry(network string, registryURL ...string) *AssetRegistry {
-	hostURL := RegistryHostURL
-	if len(registryURL) > 0 {
-		hostURL = registryURL[0]
-	}
-	client := registryclient.NewHTTPClient(resty.New().SetHostURL(hostURL))
+func NewAssetRegistry(network string) *AssetRegistry {
 	return &AssetRegistry{
-		make(map[ledgerstate.Color]Asset),
-		client,
-		network,
+		assets:  make(map[ledgerstate.Color]Asset),
+		network: network,
 	}
 }
 
@@ -145,21 +132,16 @@ func ParseAssetRegistry(marshalUtil *marshalutil.MarshalUtil) (assetRegistry *As
 	return
 }
 
-// SetRegistryURL sets the url of the registry api server.
-func (a *AssetRegistry) SetRegistryURL(url string) {
-	a.client = registryclient.NewHTTPClient(resty.New().SetHostURL(url))
-}
-
 // Network returns the current network the asset registry connects to.
 func (a *AssetRegistry) Network() string {
 	return a.network
 }
 
-// LoadAsset returns an asset either from local or from central registry.
+// LoadAsset returns an asset either from local or from the on-tangle registry.
 func (a *AssetRegistry) LoadAsset(id ledgerstate.Color) (*Asset, error) {
 	_, ok := a.assets[id]
 	if !ok {
-		success := a.updateLocalFromCentral(id)
+		success := a.updateLocalFromTangle(id)
 		if !success {
 			return nil, errors.Errorf("no asset found with assetID (color) %s", id.Base58())
 		}
@@ -168,10 +150,10 @@ func (a *AssetRegistry) LoadAsset(id ledgerstate.Color) (*Asset, error) {
 	return &asset, nil
 }
 
-// RegisterAsset registers an asset in the registry, so we can look up names and symbol of colored coins.
-func (a *AssetRegistry) RegisterAsset(color ledgerstate.Color, asset Asset) error {
+// RegisterAsset registers an asset in the local registry, so we can look up names and symbol of colored coins. The
+// wallet publishes the metadata of the assets it creates to the on-tangle registry.
+func (a *AssetRegistry) RegisterAsset(color ledgerstate.Color, asset Asset) {
 	a.assets[color] = asset
-	return a.client.SaveAsset(context.TODO(), a.network, asset.ToRegistry())
 }
 
 // Name returns the name of the given asset.
@@ -185,8 +167,8 @@ func (a *AssetRegistry) Name(color ledgerstate.Color) string {
 		return "IOTA"
 	}
 	// not in local
-	// fetch from central, update local
-	if a.updateLocalFromCentral(color) {
+	// fetch from tangle, update local
+	if a.updateLocalFromTangle(color) {
 		return a.assets[color].Name
 	}
 	// fallback if we fetch was not successful, just use the color as name
@@ -204,8 +186,8 @@ func (a *AssetRegistry) Symbol(color ledgerstate.Color) string {
 	}
 
 	// not in local
-	// fetch from central, update local
-	if a.updateLocalFromCentral(color) {
+	// fetch from tangle, update local
+	if a.updateLocalFromTangle(color) {
 		return a.assets[color].Symbol
 	}
 
@@ -223,8 +205,8 @@ func (a *AssetRegistry) Supply(color ledgerstate.Color) string {
 	}
 
 	// not in local
-	// fetch from central, update local
-	if a.updateLocalFromCentral(color) {
+	// fetch from tangle, update local
+	if a.updateLocalFromTangle(color) {
 		return strconv.FormatUint(a.assets[color].Supply, 10)
 	}
 
@@ -242,8 +224,8 @@ func (a *AssetRegistry) TransactionID(color ledgerstate.Color) string {
 	}
 
 	// not in local
-	// fetch from central, update local
-	if a.updateLocalFromCentral(color) {
+	// fetch from tangle, update local
+	if a.updateLocalFromTangle(color) {
 		return a.assets[color].TransactionID.Base58()
 	}
 
@@ -289,16 +271,17 @@ func (a *AssetRegistry) Bytes() []byte {
 	return marshalUtil.Bytes()
 }
 
-func (a *AssetRegistry) updateLocalFromCentral(color ledgerstate.Color) (success bool) {
-	loadedAsset, err := a.client.LoadAsset(context.TODO(), a.network, color.Base58())
-	if err == nil {
-		// save it locally
-		var walletAsset *Asset
-		walletAsset, err = AssetFromRegistryEntry(loadedAsset)
-		if err == nil {
-			a.assets[walletAsset.Color] = *walletAsset
-			return true
-		}
+func (a *AssetRegistry) updateLocalFromTangle(color ledgerstate.Color) (success bool) {
+	if a.connector == nil {
+		return false
+	}
+
+	loadedAsset, err := a.connector.GetAsset(color)
+	if err != nil || loadedAsset == nil {
+		return false
 	}
-	return false
+
+	// save it locally
+	a.assets[loadedAsset.Color] = *loadedAsset
+	return true
 }
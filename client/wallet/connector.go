@@ -2,6 +2,7 @@ package wallet
 
 import (
 	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/packages/assetregistry"
 	"github.com/iotaledger/goshimmer/packages/consensus/gof"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/mana"
@@ -11,9 +12,12 @@ import (
 // locally on a server or it can connect remotely using the web API.
 type Connector interface {
 	UnspentOutputs(addresses ...address.Address) (unspentOutputs OutputsByAddressAndOutputID, err error)
+	ScanAddresses(addresses ...address.Address) (unspentOutputs OutputsByAddressAndOutputID, spentAddresses map[address.Address]bool, err error)
 	SendTransaction(transaction *ledgerstate.Transaction) (err error)
 	RequestFaucetFunds(address address.Address, powTarget int) (err error)
 	GetAllowedPledgeIDs() (pledgeIDMap map[mana.Type][]string, err error)
 	GetTransactionGoF(txID ledgerstate.TransactionID) (gradeOfFinality gof.GradeOfFinality, err error)
 	GetUnspentAliasOutput(address *ledgerstate.AliasAddress) (output *ledgerstate.AliasOutput, err error)
+	IssueAssetMetadata(metadataPayload *assetregistry.Payload) (err error)
+	GetAsset(color ledgerstate.Color) (asset *Asset, err error)
 }
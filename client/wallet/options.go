@@ -28,6 +28,15 @@ func Import(seed *seed.Seed, lastAddressIndex uint64, spentAddresses []bitmask.B
 	}
 }
 
+// ImportChangeAddresses restores the remainder addresses of a wallet with a hierarchical seed. It needs to be passed
+// after Import.
+func ImportChangeAddresses(changeAddressCount uint64, spentChangeAddresses []bitmask.BitMask) Option {
+	return func(wallet *Wallet) {
+		wallet.addressManager.changeAddressCount = changeAddressCount
+		wallet.addressManager.spentChangeAddresses = spentChangeAddresses
+	}
+}
+
 // ReusableAddress configures the wallet to run in "single address" mode where all the funds are always managed on a
 // single reusable address.
 func ReusableAddress(enabled bool) Option {
@@ -84,7 +84,8 @@ func (o OutputsByAddressAndOutputID) ValueOutputsOnly() OutputsByAddressAndOutpu
 	return result
 }
 
-// ConditionalOutputsOnly return ExtendedLockedOutputs that are currently conditionally owned by the wallet.
+// ConditionalOutputsOnly return ExtendedLockedOutputs that are currently conditionally owned by the wallet. Hash locked
+// outputs are excluded as they can only be claimed with their preimage.
 func (o OutputsByAddressAndOutputID) ConditionalOutputsOnly() OutputsByAddressAndOutputID {
 	now := time.Now()
 	result := NewAddressToOutputs()
@@ -93,7 +94,7 @@ func (o OutputsByAddressAndOutputID) ConditionalOutputsOnly() OutputsByAddressAn
 			if output.Object.Type() == ledgerstate.ExtendedLockedOutputType {
 				casted := output.Object.(*ledgerstate.ExtendedLockedOutput)
 				_, fallbackDeadline := casted.FallbackOptions()
-				if !fallbackDeadline.IsZero() && addy.Address().Equals(casted.UnlockAddressNow(now)) {
+				if !fallbackDeadline.IsZero() && !casted.HashLockedNow(now) && addy.Address().Equals(casted.UnlockAddressNow(now)) {
 					if _, addressExists := result[addy]; !addressExists {
 						result[addy] = make(map[ledgerstate.OutputID]*Output)
 					}
@@ -12,6 +12,8 @@ import (
 type Address struct {
 	AddressBytes [ledgerstate.AddressLength]byte
 	Index        uint64
+	// Change is true if the address was generated on the chain of remainder addresses of a hierarchical seed.
+	Change bool
 }
 
 // Address returns the ledgerstate Address of this wallet Address.
@@ -33,6 +35,7 @@ func (a Address) String() string {
 	return stringify.Struct("Address",
 		stringify.StructField("Address", a.Address()),
 		stringify.StructField("Index", a.Index),
+		stringify.StructField("Change", a.Change),
 	)
 }
 
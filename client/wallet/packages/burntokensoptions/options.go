@@ -0,0 +1,87 @@
+package burntokensoptions
+
+import (
+	"github.com/cockroachdb/errors"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+// BurnTokensOption is a function that provides an option.
+type BurnTokensOption func(options *BurnTokensOptions) error
+
+// WaitForConfirmation defines if the call should wait for confirmation before it returns.
+func WaitForConfirmation(wait bool) BurnTokensOption {
+	return func(options *BurnTokensOptions) error {
+		options.WaitForConfirmation = wait
+		return nil
+	}
+}
+
+// Amount sets how many tokens should be burned.
+func Amount(amount uint64) BurnTokensOption {
+	return func(options *BurnTokensOptions) error {
+		if amount == 0 {
+			return errors.Errorf("can't burn zero tokens")
+		}
+		options.Amount = amount
+		return nil
+	}
+}
+
+// Alias specifies which alias controls the supply of the burned tokens.
+func Alias(aliasID string) BurnTokensOption {
+	return func(options *BurnTokensOptions) error {
+		parsed, err := ledgerstate.AliasAddressFromBase58EncodedString(aliasID)
+		if err != nil {
+			return err
+		}
+		options.Alias = parsed
+		return nil
+	}
+}
+
+// AccessManaPledgeID is an option for BurnTokens call that defines the nodeID to pledge access mana to.
+func AccessManaPledgeID(nodeID string) BurnTokensOption {
+	return func(options *BurnTokensOptions) error {
+		options.AccessManaPledgeID = nodeID
+		return nil
+	}
+}
+
+// ConsensusManaPledgeID is an option for BurnTokens call that defines the nodeID to pledge consensus mana to.
+func ConsensusManaPledgeID(nodeID string) BurnTokensOption {
+	return func(options *BurnTokensOptions) error {
+		options.ConsensusManaPledgeID = nodeID
+		return nil
+	}
+}
+
+// BurnTokensOptions is a struct that is used to aggregate the optional parameters in the BurnTokens call.
+type BurnTokensOptions struct {
+	Amount                uint64
+	Alias                 *ledgerstate.AliasAddress
+	AccessManaPledgeID    string
+	ConsensusManaPledgeID string
+	WaitForConfirmation   bool
+}
+
+// Build build the options.
+func Build(options ...BurnTokensOption) (result *BurnTokensOptions, err error) {
+	// create options to collect the arguments provided
+	result = &BurnTokensOptions{}
+
+	// apply arguments to our options
+	for _, option := range options {
+		if err = option(result); err != nil {
+			return
+		}
+	}
+	if result.Alias == nil {
+		return nil, errors.Errorf("an alias identifier must be specified for burning")
+	}
+	if result.Amount == 0 {
+		return nil, errors.Errorf("no amount provided for burning")
+	}
+
+	return
+}
@@ -0,0 +1,101 @@
+package claimhtlcoptions
+
+import (
+	"github.com/cockroachdb/errors"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+// ClaimHTLCOption is a function that provides an option.
+type ClaimHTLCOption func(options *ClaimHTLCOptions) error
+
+// WaitForConfirmation defines if the call should wait for confirmation before it returns.
+func WaitForConfirmation(wait bool) ClaimHTLCOption {
+	return func(options *ClaimHTLCOptions) error {
+		options.WaitForConfirmation = wait
+		return nil
+	}
+}
+
+// AccessManaPledgeID is an option for ClaimHTLC call that defines the nodeID to pledge access mana to.
+func AccessManaPledgeID(nodeID string) ClaimHTLCOption {
+	return func(options *ClaimHTLCOptions) error {
+		options.AccessManaPledgeID = nodeID
+		return nil
+	}
+}
+
+// ConsensusManaPledgeID is an option for ClaimHTLC call that defines the nodeID to pledge consensus mana to.
+func ConsensusManaPledgeID(nodeID string) ClaimHTLCOption {
+	return func(options *ClaimHTLCOptions) error {
+		options.ConsensusManaPledgeID = nodeID
+		return nil
+	}
+}
+
+// OutputID specifies which hash time-locked output to claim.
+func OutputID(outputID string) ClaimHTLCOption {
+	return func(options *ClaimHTLCOptions) error {
+		parsed, err := ledgerstate.OutputIDFromBase58(outputID)
+		if err != nil {
+			return err
+		}
+		options.OutputID = parsed
+		return nil
+	}
+}
+
+// Preimage specifies the preimage that satisfies the hash lock of the output.
+func Preimage(preimage []byte) ClaimHTLCOption {
+	return func(options *ClaimHTLCOptions) error {
+		if len(preimage) == 0 || len(preimage) > ledgerstate.MaxPreimageSize {
+			return errors.Errorf("preimage must be between 1 and %d bytes long", ledgerstate.MaxPreimageSize)
+		}
+		options.Preimage = preimage
+		return nil
+	}
+}
+
+// ToAddress specifies the address that receives the claimed funds.
+func ToAddress(address string) ClaimHTLCOption {
+	return func(options *ClaimHTLCOptions) error {
+		parsed, err := ledgerstate.AddressFromBase58EncodedString(address)
+		if err != nil {
+			return err
+		}
+		options.ToAddress = parsed
+		return nil
+	}
+}
+
+// ClaimHTLCOptions is a struct that is used to aggregate the optional parameters in the ClaimHTLC call.
+type ClaimHTLCOptions struct {
+	AccessManaPledgeID    string
+	ConsensusManaPledgeID string
+	OutputID              ledgerstate.OutputID
+	Preimage              []byte
+	ToAddress             ledgerstate.Address
+	WaitForConfirmation   bool
+}
+
+// Build builds the options.
+func Build(options ...ClaimHTLCOption) (result *ClaimHTLCOptions, err error) {
+	// create options to collect the arguments provided
+	result = &ClaimHTLCOptions{}
+
+	// apply arguments to our options
+	for _, option := range options {
+		if err = option(result); err != nil {
+			return
+		}
+	}
+
+	if result.OutputID == ledgerstate.EmptyOutputID {
+		return nil, errors.Errorf("an output identifier must be specified for claiming an HTLC")
+	}
+	if result.Preimage == nil {
+		return nil, errors.Errorf("a preimage must be specified for claiming an HTLC")
+	}
+
+	return
+}
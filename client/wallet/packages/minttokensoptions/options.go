@@ -0,0 +1,100 @@
+package minttokensoptions
+
+import (
+	"github.com/cockroachdb/errors"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+// MintTokensOption is a function that provides an option.
+type MintTokensOption func(options *MintTokensOptions) error
+
+// WaitForConfirmation defines if the call should wait for confirmation before it returns.
+func WaitForConfirmation(wait bool) MintTokensOption {
+	return func(options *MintTokensOptions) error {
+		options.WaitForConfirmation = wait
+		return nil
+	}
+}
+
+// Amount sets how many tokens should be minted.
+func Amount(amount uint64) MintTokensOption {
+	return func(options *MintTokensOptions) error {
+		if amount == 0 {
+			return errors.Errorf("can't mint zero tokens")
+		}
+		options.Amount = amount
+		return nil
+	}
+}
+
+// Alias specifies which alias controls the supply of the minted tokens.
+func Alias(aliasID string) MintTokensOption {
+	return func(options *MintTokensOptions) error {
+		parsed, err := ledgerstate.AliasAddressFromBase58EncodedString(aliasID)
+		if err != nil {
+			return err
+		}
+		options.Alias = parsed
+		return nil
+	}
+}
+
+// ToAddress specifies the address that receives the minted tokens.
+func ToAddress(address string) MintTokensOption {
+	return func(options *MintTokensOptions) error {
+		parsed, err := ledgerstate.AddressFromBase58EncodedString(address)
+		if err != nil {
+			return err
+		}
+		options.ToAddress = parsed
+		return nil
+	}
+}
+
+// AccessManaPledgeID is an option for MintTokens call that defines the nodeID to pledge access mana to.
+func AccessManaPledgeID(nodeID string) MintTokensOption {
+	return func(options *MintTokensOptions) error {
+		options.AccessManaPledgeID = nodeID
+		return nil
+	}
+}
+
+// ConsensusManaPledgeID is an option for MintTokens call that defines the nodeID to pledge consensus mana to.
+func ConsensusManaPledgeID(nodeID string) MintTokensOption {
+	return func(options *MintTokensOptions) error {
+		options.ConsensusManaPledgeID = nodeID
+		return nil
+	}
+}
+
+// MintTokensOptions is a struct that is used to aggregate the optional parameters in the MintTokens call.
+type MintTokensOptions struct {
+	Amount                uint64
+	Alias                 *ledgerstate.AliasAddress
+	ToAddress             ledgerstate.Address
+	AccessManaPledgeID    string
+	ConsensusManaPledgeID string
+	WaitForConfirmation   bool
+}
+
+// Build build the options.
+func Build(options ...MintTokensOption) (result *MintTokensOptions, err error) {
+	// create options to collect the arguments provided
+	result = &MintTokensOptions{}
+
+	// apply arguments to our options
+	for _, option := range options {
+		if err = option(result); err != nil {
+			return
+		}
+	}
+	if result.Alias == nil {
+		return nil, errors.Errorf("an alias identifier must be specified for minting")
+	}
+	if result.Amount == 0 {
+		return nil, errors.Errorf("no amount provided for minting")
+	}
+
+	return
+}
@@ -0,0 +1,74 @@
+package refreshoptions
+
+import (
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// DefaultGapLimit defines how many consecutive unused addresses end the recovery scan if no gap limit is provided.
+	DefaultGapLimit = 20
+
+	// DefaultBatchSize defines how many addresses are looked up with a single request during the recovery scan.
+	DefaultBatchSize = 50
+)
+
+// RefreshOption is a function that provides an option.
+type RefreshOption func(options *RefreshOptions) error
+
+// RescanSpentAddresses defines if the outputs of spent addresses should be fetched as well.
+func RescanSpentAddresses(rescan bool) RefreshOption {
+	return func(options *RefreshOptions) error {
+		options.RescanSpentAddresses = rescan
+		return nil
+	}
+}
+
+// Recover enables the recovery mode, that derives the addresses of the seed until gapLimit consecutive addresses were
+// never used and rebuilds the state of the address manager from the result.
+func Recover(gapLimit int) RefreshOption {
+	return func(options *RefreshOptions) error {
+		if gapLimit <= 0 {
+			return errors.Errorf("gap limit needs to be positive but was %d", gapLimit)
+		}
+		options.Recover = true
+		options.GapLimit = gapLimit
+		return nil
+	}
+}
+
+// BatchSize defines how many addresses are looked up with a single request during the recovery scan.
+func BatchSize(size int) RefreshOption {
+	return func(options *RefreshOptions) error {
+		if size <= 0 {
+			return errors.Errorf("batch size needs to be positive but was %d", size)
+		}
+		options.BatchSize = size
+		return nil
+	}
+}
+
+// RefreshOptions is a struct that is used to aggregate the optional parameters in the Refresh call.
+type RefreshOptions struct {
+	RescanSpentAddresses bool
+	Recover              bool
+	GapLimit             int
+	BatchSize            int
+}
+
+// Build builds the options.
+func Build(options ...RefreshOption) (result *RefreshOptions, err error) {
+	// create options to collect the arguments provided
+	result = &RefreshOptions{
+		GapLimit:  DefaultGapLimit,
+		BatchSize: DefaultBatchSize,
+	}
+
+	// apply arguments to our options
+	for _, option := range options {
+		if err = option(result); err != nil {
+			return
+		}
+	}
+
+	return
+}
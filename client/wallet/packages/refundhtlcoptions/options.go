@@ -0,0 +1,86 @@
+package refundhtlcoptions
+
+import (
+	"github.com/cockroachdb/errors"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+// RefundHTLCOption is a function that provides an option.
+type RefundHTLCOption func(options *RefundHTLCOptions) error
+
+// WaitForConfirmation defines if the call should wait for confirmation before it returns.
+func WaitForConfirmation(wait bool) RefundHTLCOption {
+	return func(options *RefundHTLCOptions) error {
+		options.WaitForConfirmation = wait
+		return nil
+	}
+}
+
+// AccessManaPledgeID is an option for RefundHTLC call that defines the nodeID to pledge access mana to.
+func AccessManaPledgeID(nodeID string) RefundHTLCOption {
+	return func(options *RefundHTLCOptions) error {
+		options.AccessManaPledgeID = nodeID
+		return nil
+	}
+}
+
+// ConsensusManaPledgeID is an option for RefundHTLC call that defines the nodeID to pledge consensus mana to.
+func ConsensusManaPledgeID(nodeID string) RefundHTLCOption {
+	return func(options *RefundHTLCOptions) error {
+		options.ConsensusManaPledgeID = nodeID
+		return nil
+	}
+}
+
+// OutputID specifies which hash time-locked output to refund.
+func OutputID(outputID string) RefundHTLCOption {
+	return func(options *RefundHTLCOptions) error {
+		parsed, err := ledgerstate.OutputIDFromBase58(outputID)
+		if err != nil {
+			return err
+		}
+		options.OutputID = parsed
+		return nil
+	}
+}
+
+// ToAddress specifies the address that receives the refunded funds.
+func ToAddress(address string) RefundHTLCOption {
+	return func(options *RefundHTLCOptions) error {
+		parsed, err := ledgerstate.AddressFromBase58EncodedString(address)
+		if err != nil {
+			return err
+		}
+		options.ToAddress = parsed
+		return nil
+	}
+}
+
+// RefundHTLCOptions is a struct that is used to aggregate the optional parameters in the RefundHTLC call.
+type RefundHTLCOptions struct {
+	AccessManaPledgeID    string
+	ConsensusManaPledgeID string
+	OutputID              ledgerstate.OutputID
+	ToAddress             ledgerstate.Address
+	WaitForConfirmation   bool
+}
+
+// Build builds the options.
+func Build(options ...RefundHTLCOption) (result *RefundHTLCOptions, err error) {
+	// create options to collect the arguments provided
+	result = &RefundHTLCOptions{}
+
+	// apply arguments to our options
+	for _, option := range options {
+		if err = option(result); err != nil {
+			return
+		}
+	}
+
+	if result.OutputID == ledgerstate.EmptyOutputID {
+		return nil, errors.Errorf("an output identifier must be specified for refunding an HTLC")
+	}
+
+	return
+}
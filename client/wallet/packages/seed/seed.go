@@ -1,23 +1,170 @@
 package seed
 
 import (
+	"bytes"
+	"strings"
+
+	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/tyler-smith/go-bip39"
 
 	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 )
 
+const (
+	// MnemonicEntropyBits defines the entropy of newly generated mnemonics (256 bits result in 24 words).
+	MnemonicEntropyBits = 256
+
+	// hierarchicalSeedSize is the size of the BIP-39 seed that is derived from a mnemonic.
+	hierarchicalSeedSize = 64
+)
+
+// Chain represents one of the two chains of addresses that the hierarchical derivation scheme defines per account.
+type Chain uint32
+
+const (
+	// ExternalChain is the chain of the addresses that are used to receive funds.
+	ExternalChain Chain = 0
+
+	// ChangeChain is the chain of the addresses that are reserved for remainders.
+	ChangeChain Chain = 1
+)
+
+// stateMagic is the prefix of the marshaled version of hierarchical seeds. It allows to tell them apart from the raw
+// bytes of legacy seeds that are stored without any prefix.
+var stateMagic = []byte{'h', 'd', 's', 'e', 'e', 'd', 0, 1}
+
 // Seed represents a seed for IOTA wallets. A seed allows us to generate a deterministic sequence of Addresses and their
 // corresponding KeyPairs.
+//
+// Legacy seeds are 32 random bytes (usually shown in base58) that derive the n'th KeyPair by a linear index. Seeds that
+// are restored from a BIP-39 mnemonic use the hierarchical SLIP-10 derivation with the path
+// m/44'/4218'/account'/chain'/index' instead, where chain is either the ExternalChain or the ChangeChain. The wallet
+// receives funds on the ExternalChain and sends its remainders to the ChangeChain of the configured account.
 type Seed struct {
 	*ed25519.Seed
+
+	hierarchical bool
+	account      uint32
+	chains       [2]extendedKey
 }
 
 // NewSeed is the factory method for an IOTA seed. It either generates a new one or imports an existing  marshaled seed.
 // before.
 func NewSeed(optionalSeedBytes ...[]byte) *Seed {
 	return &Seed{
-		ed25519.NewSeed(optionalSeedBytes...),
+		Seed: ed25519.NewSeed(optionalSeedBytes...),
+	}
+}
+
+// NewMnemonic generates a new random BIP-39 mnemonic phrase that can be used to create a Seed with FromMnemonic.
+func NewMnemonic() (mnemonic string, err error) {
+	entropy, err := bip39.NewEntropy(MnemonicEntropyBits)
+	if err != nil {
+		return "", errors.Errorf("failed to generate entropy: %w", err)
+	}
+
+	if mnemonic, err = bip39.NewMnemonic(entropy); err != nil {
+		return "", errors.Errorf("failed to generate mnemonic: %w", err)
+	}
+
+	return mnemonic, nil
+}
+
+// FromMnemonic restores the hierarchical Seed of the given account from a BIP-39 mnemonic phrase and an optional
+// passphrase.
+func FromMnemonic(mnemonic, passphrase string, account uint32) (seed *Seed, err error) {
+	seedBytes, err := bip39.NewSeedWithErrorChecking(strings.Join(strings.Fields(mnemonic), " "), passphrase)
+	if err != nil {
+		return nil, errors.Errorf("invalid mnemonic: %w", err)
+	}
+
+	return NewHierarchicalSeed(seedBytes, account)
+}
+
+// NewHierarchicalSeed creates a hierarchical Seed of the given account from the bytes of a BIP-39 seed.
+func NewHierarchicalSeed(seedBytes []byte, account uint32) (seed *Seed, err error) {
+	if len(seedBytes) != hierarchicalSeedSize {
+		return nil, errors.Errorf("hierarchical seed needs to be %d bytes long but was %d", hierarchicalSeedSize, len(seedBytes))
+	}
+	if account > MaxIndex {
+		return nil, errors.Errorf("account %d exceeds the maximum index %d", account, MaxIndex)
+	}
+
+	accountKey := newMasterKey(seedBytes).derive(Purpose, CoinType, account)
+
+	return &Seed{
+		Seed:         ed25519.NewSeed(seedBytes),
+		hierarchical: true,
+		account:      account,
+		chains: [2]extendedKey{
+			accountKey.child(uint32(ExternalChain)),
+			accountKey.child(uint32(ChangeChain)),
+		},
+	}, nil
+}
+
+// FromMarshalUtil unmarshals a Seed that was marshaled with StateBytes using a MarshalUtil (for easier unmarshaling).
+func FromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (seed *Seed, err error) {
+	if prefix, peekErr := marshalUtil.ReadBytes(len(stateMagic), marshalUtil.ReadOffset()); peekErr != nil || !bytes.Equal(prefix, stateMagic) {
+		seedBytes, readErr := marshalUtil.ReadBytes(ed25519.SeedSize)
+		if readErr != nil {
+			return nil, errors.Errorf("failed to parse seed bytes: %w", readErr)
+		}
+
+		return NewSeed(seedBytes), nil
+	}
+
+	marshalUtil.ReadSeek(len(stateMagic))
+	account, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse account: %w", err)
+	}
+	seedBytes, err := marshalUtil.ReadBytes(hierarchicalSeedSize)
+	if err != nil {
+		return nil, errors.Errorf("failed to parse seed bytes: %w", err)
+	}
+
+	return NewHierarchicalSeed(seedBytes, account)
+}
+
+// IsHierarchical returns true if the Seed uses the hierarchical derivation scheme.
+func (seed *Seed) IsHierarchical() bool {
+	return seed.hierarchical
+}
+
+// Account returns the account of the hierarchical derivation path (always 0 for legacy seeds).
+func (seed *Seed) Account() uint32 {
+	return seed.account
+}
+
+// KeyPair retrieves the KeyPair of the wallet address with the given index.
+func (seed *Seed) KeyPair(index uint64) *ed25519.KeyPair {
+	if !seed.hierarchical {
+		return seed.Seed.KeyPair(index)
+	}
+
+	return seed.ChainKeyPair(ExternalChain, index)
+}
+
+// ChainKeyPair retrieves the KeyPair with the given index on the given Chain of a hierarchical Seed. Legacy seeds only
+// have a single chain, so the Chain is ignored for them.
+func (seed *Seed) ChainKeyPair(chain Chain, index uint64) *ed25519.KeyPair {
+	if !seed.hierarchical {
+		return seed.Seed.KeyPair(index)
+	}
+	if index > MaxIndex {
+		panic(errors.Errorf("address index %d exceeds the maximum index %d of hierarchical seeds", index, MaxIndex))
+	}
+
+	addressKey := seed.chains[chain].child(uint32(index))
+	privateKey := ed25519.PrivateKeyFromSeed(addressKey.key[:])
+
+	return &ed25519.KeyPair{
+		PrivateKey: privateKey,
+		PublicKey:  privateKey.Public(),
 	}
 }
 
@@ -26,7 +173,52 @@ func (seed *Seed) Address(index uint64) (addr address.Address) {
 	addr = address.Address{
 		Index: index,
 	}
-	copy(addr.AddressBytes[:], ledgerstate.NewED25519Address(seed.Seed.KeyPair(index).PublicKey).Bytes())
+	copy(addr.AddressBytes[:], ledgerstate.NewED25519Address(seed.KeyPair(index).PublicKey).Bytes())
+
+	return
+}
+
+// ChangeAddress returns the remainder address with the given index on the ChangeChain. Legacy seeds only have a single
+// chain, so it must only be used with hierarchical seeds.
+func (seed *Seed) ChangeAddress(index uint64) (addr address.Address) {
+	addr = address.Address{
+		Index:  index,
+		Change: true,
+	}
+	copy(addr.AddressBytes[:], ledgerstate.NewED25519Address(seed.ChainKeyPair(ChangeChain, index).PublicKey).Bytes())
 
 	return
 }
+
+// AddressKeyPair retrieves the KeyPair of the given wallet address from the chain that it was generated on.
+func (seed *Seed) AddressKeyPair(addr address.Address) *ed25519.KeyPair {
+	if addr.Change {
+		return seed.ChainKeyPair(ChangeChain, addr.Index)
+	}
+
+	return seed.KeyPair(addr.Index)
+}
+
+// DerivationPath returns the hierarchical derivation path of the wallet address with the given index (empty for legacy
+// seeds).
+func (seed *Seed) DerivationPath(index uint64) string {
+	if !seed.hierarchical {
+		return ""
+	}
+
+	return DerivationPath(seed.account, ExternalChain, uint32(index))
+}
+
+// StateBytes returns the marshaled version of the Seed that is stored in the wallet state. Legacy seeds are stored as
+// their raw bytes, so existing wallet states stay compatible.
+func (seed *Seed) StateBytes() []byte {
+	if !seed.hierarchical {
+		return seed.Bytes()
+	}
+
+	return marshalutil.New().
+		WriteBytes(stateMagic).
+		WriteUint32(seed.account).
+		WriteBytes(seed.Bytes()).
+		Bytes()
+}
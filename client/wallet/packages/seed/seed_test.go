@@ -0,0 +1,88 @@
+package seed
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtendedKey(t *testing.T) {
+	// test vector 1 for ed25519 of SLIP-10
+	seedBytes, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	require.NoError(t, err)
+
+	masterKey := newMasterKey(seedBytes)
+	assert.Equal(t, "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7", hex.EncodeToString(masterKey.key[:]))
+	assert.Equal(t, "90046a93de5380a72b5e45010748567d5ea02bbf6522f979e05c0d8d8ca9fffb", hex.EncodeToString(masterKey.chainCode[:]))
+
+	childKey := masterKey.derive(0, 1, 2, 2, 1000000000)
+	assert.Equal(t, "8f94d394a8e8fd6b1bc2f3f49f5c47e385281d5c17e65324b0f62483e37e8793", hex.EncodeToString(childKey.key[:]))
+	assert.Equal(t, "68789923a0cac2cd5a29172a475fe9e0fb14cd6adb5ad98a3fa70333e7afa230", hex.EncodeToString(childKey.chainCode[:]))
+}
+
+func TestFromMnemonic(t *testing.T) {
+	const mnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	seed, err := FromMnemonic(mnemonic, "TREZOR", 0)
+	require.NoError(t, err)
+	assert.True(t, seed.IsHierarchical())
+	assert.Equal(t, "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04", hex.EncodeToString(seed.Bytes()))
+	assert.Equal(t, "m/44'/4218'/0'/0'/5'", seed.DerivationPath(5))
+
+	// the passphrase, the account and the chain derive different keys
+	withoutPassphrase, err := FromMnemonic(mnemonic, "", 0)
+	require.NoError(t, err)
+	otherAccount, err := FromMnemonic(mnemonic, "TREZOR", 1)
+	require.NoError(t, err)
+	assert.NotEqual(t, seed.Address(0), withoutPassphrase.Address(0))
+	assert.NotEqual(t, seed.Address(0), otherAccount.Address(0))
+	assert.NotEqual(t, seed.Address(0), seed.Address(1))
+	assert.NotEqual(t, seed.ChainKeyPair(ExternalChain, 0).PublicKey, seed.ChainKeyPair(ChangeChain, 0).PublicKey)
+	assert.Equal(t, seed.KeyPair(3).PublicKey, seed.ChainKeyPair(ExternalChain, 3).PublicKey)
+
+	// remainder addresses are derived on the change chain
+	changeAddress := seed.ChangeAddress(3)
+	assert.True(t, changeAddress.Change)
+	assert.Equal(t, uint64(3), changeAddress.Index)
+	assert.NotEqual(t, seed.Address(3).AddressBytes, changeAddress.AddressBytes)
+	assert.Equal(t, seed.ChainKeyPair(ChangeChain, 3).PublicKey, seed.AddressKeyPair(changeAddress).PublicKey)
+	assert.Equal(t, seed.KeyPair(3).PublicKey, seed.AddressKeyPair(seed.Address(3)).PublicKey)
+
+	// whitespace does not matter but the checksum does
+	restoredSeed, err := FromMnemonic("  abandon abandon abandon abandon abandon abandon\nabandon abandon abandon abandon abandon about ", "TREZOR", 0)
+	require.NoError(t, err)
+	assert.Equal(t, seed.Address(0), restoredSeed.Address(0))
+	_, err = FromMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", "", 0)
+	assert.Error(t, err)
+
+	generatedMnemonic, err := NewMnemonic()
+	require.NoError(t, err)
+	_, err = FromMnemonic(generatedMnemonic, "", 0)
+	assert.NoError(t, err)
+}
+
+func TestSeed_StateBytes(t *testing.T) {
+	legacySeed := NewSeed()
+	assert.Equal(t, legacySeed.Bytes(), legacySeed.StateBytes())
+
+	restoredLegacySeed, err := FromMarshalUtil(marshalutil.New(legacySeed.StateBytes()))
+	require.NoError(t, err)
+	assert.False(t, restoredLegacySeed.IsHierarchical())
+	assert.Equal(t, legacySeed.Address(7), restoredLegacySeed.Address(7))
+
+	mnemonic, err := NewMnemonic()
+	require.NoError(t, err)
+	hierarchicalSeed, err := FromMnemonic(mnemonic, "secret", 2)
+	require.NoError(t, err)
+
+	marshalUtil := marshalutil.New(append(hierarchicalSeed.StateBytes(), 1, 2, 3))
+	restoredHierarchicalSeed, err := FromMarshalUtil(marshalUtil)
+	require.NoError(t, err)
+	assert.True(t, restoredHierarchicalSeed.IsHierarchical())
+	assert.Equal(t, uint32(2), restoredHierarchicalSeed.Account())
+	assert.Equal(t, hierarchicalSeed.Address(7), restoredHierarchicalSeed.Address(7))
+	assert.Equal(t, []byte{1, 2, 3}, marshalUtil.ReadRemainingBytes())
+}
@@ -0,0 +1,81 @@
+package seed
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+const (
+	// Purpose is the BIP-44 purpose of the hierarchical derivation path.
+	Purpose = 44
+
+	// CoinType is the registered SLIP-44 coin type of IOTA that is used in the hierarchical derivation path.
+	CoinType = 4218
+
+	// hardened is the offset of hardened indexes. SLIP-10 only supports hardened derivation for ed25519 keys.
+	hardened = 0x80000000
+
+	// MaxIndex is the largest index that can be used on any level of the hierarchical derivation path.
+	MaxIndex = hardened - 1
+)
+
+// slip10Curve is the HMAC key that is used to derive the master key of ed25519 keys.
+var slip10Curve = []byte("ed25519 seed")
+
+// extendedKey is a node of the SLIP-10 key tree consisting of its private key (seed of the ed25519 key) and its chain
+// code.
+type extendedKey struct {
+	key       [32]byte
+	chainCode [32]byte
+}
+
+// newMasterKey derives the root of the key tree from the given seed.
+func newMasterKey(seedBytes []byte) extendedKey {
+	return newExtendedKey(slip10Curve, seedBytes)
+}
+
+// newExtendedKey splits the HMAC-SHA512 of the data into the private key and the chain code of an extendedKey.
+func newExtendedKey(hmacKey, data []byte) (result extendedKey) {
+	mac := hmac.New(sha512.New, hmacKey)
+	_, _ = mac.Write(data)
+	sum := mac.Sum(nil)
+
+	copy(result.key[:], sum[:32])
+	copy(result.chainCode[:], sum[32:])
+
+	return
+}
+
+// child derives the hardened child with the given index.
+func (e extendedKey) child(index uint32) extendedKey {
+	data := make([]byte, 1+32+4)
+	copy(data[1:], e.key[:])
+	binary.BigEndian.PutUint32(data[33:], index|hardened)
+
+	return newExtendedKey(e.chainCode[:], data)
+}
+
+// derive derives the node at the given path (relative to the node it is called on).
+func (e extendedKey) derive(path ...uint32) (result extendedKey) {
+	result = e
+	for _, index := range path {
+		result = result.child(index)
+	}
+
+	return
+}
+
+// DerivationPath returns the human readable representation of the hierarchical derivation path of the key with the
+// given account, chain and index.
+func DerivationPath(account uint32, chain Chain, index uint32) string {
+	var builder strings.Builder
+	builder.WriteString("m")
+	for _, level := range []uint32{Purpose, CoinType, account, uint32(chain), index} {
+		builder.WriteString(fmt.Sprintf("/%d'", level))
+	}
+
+	return builder.String()
+}
@@ -132,6 +132,19 @@ func Fallback(addy ledgerstate.Address, deadline time.Time) SendFundsOption {
 	}
 }
 
+// HashLock defines the hash lock of the created outputs: the recipient can only unlock them by revealing the preimage
+// of the given digest before the fallback deadline. Together with Fallback this creates a hash time-locked contract.
+func HashLock(hashLockType ledgerstate.HashLockType, hashLock [ledgerstate.HashLockSize]byte) SendFundsOption {
+	return func(options *SendFundsOptions) error {
+		if !hashLockType.Valid() {
+			return errors.Errorf("unsupported hash lock type %s", hashLockType)
+		}
+		options.HashLockType = hashLockType
+		options.HashLock = hashLock
+		return nil
+	}
+}
+
 // SendFundsOptions is a struct that is used to aggregate the optional parameters provided in the SendFunds call.
 type SendFundsOptions struct {
 	Destinations          map[address.Address]map[ledgerstate.Color]uint64
@@ -139,6 +152,8 @@ type SendFundsOptions struct {
 	LockUntil             time.Time
 	FallbackAddress       ledgerstate.Address
 	FallbackDeadline      time.Time
+	HashLockType          ledgerstate.HashLockType
+	HashLock              [ledgerstate.HashLockSize]byte
 	AccessManaPledgeID    string
 	ConsensusManaPledgeID string
 	WaitForConfirmation   bool
@@ -180,6 +195,11 @@ func Build(options ...SendFundsOption) (result *SendFundsOptions, err error) {
 
 		return
 	}
+	if result.HashLockType != 0 && result.FallbackAddress == nil {
+		err = errors.New("a hash lock requires fallback options, otherwise the funds can't be refunded")
+
+		return
+	}
 
 	return
 }
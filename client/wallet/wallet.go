@@ -12,19 +12,25 @@ import (
 	"golang.org/x/crypto/blake2b"
 
 	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/burntokensoptions"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/claimconditionaloptions"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/claimhtlcoptions"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/consolidateoptions"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/createnftoptions"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/delegateoptions"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/deposittonftoptions"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/destroynftoptions"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/minttokensoptions"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/reclaimoptions"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/refreshoptions"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/refundhtlcoptions"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/seed"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/sendoptions"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/sweepnftownednftsoptions"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/sweepnftownedoptions"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/transfernftoptions"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/withdrawfromnftoptions"
+	"github.com/iotaledger/goshimmer/packages/assetregistry"
 	"github.com/iotaledger/goshimmer/packages/consensus/gof"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/mana"
@@ -91,6 +97,7 @@ func New(options ...Option) (wallet *Wallet) {
 	if wallet.connector == nil {
 		panic("you need to provide a connector for your wallet")
 	}
+	wallet.assetRegistry.connector = wallet.connector
 
 	// initialize output manager
 	wallet.outputManager = NewUnspentOutputManager(wallet.addressManager, wallet.connector)
@@ -331,9 +338,66 @@ func (wallet *Wallet) ClaimConditionalFunds(options ...claimconditionaloptions.C
 
 // endregion //////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region ClaimHTLC ////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ClaimHTLC claims a hash time-locked output of the wallet by revealing the preimage of its hash lock. The preimage
+// becomes public with the transaction, so the counterparty of an atomic swap can use it to claim its side.
+func (wallet *Wallet) ClaimHTLC(options ...claimhtlcoptions.ClaimHTLCOption) (tx *ledgerstate.Transaction, err error) {
+	claimOptions, err := claimhtlcoptions.Build(options...)
+	if err != nil {
+		return
+	}
+	htlc, err := wallet.findHTLC(claimOptions.OutputID)
+	if err != nil {
+		return
+	}
+	casted := htlc.Object.(*ledgerstate.ExtendedLockedOutput)
+	now := time.Now()
+	if !casted.HashLockedNow(now) || !casted.UnlockAddressNow(now).Equals(htlc.Address.Address()) {
+		return nil, errors.Errorf("output %s can't be claimed by the wallet: not hash locked to a wallet address or the fallback deadline has passed", claimOptions.OutputID.Base58())
+	}
+	if !casted.PreimageValid(claimOptions.Preimage) {
+		return nil, errors.Errorf("preimage does not match the hash lock of output %s", claimOptions.OutputID.Base58())
+	}
+
+	return wallet.spendHTLC(htlc, claimOptions.Preimage, claimOptions.ToAddress, claimOptions.AccessManaPledgeID,
+		claimOptions.ConsensusManaPledgeID, claimOptions.WaitForConfirmation)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region RefundHTLC ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// RefundHTLC sends the funds of a hash time-locked output back to the wallet after its fallback deadline has passed.
+func (wallet *Wallet) RefundHTLC(options ...refundhtlcoptions.RefundHTLCOption) (tx *ledgerstate.Transaction, err error) {
+	refundOptions, err := refundhtlcoptions.Build(options...)
+	if err != nil {
+		return
+	}
+	htlc, err := wallet.findHTLC(refundOptions.OutputID)
+	if err != nil {
+		return
+	}
+	casted := htlc.Object.(*ledgerstate.ExtendedLockedOutput)
+	now := time.Now()
+	if casted.HashLockedNow(now) {
+		_, fallbackDeadline := casted.FallbackOptions()
+		return nil, errors.Errorf("output %s can't be refunded before the fallback deadline %s", refundOptions.OutputID.Base58(), fallbackDeadline.String())
+	}
+	if !casted.UnlockAddressNow(now).Equals(htlc.Address.Address()) {
+		return nil, errors.Errorf("output %s can't be refunded to the wallet: fallback address is not a wallet address", refundOptions.OutputID.Base58())
+	}
+
+	return wallet.spendHTLC(htlc, nil, refundOptions.ToAddress, refundOptions.AccessManaPledgeID,
+		refundOptions.ConsensusManaPledgeID, refundOptions.WaitForConfirmation)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region CreateAsset //////////////////////////////////////////////////////////////////////////////////////////////////
 
-// CreateAsset creates a new colored token with the given details.
+// CreateAsset creates a new colored token with the given details and publishes its metadata to the on-tangle asset
+// registry.
 func (wallet *Wallet) CreateAsset(asset Asset, waitForConfirmation ...bool) (assetColor ledgerstate.Color, err error) {
 	if asset.Supply == 0 {
 		err = errors.New("required to provide the amount when trying to create an asset")
@@ -347,6 +411,12 @@ func (wallet *Wallet) CreateAsset(asset Asset, waitForConfirmation ...bool) (ass
 		return
 	}
 
+	if len(asset.Name) > assetregistry.MaxNameLength || len(asset.Symbol) > assetregistry.MaxSymbolLength {
+		err = errors.Errorf("name and symbol of an asset must not be longer than %d and %d bytes", assetregistry.MaxNameLength, assetregistry.MaxSymbolLength)
+
+		return
+	}
+
 	// where will we spend from?
 	consumedOutputs, err := wallet.collectOutputsForFunding(map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: asset.Supply}, false)
 	if err != nil {
@@ -373,11 +443,13 @@ func (wallet *Wallet) CreateAsset(asset Asset, waitForConfirmation ...bool) (ass
 
 	// this only works if there is only one MINT output in the transaction
 	assetColor = ledgerstate.ColorIOTA
+	var mintingOutputID ledgerstate.OutputID
 	for _, output := range tx.Essence().Outputs() {
 		output.Balances().ForEach(func(color ledgerstate.Color, balance uint64) bool {
 			if color == ledgerstate.ColorMint {
 				digest := blake2b.Sum256(output.ID().Bytes())
 				assetColor, _, err = ledgerstate.ColorFromBytes(digest[:])
+				mintingOutputID = output.ID()
 			}
 			return true
 		})
@@ -391,11 +463,41 @@ func (wallet *Wallet) CreateAsset(asset Asset, waitForConfirmation ...bool) (ass
 		asset.Color = assetColor
 		asset.TransactionID = tx.ID()
 		wallet.assetRegistry.RegisterAsset(assetColor, asset)
+
+		if err = wallet.issueAssetMetadata(asset, mintingOutputID, tx); err != nil {
+			err = errors.Errorf("created asset %s but failed to register its metadata: %w", assetColor.Base58(), err)
+		}
 	}
 
 	return
 }
 
+// issueAssetMetadata publishes the metadata of the asset that was minted by the given transaction to the on-tangle
+// registry. The metadata is signed with the key of a wallet address that signed the minting transaction, so nodes can
+// verify that it was issued by the minter.
+func (wallet *Wallet) issueAssetMetadata(asset Asset, mintingOutputID ledgerstate.OutputID, mintingTransaction *ledgerstate.Transaction) (err error) {
+	metadataPayload, err := assetregistry.NewPayload(asset.Color, mintingOutputID, asset.Name, asset.Symbol, uint32(asset.Precision), time.Now())
+	if err != nil {
+		return
+	}
+
+	essenceBytes := mintingTransaction.Essence().Bytes()
+	for _, unlockBlock := range mintingTransaction.UnlockBlocks() {
+		signatureUnlockBlock, isSignature := unlockBlock.(*ledgerstate.SignatureUnlockBlock)
+		if !isSignature {
+			continue
+		}
+		for _, addr := range wallet.addressManager.Addresses() {
+			if signatureUnlockBlock.AddressSignatureValid(addr.Address(), essenceBytes) {
+				metadataPayload.Sign(wallet.Seed().AddressKeyPair(addr))
+				return wallet.connector.IssueAssetMetadata(metadataPayload)
+			}
+		}
+	}
+
+	return errors.Errorf("no wallet address signed the minting transaction %s", mintingTransaction.ID().Base58())
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region DelegateFunds ////////////////////////////////////////////////////////////////////////////////////////////////
@@ -714,7 +816,7 @@ func (wallet *Wallet) TransferNFT(options ...transfernftoptions.TransferNFTOptio
 		ledgerstate.NewOutputs(nextAlias),
 	)
 	// there is only one input, so signing is easy
-	keyPair := wallet.Seed().KeyPair(walletAlias.Address.Index)
+	keyPair := wallet.Seed().AddressKeyPair(walletAlias.Address)
 	tx = ledgerstate.NewTransaction(essence, ledgerstate.UnlockBlocks{
 		ledgerstate.NewSignatureUnlockBlock(ledgerstate.NewED25519Signature(keyPair.PublicKey, keyPair.PrivateKey.Sign(essence.Bytes()))),
 	})
@@ -810,7 +912,7 @@ func (wallet *Wallet) DestroyNFT(options ...destroynftoptions.DestroyNFTOption)
 		ledgerstate.NewInputs(inputs...), ledgerstate.NewOutputs(outputs...))
 
 	// there is only one input, so signing is easy
-	keyPair := wallet.Seed().KeyPair(walletAlias.Address.Index)
+	keyPair := wallet.Seed().AddressKeyPair(walletAlias.Address)
 	tx = ledgerstate.NewTransaction(essence, ledgerstate.UnlockBlocks{
 		ledgerstate.NewSignatureUnlockBlock(ledgerstate.NewED25519Signature(keyPair.PublicKey, keyPair.PrivateKey.Sign(essence.Bytes()))),
 	})
@@ -922,7 +1024,7 @@ func (wallet *Wallet) WithdrawFundsFromNFT(options ...withdrawfromnftoptions.Wit
 		ledgerstate.NewInputs(inputs...), ledgerstate.NewOutputs(outputs...))
 
 	// there is only one input, so signing is easy
-	keyPair := wallet.Seed().KeyPair(walletAlias.Address.Index)
+	keyPair := wallet.Seed().AddressKeyPair(walletAlias.Address)
 	tx = ledgerstate.NewTransaction(essence, ledgerstate.UnlockBlocks{
 		ledgerstate.NewSignatureUnlockBlock(ledgerstate.NewED25519Signature(keyPair.PublicKey, keyPair.PrivateKey.Sign(essence.Bytes()))),
 	})
@@ -1073,6 +1175,121 @@ func (wallet *Wallet) DepositFundsToNFT(options ...deposittonftoptions.DepositFu
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region MintTokens ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// MintTokens mints new tokens of the supply-controlled color of the given alias by recoloring IOTA tokens of the
+// wallet. The alias starts to control the supply of its token color with the first mint. If the wallet is not the state
+// controller of the alias, an error is returned.
+func (wallet *Wallet) MintTokens(options ...minttokensoptions.MintTokensOption) (tx *ledgerstate.Transaction, tokenColor ledgerstate.Color, err error) {
+	mintOptions, err := minttokensoptions.Build(options...)
+	if err != nil {
+		return
+	}
+	// derive mana pledge IDs
+	accessPledgeNodeID, consensusPledgeNodeID, err := wallet.derivePledgeIDs(mintOptions.AccessManaPledgeID, mintOptions.ConsensusManaPledgeID)
+	if err != nil {
+		return
+	}
+	// look up if we have the alias output. Only the state controller can change the token supply.
+	walletAlias, err := wallet.findStateControlledAliasOutputByAliasID(mintOptions.Alias)
+	if err != nil {
+		return
+	}
+	alias := walletAlias.Object.(*ledgerstate.AliasOutput)
+	tokenColor = alias.TokenColor()
+	nextSupply, ok := ledgerstate.SafeAddUint64(alias.TokenSupply(), mintOptions.Amount)
+	if !ok {
+		return nil, tokenColor, errors.Errorf("minting %d tokens would overflow the token supply of alias %s", mintOptions.Amount, alias.GetAliasAddress().Base58())
+	}
+
+	// collect the IOTA tokens that are recolored
+	consumedOutputs, err := wallet.collectOutputsForFunding(map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: mintOptions.Amount}, false)
+	if err != nil {
+		if errors.Is(err, ErrTooManyOutputs) {
+			err = errors.Errorf("consolidate funds and try again: %w", err)
+		}
+		return nil, tokenColor, err
+	}
+	var optionsToAddress address.Address
+	if mintOptions.ToAddress == nil {
+		optionsToAddress = wallet.ReceiveAddress()
+	} else {
+		optionsToAddress = address.Address{AddressBytes: mintOptions.ToAddress.Array()}
+	}
+	unsortedOutputs := ledgerstate.Outputs{ledgerstate.NewSigLockedColoredOutput(
+		ledgerstate.NewColoredBalances(map[ledgerstate.Color]uint64{tokenColor: mintOptions.Amount}), optionsToAddress.Address())}
+
+	// remainder balance = totalConsumed - minted
+	remainderBalances := consumedOutputs.TotalFundsInOutputs()
+	remainderBalances[ledgerstate.ColorIOTA] -= mintOptions.Amount
+	if remainderBalances[ledgerstate.ColorIOTA] == 0 {
+		delete(remainderBalances, ledgerstate.ColorIOTA)
+	}
+	if len(remainderBalances) != 0 {
+		unsortedOutputs = append(unsortedOutputs, ledgerstate.NewSigLockedColoredOutput(
+			ledgerstate.NewColoredBalances(remainderBalances), wallet.chooseRemainderAddress(consumedOutputs, address.AddressEmpty).Address()))
+	}
+
+	tx, err = wallet.transitionTokenSupply(walletAlias, nextSupply, consumedOutputs, unsortedOutputs, accessPledgeNodeID, consensusPledgeNodeID, mintOptions.WaitForConfirmation)
+
+	return tx, tokenColor, err
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region BurnTokens ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// BurnTokens burns tokens of the supply-controlled color of the given alias that are owned by the wallet by converting
+// them back to IOTA tokens. If the wallet is not the state controller of the alias, an error is returned.
+func (wallet *Wallet) BurnTokens(options ...burntokensoptions.BurnTokensOption) (tx *ledgerstate.Transaction, err error) {
+	burnOptions, err := burntokensoptions.Build(options...)
+	if err != nil {
+		return
+	}
+	// derive mana pledge IDs
+	accessPledgeNodeID, consensusPledgeNodeID, err := wallet.derivePledgeIDs(burnOptions.AccessManaPledgeID, burnOptions.ConsensusManaPledgeID)
+	if err != nil {
+		return
+	}
+	// look up if we have the alias output. Only the state controller can change the token supply.
+	walletAlias, err := wallet.findStateControlledAliasOutputByAliasID(burnOptions.Alias)
+	if err != nil {
+		return
+	}
+	alias := walletAlias.Object.(*ledgerstate.AliasOutput)
+	if !alias.IsSupplyControlled() {
+		return nil, errors.Errorf("alias %s does not control the supply of its tokens", alias.GetAliasAddress().Base58())
+	}
+	if alias.TokenSupply() < burnOptions.Amount {
+		return nil, errors.Errorf("trying to burn %d tokens, but only %d tokens of alias %s are in circulation",
+			burnOptions.Amount, alias.TokenSupply(), alias.GetAliasAddress().Base58())
+	}
+	tokenColor := alias.TokenColor()
+
+	// collect the tokens that are burned
+	consumedOutputs, err := wallet.collectOutputsForFunding(map[ledgerstate.Color]uint64{tokenColor: burnOptions.Amount}, false)
+	if err != nil {
+		if errors.Is(err, ErrTooManyOutputs) {
+			err = errors.Errorf("consolidate funds and try again: %w", err)
+		}
+		return nil, err
+	}
+
+	// remainder balance = totalConsumed - burned tokens + IOTA tokens that backed them
+	remainderBalances := consumedOutputs.TotalFundsInOutputs()
+	remainderBalances[tokenColor] -= burnOptions.Amount
+	if remainderBalances[tokenColor] == 0 {
+		delete(remainderBalances, tokenColor)
+	}
+	remainderBalances[ledgerstate.ColorIOTA] += burnOptions.Amount
+	unsortedOutputs := ledgerstate.Outputs{ledgerstate.NewSigLockedColoredOutput(
+		ledgerstate.NewColoredBalances(remainderBalances), wallet.chooseRemainderAddress(consumedOutputs, address.AddressEmpty).Address())}
+
+	return wallet.transitionTokenSupply(walletAlias, alias.TokenSupply()-burnOptions.Amount, consumedOutputs, unsortedOutputs, accessPledgeNodeID, consensusPledgeNodeID, burnOptions.WaitForConfirmation)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region SweepNFTOwnedFunds ///////////////////////////////////////////////////////////////////////////////////////////
 
 // SweepNFTOwnedFunds collects all funds from non-alias outputs that are owned by the nft into the wallet.
@@ -1161,7 +1378,7 @@ func (wallet Wallet) SweepNFTOwnedFunds(options ...sweepnftownedoptions.SweepNFT
 		if input.Type() == ledgerstate.UTXOInputType {
 			casted := input.(*ledgerstate.UTXOInput)
 			if casted.ReferencedOutputID() == alias.ID() {
-				keyPair := wallet.Seed().KeyPair(walletAlias.Address.Index)
+				keyPair := wallet.Seed().AddressKeyPair(walletAlias.Address)
 				unlockBlock := ledgerstate.NewSignatureUnlockBlock(ledgerstate.NewED25519Signature(keyPair.PublicKey, keyPair.PrivateKey.Sign(essence.Bytes())))
 				unlockBlocks[index] = unlockBlock
 				aliasInputIndex = index
@@ -1311,7 +1528,7 @@ func (wallet *Wallet) SweepNFTOwnedNFTs(options ...sweepnftownednftsoptions.Swee
 		if input.Type() == ledgerstate.UTXOInputType {
 			casted := input.(*ledgerstate.UTXOInput)
 			if casted.ReferencedOutputID() == alias.ID() {
-				keyPair := wallet.Seed().KeyPair(walletAlias.Address.Index)
+				keyPair := wallet.Seed().AddressKeyPair(walletAlias.Address)
 				unlockBlock := ledgerstate.NewSignatureUnlockBlock(ledgerstate.NewED25519Signature(keyPair.PublicKey, keyPair.PrivateKey.Sign(essence.Bytes())))
 				unlockBlocks[index] = unlockBlock
 				aliasInputIndex = index
@@ -1481,13 +1698,87 @@ func (wallet *Wallet) RequestFaucetFunds(waitForConfirmation ...bool) (err error
 
 // region Refresh //////////////////////////////////////////////////////////////////////////////////////////////////////
 
-// Refresh scans the addresses for incoming transactions. If the optional rescanSpentAddresses parameter is set to true
-// we also scan the spent addresses again (this can take longer).
-func (wallet *Wallet) Refresh(rescanSpentAddresses ...bool) (err error) {
-	err = wallet.outputManager.Refresh(rescanSpentAddresses...)
+// Refresh scans the addresses for incoming transactions. If the RescanSpentAddresses option is set we also scan the
+// spent addresses again (this can take longer). In recovery mode the addresses of the seed are scanned first until
+// the gap limit is reached, so that funds on addresses that are unknown to the wallet state become visible.
+func (wallet *Wallet) Refresh(options ...refreshoptions.RefreshOption) (err error) {
+	refreshOptions, err := refreshoptions.Build(options...)
+	if err != nil {
+		return
+	}
+
+	if refreshOptions.Recover {
+		if err = wallet.recoverAddresses(refreshOptions.GapLimit, refreshOptions.BatchSize); err != nil {
+			return
+		}
+	}
+
+	err = wallet.outputManager.Refresh(refreshOptions.RescanSpentAddresses)
 	return
 }
 
+// recoverAddresses derives the addresses of the seed in batches until gapLimit consecutive addresses were never used
+// and rebuilds the state of the AddressManager from the addresses that were used and spent. The change chain of
+// hierarchical seeds is scanned the same way.
+func (wallet *Wallet) recoverAddresses(gapLimit, batchSize int) (err error) {
+	lastUsedAddressIndex, usedAddressFound, spentAddressIndexes, err := wallet.scanAddresses(wallet.Seed().Address, gapLimit, batchSize)
+	if err != nil {
+		return err
+	}
+	if usedAddressFound {
+		wallet.addressManager.Recover(lastUsedAddressIndex, spentAddressIndexes)
+	}
+
+	if !wallet.Seed().IsHierarchical() {
+		return nil
+	}
+
+	lastUsedAddressIndex, usedAddressFound, spentAddressIndexes, err = wallet.scanAddresses(wallet.Seed().ChangeAddress, gapLimit, batchSize)
+	if err != nil {
+		return errors.Errorf("failed to scan change addresses: %w", err)
+	}
+	if usedAddressFound {
+		wallet.addressManager.RecoverChange(lastUsedAddressIndex, spentAddressIndexes)
+	}
+
+	return nil
+}
+
+// scanAddresses scans the addresses that are generated by the given function in batches until gapLimit consecutive
+// addresses were never used. It returns the index of the last used address and the indexes of the spent addresses.
+func (wallet *Wallet) scanAddresses(generateAddress func(index uint64) address.Address, gapLimit, batchSize int) (lastUsedAddressIndex uint64, usedAddressFound bool, spentAddressIndexes []uint64, err error) {
+	unusedAddressCount := 0
+	for nextAddressIndex := uint64(0); unusedAddressCount < gapLimit; nextAddressIndex += uint64(batchSize) {
+		addresses := make([]address.Address, batchSize)
+		for i := range addresses {
+			addresses[i] = generateAddress(nextAddressIndex + uint64(i))
+		}
+
+		unspentOutputs, spentAddresses, scanErr := wallet.connector.ScanAddresses(addresses...)
+		if scanErr != nil {
+			return 0, false, nil, errors.Errorf("failed to scan addresses %d to %d: %w", nextAddressIndex, nextAddressIndex+uint64(batchSize)-1, scanErr)
+		}
+
+		for _, addr := range addresses {
+			if len(unspentOutputs[addr]) == 0 && !spentAddresses[addr] {
+				if unusedAddressCount++; unusedAddressCount >= gapLimit {
+					break
+				}
+				continue
+			}
+
+			unusedAddressCount = 0
+			lastUsedAddressIndex = addr.Index
+			usedAddressFound = true
+			if spentAddresses[addr] && !wallet.reusableAddress {
+				spentAddressIndexes = append(spentAddressIndexes, addr.Index)
+			}
+		}
+	}
+
+	return lastUsedAddressIndex, usedAddressFound, spentAddressIndexes, nil
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region Balance //////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -1620,8 +1911,8 @@ func (wallet *Wallet) AvailableBalance(refresh ...bool) (confirmedBalance, pendi
 				})
 			case ledgerstate.ExtendedLockedOutputType:
 				casted := output.Object.(*ledgerstate.ExtendedLockedOutput)
-				if casted.TimeLockedNow(now) {
-					// timelocked funds are not available
+				if casted.TimeLockedNow(now) || casted.HashLockedNow(now) {
+					// timelocked funds and funds that need a preimage are not available
 					continue
 				}
 				unlockAddyNow := casted.UnlockAddressNow(now)
@@ -1713,8 +2004,8 @@ func (wallet *Wallet) ConditionalBalances(refresh ...bool) (confirmed, pending T
 			}
 			casted := output.Object.(*ledgerstate.ExtendedLockedOutput)
 			_, fallbackDeadline := casted.FallbackOptions()
-			if !fallbackDeadline.IsZero() && addy.Address().Equals(casted.UnlockAddressNow(now)) {
-				// fallback option is set and currently we are the unlock address
+			if !fallbackDeadline.IsZero() && !casted.HashLockedNow(now) && addy.Address().Equals(casted.UnlockAddressNow(now)) {
+				// fallback option is set, no preimage is needed and currently we are the unlock address
 				cBal := &TimedBalance{
 					Balance: casted.Balances().Map(),
 					Time:    fallbackDeadline,
@@ -1733,6 +2024,39 @@ func (wallet *Wallet) ConditionalBalances(refresh ...bool) (confirmed, pending T
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region HashTimeLockedOutputs ////////////////////////////////////////////////////////////////////////////////////////
+
+// HashTimeLockedOutputs returns the unspent hash time-locked outputs that the wallet can either claim with a preimage
+// or refund after their fallback deadline.
+func (wallet *Wallet) HashTimeLockedOutputs(refresh ...bool) (htlcs OutputsByID, err error) {
+	shouldRefresh := true
+	if len(refresh) > 0 {
+		shouldRefresh = refresh[0]
+	}
+	if shouldRefresh {
+		err = wallet.outputManager.Refresh()
+		if err != nil {
+			return
+		}
+	}
+
+	htlcs = make(OutputsByID)
+	for _, outputsOnAddress := range wallet.outputManager.UnspentOutputs(true) {
+		for outputID, output := range outputsOnAddress {
+			if output.Object.Type() != ledgerstate.ExtendedLockedOutputType {
+				continue
+			}
+			if hashLockType, _ := output.Object.(*ledgerstate.ExtendedLockedOutput).HashLock(); hashLockType != 0 {
+				htlcs[outputID] = output
+			}
+		}
+	}
+
+	return htlcs, err
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region AliasBalance /////////////////////////////////////////////////////////////////////////////////////////////////
 
 // AliasBalance returns the aliases held by this wallet.
@@ -1901,12 +2225,20 @@ func (wallet *Wallet) AddressManager() *AddressManager {
 
 // region ExportState //////////////////////////////////////////////////////////////////////////////////////////////////
 
-// ExportState exports the current state of the wallet to a marshaled version.
+// ExportState exports the current state of the wallet to a marshaled version. The state of wallets with a hierarchical
+// seed additionally contains the number of remainder addresses and their spent bitmask (prefixed by its length) before
+// the spent addresses.
 func (wallet *Wallet) ExportState() []byte {
 	marshalUtil := marshalutil.New()
-	marshalUtil.WriteBytes(wallet.Seed().Bytes())
+	marshalUtil.WriteBytes(wallet.Seed().StateBytes())
 	marshalUtil.WriteUint64(wallet.AddressManager().lastAddressIndex)
 	marshalUtil.WriteBytes(wallet.assetRegistry.Bytes())
+	if wallet.Seed().IsHierarchical() {
+		spentChangeAddressesBytes := *(*[]byte)(unsafe.Pointer(&wallet.addressManager.spentChangeAddresses))
+		marshalUtil.WriteUint64(wallet.addressManager.changeAddressCount)
+		marshalUtil.WriteUint32(uint32(len(spentChangeAddressesBytes)))
+		marshalUtil.WriteBytes(spentChangeAddressesBytes)
+	}
 	marshalUtil.WriteBytes(*(*[]byte)(unsafe.Pointer(&wallet.addressManager.spentAddresses)))
 
 	return marshalUtil.Bytes()
@@ -2087,7 +2419,7 @@ func (wallet *Wallet) collectOutputsForFunding(fundingBalance map[ledgerstate.Co
 		for outputID, output := range unspentOutputs[addy] {
 			if output.Object.Type() == ledgerstate.ExtendedLockedOutputType {
 				casted := output.Object.(*ledgerstate.ExtendedLockedOutput)
-				if casted.TimeLockedNow(now) || !casted.UnlockAddressNow(now).Equals(addy.Address()) {
+				if casted.TimeLockedNow(now) || casted.HashLockedNow(now) || !casted.UnlockAddressNow(now).Equals(addy.Address()) {
 					// skip the output because we wouldn't be able to unlock it
 					continue
 				}
@@ -2187,7 +2519,7 @@ func (wallet *Wallet) buildOutputs(
 	for addr, outputBalanceMap := range outputsByColor {
 		coloredBalances := ledgerstate.NewColoredBalances(outputBalanceMap)
 		var output ledgerstate.Output
-		if !sendOptions.LockUntil.IsZero() || !sendOptions.FallbackDeadline.IsZero() || sendOptions.FallbackAddress != nil {
+		if !sendOptions.LockUntil.IsZero() || !sendOptions.FallbackDeadline.IsZero() || sendOptions.FallbackAddress != nil || sendOptions.HashLockType != 0 {
 			extended := ledgerstate.NewExtendedLockedOutput(outputBalanceMap, addr.Address())
 			if !sendOptions.LockUntil.IsZero() {
 				extended = extended.WithTimeLock(sendOptions.LockUntil)
@@ -2195,6 +2527,9 @@ func (wallet *Wallet) buildOutputs(
 			if !sendOptions.FallbackDeadline.IsZero() && sendOptions.FallbackAddress != nil {
 				extended = extended.WithFallbackOptions(sendOptions.FallbackAddress, sendOptions.FallbackDeadline)
 			}
+			if sendOptions.HashLockType != 0 {
+				extended = extended.WithHashLock(sendOptions.HashLockType, sendOptions.HashLock)
+			}
 			output = extended
 		} else {
 			output = ledgerstate.NewSigLockedColoredOutput(coloredBalances, addr.Address())
@@ -2219,7 +2554,7 @@ func (wallet *Wallet) buildUnlockBlocks(inputs ledgerstate.Inputs, consumedOutpu
 			continue
 		}
 
-		keyPair := wallet.Seed().KeyPair(output.Address.Index)
+		keyPair := wallet.Seed().AddressKeyPair(output.Address)
 		unlockBlock := ledgerstate.NewSignatureUnlockBlock(ledgerstate.NewED25519Signature(keyPair.PublicKey, keyPair.PrivateKey.Sign(essence.Bytes())))
 		unlocks[outputIndex] = unlockBlock
 		existingUnlockBlocks[output.Address] = uint16(outputIndex)
@@ -2227,6 +2562,145 @@ func (wallet *Wallet) buildUnlockBlocks(inputs ledgerstate.Inputs, consumedOutpu
 	return
 }
 
+// findHTLC returns the confirmed hash time-locked output with the given ID from the outputs of the wallet.
+func (wallet *Wallet) findHTLC(outputID ledgerstate.OutputID) (htlc *Output, err error) {
+	if err = wallet.outputManager.Refresh(); err != nil {
+		return
+	}
+	for _, outputs := range wallet.outputManager.UnspentValueOutputs(false) {
+		output, exists := outputs[outputID]
+		if !exists {
+			continue
+		}
+		casted, isExtended := output.Object.(*ledgerstate.ExtendedLockedOutput)
+		if !isExtended {
+			break
+		}
+		if hashLockType, _ := casted.HashLock(); hashLockType == 0 {
+			break
+		}
+		if _, fallbackDeadline := casted.FallbackOptions(); fallbackDeadline.IsZero() {
+			break
+		}
+		if casted.TimeLockedNow(time.Now()) {
+			return nil, errors.Errorf("output %s is still time locked until %s", outputID.Base58(), casted.TimeLock().String())
+		}
+		return output, nil
+	}
+	return nil, errors.Errorf("failed to find confirmed and unspent hash time-locked output %s in the wallet", outputID.Base58())
+}
+
+// spendHTLC moves the funds of the hash time-locked output to the given address (or the wallet if nil). The output is
+// unlocked with a PreimageUnlockBlock if a preimage is given and with a SignatureUnlockBlock otherwise.
+func (wallet *Wallet) spendHTLC(htlc *Output, preimage []byte, toAddress ledgerstate.Address, accessManaPledgeID, consensusManaPledgeID string, waitForConfirmation bool) (tx *ledgerstate.Transaction, err error) {
+	consumedOutputs := OutputsByID{htlc.Object.ID(): htlc}.OutputsByAddressAndOutputID()
+	if toAddress == nil {
+		toAddress = wallet.chooseToAddress(consumedOutputs, address.AddressEmpty).Address()
+	}
+
+	// determine pledgeIDs
+	aPledgeID, cPledgeID, err := wallet.derivePledgeIDs(accessManaPledgeID, consensusManaPledgeID)
+	if err != nil {
+		return
+	}
+
+	inputs := wallet.buildInputs(consumedOutputs)
+	outputs := ledgerstate.NewOutputs(ledgerstate.NewSigLockedColoredOutput(htlc.Object.Balances(), toAddress))
+	txEssence := ledgerstate.NewTransactionEssence(0, time.Now(), aPledgeID, cPledgeID, inputs, outputs)
+
+	keyPair := wallet.Seed().AddressKeyPair(htlc.Address)
+	signature := ledgerstate.NewED25519Signature(keyPair.PublicKey, keyPair.PrivateKey.Sign(txEssence.Bytes()))
+	var unlockBlock ledgerstate.UnlockBlock = ledgerstate.NewSignatureUnlockBlock(signature)
+	if preimage != nil {
+		unlockBlock = ledgerstate.NewPreimageUnlockBlock(signature, preimage)
+	}
+	tx = ledgerstate.NewTransaction(txEssence, ledgerstate.UnlockBlocks{unlockBlock})
+
+	// check syntactical validity by marshaling an unmarshaling
+	tx, _, err = ledgerstate.TransactionFromBytes(tx.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	// check tx validity (balances, unlock blocks)
+	ok, err := checkBalancesAndUnlocks(ledgerstate.Outputs{htlc.Object}, tx)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.Errorf("created transaction is invalid: %s", tx.String())
+	}
+
+	wallet.markOutputsAndAddressesSpent(consumedOutputs)
+
+	err = wallet.connector.SendTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+	if waitForConfirmation {
+		err = wallet.WaitForTxConfirmation(tx.ID())
+	}
+	return tx, err
+}
+
+// transitionTokenSupply issues a state transition of the alias that updates its token supply to nextSupply together with
+// the consumed outputs and the given outputs that mint or burn the tokens.
+func (wallet *Wallet) transitionTokenSupply(walletAlias *Output, nextSupply uint64, consumedOutputs OutputsByAddressAndOutputID,
+	unsortedOutputs ledgerstate.Outputs, accessPledgeNodeID, consensusPledgeNodeID identity.ID, waitForConfirmation bool) (tx *ledgerstate.Transaction, err error) {
+	alias := walletAlias.Object.(*ledgerstate.AliasOutput)
+	// create the alias state transition (only state transition can modify the token supply)
+	nextAlias := alias.NewAliasOutputNext(false)
+	nextAlias.SetIsSupplyControlled(true)
+	if err = nextAlias.SetTokenSupply(nextSupply); err != nil {
+		return nil, err
+	}
+
+	// add the alias to the consumed outputs
+	if _, exists := consumedOutputs[walletAlias.Address]; !exists {
+		consumedOutputs[walletAlias.Address] = make(map[ledgerstate.OutputID]*Output)
+	}
+	consumedOutputs[walletAlias.Address][walletAlias.Object.ID()] = walletAlias
+	inputs := wallet.buildInputs(consumedOutputs)
+	outputs := ledgerstate.NewOutputs(append(unsortedOutputs, nextAlias)...)
+	txEssence := ledgerstate.NewTransactionEssence(0, time.Now(), accessPledgeNodeID, consensusPledgeNodeID, inputs, outputs)
+
+	// build unlock blocks
+	unlockBlocks, inputsInOrder := wallet.buildUnlockBlocks(inputs, consumedOutputs.OutputsByID(), txEssence)
+
+	tx = ledgerstate.NewTransaction(txEssence, unlockBlocks)
+
+	// check syntactical validity by marshaling an unmarshaling
+	tx, _, err = ledgerstate.TransactionFromBytes(tx.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	// check tx validity (balances, unlock blocks, token supply)
+	ok, err := checkBalancesAndUnlocks(inputsInOrder, tx)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.Errorf("created transaction is invalid: %s", tx.String())
+	}
+	if err = ledgerstate.TokenSuppliesValid(inputsInOrder, tx, func(ledgerstate.Color) *ledgerstate.AliasAddress { return nil }); err != nil {
+		return nil, err
+	}
+
+	wallet.markOutputsAndAddressesSpent(consumedOutputs)
+
+	err = wallet.connector.SendTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if waitForConfirmation {
+		err = wallet.WaitForTxConfirmation(tx.ID())
+	}
+
+	return tx, err
+}
+
 // markOutputsAndAddressesSpent marks consumed outputs and their addresses as spent.
 func (wallet *Wallet) markOutputsAndAddressesSpent(consumedOutputs OutputsByAddressAndOutputID) {
 	// mark outputs as spent
@@ -2239,17 +2713,25 @@ func (wallet *Wallet) markOutputsAndAddressesSpent(consumedOutputs OutputsByAddr
 	// mark addresses as spent
 	if !wallet.reusableAddress {
 		for addr := range consumedOutputs {
+			if addr.Change {
+				wallet.addressManager.MarkChangeAddressSpent(addr.Index)
+				continue
+			}
 			wallet.addressManager.MarkAddressSpent(addr.Index)
 		}
 	}
 }
 
 // chooseRemainderAddress chooses an appropriate remainder address based on the wallet configuration and where we are spending from.
+// Wallets with a hierarchical seed send every remainder to a new address on the change chain.
 func (wallet *Wallet) chooseRemainderAddress(consumedOutputs OutputsByAddressAndOutputID, optionsRemainder address.Address) (remainder address.Address) {
 	if optionsRemainder == address.AddressEmpty {
 		if wallet.reusableAddress {
 			return wallet.RemainderAddress()
 		}
+		if wallet.Seed().IsHierarchical() {
+			return wallet.addressManager.NewChangeAddress()
+		}
 		_, spendFromRemainderAddress := consumedOutputs[wallet.RemainderAddress()]
 		_, spendFromReceiveAddress := consumedOutputs[wallet.ReceiveAddress()]
 		if spendFromRemainderAddress && spendFromReceiveAddress {
@@ -0,0 +1,123 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/iotaledger/hive.go/bitmask"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/refreshoptions"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/seed"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+func TestWallet_RefreshRecover(t *testing.T) {
+	walletSeed := seed.NewSeed()
+	connector := &recoveryConnector{
+		unspentOutputs: map[uint64]bool{2: true, 9: true},
+		spentAddresses: map[uint64]bool{0: true, 4: true},
+	}
+	wallet := New(Import(walletSeed, 0, []bitmask.BitMask{}, nil), func(wallet *Wallet) {
+		wallet.connector = connector
+	})
+
+	// without recovery only the known address is scanned
+	require.NoError(t, wallet.Refresh())
+	assert.Len(t, wallet.AddressManager().Addresses(), 1)
+	assert.Empty(t, wallet.UnspentOutputs())
+
+	// address 9 is found because the gap between address 4 and 9 is smaller than the gap limit
+	require.NoError(t, wallet.Refresh(refreshoptions.Recover(5), refreshoptions.BatchSize(3)))
+	assert.Len(t, wallet.AddressManager().Addresses(), 11)
+	assert.True(t, wallet.AddressManager().IsAddressSpent(0))
+	assert.False(t, wallet.AddressManager().IsAddressSpent(2))
+	assert.True(t, wallet.AddressManager().IsAddressSpent(4))
+	assert.Equal(t, uint64(10), wallet.ReceiveAddress().Index)
+	assert.Len(t, wallet.UnspentOutputs(), 2)
+	assert.Equal(t, uint64(14), connector.maxScannedIndex)
+
+	// a smaller gap limit stops before address 9
+	restoredWallet := New(Import(walletSeed, 0, []bitmask.BitMask{}, nil), func(wallet *Wallet) {
+		wallet.connector = &recoveryConnector{unspentOutputs: connector.unspentOutputs, spentAddresses: connector.spentAddresses}
+	})
+	require.NoError(t, restoredWallet.Refresh(refreshoptions.Recover(4)))
+	assert.Len(t, restoredWallet.AddressManager().Addresses(), 6)
+	assert.Equal(t, uint64(5), restoredWallet.ReceiveAddress().Index)
+}
+
+func TestWallet_ChangeAddresses(t *testing.T) {
+	walletSeed, err := seed.FromMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "", 0)
+	require.NoError(t, err)
+	connector := &recoveryConnector{
+		unspentOutputs:       map[uint64]bool{1: true},
+		unspentChangeOutputs: map[uint64]bool{0: true, 3: true},
+	}
+	wallet := New(Import(walletSeed, 0, []bitmask.BitMask{}, nil), func(wallet *Wallet) {
+		wallet.connector = connector
+	})
+
+	// the change chain is scanned during the recovery
+	require.NoError(t, wallet.Refresh(refreshoptions.Recover(3)))
+	assert.Equal(t, []address.Address{walletSeed.ChangeAddress(0), walletSeed.ChangeAddress(1), walletSeed.ChangeAddress(2), walletSeed.ChangeAddress(3)}, wallet.AddressManager().ChangeAddresses())
+	assert.Len(t, wallet.UnspentOutputs(), 3)
+
+	// remainders are sent to new addresses on the change chain
+	remainderAddress := wallet.chooseRemainderAddress(OutputsByAddressAndOutputID{}, address.AddressEmpty)
+	assert.Equal(t, walletSeed.ChangeAddress(4), remainderAddress)
+	assert.Equal(t, walletSeed.ChangeAddress(5), wallet.chooseRemainderAddress(OutputsByAddressAndOutputID{}, address.AddressEmpty))
+
+	wallet.markOutputsAndAddressesSpent(OutputsByAddressAndOutputID{remainderAddress: {}})
+	assert.True(t, wallet.AddressManager().IsSpent(remainderAddress))
+	assert.False(t, wallet.AddressManager().IsSpent(walletSeed.Address(4)))
+	assert.NotContains(t, wallet.AddressManager().UnspentAddresses(), remainderAddress)
+	assert.Contains(t, wallet.AddressManager().SpentAddresses(), remainderAddress)
+}
+
+// recoveryConnector is a Connector that reports unspent outputs and spent outputs for the configured address indexes.
+type recoveryConnector struct {
+	Connector
+
+	unspentOutputs       map[uint64]bool
+	unspentChangeOutputs map[uint64]bool
+	spentAddresses       map[uint64]bool
+	maxScannedIndex      uint64
+}
+
+func (r *recoveryConnector) UnspentOutputs(addresses ...address.Address) (unspentOutputs OutputsByAddressAndOutputID, err error) {
+	unspentOutputs, _, err = r.ScanAddresses(addresses...)
+
+	return
+}
+
+func (r *recoveryConnector) ScanAddresses(addresses ...address.Address) (unspentOutputs OutputsByAddressAndOutputID, spentAddresses map[address.Address]bool, err error) {
+	unspentOutputs = make(OutputsByAddressAndOutputID)
+	spentAddresses = make(map[address.Address]bool)
+	for _, addr := range addresses {
+		if addr.Change {
+			if r.unspentChangeOutputs[addr.Index] {
+				output := ledgerstate.NewSigLockedSingleOutput(1, addr.Address()).SetID(ledgerstate.NewOutputID(ledgerstate.TransactionID{1, byte(addr.Index)}, 0))
+				unspentOutputs[addr] = map[ledgerstate.OutputID]*Output{
+					output.ID(): {Address: addr, Object: output, GradeOfFinalityReached: true},
+				}
+			}
+			continue
+		}
+
+		if addr.Index > r.maxScannedIndex {
+			r.maxScannedIndex = addr.Index
+		}
+		if r.spentAddresses[addr.Index] {
+			spentAddresses[addr] = true
+		}
+		if r.unspentOutputs[addr.Index] {
+			output := ledgerstate.NewSigLockedSingleOutput(1, addr.Address()).SetID(ledgerstate.NewOutputID(ledgerstate.TransactionID{byte(addr.Index)}, 0))
+			unspentOutputs[addr] = map[ledgerstate.OutputID]*Output{
+				output.ID(): {Address: addr, Object: output, GradeOfFinalityReached: true},
+			}
+		}
+	}
+
+	return
+}
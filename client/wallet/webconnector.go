@@ -1,11 +1,15 @@
 package wallet
 
 import (
+	"context"
+
 	"github.com/cockroachdb/errors"
 
 	"github.com/iotaledger/goshimmer/client"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/packages/assetregistry"
 	"github.com/iotaledger/goshimmer/packages/consensus/gof"
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/mana"
 )
@@ -25,7 +29,7 @@ func NewWebConnector(baseURL string, setters ...client.Option) *WebConnector {
 
 // ServerStatus retrieves the connected server status with Info api.
 func (webConnector *WebConnector) ServerStatus() (status ServerStatus, err error) {
-	response, err := webConnector.client.Info()
+	response, err := webConnector.client.Info(context.Background())
 	if err != nil {
 		return
 	}
@@ -41,13 +45,20 @@ func (webConnector *WebConnector) ServerStatus() (status ServerStatus, err error
 
 // RequestFaucetFunds request some funds from the faucet for test purposes.
 func (webConnector *WebConnector) RequestFaucetFunds(addr address.Address, powTarget int) (err error) {
-	_, err = webConnector.client.SendFaucetRequest(addr.Address().Base58(), powTarget)
+	_, err = webConnector.client.SendFaucetRequest(context.Background(), addr.Address().Base58(), powTarget)
 
 	return
 }
 
 // UnspentOutputs returns the outputs of transactions on the given addresses that have not been spent yet.
 func (webConnector WebConnector) UnspentOutputs(addresses ...address.Address) (unspentOutputs OutputsByAddressAndOutputID, err error) {
+	unspentOutputs, _, err = webConnector.ScanAddresses(addresses...)
+
+	return
+}
+
+// ScanAddresses returns the unspent outputs on the given addresses and the addresses that outputs were spent from.
+func (webConnector WebConnector) ScanAddresses(addresses ...address.Address) (unspentOutputs OutputsByAddressAndOutputID, spentAddresses map[address.Address]bool, err error) {
 	// build reverse lookup table + arguments for client call
 	addressReverseLookupTable := make(map[string]address.Address)
 	base58EncodedAddresses := make([]string, len(addresses))
@@ -57,25 +68,29 @@ func (webConnector WebConnector) UnspentOutputs(addresses ...address.Address) (u
 	}
 
 	// request unspent outputs
-	response, err := webConnector.client.PostAddressUnspentOutputs(base58EncodedAddresses)
+	response, err := webConnector.client.PostAddressUnspentOutputs(context.Background(), base58EncodedAddresses)
 	if err != nil {
 		return
 	}
 
 	// build result
 	unspentOutputs = make(map[address.Address]map[ledgerstate.OutputID]*Output)
+	spentAddresses = make(map[address.Address]bool)
 	for _, unspentOutput := range response.UnspentOutputs {
 		// lookup wallet address from raw address
 		addr, addressRequested := addressReverseLookupTable[unspentOutput.Address.Base58]
 		if !addressRequested {
 			panic("the server returned an unrequested address")
 		}
+		if unspentOutput.SpentOutputs > 0 {
+			spentAddresses[addr] = true
+		}
 
 		// iterate through outputs
 		for _, output := range unspentOutput.Outputs {
 			lOutput, err := output.Output.ToLedgerstateOutput()
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			// build output
 			walletOutput := &Output{
@@ -101,14 +116,14 @@ func (webConnector WebConnector) UnspentOutputs(addresses ...address.Address) (u
 
 // SendTransaction sends a new transaction to the network.
 func (webConnector WebConnector) SendTransaction(tx *ledgerstate.Transaction) (err error) {
-	_, err = webConnector.client.PostTransaction(tx.Bytes())
+	_, err = webConnector.client.PostTransaction(context.Background(), tx.Bytes())
 
 	return
 }
 
 // GetTransactionGoF fetches the GoF of the transaction.
 func (webConnector WebConnector) GetTransactionGoF(txID ledgerstate.TransactionID) (gradeOfFinality gof.GradeOfFinality, err error) {
-	txmeta, err := webConnector.client.GetTransactionMetadata(txID.Base58())
+	txmeta, err := webConnector.client.GetTransactionMetadata(context.Background(), txID.Base58())
 	if err != nil {
 		return
 	}
@@ -118,7 +133,7 @@ func (webConnector WebConnector) GetTransactionGoF(txID ledgerstate.TransactionI
 
 // GetAllowedPledgeIDs gets the list of nodeIDs that the node accepts as pledgeIDs in a transaction.
 func (webConnector WebConnector) GetAllowedPledgeIDs() (pledgeIDMap map[mana.Type][]string, err error) {
-	res, err := webConnector.client.GetAllowedManaPledgeNodeIDs()
+	res, err := webConnector.client.GetAllowedManaPledgeNodeIDs(context.Background())
 	if err != nil {
 		return
 	}
@@ -132,33 +147,64 @@ func (webConnector WebConnector) GetAllowedPledgeIDs() (pledgeIDMap map[mana.Typ
 
 // GetUnspentAliasOutput returns the current unspent alias output that belongs to a given alias address.
 func (webConnector WebConnector) GetUnspentAliasOutput(addr *ledgerstate.AliasAddress) (output *ledgerstate.AliasOutput, err error) {
-	res, err := webConnector.client.GetAddressUnspentOutputs(addr.Base58())
-	if err != nil {
-		return
-	}
-	for _, o := range res.Outputs {
-		if o.Type != ledgerstate.AliasOutputType.String() {
-			continue
-		}
-		var uncastedOutput ledgerstate.Output
-		uncastedOutput, err = o.ToLedgerstateOutput()
+	for cursor := ""; ; {
+		var res *jsonmodels.GetAddressResponse
+		res, err = webConnector.client.GetAddressUnspentOutputs(context.Background(), addr.Base58(),
+			client.WithOutputType(ledgerstate.AliasOutputType), client.WithOutputsCursor(cursor))
 		if err != nil {
 			return
 		}
-		alias, ok := uncastedOutput.(*ledgerstate.AliasOutput)
-		if !ok {
-			err = errors.Errorf("alias output received from api cannot be casted to ledgerstate representation")
-			return
+		for _, o := range res.Outputs {
+			var uncastedOutput ledgerstate.Output
+			uncastedOutput, err = o.ToLedgerstateOutput()
+			if err != nil {
+				return
+			}
+			alias, ok := uncastedOutput.(*ledgerstate.AliasOutput)
+			if !ok {
+				err = errors.Errorf("alias output received from api cannot be casted to ledgerstate representation")
+				return
+			}
+			if alias.GetAliasAddress().Equals(addr) {
+				// we found what we were looking for
+				output = alias
+				return
+			}
 		}
-		if alias.GetAliasAddress().Equals(addr) {
-			// we found what we were looking for
-			output = alias
-			return
+		if cursor = res.NextCursor; cursor == "" {
+			break
 		}
 	}
 	return nil, errors.Errorf("couldn't find unspent alias output for alias addr %s", addr.Base58())
 }
 
+// IssueAssetMetadata issues the given asset metadata to the tangle.
+func (webConnector WebConnector) IssueAssetMetadata(metadataPayload *assetregistry.Payload) (err error) {
+	_, err = webConnector.client.SendPayload(context.Background(), metadataPayload.Bytes())
+	return
+}
+
+// GetAsset returns the asset metadata that was registered on the tangle for the given color.
+func (webConnector WebConnector) GetAsset(color ledgerstate.Color) (asset *Asset, err error) {
+	res, err := webConnector.client.GetAsset(context.Background(), color.Base58())
+	if err != nil {
+		return
+	}
+	mintingOutputID, err := ledgerstate.OutputIDFromBase58(res.MintingOutputID.Base58)
+	if err != nil {
+		return nil, errors.Errorf("failed to parse minting OutputID of asset %s: %w", color.Base58(), err)
+	}
+
+	return &Asset{
+		Color:         color,
+		Name:          res.Name,
+		Symbol:        res.Symbol,
+		Precision:     int(res.Precision),
+		Supply:        res.Supply,
+		TransactionID: mintingOutputID.TransactionID(),
+	}, nil
+}
+
 // colorFromString is an internal utility method that parses the given string into a Color.
 func colorFromString(colorStr string) (color ledgerstate.Color) {
 	if colorStr == "IOTA" {
@@ -287,6 +287,12 @@ module.exports = {
         label: 'Tools',
         id: 'apis/tools',
       },
+
+      {
+        type: 'doc',
+        label: 'Admin',
+        id: 'apis/admin',
+      },
     ],
   },
   {
@@ -0,0 +1,275 @@
+package assetregistry
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/stringify"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+)
+
+const (
+	// ObjectName defines the name of the asset metadata object (payload).
+	ObjectName  = "assetMetadata"
+	payloadType = 1338
+
+	// MaxNameLength defines the maximum length of the name of an asset in bytes.
+	MaxNameLength = 64
+
+	// MaxSymbolLength defines the maximum length of the symbol of an asset in bytes.
+	MaxSymbolLength = 16
+)
+
+// Type represents the identifier for the asset metadata Payload type.
+var Type = payload.NewType(payloadType, ObjectName, PayloadUnmarshaler)
+
+// region Payload //////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Payload represents the metadata of a colored token that is issued by the address that minted the token. It references
+// the Output that minted the token, so nodes can verify that the signer is allowed to describe the Color. The signing
+// time is covered by the signature and orders the metadata of a Color, so a copy of an older Payload can't replace a
+// later correction.
+type Payload struct {
+	color           ledgerstate.Color
+	mintingOutputID ledgerstate.OutputID
+	name            string
+	symbol          string
+	precision       uint32
+	signingTime     time.Time
+	signature       *ledgerstate.ED25519Signature
+}
+
+// NewPayload is the constructor of an unsigned Payload for the given details.
+func NewPayload(color ledgerstate.Color, mintingOutputID ledgerstate.OutputID, name, symbol string, precision uint32, signingTime time.Time) (p *Payload, err error) {
+	p = &Payload{
+		color:           color,
+		mintingOutputID: mintingOutputID,
+		name:            name,
+		symbol:          symbol,
+		precision:       precision,
+		signingTime:     signingTime,
+	}
+	if err = p.checkLengths(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// FromBytes parses the marshaled version of a Payload into a Payload object.
+func FromBytes(bytes []byte) (result *Payload, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if result, err = FromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse asset metadata Payload from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// FromMarshalUtil unmarshals a Payload using a MarshalUtil (for easier unmarshaling).
+func FromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (result *Payload, err error) {
+	if _, err = marshalUtil.ReadUint32(); err != nil {
+		err = errors.Errorf("failed to parse payload size (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	parsedType, err := payload.TypeFromMarshalUtil(marshalUtil)
+	if err != nil {
+		err = errors.Errorf("failed to parse Type from MarshalUtil: %w", err)
+		return
+	}
+	if parsedType != payload.Type(payloadType) {
+		err = errors.Errorf("invalid payload type %s: %w", parsedType, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	result = &Payload{}
+	if result.color, err = ledgerstate.ColorFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse Color from MarshalUtil: %w", err)
+		return
+	}
+	if result.mintingOutputID, err = ledgerstate.OutputIDFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse minting OutputID from MarshalUtil: %w", err)
+		return
+	}
+	if result.name, err = readString(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse name (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if result.symbol, err = readString(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse symbol (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if result.precision, err = marshalUtil.ReadUint32(); err != nil {
+		err = errors.Errorf("failed to parse precision (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if result.signingTime, err = marshalUtil.ReadTime(); err != nil {
+		err = errors.Errorf("failed to parse signing time (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if result.signature, err = ledgerstate.ED25519SignatureFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse ED25519Signature from MarshalUtil: %w", err)
+		return
+	}
+	if err = result.checkLengths(); err != nil {
+		err = errors.Errorf("%v: %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// Color returns the Color that the Payload describes.
+func (p *Payload) Color() ledgerstate.Color {
+	return p.color
+}
+
+// MintingOutputID returns the OutputID of the Output that minted the Color.
+func (p *Payload) MintingOutputID() ledgerstate.OutputID {
+	return p.mintingOutputID
+}
+
+// Name returns the name of the asset.
+func (p *Payload) Name() string {
+	return p.name
+}
+
+// Symbol returns the currency symbol of the asset.
+func (p *Payload) Symbol() string {
+	return p.symbol
+}
+
+// Precision returns the amount of decimal places that wallets show for the asset.
+func (p *Payload) Precision() uint32 {
+	return p.precision
+}
+
+// SigningTime returns the time at which the issuer signed the Payload. Of two Payloads of the same Color, the one
+// signed later wins.
+func (p *Payload) SigningTime() time.Time {
+	return p.signingTime
+}
+
+// Signature returns the signature of the issuer of the Payload (nil if it was not signed yet).
+func (p *Payload) Signature() *ledgerstate.ED25519Signature {
+	return p.signature
+}
+
+// Issuer returns the Address that signed the Payload (nil if it was not signed yet).
+func (p *Payload) Issuer() ledgerstate.Address {
+	if p.signature == nil {
+		return nil
+	}
+
+	return ledgerstate.NewED25519Address(p.signature.PublicKey)
+}
+
+// Sign signs the Payload with the given KeyPair.
+func (p *Payload) Sign(keyPair *ed25519.KeyPair) {
+	p.signature = ledgerstate.NewED25519Signature(keyPair.PublicKey, keyPair.PrivateKey.Sign(p.EssenceBytes()))
+}
+
+// SignatureValid returns true if the Payload was signed by its Issuer.
+func (p *Payload) SignatureValid() bool {
+	return p.signature != nil && p.signature.SignatureValid(p.EssenceBytes())
+}
+
+// EssenceBytes returns the bytes that are covered by the signature. They are prefixed with the payload Type, so that the
+// signature can not be replayed in a different context.
+func (p *Payload) EssenceBytes() []byte {
+	return marshalutil.New().
+		Write(Type).
+		Write(p.color).
+		Write(p.mintingOutputID).
+		WriteUint16(uint16(len(p.name))).
+		WriteBytes([]byte(p.name)).
+		WriteUint16(uint16(len(p.symbol))).
+		WriteBytes([]byte(p.symbol)).
+		WriteUint32(p.precision).
+		WriteTime(p.signingTime).
+		Bytes()
+}
+
+// Type returns the Type of the Payload.
+func (p *Payload) Type() payload.Type {
+	return Type
+}
+
+// Bytes marshals the Payload into a sequence of bytes.
+func (p *Payload) Bytes() []byte {
+	// the essence already starts with the payload Type
+	payloadBytes := p.EssenceBytes()
+	if p.signature != nil {
+		payloadBytes = append(payloadBytes, p.signature.Bytes()...)
+	}
+
+	return marshalutil.New().
+		WriteUint32(uint32(len(payloadBytes))).
+		WriteBytes(payloadBytes).
+		Bytes()
+}
+
+// String returns a human readable version of the Payload.
+func (p *Payload) String() string {
+	return stringify.Struct("AssetMetadataPayload",
+		stringify.StructField("color", p.color),
+		stringify.StructField("mintingOutputID", p.mintingOutputID),
+		stringify.StructField("name", p.name),
+		stringify.StructField("symbol", p.symbol),
+		stringify.StructField("precision", p.precision),
+		stringify.StructField("signingTime", p.signingTime),
+		stringify.StructField("signature", p.signature),
+	)
+}
+
+// checkLengths returns an error if the name or symbol of the Payload exceed their maximum length.
+func (p *Payload) checkLengths() error {
+	if p.name == "" {
+		return errors.New("name of asset is empty")
+	}
+	if len(p.name) > MaxNameLength {
+		return errors.Errorf("name of asset is longer than %d bytes", MaxNameLength)
+	}
+	if len(p.symbol) > MaxSymbolLength {
+		return errors.Errorf("symbol of asset is longer than %d bytes", MaxSymbolLength)
+	}
+
+	return nil
+}
+
+// PayloadUnmarshaler sets the generic unmarshaler.
+func PayloadUnmarshaler(data []byte) (payload payload.Payload, err error) {
+	var consumedBytes int
+	payload, consumedBytes, err = FromBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	if consumedBytes != len(data) {
+		return nil, errors.New("not all payload bytes were consumed")
+	}
+	return
+}
+
+// code contract (make sure the struct implements all required methods)
+var _ payload.Payload = &Payload{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func readString(marshalUtil *marshalutil.MarshalUtil) (string, error) {
+	length, err := marshalUtil.ReadUint16()
+	if err != nil {
+		return "", err
+	}
+	bytes, err := marshalUtil.ReadBytes(int(length))
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
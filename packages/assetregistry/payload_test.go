@@ -0,0 +1,61 @@
+package assetregistry
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+)
+
+func TestPayload(t *testing.T) {
+	keyPair := ed25519.GenerateKeyPair()
+	color := ledgerstate.Color{1}
+	outputID := ledgerstate.NewOutputID(ledgerstate.TransactionID{2}, 1)
+
+	signingTime := time.Unix(1600000000, 0)
+
+	metadataPayload, err := NewPayload(color, outputID, "Test Token", "TT", 2, signingTime)
+	require.NoError(t, err)
+	assert.False(t, metadataPayload.SignatureValid())
+	assert.Nil(t, metadataPayload.Issuer())
+
+	metadataPayload.Sign(&keyPair)
+	assert.True(t, metadataPayload.SignatureValid())
+	assert.True(t, ledgerstate.NewED25519Address(keyPair.PublicKey).Equals(metadataPayload.Issuer()))
+
+	restoredPayload, consumedBytes, err := FromBytes(metadataPayload.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, len(metadataPayload.Bytes()), consumedBytes)
+	assert.Equal(t, color, restoredPayload.Color())
+	assert.Equal(t, outputID, restoredPayload.MintingOutputID())
+	assert.Equal(t, "Test Token", restoredPayload.Name())
+	assert.Equal(t, "TT", restoredPayload.Symbol())
+	assert.Equal(t, uint32(2), restoredPayload.Precision())
+	assert.True(t, signingTime.Equal(restoredPayload.SigningTime()))
+	assert.True(t, restoredPayload.SignatureValid())
+
+	genericPayload, _, err := payload.FromBytes(metadataPayload.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, Type, genericPayload.Type())
+
+	restoredPayload.symbol = "XX"
+	assert.False(t, restoredPayload.SignatureValid())
+
+	restoredPayload, _, err = FromBytes(metadataPayload.Bytes())
+	require.NoError(t, err)
+	restoredPayload.signingTime = signingTime.Add(time.Second)
+	assert.False(t, restoredPayload.SignatureValid())
+
+	_, err = NewPayload(color, outputID, "", "TT", 2, signingTime)
+	assert.Error(t, err)
+	_, err = NewPayload(color, outputID, strings.Repeat("a", MaxNameLength+1), "TT", 2, signingTime)
+	assert.Error(t, err)
+	_, err = NewPayload(color, outputID, "Test Token", strings.Repeat("a", MaxSymbolLength+1), 2, signingTime)
+	assert.Error(t, err)
+}
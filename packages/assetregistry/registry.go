@@ -0,0 +1,443 @@
+package assetregistry
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/stringify"
+	"github.com/iotaledger/hive.go/types"
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+// maxParkedPayloads defines the maximum number of Payloads that wait for their minting Output to be booked.
+const maxParkedPayloads = 1000
+
+var (
+	// ErrInvalidSignature is returned if the signature of a Payload is missing or invalid.
+	ErrInvalidSignature = errors.New("invalid signature")
+
+	// ErrUnknownMintingOutput is returned if the Output that minted the Color is not known to the ledger.
+	ErrUnknownMintingOutput = errors.New("unknown minting output")
+
+	// ErrUnauthorizedIssuer is returned if the signer of a Payload did not mint the described Color.
+	ErrUnauthorizedIssuer = errors.New("issuer is not allowed to register the asset")
+
+	// ErrFutureSigningTime is returned if a Payload was signed after the message that contains it was issued.
+	ErrFutureSigningTime = errors.New("payload was signed after the message was issued")
+
+	// ErrTooManyParkedPayloads is returned if a Payload can't be parked, because too many Payloads wait for their
+	// minting Output already.
+	ErrTooManyParkedPayloads = errors.New("too many payloads wait for their minting output")
+)
+
+// region Registry /////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Registry indexes the metadata of colored tokens that was issued to the Tangle by the addresses that minted them.
+type Registry struct {
+	store   kvstore.KVStore
+	utxoDAG ledgerstate.IUTXODAG
+	mutex   sync.Mutex
+
+	// parked contains the Payloads whose minting Output was not booked yet, grouped by the OutputID.
+	parked      map[ledgerstate.OutputID][]*parkedPayload
+	parkedCount int
+	parkedMutex sync.Mutex
+}
+
+// NewRegistry returns a new Registry that persists the assets in the given store and uses the given UTXODAG to verify
+// the issuers of the metadata.
+func NewRegistry(store kvstore.KVStore, utxoDAG ledgerstate.IUTXODAG) *Registry {
+	return &Registry{
+		store:   store,
+		utxoDAG: utxoDAG,
+		parked:  make(map[ledgerstate.OutputID][]*parkedPayload),
+	}
+}
+
+// Register verifies the given Payload that was contained in the message with the given issuing time and MessageID and
+// stores its metadata. If the Color was registered before, the metadata with the later signing time wins (and the
+// higher metadata bytes if both were signed at the same time), so the minter can correct it and all nodes end up with
+// the same result. As the signing time is signed and may not be after the issuing time, copying an older Payload into
+// a new message does not replace a later correction. It returns true if the stored metadata was updated.
+//
+// If the minting Output is not booked yet, the Payload is parked and registered by RegisterParked once the Output is
+// booked. In that case ErrUnknownMintingOutput is returned.
+func (r *Registry) Register(metadataPayload *Payload, issuingTime time.Time, messageID tangle.MessageID) (updated bool, err error) {
+	supply, err := r.checkIssuer(metadataPayload, issuingTime)
+	if errors.Is(err, ErrUnknownMintingOutput) {
+		return r.park(&parkedPayload{payload: metadataPayload, issuingTime: issuingTime, messageID: messageID}, err)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return r.storeAsset(metadataPayload, supply, issuingTime, messageID)
+}
+
+// RegisterParked registers the Payloads that were parked until the given Output was booked. It returns the Assets
+// whose stored metadata was updated.
+func (r *Registry) RegisterParked(mintingOutputID ledgerstate.OutputID) (updatedAssets []*Asset, err error) {
+	r.parkedMutex.Lock()
+	parkedPayloads := r.parked[mintingOutputID]
+	delete(r.parked, mintingOutputID)
+	r.parkedCount -= len(parkedPayloads)
+	r.parkedMutex.Unlock()
+
+	for _, parked := range parkedPayloads {
+		supply, checkErr := r.checkIssuer(parked.payload, parked.issuingTime)
+		if checkErr != nil {
+			err = errors.CombineErrors(err, checkErr)
+			continue
+		}
+
+		updated, storeErr := r.storeAsset(parked.payload, supply, parked.issuingTime, parked.messageID)
+		if storeErr != nil {
+			err = errors.CombineErrors(err, storeErr)
+			continue
+		}
+		if updated {
+			updatedAssets = append(updatedAssets, newAsset(parked.payload, supply, parked.issuingTime, parked.messageID))
+		}
+	}
+
+	return updatedAssets, err
+}
+
+// park stores the Payload until its minting Output is booked and returns the given error if it was parked.
+func (r *Registry) park(parked *parkedPayload, unknownOutputErr error) (updated bool, err error) {
+	mintingOutputID := parked.payload.MintingOutputID()
+
+	r.parkedMutex.Lock()
+	if r.parkedCount >= maxParkedPayloads {
+		r.parkedMutex.Unlock()
+		return false, errors.Errorf("failed to park metadata of asset %s: %w", parked.payload.Color(), ErrTooManyParkedPayloads)
+	}
+	r.parked[mintingOutputID] = append(r.parked[mintingOutputID], parked)
+	r.parkedCount++
+	r.parkedMutex.Unlock()
+
+	// the Output might have been booked after the check, so RegisterParked could have missed the Payload
+	if r.utxoDAG.CachedOutput(mintingOutputID).Consume(func(ledgerstate.Output) {}) {
+		updatedAssets, registerErr := r.RegisterParked(mintingOutputID)
+		for _, asset := range updatedAssets {
+			if asset.MessageID == parked.messageID {
+				updated = true
+			}
+		}
+
+		return updated, registerErr
+	}
+
+	return false, unknownOutputErr
+}
+
+// storeAsset persists the metadata of the Payload if it wins against the metadata that is registered already.
+func (r *Registry) storeAsset(metadataPayload *Payload, supply uint64, issuingTime time.Time, messageID tangle.MessageID) (updated bool, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	existingAsset, exists, err := r.load(metadataPayload.Color())
+	if err != nil {
+		return false, err
+	}
+	asset := newAsset(metadataPayload, supply, issuingTime, messageID)
+	if exists && !existingAsset.replacedBy(asset) {
+		return false, nil
+	}
+
+	if err = r.store.Set(asset.Color.Bytes(), asset.Bytes()); err != nil {
+		return false, errors.Errorf("failed to store asset %s: %w", asset.Color, err)
+	}
+
+	return true, nil
+}
+
+// Asset returns the registered metadata of the given Color.
+func (r *Registry) Asset(color ledgerstate.Color) (asset *Asset, exists bool, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.load(color)
+}
+
+// load retrieves the Asset of the given Color from the store.
+func (r *Registry) load(color ledgerstate.Color) (asset *Asset, exists bool, err error) {
+	assetBytes, err := r.store.Get(color.Bytes())
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			return nil, false, nil
+		}
+		return nil, false, errors.Errorf("failed to load asset %s: %w", color, err)
+	}
+
+	if asset, _, err = AssetFromBytes(assetBytes); err != nil {
+		return nil, false, err
+	}
+
+	return asset, true, nil
+}
+
+// checkIssuer verifies that the Payload was signed by an address that minted its Color and returns the supply of the
+// Color in the minting Output. A ColorMint color is described by a signer of the Transaction that created the minting
+// Output, while the token of a supply-controlled alias is described by the state controller of the referenced
+// AliasOutput, which needs to be the current state of the alias at the given issuing time of the message.
+func (r *Registry) checkIssuer(metadataPayload *Payload, issuingTime time.Time) (supply uint64, err error) {
+	if !metadataPayload.SignatureValid() {
+		return 0, errors.Errorf("metadata of asset %s: %w", metadataPayload.Color(), ErrInvalidSignature)
+	}
+	if metadataPayload.SigningTime().After(issuingTime) {
+		return 0, errors.Errorf("metadata of asset %s: %w", metadataPayload.Color(), ErrFutureSigningTime)
+	}
+
+	var mintingOutput ledgerstate.Output
+	r.utxoDAG.CachedOutput(metadataPayload.MintingOutputID()).Consume(func(output ledgerstate.Output) {
+		mintingOutput = output
+	})
+	if mintingOutput == nil {
+		return 0, errors.Errorf("output %s: %w", metadataPayload.MintingOutputID().Base58(), ErrUnknownMintingOutput)
+	}
+
+	if aliasOutput, isAlias := mintingOutput.(*ledgerstate.AliasOutput); isAlias {
+		if !aliasOutput.IsSupplyControlled() || aliasOutput.TokenColor() != metadataPayload.Color() {
+			return 0, errors.Errorf("alias %s does not control the supply of %s: %w", aliasOutput.GetAliasAddress().Base58(), metadataPayload.Color(), ErrUnauthorizedIssuer)
+		}
+		if r.consumedBefore(aliasOutput.ID(), issuingTime) {
+			return 0, errors.Errorf("output %s is not the current state of alias %s: %w", aliasOutput.ID().Base58(), aliasOutput.GetAliasAddress().Base58(), ErrUnauthorizedIssuer)
+		}
+		if !aliasOutput.GetStateAddress().Equals(metadataPayload.Issuer()) {
+			return 0, errors.Errorf("%s is not the state controller of alias %s: %w", metadataPayload.Issuer().Base58(), aliasOutput.GetAliasAddress().Base58(), ErrUnauthorizedIssuer)
+		}
+
+		return aliasOutput.TokenSupply(), nil
+	}
+
+	if mintedColor := ledgerstate.Color(blake2b.Sum256(mintingOutput.ID().Bytes())); mintedColor != metadataPayload.Color() {
+		return 0, errors.Errorf("output %s did not mint %s: %w", mintingOutput.ID().Base58(), metadataPayload.Color(), ErrUnauthorizedIssuer)
+	}
+	supply, minted := mintingOutput.Balances().Get(metadataPayload.Color())
+	if !minted {
+		return 0, errors.Errorf("output %s did not mint %s: %w", mintingOutput.ID().Base58(), metadataPayload.Color(), ErrUnauthorizedIssuer)
+	}
+
+	signedMintingTransaction := false
+	r.utxoDAG.CachedTransaction(mintingOutput.ID().TransactionID()).Consume(func(transaction *ledgerstate.Transaction) {
+		essenceBytes := transaction.Essence().Bytes()
+		for _, unlockBlock := range transaction.UnlockBlocks() {
+			signatureUnlockBlock, isSignature := unlockBlock.(*ledgerstate.SignatureUnlockBlock)
+			if isSignature && signatureUnlockBlock.AddressSignatureValid(metadataPayload.Issuer(), essenceBytes) {
+				signedMintingTransaction = true
+				return
+			}
+		}
+	})
+	if !signedMintingTransaction {
+		return 0, errors.Errorf("%s did not sign the transaction that minted %s: %w", metadataPayload.Issuer().Base58(), metadataPayload.Color(), ErrUnauthorizedIssuer)
+	}
+
+	return supply, nil
+}
+
+// consumedBefore returns true if the Output with the given OutputID was consumed by a valid Transaction with a
+// timestamp that is not after the given time. It compares the timestamps of the Transactions instead of the local
+// time, so the former controllers of an alias can not describe its token after they handed it over.
+func (r *Registry) consumedBefore(outputID ledgerstate.OutputID, t time.Time) (consumed bool) {
+	cachedConsumers := r.utxoDAG.CachedConsumers(outputID)
+	defer cachedConsumers.Release()
+
+	for _, consumer := range cachedConsumers.Unwrap() {
+		if consumer == nil || consumer.Valid() == types.False {
+			continue
+		}
+		r.utxoDAG.CachedTransaction(consumer.TransactionID()).Consume(func(transaction *ledgerstate.Transaction) {
+			consumed = consumed || !transaction.Essence().Timestamp().After(t)
+		})
+	}
+
+	return consumed
+}
+
+// parkedPayload is a Payload that waits for its minting Output to be booked.
+type parkedPayload struct {
+	payload     *Payload
+	issuingTime time.Time
+	messageID   tangle.MessageID
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Asset ////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Asset represents the registered metadata of a colored token.
+type Asset struct {
+	// Color contains the identifier of the asset.
+	Color ledgerstate.Color
+
+	// Name of the asset.
+	Name string
+
+	// Symbol is the currency symbol of the asset (optional).
+	Symbol string
+
+	// Precision defines how many decimal places are shown when showing the asset in wallets.
+	Precision uint32
+
+	// Supply is the amount of tokens in the minting Output (the token supply of the alias for supply-controlled tokens).
+	Supply uint64
+
+	// MintingOutputID is the Output that minted the asset.
+	MintingOutputID ledgerstate.OutputID
+
+	// Issuer is the Address that signed the metadata.
+	Issuer ledgerstate.Address
+
+	// SigningTime is the time at which the Issuer signed the metadata.
+	SigningTime time.Time
+
+	// Timestamp is the issuing time of the message that contained the metadata.
+	Timestamp time.Time
+
+	// MessageID is the identifier of the message that contained the metadata.
+	MessageID tangle.MessageID
+}
+
+// newAsset returns the Asset that is described by the given Payload.
+func newAsset(metadataPayload *Payload, supply uint64, issuingTime time.Time, messageID tangle.MessageID) *Asset {
+	return &Asset{
+		Color:           metadataPayload.Color(),
+		Name:            metadataPayload.Name(),
+		Symbol:          metadataPayload.Symbol(),
+		Precision:       metadataPayload.Precision(),
+		Supply:          supply,
+		MintingOutputID: metadataPayload.MintingOutputID(),
+		Issuer:          metadataPayload.Issuer(),
+		SigningTime:     metadataPayload.SigningTime(),
+		Timestamp:       issuingTime,
+		MessageID:       messageID,
+	}
+}
+
+// AssetFromBytes unmarshals an Asset from a sequence of bytes.
+func AssetFromBytes(bytes []byte) (asset *Asset, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if asset, err = AssetFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse Asset from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// AssetFromMarshalUtil unmarshals an Asset using a MarshalUtil (for easier unmarshaling).
+func AssetFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (asset *Asset, err error) {
+	asset = &Asset{}
+	if asset.Color, err = ledgerstate.ColorFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse Color from MarshalUtil: %w", err)
+		return
+	}
+	if asset.Name, err = readString(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse name (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if asset.Symbol, err = readString(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse symbol (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if asset.Precision, err = marshalUtil.ReadUint32(); err != nil {
+		err = errors.Errorf("failed to parse precision (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if asset.Supply, err = marshalUtil.ReadUint64(); err != nil {
+		err = errors.Errorf("failed to parse supply (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if asset.MintingOutputID, err = ledgerstate.OutputIDFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse minting OutputID from MarshalUtil: %w", err)
+		return
+	}
+	if asset.Issuer, err = ledgerstate.AddressFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse issuer Address from MarshalUtil: %w", err)
+		return
+	}
+	if asset.SigningTime, err = marshalUtil.ReadTime(); err != nil {
+		err = errors.Errorf("failed to parse signing time (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if asset.Timestamp, err = marshalUtil.ReadTime(); err != nil {
+		err = errors.Errorf("failed to parse timestamp (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if asset.MessageID, err = tangle.ReferenceFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse MessageID from MarshalUtil: %w", err)
+		return
+	}
+
+	return
+}
+
+// Bytes returns a marshaled version of the Asset.
+func (a *Asset) Bytes() []byte {
+	return marshalutil.New().
+		Write(a.Color).
+		WriteUint16(uint16(len(a.Name))).
+		WriteBytes([]byte(a.Name)).
+		WriteUint16(uint16(len(a.Symbol))).
+		WriteBytes([]byte(a.Symbol)).
+		WriteUint32(a.Precision).
+		WriteUint64(a.Supply).
+		Write(a.MintingOutputID).
+		Write(a.Issuer).
+		WriteTime(a.SigningTime).
+		WriteTime(a.Timestamp).
+		Write(a.MessageID).
+		Bytes()
+}
+
+// replacedBy returns true if the metadata of the given Asset wins against the Asset. Ties of the signing time are broken
+// by the metadata bytes, so all nodes keep the same metadata regardless of the order in which they receive the
+// messages. The same metadata in a different message never replaces the Asset.
+func (a *Asset) replacedBy(other *Asset) bool {
+	if !other.SigningTime.Equal(a.SigningTime) {
+		return other.SigningTime.After(a.SigningTime)
+	}
+
+	return bytes.Compare(other.metadataBytes(), a.metadataBytes()) > 0
+}
+
+// metadataBytes returns the bytes of the metadata that was signed by the Issuer.
+func (a *Asset) metadataBytes() []byte {
+	return marshalutil.New().
+		Write(a.MintingOutputID).
+		WriteUint16(uint16(len(a.Name))).
+		WriteBytes([]byte(a.Name)).
+		WriteUint16(uint16(len(a.Symbol))).
+		WriteBytes([]byte(a.Symbol)).
+		WriteUint32(a.Precision).
+		Bytes()
+}
+
+// String returns a human readable version of the Asset.
+func (a *Asset) String() string {
+	return stringify.Struct("Asset",
+		stringify.StructField("color", a.Color),
+		stringify.StructField("name", a.Name),
+		stringify.StructField("symbol", a.Symbol),
+		stringify.StructField("precision", a.Precision),
+		stringify.StructField("supply", a.Supply),
+		stringify.StructField("mintingOutputID", a.MintingOutputID),
+		stringify.StructField("issuer", a.Issuer),
+		stringify.StructField("signingTime", a.SigningTime),
+		stringify.StructField("timestamp", a.Timestamp),
+		stringify.StructField("messageID", a.MessageID),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,286 @@
+package assetregistry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+func TestRegistry_Register(t *testing.T) {
+	store := mapdb.NewMapDB()
+	cacheTimeProvider := database.NewCacheTimeProvider(0)
+	branchDAG := ledgerstate.NewBranchDAG(store, cacheTimeProvider)
+	require.NoError(t, branchDAG.Prune())
+	defer branchDAG.Shutdown()
+	utxoDAG := ledgerstate.NewUTXODAG(store, cacheTimeProvider, branchDAG)
+	defer utxoDAG.Shutdown()
+
+	minter := ed25519.GenerateKeyPair()
+	minterAddress := ledgerstate.NewED25519Address(minter.PublicKey)
+
+	// fund the minter with a genesis output
+	genesisEssence := ledgerstate.NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{},
+		ledgerstate.NewInputs(ledgerstate.NewUTXOInput(ledgerstate.EmptyOutputID)),
+		ledgerstate.NewOutputs(ledgerstate.NewSigLockedSingleOutput(100, minterAddress)),
+	)
+	genesisTransaction := ledgerstate.NewTransaction(genesisEssence, ledgerstate.UnlockBlocks{ledgerstate.NewReferenceUnlockBlock(0)})
+	utxoDAG.LoadSnapshot(&ledgerstate.Snapshot{Transactions: map[ledgerstate.TransactionID]ledgerstate.Record{
+		genesisTransaction.ID(): {Essence: genesisEssence, UnlockBlocks: genesisTransaction.UnlockBlocks(), UnspentOutputs: []bool{true}},
+	}})
+
+	// mint a new color
+	mintTransaction := newMintTransaction(ledgerstate.NewOutputID(genesisTransaction.ID(), 0), minter)
+	_, err := utxoDAG.BookTransaction(mintTransaction)
+	require.NoError(t, err)
+	mintingOutputID := ledgerstate.NewOutputID(mintTransaction.ID(), 0)
+	color := ledgerstate.Color(blake2b.Sum256(mintingOutputID.Bytes()))
+
+	registry := NewRegistry(mapdb.NewMapDB(), utxoDAG)
+	signingTime := time.Now()
+	signedPayload := func(color ledgerstate.Color, outputID ledgerstate.OutputID, name string, signingTime time.Time, keyPair ed25519.KeyPair) *Payload {
+		metadataPayload, payloadErr := NewPayload(color, outputID, name, "TT", 2, signingTime)
+		require.NoError(t, payloadErr)
+		metadataPayload.Sign(&keyPair)
+		return metadataPayload
+	}
+
+	t.Run("CASE: Unsigned payload", func(t *testing.T) {
+		metadataPayload, payloadErr := NewPayload(color, mintingOutputID, "Test Token", "TT", 2, signingTime)
+		require.NoError(t, payloadErr)
+		_, registerErr := registry.Register(metadataPayload, signingTime, tangle.MessageID{1})
+		assert.ErrorIs(t, registerErr, ErrInvalidSignature)
+	})
+
+	t.Run("CASE: Signed after issuing time", func(t *testing.T) {
+		_, registerErr := registry.Register(signedPayload(color, mintingOutputID, "Test Token", signingTime, minter), signingTime.Add(-time.Second), tangle.MessageID{1})
+		assert.ErrorIs(t, registerErr, ErrFutureSigningTime)
+	})
+
+	t.Run("CASE: Unknown minting output", func(t *testing.T) {
+		_, registerErr := registry.Register(signedPayload(color, ledgerstate.NewOutputID(ledgerstate.TransactionID{1}, 0), "Test Token", signingTime, minter), signingTime, tangle.MessageID{1})
+		assert.ErrorIs(t, registerErr, ErrUnknownMintingOutput)
+	})
+
+	t.Run("CASE: Metadata waits for minting output", func(t *testing.T) {
+		parkedMintTransaction := newMintTransaction(ledgerstate.NewOutputID(mintTransaction.ID(), 0), minter)
+		parkedOutputID := ledgerstate.NewOutputID(parkedMintTransaction.ID(), 0)
+		parkedColor := ledgerstate.Color(blake2b.Sum256(parkedOutputID.Bytes()))
+
+		_, registerErr := registry.Register(signedPayload(parkedColor, parkedOutputID, "Parked Token", signingTime, minter), signingTime, tangle.MessageID{1})
+		assert.ErrorIs(t, registerErr, ErrUnknownMintingOutput)
+		_, exists, loadErr := registry.Asset(parkedColor)
+		require.NoError(t, loadErr)
+		assert.False(t, exists)
+
+		_, bookErr := utxoDAG.BookTransaction(parkedMintTransaction)
+		require.NoError(t, bookErr)
+		updatedAssets, registerErr := registry.RegisterParked(parkedOutputID)
+		require.NoError(t, registerErr)
+		require.Len(t, updatedAssets, 1)
+		assert.Equal(t, "Parked Token", updatedAssets[0].Name)
+
+		asset, exists, loadErr := registry.Asset(parkedColor)
+		require.NoError(t, loadErr)
+		require.True(t, exists)
+		assert.Equal(t, "Parked Token", asset.Name)
+
+		// the parked payloads are only registered once
+		updatedAssets, registerErr = registry.RegisterParked(parkedOutputID)
+		require.NoError(t, registerErr)
+		assert.Empty(t, updatedAssets)
+	})
+
+	t.Run("CASE: Output did not mint color", func(t *testing.T) {
+		_, registerErr := registry.Register(signedPayload(ledgerstate.Color{1}, mintingOutputID, "Test Token", signingTime, minter), signingTime, tangle.MessageID{1})
+		assert.ErrorIs(t, registerErr, ErrUnauthorizedIssuer)
+	})
+
+	t.Run("CASE: Issuer did not sign minting transaction", func(t *testing.T) {
+		_, registerErr := registry.Register(signedPayload(color, mintingOutputID, "Test Token", signingTime, ed25519.GenerateKeyPair()), signingTime, tangle.MessageID{1})
+		assert.ErrorIs(t, registerErr, ErrUnauthorizedIssuer)
+	})
+
+	issuingTime := signingTime.Add(time.Minute)
+	t.Run("CASE: Register asset", func(t *testing.T) {
+		updated, registerErr := registry.Register(signedPayload(color, mintingOutputID, "Test Token", signingTime, minter), issuingTime, tangle.MessageID{1})
+		require.NoError(t, registerErr)
+		assert.True(t, updated)
+
+		asset, exists, loadErr := registry.Asset(color)
+		require.NoError(t, loadErr)
+		require.True(t, exists)
+		assert.Equal(t, color, asset.Color)
+		assert.Equal(t, "Test Token", asset.Name)
+		assert.Equal(t, "TT", asset.Symbol)
+		assert.Equal(t, uint32(2), asset.Precision)
+		assert.Equal(t, uint64(100), asset.Supply)
+		assert.Equal(t, mintingOutputID, asset.MintingOutputID)
+		assert.True(t, minterAddress.Equals(asset.Issuer))
+		assert.True(t, signingTime.Equal(asset.SigningTime))
+		assert.True(t, issuingTime.Equal(asset.Timestamp))
+		assert.Equal(t, tangle.MessageID{1}, asset.MessageID)
+
+		restoredAsset, _, parseErr := AssetFromBytes(asset.Bytes())
+		require.NoError(t, parseErr)
+		assert.True(t, asset.SigningTime.Equal(restoredAsset.SigningTime))
+		assert.Equal(t, asset.MessageID, restoredAsset.MessageID)
+	})
+
+	t.Run("CASE: Same signing time is resolved by metadata", func(t *testing.T) {
+		updated, registerErr := registry.Register(signedPayload(color, mintingOutputID, "Best Token", signingTime, minter), issuingTime, tangle.MessageID{2})
+		require.NoError(t, registerErr)
+		assert.False(t, updated)
+
+		updated, registerErr = registry.Register(signedPayload(color, mintingOutputID, "West Token", signingTime, minter), issuingTime, tangle.MessageID{0})
+		require.NoError(t, registerErr)
+		assert.True(t, updated)
+
+		asset, _, loadErr := registry.Asset(color)
+		require.NoError(t, loadErr)
+		assert.Equal(t, "West Token", asset.Name)
+	})
+
+	t.Run("CASE: Older metadata is ignored", func(t *testing.T) {
+		updated, registerErr := registry.Register(signedPayload(color, mintingOutputID, "Old Name", signingTime.Add(-time.Second), minter), issuingTime.Add(time.Hour), tangle.MessageID{3})
+		require.NoError(t, registerErr)
+		assert.False(t, updated)
+
+		asset, _, loadErr := registry.Asset(color)
+		require.NoError(t, loadErr)
+		assert.Equal(t, "West Token", asset.Name)
+	})
+
+	correctedPayload := signedPayload(color, mintingOutputID, "New Name", signingTime.Add(time.Second), minter)
+	t.Run("CASE: Newer metadata replaces asset", func(t *testing.T) {
+		updated, registerErr := registry.Register(correctedPayload, issuingTime, tangle.MessageID{1})
+		require.NoError(t, registerErr)
+		assert.True(t, updated)
+
+		asset, _, loadErr := registry.Asset(color)
+		require.NoError(t, loadErr)
+		assert.Equal(t, "New Name", asset.Name)
+	})
+
+	t.Run("CASE: Replayed metadata is ignored", func(t *testing.T) {
+		updated, registerErr := registry.Register(correctedPayload, issuingTime.Add(time.Hour), tangle.MessageID{4})
+		require.NoError(t, registerErr)
+		assert.False(t, updated)
+
+		asset, _, loadErr := registry.Asset(color)
+		require.NoError(t, loadErr)
+		assert.Equal(t, "New Name", asset.Name)
+		assert.Equal(t, tangle.MessageID{1}, asset.MessageID)
+	})
+
+	t.Run("CASE: Unknown asset", func(t *testing.T) {
+		_, exists, loadErr := registry.Asset(ledgerstate.Color{3})
+		require.NoError(t, loadErr)
+		assert.False(t, exists)
+	})
+}
+
+func TestRegistry_RegisterAliasToken(t *testing.T) {
+	store := mapdb.NewMapDB()
+	cacheTimeProvider := database.NewCacheTimeProvider(0)
+	branchDAG := ledgerstate.NewBranchDAG(store, cacheTimeProvider)
+	require.NoError(t, branchDAG.Prune())
+	defer branchDAG.Shutdown()
+	utxoDAG := ledgerstate.NewUTXODAG(store, cacheTimeProvider, branchDAG)
+	defer utxoDAG.Shutdown()
+
+	formerController := ed25519.GenerateKeyPair()
+	controller := ed25519.GenerateKeyPair()
+	controllerAddress := ledgerstate.NewED25519Address(controller.PublicKey)
+
+	// create a supply-controlled alias in the snapshot
+	originAlias, err := ledgerstate.NewAliasOutputMint(map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 100}, ledgerstate.NewED25519Address(formerController.PublicKey))
+	require.NoError(t, err)
+	originAlias = originAlias.WithSupplyControl()
+	genesisTime := time.Now()
+	genesisEssence := ledgerstate.NewTransactionEssence(0, genesisTime, identity.ID{}, identity.ID{},
+		ledgerstate.NewInputs(ledgerstate.NewUTXOInput(ledgerstate.EmptyOutputID)),
+		ledgerstate.NewOutputs(originAlias),
+	)
+	genesisTransaction := ledgerstate.NewTransaction(genesisEssence, ledgerstate.UnlockBlocks{ledgerstate.NewReferenceUnlockBlock(0)})
+	utxoDAG.LoadSnapshot(&ledgerstate.Snapshot{Transactions: map[ledgerstate.TransactionID]ledgerstate.Record{
+		genesisTransaction.ID(): {Essence: genesisEssence, UnlockBlocks: genesisTransaction.UnlockBlocks(), UnspentOutputs: []bool{true}},
+	}})
+	color := originAlias.TokenColor()
+
+	// hand the alias over to the new controller
+	nextAlias := originAlias.NewAliasOutputNext(true)
+	require.NoError(t, nextAlias.SetStateAddress(controllerAddress))
+	transferTime := genesisTime.Add(time.Minute)
+	transferEssence := ledgerstate.NewTransactionEssence(0, transferTime, identity.ID{}, identity.ID{},
+		ledgerstate.NewInputs(ledgerstate.NewUTXOInput(originAlias.ID())),
+		ledgerstate.NewOutputs(nextAlias),
+	)
+	transferTransaction := ledgerstate.NewTransaction(transferEssence, ledgerstate.UnlockBlocks{
+		ledgerstate.NewSignatureUnlockBlock(ledgerstate.NewED25519Signature(formerController.PublicKey, formerController.PrivateKey.Sign(transferEssence.Bytes()))),
+	})
+	_, err = utxoDAG.BookTransaction(transferTransaction)
+	require.NoError(t, err)
+
+	registry := NewRegistry(mapdb.NewMapDB(), utxoDAG)
+	signedPayload := func(outputID ledgerstate.OutputID, name string, signingTime time.Time, keyPair ed25519.KeyPair) *Payload {
+		metadataPayload, payloadErr := NewPayload(color, outputID, name, "TT", 2, signingTime)
+		require.NoError(t, payloadErr)
+		metadataPayload.Sign(&keyPair)
+		return metadataPayload
+	}
+
+	t.Run("CASE: Former controller after the transfer", func(t *testing.T) {
+		signingTime := transferTime.Add(time.Second)
+		_, registerErr := registry.Register(signedPayload(originAlias.ID(), "Stolen Token", signingTime, formerController), signingTime, tangle.MessageID{1})
+		assert.ErrorIs(t, registerErr, ErrUnauthorizedIssuer)
+	})
+
+	t.Run("CASE: Former controller before the transfer", func(t *testing.T) {
+		signingTime := transferTime.Add(-time.Second)
+		updated, registerErr := registry.Register(signedPayload(originAlias.ID(), "Old Token", signingTime, formerController), signingTime, tangle.MessageID{1})
+		require.NoError(t, registerErr)
+		assert.True(t, updated)
+	})
+
+	t.Run("CASE: Former controller signs the current alias output", func(t *testing.T) {
+		signingTime := transferTime.Add(time.Second)
+		_, registerErr := registry.Register(signedPayload(nextAlias.ID(), "Stolen Token", signingTime, formerController), signingTime, tangle.MessageID{1})
+		assert.ErrorIs(t, registerErr, ErrUnauthorizedIssuer)
+	})
+
+	t.Run("CASE: Current controller", func(t *testing.T) {
+		signingTime := transferTime.Add(time.Second)
+		updated, registerErr := registry.Register(signedPayload(nextAlias.ID(), "New Token", signingTime, controller), signingTime, tangle.MessageID{2})
+		require.NoError(t, registerErr)
+		assert.True(t, updated)
+
+		asset, exists, loadErr := registry.Asset(color)
+		require.NoError(t, loadErr)
+		require.True(t, exists)
+		assert.Equal(t, "New Token", asset.Name)
+		assert.True(t, controllerAddress.Equals(asset.Issuer))
+	})
+}
+
+// newMintTransaction returns a Transaction that mints a new color with the tokens of the given Output.
+func newMintTransaction(inputID ledgerstate.OutputID, minter ed25519.KeyPair) *ledgerstate.Transaction {
+	minterAddress := ledgerstate.NewED25519Address(minter.PublicKey)
+	mintEssence := ledgerstate.NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{},
+		ledgerstate.NewInputs(ledgerstate.NewUTXOInput(inputID)),
+		ledgerstate.NewOutputs(ledgerstate.NewSigLockedColoredOutput(ledgerstate.NewColoredBalances(map[ledgerstate.Color]uint64{ledgerstate.ColorMint: 100}), minterAddress)),
+	)
+
+	return ledgerstate.NewTransaction(mintEssence, ledgerstate.UnlockBlocks{
+		ledgerstate.NewSignatureUnlockBlock(ledgerstate.NewED25519Signature(minter.PublicKey, minter.PrivateKey.Sign(mintEssence.Bytes()))),
+	})
+}
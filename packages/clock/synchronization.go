@@ -45,3 +45,11 @@ func SyncedTime() time.Time {
 func Since(t time.Time) time.Duration {
 	return SyncedTime().Sub(t)
 }
+
+// Offset returns the difference between the network time and the local time of the node.
+func Offset() time.Duration {
+	offsetMutex.RLock()
+	defer offsetMutex.RUnlock()
+
+	return offset
+}
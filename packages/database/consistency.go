@@ -0,0 +1,125 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// region ConsistencyCheckMode /////////////////////////////////////////////////////////////////////////////////////////
+
+// ConsistencyCheckMode defines how thorough a consistency check of the database is.
+type ConsistencyCheckMode uint8
+
+const (
+	// FastConsistencyCheck only checks the invariants that are most likely broken by an unclean shutdown.
+	FastConsistencyCheck ConsistencyCheckMode = iota
+
+	// FullConsistencyCheck checks all the invariants.
+	FullConsistencyCheck
+)
+
+// String returns a human-readable version of the ConsistencyCheckMode.
+func (c ConsistencyCheckMode) String() string {
+	switch c {
+	case FastConsistencyCheck:
+		return "fast"
+	case FullConsistencyCheck:
+		return "full"
+	default:
+		return fmt.Sprintf("ConsistencyCheckMode(%d)", c)
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ConsistencyIssue /////////////////////////////////////////////////////////////////////////////////////////////
+
+// ConsistencyIssue describes an entry of the database that violates an invariant.
+type ConsistencyIssue struct {
+	// Storage is the name of the storage that contains the entry.
+	Storage string
+	// Key is the human-readable key of the entry.
+	Key string
+	// Problem describes the violated invariant.
+	Problem string
+	// Repaired is true if the entry was repaired or rolled back.
+	Repaired bool
+}
+
+// String returns a human-readable version of the ConsistencyIssue.
+func (c *ConsistencyIssue) String() string {
+	status := "unrepaired"
+	if c.Repaired {
+		status = "repaired"
+	}
+	return fmt.Sprintf("%s %s: %s (%s)", c.Storage, c.Key, c.Problem, status)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ConsistencyReport ////////////////////////////////////////////////////////////////////////////////////////////
+
+// ConsistencyReport collects the results of a consistency check of the database.
+type ConsistencyReport struct {
+	// Mode is the mode of the consistency check.
+	Mode ConsistencyCheckMode
+	// CheckedEntries contains the number of checked entries per storage.
+	CheckedEntries map[string]int
+	// Issues contains the entries that violate an invariant.
+	Issues []*ConsistencyIssue
+}
+
+// NewConsistencyReport creates an empty ConsistencyReport for a check with the given mode.
+func NewConsistencyReport(mode ConsistencyCheckMode) *ConsistencyReport {
+	return &ConsistencyReport{
+		Mode:           mode,
+		CheckedEntries: make(map[string]int),
+	}
+}
+
+// EntryChecked counts a checked entry of the given storage.
+func (c *ConsistencyReport) EntryChecked(storage string) {
+	c.CheckedEntries[storage]++
+}
+
+// AddIssue adds an entry that violates an invariant to the report and returns the issue, so that it can be marked
+// as repaired.
+func (c *ConsistencyReport) AddIssue(storage, key, problem string) *ConsistencyIssue {
+	issue := &ConsistencyIssue{Storage: storage, Key: key, Problem: problem}
+	c.Issues = append(c.Issues, issue)
+	return issue
+}
+
+// Consistent returns true if no issues were found.
+func (c *ConsistencyReport) Consistent() bool {
+	return len(c.Issues) == 0
+}
+
+// Unrepaired returns the issues that were not repaired.
+func (c *ConsistencyReport) Unrepaired() (unrepaired []*ConsistencyIssue) {
+	for _, issue := range c.Issues {
+		if !issue.Repaired {
+			unrepaired = append(unrepaired, issue)
+		}
+	}
+	return unrepaired
+}
+
+// String returns a human-readable summary of the ConsistencyReport.
+func (c *ConsistencyReport) String() string {
+	storages := make([]string, 0, len(c.CheckedEntries))
+	for storage := range c.CheckedEntries {
+		storages = append(storages, storage)
+	}
+	sort.Strings(storages)
+
+	checked := make([]string, 0, len(storages))
+	for _, storage := range storages {
+		checked = append(checked, fmt.Sprintf("%s=%d", storage, c.CheckedEntries[storage]))
+	}
+	return fmt.Sprintf("ConsistencyReport(mode=%s, checked={%s}, issues=%d, unrepaired=%d)",
+		c.Mode, strings.Join(checked, ", "), len(c.Issues), len(c.Unrepaired()))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -21,4 +21,13 @@ const (
 
 	// PrefixEpochs defines the storage prefix for the epochs package.
 	PrefixEpochs
+
+	// PrefixManualPeering defines the storage prefix for the manualpeering package.
+	PrefixManualPeering
+
+	// PrefixAssetRegistry defines the storage prefix for the assetregistry package.
+	PrefixAssetRegistry
+
+	// PrefixAnalysisHistory defines the storage prefix for the history of the analysis server.
+	PrefixAnalysisHistory
 )
@@ -0,0 +1,141 @@
+package gossip
+
+import (
+	"time"
+
+	"go.uber.org/atomic"
+	"golang.org/x/crypto/blake2b"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/iotaledger/goshimmer/packages/gossip/gossipproto"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+// announcementRequestTimeout defines how long an announced message is not requested again from another neighbor after
+// it has been requested.
+const announcementRequestTimeout = 5 * time.Second
+
+// HasMessageFunc defines a function that returns whether the message with the given id is already known.
+type HasMessageFunc func(messageID tangle.MessageID) bool
+
+// ManagerOption defines an option for the Manager.
+type ManagerOption func(m *Manager)
+
+// WithMessageAnnouncements returns a ManagerOption that enables the announcement gossip mode. Neighbors that support it
+// as well only receive the IDs of new messages and request the messages that they are missing. Messages smaller than
+// minMessageSize are always sent in full, as their announcement would not save enough bandwidth.
+func WithMessageAnnouncements(hasMessage HasMessageFunc, minMessageSize int) ManagerOption {
+	return func(m *Manager) {
+		m.hasMessageFunc = hasMessage
+		m.announcementMinMessageSize = minMessageSize
+	}
+}
+
+// AnnouncementMetrics contains the traffic statistics of the announcement gossip mode.
+type AnnouncementMetrics struct {
+	// AnnouncedMessages is the number of messages that were announced to a neighbor instead of being sent.
+	AnnouncedMessages uint64
+	// AnnouncedBytes is the size of the packets that flooding the announced messages would have sent.
+	AnnouncedBytes uint64
+	// AnnouncementBytes is the size of the sent announcement packets.
+	AnnouncementBytes uint64
+	// RequestedAnnouncements is the number of announced messages that were missing and requested from the neighbor.
+	RequestedAnnouncements uint64
+	// RequestedBytes is the size of the message packets that were sent on request of a neighbor.
+	RequestedBytes uint64
+}
+
+// BytesSaved returns the number of bytes that the announcement gossip mode saved compared to flooding. It is a lower
+// bound, as the requested bytes also contain the replies to requests of missing messages, which are sent regardless
+// of the gossip mode.
+func (a AnnouncementMetrics) BytesSaved() int64 {
+	return int64(a.AnnouncedBytes) - int64(a.AnnouncementBytes) - int64(a.RequestedBytes)
+}
+
+// announcementCounters holds the counters of the AnnouncementMetrics.
+type announcementCounters struct {
+	announcedMessages      atomic.Uint64
+	announcedBytes         atomic.Uint64
+	announcementBytes      atomic.Uint64
+	requestedAnnouncements atomic.Uint64
+	requestedBytes         atomic.Uint64
+}
+
+// AnnouncementMetrics returns the traffic statistics of the announcement gossip mode.
+func (m *Manager) AnnouncementMetrics() AnnouncementMetrics {
+	return AnnouncementMetrics{
+		AnnouncedMessages:      m.announcementCounters.announcedMessages.Load(),
+		AnnouncedBytes:         m.announcementCounters.announcedBytes.Load(),
+		AnnouncementBytes:      m.announcementCounters.announcementBytes.Load(),
+		RequestedAnnouncements: m.announcementCounters.requestedAnnouncements.Load(),
+		RequestedBytes:         m.announcementCounters.requestedBytes.Load(),
+	}
+}
+
+// messageAnnouncementsEnabled returns whether the announcement gossip mode is enabled on the local node.
+func (m *Manager) messageAnnouncementsEnabled() bool {
+	return m.hasMessageFunc != nil
+}
+
+// sendMessageOrAnnouncement sends the message packet to neighbors that don't support announcements and the
+// announcement of the message to those that do.
+func (m *Manager) sendMessageOrAnnouncement(msgData []byte, msgPacket *pb.Packet, neighbors []*Neighbor) {
+	// the message ID is the hash of the message bytes (see tangle.Message.ID)
+	messageID := tangle.MessageID(blake2b.Sum256(msgData))
+	announcementPacket := &pb.Packet{Body: &pb.Packet_MessageAnnouncement{MessageAnnouncement: &pb.MessageAnnouncement{Id: messageID.Bytes()}}}
+	msgPacketSize, announcementPacketSize := uint64(proto.Size(msgPacket)), uint64(proto.Size(announcementPacket))
+
+	for _, nbr := range neighbors {
+		if !nbr.MessageAnnouncementsEnabled() {
+			m.sendPacket(nbr, msgPacket)
+			continue
+		}
+
+		m.sendPacket(nbr, announcementPacket)
+		m.announcementCounters.announcedMessages.Inc()
+		m.announcementCounters.announcedBytes.Add(msgPacketSize)
+		m.announcementCounters.announcementBytes.Add(announcementPacketSize)
+	}
+}
+
+// processMessageAnnouncement requests the announced message from the neighbor, if it is missing and not requested from
+// another neighbor already.
+func (m *Manager) processMessageAnnouncement(packetAnnouncement *pb.Packet_MessageAnnouncement, nbr *Neighbor) {
+	msgID, _, err := tangle.MessageIDFromBytes(packetAnnouncement.MessageAnnouncement.GetId())
+	if err != nil {
+		m.log.Debugw("invalid message id:", "err", err)
+		return
+	}
+	if !m.messageAnnouncementsEnabled() || m.hasMessageFunc(msgID) || !m.markAnnouncementRequested(msgID) {
+		return
+	}
+
+	m.announcementCounters.requestedAnnouncements.Inc()
+	nbr.quality.messageRequested(msgID, time.Now())
+	m.sendPacket(nbr, &pb.Packet{Body: &pb.Packet_MessageRequest{MessageRequest: &pb.MessageRequest{Id: msgID.Bytes()}}})
+}
+
+// markAnnouncementRequested marks the announced message as requested. It returns false if the message has already
+// been requested within the announcementRequestTimeout.
+func (m *Manager) markAnnouncementRequested(msgID tangle.MessageID) bool {
+	m.requestedAnnouncementsMutex.Lock()
+	defer m.requestedAnnouncementsMutex.Unlock()
+
+	now := time.Now()
+	if requestTime, requested := m.requestedAnnouncements[msgID]; requested && now.Sub(requestTime) < announcementRequestTimeout {
+		return false
+	}
+	m.requestedAnnouncements[msgID] = now
+
+	// remove the expired requests from time to time, so that the map does not grow indefinitely
+	if now.Sub(m.requestedAnnouncementsCleanup) > announcementRequestTimeout {
+		for requestedMsgID, requestTime := range m.requestedAnnouncements {
+			if now.Sub(requestTime) >= announcementRequestTimeout {
+				delete(m.requestedAnnouncements, requestedMsgID)
+			}
+		}
+		m.requestedAnnouncementsCleanup = now
+	}
+
+	return true
+}
@@ -0,0 +1,177 @@
+package gossip
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"go.uber.org/atomic"
+
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+// BandwidthLimits defines the bandwidth caps of the gossip streams in bytes per second. A value of 0 disables the
+// respective cap.
+type BandwidthLimits struct {
+	// NeighborInbound is the maximum inbound bandwidth of a single neighbor.
+	NeighborInbound int
+	// NeighborOutbound is the maximum outbound bandwidth of a single neighbor.
+	NeighborOutbound int
+	// GlobalInbound is the maximum inbound bandwidth of all neighbors together.
+	GlobalInbound int
+	// GlobalOutbound is the maximum outbound bandwidth of all neighbors together.
+	GlobalOutbound int
+}
+
+// WithBandwidthLimits returns a ManagerOption that caps the bandwidth of the gossip streams. The caps apply to the
+// bytes that are actually sent over the wire, i.e. after the compression.
+func WithBandwidthLimits(limits BandwidthLimits) ManagerOption {
+	return func(m *Manager) {
+		m.bandwidthLimits = limits
+	}
+}
+
+// newPacketsStream creates a packetsStream that is limited by its own limiters and the global ones.
+func (m *Manager) newPacketsStream(stream network.Stream) *packetsStream {
+	var inbound, outbound []*bandwidthLimiter
+	if limiter := newBandwidthLimiter(m.bandwidthLimits.NeighborInbound); limiter != nil {
+		inbound = append(inbound, limiter)
+	}
+	if m.globalInboundLimiter != nil {
+		inbound = append(inbound, m.globalInboundLimiter)
+	}
+	if limiter := newBandwidthLimiter(m.bandwidthLimits.NeighborOutbound); limiter != nil {
+		outbound = append(outbound, limiter)
+	}
+	if m.globalOutboundLimiter != nil {
+		outbound = append(outbound, m.globalOutboundLimiter)
+	}
+	return newPacketsStream(stream, inbound, outbound)
+}
+
+// region bandwidthLimiter /////////////////////////////////////////////////////////////////////////////////////////////
+
+// bandwidthLimiter is a token bucket that is allowed to go into debt: the transferred bytes are consumed after the
+// transfer and the next transfer waits until the debt is paid off. This allows to throttle a stream before the I/O
+// deadline of a packet is set and without knowing the size of the packet on the wire in advance.
+type bandwidthLimiter struct {
+	rate       float64
+	burst      float64
+	tokens     float64
+	lastUpdate time.Time
+	mutex      sync.Mutex
+}
+
+// newBandwidthLimiter creates a limiter for the given rate in bytes per second. It returns nil if the rate is not
+// positive, i.e. if the bandwidth is not limited.
+func newBandwidthLimiter(bytesPerSecond int) *bandwidthLimiter {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+
+	// allow a burst of at least a full message, so that large messages don't always need to wait
+	burst := float64(bytesPerSecond)
+	if burst < tangle.MaxMessageSize {
+		burst = tangle.MaxMessageSize
+	}
+	return &bandwidthLimiter{
+		rate:       float64(bytesPerSecond),
+		burst:      burst,
+		tokens:     burst,
+		lastUpdate: time.Now(),
+	}
+}
+
+// consume takes the transferred bytes from the bucket.
+func (b *bandwidthLimiter) consume(bytes int) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.refill(time.Now())
+	b.tokens -= float64(bytes)
+}
+
+// delay returns how long the next transfer needs to wait until the debt of the bucket is paid off.
+func (b *bandwidthLimiter) delay() time.Duration {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.refill(time.Now())
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+func (b *bandwidthLimiter) refill(now time.Time) {
+	b.tokens += now.Sub(b.lastUpdate).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.lastUpdate = now
+}
+
+// throttle waits until all given limiters allow the next transfer or until done is closed, and returns the time it
+// waited.
+func throttle(limiters []*bandwidthLimiter, done <-chan struct{}) (throttled time.Duration) {
+	for {
+		var delay time.Duration
+		for _, limiter := range limiters {
+			if limiterDelay := limiter.delay(); limiterDelay > delay {
+				delay = limiterDelay
+			}
+		}
+		// the shared limiters can go into debt again while waiting, so check again after the delay
+		if delay <= 0 {
+			return throttled
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+			throttled += delay
+		case <-done:
+			timer.Stop()
+			return throttled
+		}
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region meteredReader/Writer /////////////////////////////////////////////////////////////////////////////////////////
+
+// meteredReader counts the bytes that are read from the wire and consumes them from the limiters.
+type meteredReader struct {
+	reader    io.Reader
+	bytesRead *atomic.Uint64
+	limiters  []*bandwidthLimiter
+}
+
+func (r *meteredReader) Read(p []byte) (n int, err error) {
+	n, err = r.reader.Read(p)
+	r.bytesRead.Add(uint64(n))
+	for _, limiter := range r.limiters {
+		limiter.consume(n)
+	}
+	return n, err
+}
+
+// meteredWriter counts the bytes that are written to the wire and consumes them from the limiters.
+type meteredWriter struct {
+	writer       io.Writer
+	bytesWritten *atomic.Uint64
+	limiters     []*bandwidthLimiter
+}
+
+func (w *meteredWriter) Write(p []byte) (n int, err error) {
+	n, err = w.writer.Write(p)
+	w.bytesWritten.Add(uint64(n))
+	for _, limiter := range w.limiters {
+		limiter.consume(n)
+	}
+	return n, err
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,43 @@
+package gossip
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+func TestBandwidthLimiter(t *testing.T) {
+	assert.Nil(t, newBandwidthLimiter(0))
+
+	const rate = 10 * tangle.MaxMessageSize
+	limiter := newBandwidthLimiter(rate)
+	require.NotNil(t, limiter)
+
+	// the burst can be used without waiting
+	limiter.consume(rate)
+	assert.Zero(t, limiter.delay())
+
+	// the debt needs to be paid off before the next transfer
+	limiter.consume(rate / 10)
+	delay := limiter.delay()
+	assert.InDelta(t, 100*time.Millisecond, delay, float64(10*time.Millisecond))
+
+	throttled := throttle([]*bandwidthLimiter{limiter}, nil)
+	assert.InDelta(t, delay, throttled, float64(20*time.Millisecond))
+	assert.Zero(t, limiter.delay())
+
+	// nothing is throttled without limiters
+	assert.Zero(t, throttle(nil, nil))
+
+	// the wait ends early once done is closed
+	limiter.consume(rate)
+	done := make(chan struct{})
+	close(done)
+	start := time.Now()
+	assert.Zero(t, throttle([]*bandwidthLimiter{limiter}, done))
+	assert.Less(t, int64(time.Since(start)), int64(10*time.Millisecond))
+}
@@ -0,0 +1,83 @@
+package gossip
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/DataDog/zstd"
+	"github.com/cockroachdb/errors"
+
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+const (
+	// compressionMinFrameSize defines the minimum size of a packet in bytes to be compressed, as smaller packets don't
+	// benefit from the compression.
+	compressionMinFrameSize = 128
+	// compressionLevel defines the zstd compression level, which favors the speed to keep the gossip latency low.
+	compressionLevel = zstd.BestSpeed
+)
+
+const (
+	// frameRaw marks a frame of a compressed stream that contains the uncompressed packet.
+	frameRaw byte = iota
+	// frameZstd marks a frame of a compressed stream that contains the zstd compressed packet.
+	frameZstd
+)
+
+// ErrInvalidFrame is returned when a frame of a compressed stream can't be decoded.
+var ErrInvalidFrame = errors.New("invalid frame")
+
+// WithCompression returns a ManagerOption that enables the compression of the gossip streams to neighbors that support
+// it as well. Each packet is compressed on its own with zstd, so that it can be sent without waiting for others.
+func WithCompression() ManagerOption {
+	return func(m *Manager) {
+		m.compression = true
+	}
+}
+
+// compressFrame encodes the packet bytes as a frame of a compressed stream. Packets that don't get smaller are sent
+// uncompressed.
+func compressFrame(packetBytes []byte) ([]byte, error) {
+	if len(packetBytes) >= compressionMinFrameSize {
+		compressed, err := zstd.CompressLevel(nil, packetBytes, compressionLevel)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to compress packet")
+		}
+		if len(compressed) < len(packetBytes) {
+			return append([]byte{frameZstd}, compressed...), nil
+		}
+	}
+	return append([]byte{frameRaw}, packetBytes...), nil
+}
+
+// decompressFrame decodes a frame of a compressed stream and returns the packet bytes. The size of the decompressed
+// packet is limited like the size of an uncompressed one.
+func decompressFrame(frame []byte) ([]byte, error) {
+	if len(frame) < 2 {
+		return nil, errors.Wrapf(ErrInvalidFrame, "frame of %d bytes is too short", len(frame))
+	}
+
+	switch frame[0] {
+	case frameRaw:
+		return frame[1:], nil
+	case frameZstd:
+		reader := zstd.NewReader(bytes.NewReader(frame[1:]))
+		defer reader.Close()
+
+		packetBytes, err := io.ReadAll(io.LimitReader(reader, tangle.MaxMessageSize+1))
+		if err != nil {
+			return nil, errors.Wrapf(ErrInvalidFrame, "failed to decompress packet: %s", err)
+		}
+		// only packets of at least compressionMinFrameSize bytes are compressed, so an empty result means a corrupt frame
+		if len(packetBytes) == 0 {
+			return nil, errors.Wrap(ErrInvalidFrame, "compressed packet is empty")
+		}
+		if len(packetBytes) > tangle.MaxMessageSize {
+			return nil, errors.Wrapf(ErrInvalidFrame, "decompressed packet exceeds %d bytes", tangle.MaxMessageSize)
+		}
+		return packetBytes, nil
+	default:
+		return nil, errors.Wrapf(ErrInvalidFrame, "unknown frame type %d", frame[0])
+	}
+}
@@ -0,0 +1,49 @@
+package gossip
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/DataDog/zstd"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+func TestCompressFrame(t *testing.T) {
+	compressible := bytes.Repeat([]byte("gossip"), 100)
+	frame, err := compressFrame(compressible)
+	require.NoError(t, err)
+	assert.Equal(t, frameZstd, frame[0])
+	assert.Less(t, len(frame), len(compressible))
+	packetBytes, err := decompressFrame(frame)
+	require.NoError(t, err)
+	assert.Equal(t, compressible, packetBytes)
+
+	// small packets are not compressed
+	frame, err = compressFrame(testMessageData)
+	require.NoError(t, err)
+	assert.Equal(t, append([]byte{frameRaw}, testMessageData...), frame)
+	packetBytes, err = decompressFrame(frame)
+	require.NoError(t, err)
+	assert.Equal(t, testMessageData, packetBytes)
+}
+
+func TestDecompressFrameInvalid(t *testing.T) {
+	_, err := decompressFrame([]byte{frameZstd})
+	assert.True(t, errors.Is(err, ErrInvalidFrame))
+
+	_, err = decompressFrame([]byte{42, 0})
+	assert.True(t, errors.Is(err, ErrInvalidFrame))
+
+	_, err = decompressFrame([]byte{frameZstd, 1, 2, 3})
+	assert.True(t, errors.Is(err, ErrInvalidFrame))
+
+	// packets that would exceed the maximum size after the decompression are rejected
+	bomb, err := zstd.Compress(nil, make([]byte, 2*tangle.MaxMessageSize))
+	require.NoError(t, err)
+	_, err = decompressFrame(append([]byte{frameZstd}, bomb...))
+	assert.True(t, errors.Is(err, ErrInvalidFrame))
+}
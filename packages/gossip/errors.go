@@ -11,6 +11,12 @@ var (
 	ErrLoopbackNeighbor = errors.New("loopback connection not allowed")
 	// ErrDuplicateNeighbor is returned when the same peer is added more than once as a neighbor.
 	ErrDuplicateNeighbor = errors.New("already connected")
+	// ErrNeighborDropped is the disconnect reason of a neighbor whose connection was closed by the local node.
+	ErrNeighborDropped = errors.New("dropped by the local node")
 	// ErrNeighborQueueFull is returned when the send queue is already full.
 	ErrNeighborQueueFull = errors.New("send queue is full")
+	// ErrBulkSyncDisabled is returned when a bulk sync is started without enabling the bulk sync protocol.
+	ErrBulkSyncDisabled = errors.New("bulk sync is disabled")
+	// ErrBulkSyncRunning is returned when a bulk sync is started while another one is still running.
+	ErrBulkSyncRunning = errors.New("bulk sync is already running")
 )
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"runtime"
 	"sync"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/autopeering/peer"
@@ -14,6 +15,8 @@ import (
 	"github.com/iotaledger/hive.go/workerpool"
 	"github.com/libp2p/go-libp2p-core/host"
 	libp2ppeer "github.com/libp2p/go-libp2p-core/peer"
+	"go.uber.org/atomic"
+	"google.golang.org/protobuf/proto"
 
 	pb "github.com/iotaledger/goshimmer/packages/gossip/gossipproto"
 	"github.com/iotaledger/goshimmer/packages/tangle"
@@ -25,6 +28,12 @@ var (
 
 	messageRequestWorkerCount     = runtime.GOMAXPROCS(0)
 	messageRequestWorkerQueueSize = 100
+
+	messageAnnouncementWorkerCount     = runtime.GOMAXPROCS(0)
+	messageAnnouncementWorkerQueueSize = 1000
+
+	syncWorkerCount     = runtime.GOMAXPROCS(0)
+	syncWorkerQueueSize = 1000
 )
 
 // LoadMessageFunc defines a function that returns the message for the given id.
@@ -78,10 +87,37 @@ type Manager struct {
 	messageWorkerPool *workerpool.NonBlockingQueuedWorkerPool
 
 	messageRequestWorkerPool *workerpool.NonBlockingQueuedWorkerPool
+
+	messageAnnouncementWorkerPool *workerpool.NonBlockingQueuedWorkerPool
+
+	// hasMessageFunc is only set if the announcement gossip mode is enabled.
+	hasMessageFunc                HasMessageFunc
+	announcementMinMessageSize    int
+	announcementCounters          announcementCounters
+	requestedAnnouncements        map[tangle.MessageID]time.Time
+	requestedAnnouncementsCleanup time.Time
+	requestedAnnouncementsMutex   sync.Mutex
+
+	syncWorkerPool *workerpool.NonBlockingQueuedWorkerPool
+
+	// loadTipsFunc, syncHasMessageFunc and syncConfirmedTimeFunc are only set if the bulk sync protocol is enabled.
+	loadTipsFunc          LoadTipsFunc
+	syncHasMessageFunc    HasMessageFunc
+	syncConfirmedTimeFunc ConfirmedTimeFunc
+	syncRequestIDCounter  atomic.Uint64
+	bulkSync              *bulkSyncSession
+	bulkSyncMutex         sync.RWMutex
+
+	quality *qualityTracker
+
+	compression           bool
+	bandwidthLimits       BandwidthLimits
+	globalInboundLimiter  *bandwidthLimiter
+	globalOutboundLimiter *bandwidthLimiter
 }
 
 // NewManager creates a new Manager.
-func NewManager(libp2pHost host.Host, local *peer.Local, f LoadMessageFunc, log *logger.Logger) *Manager {
+func NewManager(libp2pHost host.Host, local *peer.Local, f LoadMessageFunc, log *logger.Logger, opts ...ManagerOption) *Manager {
 	m := &Manager{
 		Libp2pHost:      libp2pHost,
 		acceptMap:       map[libp2ppeer.ID]*acceptMatcher{},
@@ -95,8 +131,15 @@ func NewManager(libp2pHost host.Host, local *peer.Local, f LoadMessageFunc, log
 			NeighborsGroupAuto:   NewNeighborsEvents(),
 			NeighborsGroupManual: NewNeighborsEvents(),
 		},
-		neighbors: map[identity.ID]*Neighbor{},
+		neighbors:              map[identity.ID]*Neighbor{},
+		requestedAnnouncements: map[tangle.MessageID]time.Time{},
+		quality:                newQualityTracker(),
+	}
+	for _, opt := range opts {
+		opt(m)
 	}
+	m.globalInboundLimiter = newBandwidthLimiter(m.bandwidthLimits.GlobalInbound)
+	m.globalOutboundLimiter = newBandwidthLimiter(m.bandwidthLimits.GlobalOutbound)
 	m.messageWorkerPool = workerpool.NewNonBlockingQueuedWorkerPool(func(task workerpool.Task) {
 		m.processPacketMessage(task.Param(0).(*pb.Packet_Message), task.Param(1).(*Neighbor))
 
@@ -109,6 +152,18 @@ func NewManager(libp2pHost host.Host, local *peer.Local, f LoadMessageFunc, log
 		task.Return(nil)
 	}, workerpool.WorkerCount(messageRequestWorkerCount), workerpool.QueueSize(messageRequestWorkerQueueSize))
 
+	m.messageAnnouncementWorkerPool = workerpool.NewNonBlockingQueuedWorkerPool(func(task workerpool.Task) {
+		m.processMessageAnnouncement(task.Param(0).(*pb.Packet_MessageAnnouncement), task.Param(1).(*Neighbor))
+
+		task.Return(nil)
+	}, workerpool.WorkerCount(messageAnnouncementWorkerCount), workerpool.QueueSize(messageAnnouncementWorkerQueueSize))
+
+	m.syncWorkerPool = workerpool.NewNonBlockingQueuedWorkerPool(func(task workerpool.Task) {
+		m.processSyncPacket(task.Param(0), task.Param(1).(*Neighbor))
+
+		task.Return(nil)
+	}, workerpool.WorkerCount(syncWorkerCount), workerpool.QueueSize(syncWorkerQueueSize))
+
 	m.Libp2pHost.SetStreamHandler(protocolID, m.streamHandler)
 
 	return m
@@ -124,10 +179,13 @@ func (m *Manager) Stop() {
 	}
 	m.isStopped = true
 	m.Libp2pHost.RemoveStreamHandler(protocolID)
+	m.stopBulkSync()
 	m.dropAllNeighbors()
 
 	m.messageWorkerPool.Stop()
 	m.messageRequestWorkerPool.Stop()
+	m.messageAnnouncementWorkerPool.Stop()
+	m.syncWorkerPool.Stop()
 }
 
 func (m *Manager) dropAllNeighbors() {
@@ -185,15 +243,26 @@ func (m *Manager) getNeighbor(id identity.ID, group NeighborsGroup) (*Neighbor,
 func (m *Manager) RequestMessage(messageID []byte, to ...identity.ID) {
 	msgReq := &pb.MessageRequest{Id: messageID}
 	packet := &pb.Packet{Body: &pb.Packet_MessageRequest{MessageRequest: msgReq}}
-	m.send(packet, to...)
+	msgID, _, err := tangle.MessageIDFromBytes(messageID)
+	for _, nbr := range m.targetNeighbors(to) {
+		if err == nil {
+			nbr.quality.messageRequested(msgID, time.Now())
+		}
+		m.sendPacket(nbr, packet)
+	}
 }
 
 // SendMessage adds the given message the send queue of the neighbors.
 // The actual send then happens asynchronously. If no peer is provided, it is send to all neighbors.
+// If the announcement gossip mode is enabled, neighbors that support it only receive the announcement of the message.
 func (m *Manager) SendMessage(msgData []byte, to ...identity.ID) {
 	msg := &pb.Message{Data: msgData}
 	packet := &pb.Packet{Body: &pb.Packet_Message{Message: msg}}
-	m.send(packet, to...)
+	if !m.messageAnnouncementsEnabled() || len(msgData) < m.announcementMinMessageSize {
+		m.send(packet, to...)
+		return
+	}
+	m.sendMessageOrAnnouncement(msgData, packet, m.targetNeighbors(to))
 }
 
 // AllNeighbors returns all the neighbors that are currently connected.
@@ -224,17 +293,23 @@ func (m *Manager) getNeighborsByID(ids []identity.ID) []*Neighbor {
 }
 
 func (m *Manager) send(packet *pb.Packet, to ...identity.ID) {
-	neighbors := m.getNeighborsByID(to)
+	for _, nbr := range m.targetNeighbors(to) {
+		m.sendPacket(nbr, packet)
+	}
+}
+
+// targetNeighbors returns the neighbors with the given IDs or all neighbors if no ID is provided.
+func (m *Manager) targetNeighbors(ids []identity.ID) []*Neighbor {
+	neighbors := m.getNeighborsByID(ids)
 	if len(neighbors) == 0 {
 		neighbors = m.AllNeighbors()
 	}
+	return neighbors
+}
 
-	for _, nbr := range neighbors {
-		if err := nbr.ps.writePacket(packet); err != nil && !isAlreadyClosedError(err) {
-			m.log.Warnw("send error", "peer-id", nbr.ID(), "err", err)
-			nbr.close()
-		}
-	}
+// sendPacket queues the packet for the neighbor. It does not block, so that a slow neighbor does not delay the others.
+func (m *Manager) sendPacket(nbr *Neighbor, packet *pb.Packet) {
+	nbr.send(packet)
 }
 
 func (m *Manager) addNeighbor(ctx context.Context, p *peer.Peer, group NeighborsGroup,
@@ -260,6 +335,7 @@ func (m *Manager) addNeighbor(ctx context.Context, p *peer.Peer, group Neighbors
 
 	// create and add the neighbor
 	nbr := NewNeighbor(p, group, ps, m.log)
+	nbr.quality.setFirstSeenBaseline(m.quality.firstSeenMessages())
 	if err := m.setNeighbor(nbr); err != nil {
 		if resetErr := ps.Close(); resetErr != nil {
 			err = errors.CombineErrors(err, resetErr)
@@ -276,6 +352,7 @@ func (m *Manager) addNeighbor(ctx context.Context, p *peer.Peer, group Neighbors
 		}
 	}))
 	nbr.readLoop()
+	nbr.writeLoop()
 	nbr.log.Info("Connection established")
 	m.neighborsEvents[group].NeighborAdded.Trigger(nbr)
 
@@ -315,6 +392,27 @@ func (m *Manager) handlePacket(packet *pb.Packet, nbr *Neighbor) error {
 		if _, added := m.messageRequestWorkerPool.TrySubmit(packetBody, nbr); !added {
 			return fmt.Errorf("messageRequestWorkerPool full: message request discarded")
 		}
+	case *pb.Packet_MessageAnnouncement:
+		if _, added := m.messageAnnouncementWorkerPool.TrySubmit(packetBody, nbr); !added {
+			return fmt.Errorf("messageAnnouncementWorkerPool full: message announcement discarded")
+		}
+	case *pb.Packet_SyncTipsRequest, *pb.Packet_SyncTips, *pb.Packet_SyncMessagesRequest, *pb.Packet_SyncMessages:
+		if _, added := m.syncWorkerPool.TrySubmit(packetBody, nbr); !added {
+			return fmt.Errorf("syncWorkerPool full: sync packet discarded")
+		}
+	case *pb.Packet_Negotiation:
+		// the reply of the accepting peer to our negotiation message
+		if m.messageAnnouncementsEnabled() && packetBody.Negotiation.GetMessageAnnouncements() {
+			nbr.ps.messageAnnouncements.Store(true)
+		}
+		if m.compression && packetBody.Negotiation.GetCompression() {
+			if err := nbr.ps.startCompression(&pb.Packet{Body: &pb.Packet_CompressionStart{CompressionStart: &pb.CompressionStart{}}}); err != nil {
+				return errors.Wrap(err, "failed to start compression")
+			}
+			nbr.ps.compression.Store(true)
+		}
+	case *pb.Packet_CompressionStart:
+		// the stream already switched to the compressed framing when the packet was read
 
 	default:
 		return errors.Newf("unsupported packet; packet=%+v, packetBody=%T-%+v", packet, packetBody, packetBody)
@@ -333,7 +431,13 @@ func (m *Manager) MessageRequestWorkerPoolStatus() (name string, load int) {
 	return "messageRequestWorkerPool", m.messageRequestWorkerPool.GetPendingQueueSize()
 }
 
+// MessageAnnouncementWorkerPoolStatus returns the name and the load of the workerpool.
+func (m *Manager) MessageAnnouncementWorkerPoolStatus() (name string, load int) {
+	return "messageAnnouncementWorkerPool", m.messageAnnouncementWorkerPool.GetPendingQueueSize()
+}
+
 func (m *Manager) processPacketMessage(packetMsg *pb.Packet_Message, nbr *Neighbor) {
+	m.recordDeliveredMessage(packetMsg.Message.GetData(), nbr)
 	m.events.MessageReceived.Trigger(&MessageReceivedEvent{Data: packetMsg.Message.GetData(), Peer: nbr.Peer})
 }
 
@@ -352,8 +456,10 @@ func (m *Manager) processMessageRequest(packetMsgReq *pb.Packet_MessageRequest,
 
 	// send the loaded message directly to the neighbor
 	packet := &pb.Packet{Body: &pb.Packet_Message{Message: &pb.Message{Data: msgBytes}}}
-	if err := nbr.ps.writePacket(packet); err != nil && !isAlreadyClosedError(err) {
-		nbr.log.Warnw("Failed to send requested message back to the neighbor", "err", err)
-		nbr.close()
+	if !nbr.send(packet) {
+		return
+	}
+	if m.messageAnnouncementsEnabled() {
+		m.announcementCounters.requestedBytes.Add(uint64(proto.Size(packet)))
 	}
 }
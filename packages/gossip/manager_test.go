@@ -1,6 +1,7 @@
 package gossip
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"net"
@@ -20,6 +21,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
 	"google.golang.org/protobuf/proto"
 
 	pb "github.com/iotaledger/goshimmer/packages/gossip/gossipproto"
@@ -373,6 +375,200 @@ func TestMessageRequest(t *testing.T) {
 	mgrB.AssertExpectations(t)
 }
 
+func TestMessageAnnouncements(t *testing.T) {
+	var messageStored atomic.Bool
+	hasMessage := func(tangle.MessageID) bool { return messageStored.Load() }
+	announcementsOption := WithMessageAnnouncements(hasMessage, 0)
+	opts := map[string][]ManagerOption{
+		t.Name() + "_A": {announcementsOption},
+		t.Name() + "_B": {announcementsOption},
+	}
+
+	testMgrs := newTestManagersWithOptions(t, true /* doMock */, opts, t.Name()+"_A", t.Name()+"_B")
+	mgrA, closeA, peerA := testMgrs[0].mockManager, testMgrs[0].close, testMgrs[0].peer
+	mgrB, closeB, peerB := testMgrs[1].mockManager, testMgrs[1].close, testMgrs[1].peer
+
+	connectTestManagers(t, mgrA, peerA, mgrB, peerB)
+	assertMessageAnnouncementsEnabled(t, mgrA.Manager, true)
+	assertMessageAnnouncementsEnabled(t, mgrB.Manager, true)
+
+	// mgrB should request the announced message and eventually receive it
+	mgrB.On("messageReceived", &MessageReceivedEvent{Data: testMessageData, Peer: peerA}).Once()
+
+	mgrA.SendMessage(testMessageData)
+	time.Sleep(graceTime)
+
+	// messages that are already known are not requested
+	messageStored.Store(true)
+	mgrA.SendMessage(testMessageData)
+	time.Sleep(graceTime)
+
+	announcementMetrics := mgrA.AnnouncementMetrics()
+	assert.EqualValues(t, 2, announcementMetrics.AnnouncedMessages)
+	assert.EqualValues(t, 2*proto.Size(&pb.Packet{Body: &pb.Packet_Message{Message: &pb.Message{Data: testMessageData}}}), announcementMetrics.AnnouncedBytes)
+	assert.Positive(t, announcementMetrics.RequestedBytes)
+	assert.EqualValues(t, 1, mgrB.AnnouncementMetrics().RequestedAnnouncements)
+
+	mgrA.On("neighborRemoved", mock.Anything).Once()
+	mgrB.On("neighborRemoved", mock.Anything).Once()
+
+	closeA()
+	closeB()
+	time.Sleep(graceTime)
+
+	mgrA.AssertExpectations(t)
+	mgrB.AssertExpectations(t)
+}
+
+func TestMessageAnnouncementsFallback(t *testing.T) {
+	hasNoMessage := func(tangle.MessageID) bool { return false }
+
+	opts := map[string][]ManagerOption{
+		t.Name() + "_A": {WithMessageAnnouncements(hasNoMessage, 0)},
+		// messages that are smaller than the minimum size are flooded
+		t.Name() + "_C": {WithMessageAnnouncements(hasNoMessage, len(testMessageData)+1)},
+	}
+
+	testMgrs := newTestManagersWithOptions(t, true /* doMock */, opts, t.Name()+"_A", t.Name()+"_B", t.Name()+"_C")
+	mgrA, closeA, peerA := testMgrs[0].mockManager, testMgrs[0].close, testMgrs[0].peer
+	mgrB, closeB, peerB := testMgrs[1].mockManager, testMgrs[1].close, testMgrs[1].peer
+	mgrC, closeC, peerC := testMgrs[2].mockManager, testMgrs[2].close, testMgrs[2].peer
+
+	// messages are flooded to neighbors that don't support announcements
+	connectTestManagers(t, mgrA, peerA, mgrB, peerB)
+	assertMessageAnnouncementsEnabled(t, mgrA.Manager, false)
+	assertMessageAnnouncementsEnabled(t, mgrB.Manager, false)
+
+	mgrB.On("messageReceived", &MessageReceivedEvent{Data: testMessageData, Peer: peerA}).Once()
+	mgrA.SendMessage(testMessageData)
+	time.Sleep(graceTime)
+	assert.Zero(t, mgrA.AnnouncementMetrics().AnnouncedMessages)
+
+	mgrA.On("neighborRemoved", mock.Anything).Once()
+	mgrB.On("neighborRemoved", mock.Anything).Once()
+	closeB()
+	time.Sleep(graceTime)
+
+	connectTestManagers(t, mgrA, peerA, mgrC, peerC)
+	assertMessageAnnouncementsEnabled(t, mgrA.Manager, true)
+	assertMessageAnnouncementsEnabled(t, mgrC.Manager, true)
+
+	mgrA.On("messageReceived", &MessageReceivedEvent{Data: testMessageData, Peer: peerC}).Once()
+	mgrC.SendMessage(testMessageData)
+	time.Sleep(graceTime)
+	assert.Zero(t, mgrC.AnnouncementMetrics().AnnouncedMessages)
+
+	mgrA.On("neighborRemoved", mock.Anything).Once()
+	mgrC.On("neighborRemoved", mock.Anything).Once()
+	closeA()
+	closeC()
+	time.Sleep(graceTime)
+
+	mgrA.AssertExpectations(t)
+	mgrB.AssertExpectations(t)
+	mgrC.AssertExpectations(t)
+}
+
+func TestCompression(t *testing.T) {
+	opts := map[string][]ManagerOption{
+		t.Name() + "_A": {WithCompression()},
+		t.Name() + "_B": {WithCompression()},
+		t.Name() + "_C": {WithCompression()},
+	}
+	testMgrs := newTestManagersWithOptions(t, true /* doMock */, opts, t.Name()+"_A", t.Name()+"_B", t.Name()+"_C", t.Name()+"_D")
+	mgrA, closeA, peerA := testMgrs[0].mockManager, testMgrs[0].close, testMgrs[0].peer
+	mgrB, closeB, peerB := testMgrs[1].mockManager, testMgrs[1].close, testMgrs[1].peer
+	mgrC, closeC, peerC := testMgrs[2].mockManager, testMgrs[2].close, testMgrs[2].peer
+	mgrD, closeD, peerD := testMgrs[3].mockManager, testMgrs[3].close, testMgrs[3].peer
+
+	compressibleData := bytes.Repeat([]byte("compressible"), 1000)
+
+	// both directions of the stream are compressed
+	connectTestManagers(t, mgrA, peerA, mgrB, peerB)
+	assertCompressionEnabled(t, mgrA.Manager, true)
+	assertCompressionEnabled(t, mgrB.Manager, true)
+
+	mgrB.On("messageReceived", &MessageReceivedEvent{Data: compressibleData, Peer: peerA}).Once()
+	mgrA.On("messageReceived", &MessageReceivedEvent{Data: compressibleData, Peer: peerB}).Once()
+	nbrA := mgrA.AllNeighbors()[0]
+	packetsWritten, packetsRead := nbrA.PacketsWritten(), nbrA.PacketsRead()
+	mgrA.SendMessage(compressibleData)
+	mgrB.SendMessage(compressibleData)
+	require.Eventually(t, func() bool {
+		return nbrA.PacketsWritten() > packetsWritten && nbrA.PacketsRead() > packetsRead
+	}, time.Second, graceTime)
+
+	assert.Less(t, nbrA.BytesWritten(), uint64(len(compressibleData)))
+	assert.Less(t, nbrA.BytesRead(), uint64(len(compressibleData)))
+
+	// streams to neighbors that don't support the compression stay uncompressed
+	connectTestManagers(t, mgrC, peerC, mgrD, peerD)
+	assertCompressionEnabled(t, mgrC.Manager, false)
+	assertCompressionEnabled(t, mgrD.Manager, false)
+
+	mgrD.On("messageReceived", &MessageReceivedEvent{Data: compressibleData, Peer: peerC}).Once()
+	mgrC.SendMessage(compressibleData)
+	assert.Eventually(t, func() bool {
+		return mgrC.AllNeighbors()[0].BytesWritten() > uint64(len(compressibleData))
+	}, time.Second, graceTime)
+
+	mgrA.On("neighborRemoved", mock.Anything).Once()
+	mgrB.On("neighborRemoved", mock.Anything).Once()
+	mgrC.On("neighborRemoved", mock.Anything).Once()
+	mgrD.On("neighborRemoved", mock.Anything).Once()
+	closeA()
+	closeB()
+	closeC()
+	closeD()
+	time.Sleep(graceTime)
+
+	mgrA.AssertExpectations(t)
+	mgrB.AssertExpectations(t)
+	mgrC.AssertExpectations(t)
+	mgrD.AssertExpectations(t)
+}
+
+func TestBandwidthLimits(t *testing.T) {
+	const rate = 2 * tangle.MaxMessageSize
+	opts := map[string][]ManagerOption{
+		t.Name() + "_A": {WithBandwidthLimits(BandwidthLimits{NeighborOutbound: rate})},
+	}
+	testMgrs := newTestManagersWithOptions(t, true /* doMock */, opts, t.Name()+"_A", t.Name()+"_B")
+	mgrA, closeA, peerA := testMgrs[0].mockManager, testMgrs[0].close, testMgrs[0].peer
+	mgrB, closeB, peerB := testMgrs[1].mockManager, testMgrs[1].close, testMgrs[1].peer
+
+	connectTestManagers(t, mgrA, peerA, mgrB, peerB)
+
+	// the messages are queued without waiting for the bandwidth
+	largeData := make([]byte, tangle.MaxMessageSize/2)
+	mgrB.On("messageReceived", &MessageReceivedEvent{Data: largeData, Peer: peerA}).Times(12)
+	start := time.Now()
+	for i := 0; i < 12; i++ {
+		mgrA.SendMessage(largeData)
+	}
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+
+	// sending three times the rate takes about two seconds after the burst of one second is used up
+	nbrA := mgrA.AllNeighbors()[0]
+	require.Eventually(t, func() bool { return nbrA.BytesWritten() >= uint64(12*len(largeData)) }, 5*time.Second, graceTime)
+	assert.Greater(t, time.Since(start), 1500*time.Millisecond)
+	time.Sleep(graceTime)
+
+	assert.Greater(t, nbrA.WriteThrottlingTime(), 1500*time.Millisecond)
+	assert.Zero(t, nbrA.ReadThrottlingTime())
+	assert.GreaterOrEqual(t, nbrA.BytesWritten(), uint64(12*len(largeData)))
+	assert.Equal(t, nbrA.BytesWritten(), mgrB.AllNeighbors()[0].BytesRead())
+
+	mgrA.On("neighborRemoved", mock.Anything).Once()
+	mgrB.On("neighborRemoved", mock.Anything).Once()
+	closeA()
+	closeB()
+	time.Sleep(graceTime)
+
+	mgrA.AssertExpectations(t)
+	mgrB.AssertExpectations(t)
+}
+
 func TestDropNeighbor(t *testing.T) {
 	testMgrs := newTestManagers(t, false /* doMock */, t.Name()+"_A", t.Name()+"_B")
 	mgrA, closeA, peerA := testMgrs[0].manager, testMgrs[0].close, testMgrs[0].peer
@@ -487,6 +683,43 @@ func TestDropNeighborDifferentGroup(t *testing.T) {
 	}
 }
 
+// connectTestManagers connects the managers in the following way: B -> A.
+func connectTestManagers(t *testing.T, mgrA *mockedManager, peerA *peer.Peer, mgrB *mockedManager, peerB *peer.Peer) {
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	mgrA.On("neighborAdded", mock.Anything).Once()
+	mgrB.On("neighborAdded", mock.Anything).Once()
+
+	go func() {
+		defer wg.Done()
+		err := mgrA.AddInbound(context.Background(), peerB, NeighborsGroupAuto)
+		assert.NoError(t, err)
+	}()
+	time.Sleep(graceTime)
+	go func() {
+		defer wg.Done()
+		err := mgrB.AddOutbound(context.Background(), peerA, NeighborsGroupAuto)
+		assert.NoError(t, err)
+	}()
+
+	// wait for the connections to establish and the negotiation to finish
+	wg.Wait()
+	time.Sleep(graceTime)
+}
+
+func assertMessageAnnouncementsEnabled(t *testing.T, mgr *Manager, enabled bool) {
+	neighbors := mgr.AllNeighbors()
+	require.Len(t, neighbors, 1)
+	assert.Equal(t, enabled, neighbors[0].MessageAnnouncementsEnabled())
+}
+
+func assertCompressionEnabled(t *testing.T, mgr *Manager, enabled bool) {
+	neighbors := mgr.AllNeighbors()
+	require.Len(t, neighbors, 1)
+	assert.Equal(t, enabled, neighbors[0].CompressionEnabled())
+}
+
 func newTestDB(t require.TestingT) *peer.DB {
 	db, err := peer.NewDB(mapdb.NewMapDB())
 	require.NoError(t, err)
@@ -501,6 +734,11 @@ type testManager struct {
 }
 
 func newTestManagers(t testing.TB, doMock bool, names ...string) []*testManager {
+	return newTestManagersWithOptions(t, doMock, nil, names...)
+}
+
+// newTestManagersWithOptions creates the test managers and passes the options that are mapped to their names.
+func newTestManagersWithOptions(t testing.TB, doMock bool, opts map[string][]ManagerOption, names ...string) []*testManager {
 	ctx := context.Background()
 	mn := mocknet.New(ctx)
 	var results []*testManager
@@ -537,7 +775,7 @@ func newTestManagers(t testing.TB, doMock bool, names ...string) []*testManager
 		require.NoError(t, err)
 
 		// start the actual gossipping
-		mgr := NewManager(hst, local, loadTestMessage, l)
+		mgr := NewManager(hst, local, loadTestMessage, l, opts[name]...)
 		tearDown := func() {
 			mgr.Stop()
 			err := hst.Close()
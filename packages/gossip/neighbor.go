@@ -12,10 +12,15 @@ import (
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/libp2p/go-libp2p-core/mux"
 	"github.com/libp2p/go-yamux/v2"
+	"go.uber.org/atomic"
 
 	pb "github.com/iotaledger/goshimmer/packages/gossip/gossipproto"
 )
 
+// neighborSendQueueSize defines the number of packets that can be queued for a neighbor, before further packets are
+// dropped.
+const neighborSendQueueSize = 1000
+
 // NeighborsGroup is an enum type for various neighbors groups like auto/manual.
 type NeighborsGroup int8
 
@@ -35,10 +40,19 @@ type Neighbor struct {
 	disconnectOnce sync.Once
 	wg             sync.WaitGroup
 
+	disconnectReason     atomic.Error
+	disconnectReasonOnce sync.Once
+
 	disconnected   *events.Event
 	packetReceived *events.Event
 
-	ps *packetsStream
+	ps      *packetsStream
+	quality *neighborQuality
+
+	// sendQueue contains the packets that wait to be written to the stream, so that a neighbor whose bandwidth is
+	// exhausted does not block the senders.
+	sendQueue chan *pb.Packet
+	closing   chan struct{}
 }
 
 // NewNeighbor creates a new neighbor from the provided peer and connection.
@@ -57,7 +71,11 @@ func NewNeighbor(p *peer.Peer, group NeighborsGroup, ps *packetsStream, log *log
 		disconnected:   events.NewEvent(disconnected),
 		packetReceived: events.NewEvent(packetReceived),
 
-		ps: ps,
+		ps:      ps,
+		quality: newNeighborQuality(),
+
+		sendQueue: make(chan *pb.Packet, neighborSendQueueSize),
+		closing:   make(chan struct{}),
 	}
 }
 
@@ -71,6 +89,36 @@ func (n *Neighbor) PacketsWritten() uint64 {
 	return n.ps.packetsWritten.Load()
 }
 
+// BytesRead returns number of bytes this neighbor has received over the wire.
+func (n *Neighbor) BytesRead() uint64 {
+	return n.ps.bytesRead.Load()
+}
+
+// BytesWritten returns number of bytes this neighbor has sent over the wire.
+func (n *Neighbor) BytesWritten() uint64 {
+	return n.ps.bytesWritten.Load()
+}
+
+// ReadThrottlingTime returns how long reading from this neighbor was delayed by the inbound bandwidth limits.
+func (n *Neighbor) ReadThrottlingTime() time.Duration {
+	return n.ps.readThrottling.Load()
+}
+
+// WriteThrottlingTime returns how long writing to this neighbor was delayed by the outbound bandwidth limits.
+func (n *Neighbor) WriteThrottlingTime() time.Duration {
+	return n.ps.writeThrottling.Load()
+}
+
+// CompressionEnabled returns whether the compression of the stream was negotiated with this neighbor.
+func (n *Neighbor) CompressionEnabled() bool {
+	return n.ps.compression.Load()
+}
+
+// MessageAnnouncementsEnabled returns whether the announcement gossip mode was negotiated with this neighbor.
+func (n *Neighbor) MessageAnnouncementsEnabled() bool {
+	return n.ps.messageAnnouncements.Load()
+}
+
 func disconnected(handler interface{}, _ ...interface{}) {
 	handler.(func())()
 }
@@ -79,6 +127,19 @@ func packetReceived(handler interface{}, params ...interface{}) {
 	handler.(func(*pb.Packet))(params[0].(*pb.Packet))
 }
 
+// DisconnectReason returns the reason why the connection to the neighbor was closed.
+// It returns nil as long as the neighbor is still connected.
+func (n *Neighbor) DisconnectReason() error {
+	return n.disconnectReason.Load()
+}
+
+// setDisconnectReason records the reason of the disconnect. Only the first reason is kept.
+func (n *Neighbor) setDisconnectReason(reason error) {
+	n.disconnectReasonOnce.Do(func() {
+		n.disconnectReason.Store(reason)
+	})
+}
+
 // ConnectionEstablished returns the connection established.
 func (n *Neighbor) ConnectionEstablished() time.Time {
 	return n.ps.Stat().Opened
@@ -99,6 +160,7 @@ func (n *Neighbor) readLoop() {
 			err := n.ps.readPacket(packet)
 			if err != nil {
 				if isAlreadyClosedError(err) {
+					n.setDisconnectReason(errors.Wrap(err, "connection closed"))
 					if disconnectErr := n.disconnect(); disconnectErr != nil {
 						n.log.Warnw("Failed to disconnect", "err", disconnectErr)
 					}
@@ -114,6 +176,52 @@ func (n *Neighbor) readLoop() {
 	}()
 }
 
+// send queues the packet to be written to the neighbor. It returns false if the packet was dropped, because the queue
+// is full or the neighbor is disconnected.
+func (n *Neighbor) send(packet *pb.Packet) bool {
+	select {
+	case <-n.closing:
+		return false
+	default:
+	}
+
+	select {
+	case n.sendQueue <- packet:
+		return true
+	default:
+		n.log.Debugw("Send queue is full, dropping packet")
+		return false
+	}
+}
+
+func (n *Neighbor) writeLoop() {
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+		for {
+			select {
+			case <-n.closing:
+				return
+			case packet := <-n.sendQueue:
+				// wait for the bandwidth before the deadline is set, so that throttling does not cause timeouts
+				n.ps.writeThrottling.Add(throttle(n.ps.outboundLimiters, n.closing))
+				err := n.ps.writePacket(packet)
+				if err == nil {
+					continue
+				}
+				if !isAlreadyClosedError(err) {
+					n.log.Warnw("Send error", "err", err)
+					n.setDisconnectReason(errors.Wrap(err, "send error"))
+					if disconnectErr := n.disconnect(); disconnectErr != nil {
+						n.log.Warnw("Failed to disconnect", "err", disconnectErr)
+					}
+				}
+				return
+			}
+		}
+	}()
+}
+
 func (n *Neighbor) close() {
 	if err := n.disconnect(); err != nil {
 		n.log.Errorw("Failed to disconnect the neighbor", "err", err)
@@ -123,6 +231,8 @@ func (n *Neighbor) close() {
 
 func (n *Neighbor) disconnect() (err error) {
 	n.disconnectOnce.Do(func() {
+		n.setDisconnectReason(ErrNeighborDropped)
+		close(n.closing)
 		if streamErr := n.ps.Close(); streamErr != nil {
 			err = errors.WithStack(streamErr)
 		}
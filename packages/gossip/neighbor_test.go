@@ -17,6 +17,7 @@ import (
 
 	pb "github.com/iotaledger/goshimmer/packages/gossip/gossipproto"
 	"github.com/iotaledger/goshimmer/packages/libp2putil/libp2ptesting"
+	"github.com/iotaledger/goshimmer/packages/tangle"
 )
 
 var (
@@ -75,8 +76,53 @@ func TestNeighborWrite(t *testing.T) {
 	assert.Eventually(t, func() bool { return atomic.LoadUint32(&countB) == 1 }, time.Second, 10*time.Millisecond)
 }
 
+func TestNeighborSendThrottled(t *testing.T) {
+	a, b, teardown := libp2ptesting.NewStreamsPipe(t)
+	defer teardown()
+
+	// the outbound bandwidth of A is exhausted for a long time
+	limiter := newBandwidthLimiter(1)
+	limiter.consume(10 * tangle.MaxMessageSize)
+	neighborA := NewNeighbor(newTestPeer("A"), NeighborsGroupAuto, newPacketsStream(a, nil, []*bandwidthLimiter{limiter}), log.Named("A"))
+	neighborA.writeLoop()
+
+	neighborB := newTestNeighbor("B", b)
+	defer neighborB.disconnect()
+	var countB uint32
+	neighborB.packetReceived.Attach(events.NewClosure(func(*pb.Packet) {
+		atomic.AddUint32(&countB, 1)
+	}))
+	neighborB.readLoop()
+
+	// sending does not wait for the bandwidth, the packets are queued and dropped once the queue is full
+	start := time.Now()
+	dropped := false
+	for i := 0; i <= neighborSendQueueSize+1; i++ {
+		if !neighborA.send(testPacket1) {
+			dropped = true
+		}
+	}
+	assert.True(t, dropped)
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+	time.Sleep(100 * time.Millisecond)
+	assert.Zero(t, atomic.LoadUint32(&countB))
+
+	// closing the neighbor stops the throttled write loop
+	closed := make(chan struct{})
+	go func() {
+		neighborA.close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("closing a throttled neighbor blocks")
+	}
+	assert.False(t, neighborA.send(testPacket1))
+}
+
 func newTestNeighbor(name string, stream network.Stream) *Neighbor {
-	return NewNeighbor(newTestPeer(name), NeighborsGroupAuto, newPacketsStream(stream), log.Named(name))
+	return NewNeighbor(newTestPeer(name), NeighborsGroupAuto, newPacketsStream(stream, nil, nil), log.Named(name))
 }
 
 func newTestPeer(name string) *peer.Peer {
@@ -0,0 +1,240 @@
+package gossip
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+const (
+	// qualitySeenMessagesTTL defines how long the first arrival of a message is remembered to measure the latency of
+	// the neighbors that deliver it later.
+	qualitySeenMessagesTTL = 1 * time.Minute
+	// qualityRequestTimeout defines how long a neighbor has to answer a message request.
+	qualityRequestTimeout = 10 * time.Second
+	// qualityRequestExpiryInterval defines how often the pending requests of a neighbor are checked for the timeout.
+	qualityRequestExpiryInterval = 1 * time.Second
+	// qualityLatencyReference defines the average latency at which the latency score of a neighbor drops to one half.
+	qualityLatencyReference = 500 * time.Millisecond
+	// qualityLatencySmoothing defines the weight of a new sample in the moving average of the latency.
+	qualityLatencySmoothing = 0.05
+	// qualityMinObservationTime defines how long a neighbor needs to be connected before its score is meaningful.
+	qualityMinObservationTime = 5 * time.Minute
+)
+
+// NeighborQuality describes how useful a neighbor is for the dissemination of the messages.
+type NeighborQuality struct {
+	// DeliveredMessages is the number of messages the neighbor sent to us.
+	DeliveredMessages uint64
+	// FirstSeenMessages is the number of messages the neighbor delivered before any other neighbor.
+	FirstSeenMessages uint64
+	// FirstSeenShare is the share of all the messages first seen since the neighbor connected that it delivered first.
+	FirstSeenShare float64
+	// AverageLatency is the moving average of how much later than the first neighbor it delivered the messages.
+	AverageLatency time.Duration
+	// Requests is the number of finished message requests to the neighbor, i.e. answered or timed out.
+	Requests uint64
+	// AnsweredRequests is the number of message requests the neighbor answered within the qualityRequestTimeout.
+	AnsweredRequests uint64
+	// Scored is true if the neighbor has been observed long enough for the Score to be meaningful.
+	Scored bool
+	// Score is the combined quality of the latency, the first seen share and the request responsiveness between 0
+	// (useless) and 1 (perfect).
+	Score float64
+}
+
+// NeighborQualities returns the quality of all connected neighbors.
+func (m *Manager) NeighborQualities() map[identity.ID]NeighborQuality {
+	neighbors := m.AllNeighbors()
+	totalFirstSeen := m.quality.firstSeenMessages()
+
+	now := time.Now()
+	qualities := make(map[identity.ID]NeighborQuality, len(neighbors))
+	for _, nbr := range neighbors {
+		qualities[nbr.ID()] = nbr.quality.evaluate(now, totalFirstSeen, len(neighbors))
+	}
+	return qualities
+}
+
+// recordDeliveredMessage updates the quality of the neighbor that sent us the given message.
+func (m *Manager) recordDeliveredMessage(msgData []byte, nbr *Neighbor) {
+	// the message ID is the hash of the message bytes (see tangle.Message.ID)
+	msgID := tangle.MessageID(blake2b.Sum256(msgData))
+	now := time.Now()
+	firstSeen, isFirst := m.quality.messageSeen(msgID, now)
+	nbr.quality.messageDelivered(msgID, now, now.Sub(firstSeen), isFirst)
+}
+
+// region qualityTracker ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// qualityTracker remembers when the messages were first seen from any neighbor.
+type qualityTracker struct {
+	mutex          sync.Mutex
+	seenMessages   map[tangle.MessageID]time.Time
+	totalFirstSeen uint64
+	lastCleanup    time.Time
+}
+
+func newQualityTracker() *qualityTracker {
+	return &qualityTracker{
+		seenMessages: make(map[tangle.MessageID]time.Time),
+		lastCleanup:  time.Now(),
+	}
+}
+
+// messageSeen returns when the message was first seen and whether it is seen for the first time right now.
+func (q *qualityTracker) messageSeen(msgID tangle.MessageID, now time.Time) (firstSeen time.Time, isFirst bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	// remove the expired messages from time to time, so that the map does not grow indefinitely
+	if now.Sub(q.lastCleanup) > qualitySeenMessagesTTL {
+		for seenMsgID, seenTime := range q.seenMessages {
+			if now.Sub(seenTime) >= qualitySeenMessagesTTL {
+				delete(q.seenMessages, seenMsgID)
+			}
+		}
+		q.lastCleanup = now
+	}
+
+	if firstSeen, seen := q.seenMessages[msgID]; seen {
+		return firstSeen, false
+	}
+	q.seenMessages[msgID] = now
+	q.totalFirstSeen++
+	return now, true
+}
+
+func (q *qualityTracker) firstSeenMessages() uint64 {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return q.totalFirstSeen
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region neighborQuality //////////////////////////////////////////////////////////////////////////////////////////////
+
+// neighborQuality collects the quality statistics of a single neighbor.
+type neighborQuality struct {
+	mutex     sync.Mutex
+	connected time.Time
+	// firstSeenBaseline is the total number of first seen messages when the neighbor connected.
+	firstSeenBaseline uint64
+	deliveredMessages uint64
+	firstSeenMessages uint64
+	// averageLatency is the moving average of the latency in seconds.
+	averageLatency    float64
+	pendingRequests   map[tangle.MessageID]time.Time
+	lastRequestExpiry time.Time
+	requests          uint64
+	answeredRequests  uint64
+}
+
+func newNeighborQuality() *neighborQuality {
+	return &neighborQuality{
+		connected:       time.Now(),
+		pendingRequests: make(map[tangle.MessageID]time.Time),
+	}
+}
+
+// setFirstSeenBaseline sets the total number of first seen messages when the neighbor connected.
+func (q *neighborQuality) setFirstSeenBaseline(totalFirstSeen uint64) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.firstSeenBaseline = totalFirstSeen
+}
+
+// messageRequested records a message request to the neighbor.
+func (q *neighborQuality) messageRequested(msgID tangle.MessageID, now time.Time) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.expireRequests(now)
+	if _, pending := q.pendingRequests[msgID]; !pending {
+		q.pendingRequests[msgID] = now
+	}
+}
+
+// messageDelivered records a message that the neighbor sent us the given latency after it was first seen.
+func (q *neighborQuality) messageDelivered(msgID tangle.MessageID, now time.Time, latency time.Duration, isFirst bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.expireRequests(now)
+	if requestTime, pending := q.pendingRequests[msgID]; pending {
+		delete(q.pendingRequests, msgID)
+		q.requests++
+		if now.Sub(requestTime) < qualityRequestTimeout {
+			q.answeredRequests++
+		}
+	}
+
+	if q.deliveredMessages == 0 {
+		q.averageLatency = latency.Seconds()
+	} else {
+		q.averageLatency += qualityLatencySmoothing * (latency.Seconds() - q.averageLatency)
+	}
+	q.deliveredMessages++
+	if isFirst {
+		q.firstSeenMessages++
+	}
+}
+
+// expireRequests counts the requests that were not answered in time as finished. The pending requests are only checked
+// about once a second to keep the delivery of messages cheap. The caller needs to hold the mutex.
+func (q *neighborQuality) expireRequests(now time.Time) {
+	if now.Sub(q.lastRequestExpiry) < qualityRequestExpiryInterval {
+		return
+	}
+	q.lastRequestExpiry = now
+
+	for msgID, requestTime := range q.pendingRequests {
+		if now.Sub(requestTime) >= qualityRequestTimeout {
+			delete(q.pendingRequests, msgID)
+			q.requests++
+		}
+	}
+}
+
+// evaluate returns the quality of the neighbor given the total number of first seen messages and the number of
+// connected neighbors, which share the first seen messages.
+func (q *neighborQuality) evaluate(now time.Time, totalFirstSeen uint64, neighborCount int) NeighborQuality {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.expireRequests(now)
+	quality := NeighborQuality{
+		DeliveredMessages: q.deliveredMessages,
+		FirstSeenMessages: q.firstSeenMessages,
+		AverageLatency:    time.Duration(q.averageLatency * float64(time.Second)),
+		Requests:          q.requests,
+		AnsweredRequests:  q.answeredRequests,
+		Scored:            now.Sub(q.connected) >= qualityMinObservationTime,
+	}
+
+	// without any traffic since the neighbor connected, there is nothing to judge it by
+	latencyScore, shareScore, responsivenessScore := 1.0, 1.0, 1.0
+	if firstSeenSinceConnected := totalFirstSeen - q.firstSeenBaseline; firstSeenSinceConnected > 0 {
+		quality.FirstSeenShare = float64(q.firstSeenMessages) / float64(firstSeenSinceConnected)
+		// a neighbor that delivers its fair share of the first seen messages gets the full score
+		shareScore = math.Min(1, quality.FirstSeenShare*float64(neighborCount))
+		latencyScore = 0
+		if q.deliveredMessages > 0 {
+			latencyScore = 1 / (1 + q.averageLatency/qualityLatencyReference.Seconds())
+		}
+	}
+	if q.requests > 0 {
+		responsivenessScore = float64(q.answeredRequests) / float64(q.requests)
+	}
+	quality.Score = (latencyScore + shareScore + responsivenessScore) / 3
+
+	return quality
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
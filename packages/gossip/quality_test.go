@@ -0,0 +1,100 @@
+package gossip
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+func TestQualityTracker(t *testing.T) {
+	tracker := newQualityTracker()
+	msgID := tangle.MessageID{1}
+	start := time.Now()
+
+	firstSeen, isFirst := tracker.messageSeen(msgID, start)
+	assert.True(t, isFirst)
+	assert.Equal(t, start, firstSeen)
+
+	firstSeen, isFirst = tracker.messageSeen(msgID, start.Add(time.Second))
+	assert.False(t, isFirst)
+	assert.Equal(t, start, firstSeen)
+	assert.EqualValues(t, 1, tracker.firstSeenMessages())
+
+	// expired messages are forgotten
+	_, isFirst = tracker.messageSeen(tangle.MessageID{2}, start.Add(2*qualitySeenMessagesTTL))
+	assert.True(t, isFirst)
+	assert.NotContains(t, tracker.seenMessages, msgID)
+	assert.EqualValues(t, 2, tracker.firstSeenMessages())
+}
+
+func TestNeighborQuality(t *testing.T) {
+	fast, slow, silent := newNeighborQuality(), newNeighborQuality(), newNeighborQuality()
+	start := time.Now()
+
+	// without any traffic, the neighbors can't be judged
+	quality := silent.evaluate(start, 0, 3)
+	assert.False(t, quality.Scored)
+	assert.Equal(t, 1.0, quality.Score)
+
+	for i := 0; i < 100; i++ {
+		msgID := tangle.MessageID{byte(i)}
+		fast.messageDelivered(msgID, start, 0, true)
+		slow.messageDelivered(msgID, start, 2*time.Second, false)
+	}
+	// the slow neighbor answers only one of two requests
+	slow.messageRequested(tangle.MessageID{1}, start)
+	slow.messageRequested(tangle.MessageID{2}, start)
+	slow.messageDelivered(tangle.MessageID{1}, start.Add(time.Second), 0, false)
+
+	now := start.Add(qualityMinObservationTime)
+	fastQuality := fast.evaluate(now, 100, 3)
+	assert.True(t, fastQuality.Scored)
+	assert.EqualValues(t, 100, fastQuality.DeliveredMessages)
+	assert.EqualValues(t, 100, fastQuality.FirstSeenMessages)
+	assert.Equal(t, 1.0, fastQuality.FirstSeenShare)
+	assert.Zero(t, fastQuality.AverageLatency)
+	assert.Equal(t, 1.0, fastQuality.Score)
+
+	slowQuality := slow.evaluate(now, 100, 3)
+	assert.Zero(t, slowQuality.FirstSeenShare)
+	assert.EqualValues(t, 2, slowQuality.Requests)
+	assert.EqualValues(t, 1, slowQuality.AnsweredRequests)
+	assert.Greater(t, slowQuality.AverageLatency, time.Second)
+	assert.Less(t, slowQuality.Score, 0.3)
+
+	// a neighbor that does not deliver anything is useless
+	assert.Equal(t, 1.0/3, silent.evaluate(now, 100, 3).Score)
+}
+
+func TestNeighborQualities(t *testing.T) {
+	testMgrs := newTestManagers(t, true /* doMock */, t.Name()+"_A", t.Name()+"_B")
+	mgrA, closeA, peerA := testMgrs[0].mockManager, testMgrs[0].close, testMgrs[0].peer
+	mgrB, closeB, peerB := testMgrs[1].mockManager, testMgrs[1].close, testMgrs[1].peer
+
+	connectTestManagers(t, mgrA, peerA, mgrB, peerB)
+
+	mgrB.On("messageReceived", &MessageReceivedEvent{Data: testMessageData, Peer: peerA}).Once()
+	mgrA.SendMessage(testMessageData)
+	time.Sleep(graceTime)
+
+	qualities := mgrB.NeighborQualities()
+	require.Contains(t, qualities, peerA.ID())
+	assert.EqualValues(t, 1, qualities[peerA.ID()].DeliveredMessages)
+	assert.EqualValues(t, 1, qualities[peerA.ID()].FirstSeenMessages)
+	assert.Equal(t, 1.0, qualities[peerA.ID()].FirstSeenShare)
+	assert.False(t, qualities[peerA.ID()].Scored)
+
+	mgrA.On("neighborRemoved", mock.Anything).Once()
+	mgrB.On("neighborRemoved", mock.Anything).Once()
+	closeA()
+	closeB()
+	time.Sleep(graceTime)
+
+	mgrA.AssertExpectations(t)
+	mgrB.AssertExpectations(t)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/libp2p/go-libp2p-core/peerstore"
 	"github.com/multiformats/go-multiaddr"
 	"go.uber.org/atomic"
+	"google.golang.org/protobuf/proto"
 
 	pb "github.com/iotaledger/goshimmer/packages/gossip/gossipproto"
 	"github.com/iotaledger/goshimmer/packages/libp2putil"
@@ -66,12 +67,14 @@ func (m *Manager) dialPeer(ctx context.Context, p *peer.Peer, opts []ConnectPeer
 	if err != nil {
 		return nil, errors.Wrapf(err, "dial %s / %s failed", address, p.ID())
 	}
-	ps := newPacketsStream(stream)
-	if err := sendNegotiationMessage(ps); err != nil {
+	ps := m.newPacketsStream(stream)
+	if err := sendNegotiationMessage(ps, m.messageAnnouncementsEnabled(), m.compression); err != nil {
 		err = errors.Wrap(err, "failed to send negotiation message")
 		err = errors.CombineErrors(err, stream.Close())
 		return nil, err
 	}
+	// the stream is not read before the neighbor is added, so the flag is set without holding the readerLock
+	ps.compressionRequested = m.compression
 	m.log.Debugw("outgoing connection established",
 		"id", p.ID(),
 		"addr", stream.Conn().RemoteMultiaddr(),
@@ -166,14 +169,34 @@ func (m *Manager) removeAcceptMatcher(am *acceptMatcher) {
 }
 
 func (m *Manager) streamHandler(stream network.Stream) {
-	ps := newPacketsStream(stream)
-	if err := receiveNegotiationMessage(ps); err != nil {
+	ps := m.newPacketsStream(stream)
+	negotiation, err := receiveNegotiationMessage(ps)
+	if err != nil {
 		m.log.Warnw("Failed to receive negotiation message", "err", err)
 		m.closeStream(stream)
 		return
 	}
 	am := m.matchNewStream(stream)
 	if am != nil {
+		// only dialing peers that support announcements or compression expect a negotiation message in return
+		messageAnnouncements := m.messageAnnouncementsEnabled() && negotiation.GetMessageAnnouncements()
+		compression := m.compression && negotiation.GetCompression()
+		if messageAnnouncements || compression {
+			var err error
+			if compression {
+				// the reply marks the start of the compressed packets, as the dialing peer waits for it anyway
+				err = ps.startCompression(newNegotiationPacket(messageAnnouncements, compression))
+			} else {
+				err = sendNegotiationMessage(ps, messageAnnouncements, compression)
+			}
+			if err != nil {
+				m.log.Warnw("Failed to send negotiation message", "err", err)
+				m.closeStream(stream)
+				return
+			}
+			ps.messageAnnouncements.Store(messageAnnouncements)
+			ps.compression.Store(compression)
+		}
 		am.streamCh <- ps
 	} else {
 		// close the connection if not matched
@@ -192,63 +215,155 @@ type packetsStream struct {
 	writer         *libp2putil.UvarintWriter
 	packetsRead    *atomic.Uint64
 	packetsWritten *atomic.Uint64
+	bytesRead      *atomic.Uint64
+	bytesWritten   *atomic.Uint64
+	// messageAnnouncements is true if both peers negotiated the announcement gossip mode.
+	messageAnnouncements *atomic.Bool
+	// compression is true if both peers negotiated compressed streams.
+	compression *atomic.Bool
+	// compressionRequested is true if we dialed the neighbor and asked for compressed streams.
+	compressionRequested bool
+	// readCompressed and writeCompressed are set after the respective direction of the stream switched to the
+	// compressed framing. They are protected by the readerLock and the writerLock.
+	readCompressed  bool
+	writeCompressed bool
+
+	inboundLimiters  []*bandwidthLimiter
+	outboundLimiters []*bandwidthLimiter
+	readThrottling   *atomic.Duration
+	writeThrottling  *atomic.Duration
 }
 
-func newPacketsStream(stream network.Stream) *packetsStream {
-	return &packetsStream{
-		Stream:         stream,
-		reader:         libp2putil.NewDelimitedReader(stream),
-		writer:         libp2putil.NewDelimitedWriter(stream),
-		packetsRead:    atomic.NewUint64(0),
-		packetsWritten: atomic.NewUint64(0),
+func newPacketsStream(stream network.Stream, inboundLimiters, outboundLimiters []*bandwidthLimiter) *packetsStream {
+	ps := &packetsStream{
+		Stream:               stream,
+		packetsRead:          atomic.NewUint64(0),
+		packetsWritten:       atomic.NewUint64(0),
+		bytesRead:            atomic.NewUint64(0),
+		bytesWritten:         atomic.NewUint64(0),
+		messageAnnouncements: atomic.NewBool(false),
+		compression:          atomic.NewBool(false),
+		inboundLimiters:      inboundLimiters,
+		outboundLimiters:     outboundLimiters,
+		readThrottling:       atomic.NewDuration(0),
+		writeThrottling:      atomic.NewDuration(0),
 	}
+	ps.reader = libp2putil.NewDelimitedReader(&meteredReader{reader: stream, bytesRead: ps.bytesRead, limiters: inboundLimiters})
+	ps.writer = libp2putil.NewDelimitedWriter(&meteredWriter{writer: stream, bytesWritten: ps.bytesWritten, limiters: outboundLimiters})
+	return ps
 }
 
 func (ps *packetsStream) writePacket(packet *pb.Packet) error {
 	ps.writerLock.Lock()
 	defer ps.writerLock.Unlock()
+	return ps.write(packet)
+}
+
+// write writes the packet to the stream. The caller needs to hold the writerLock. The outbound bandwidth is not waited
+// for, as the packets of a neighbor are throttled by its send queue.
+func (ps *packetsStream) write(packet *pb.Packet) error {
 	if err := ps.SetWriteDeadline(time.Now().Add(ioTimeout)); err != nil && !isDeadlineUnsupportedError(err) {
 		return errors.WithStack(err)
 	}
-	err := ps.writer.WriteMsg(packet)
-	if err != nil {
+	if err := ps.writeFrame(packet); err != nil {
 		return errors.WithStack(err)
 	}
 	ps.packetsWritten.Inc()
 	return nil
 }
 
+func (ps *packetsStream) writeFrame(packet *pb.Packet) error {
+	if !ps.writeCompressed {
+		return ps.writer.WriteMsg(packet)
+	}
+
+	packetBytes, err := proto.Marshal(packet)
+	if err != nil {
+		return err
+	}
+	frame, err := compressFrame(packetBytes)
+	if err != nil {
+		return err
+	}
+	return ps.writer.WriteFrame(frame)
+}
+
+// startCompression writes the packet that tells the neighbor that all following packets are compressed and switches
+// the outgoing direction of the stream to the compressed framing.
+func (ps *packetsStream) startCompression(marker *pb.Packet) error {
+	ps.writerLock.Lock()
+	defer ps.writerLock.Unlock()
+	if ps.writeCompressed {
+		return nil
+	}
+
+	if err := ps.write(marker); err != nil {
+		return err
+	}
+	ps.writeCompressed = true
+	return nil
+}
+
 func (ps *packetsStream) readPacket(packet *pb.Packet) error {
 	ps.readerLock.Lock()
 	defer ps.readerLock.Unlock()
+	ps.readThrottling.Add(throttle(ps.inboundLimiters, nil))
 	if err := ps.SetReadDeadline(time.Now().Add(ioTimeout)); err != nil && !isDeadlineUnsupportedError(err) {
 		return errors.WithStack(err)
 	}
-	if err := ps.reader.ReadMsg(packet); err != nil {
+	if err := ps.readFrame(packet); err != nil {
 		return errors.WithStack(err)
 	}
+	// the neighbor compresses all packets after its CompressionStart packet or after the reply to our negotiation
+	// message that agrees on the compression
+	if packet.GetCompressionStart() != nil || (ps.compressionRequested && packet.GetNegotiation().GetCompression()) {
+		ps.readCompressed = true
+	}
 	ps.packetsRead.Inc()
 	return nil
 }
 
-func sendNegotiationMessage(ps *packetsStream) error {
-	packet := &pb.Packet{Body: &pb.Packet_Negotiation{Negotiation: &pb.Negotiation{}}}
-	return errors.WithStack(ps.writePacket(packet))
+func (ps *packetsStream) readFrame(packet *pb.Packet) error {
+	if !ps.readCompressed {
+		return ps.reader.ReadMsg(packet)
+	}
+
+	frame, err := ps.reader.ReadFrame()
+	if err != nil {
+		return err
+	}
+	packetBytes, err := decompressFrame(frame)
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(packetBytes, packet)
 }
 
-func receiveNegotiationMessage(ps *packetsStream) (err error) {
+func sendNegotiationMessage(ps *packetsStream, messageAnnouncements, compression bool) error {
+	return errors.WithStack(ps.writePacket(newNegotiationPacket(messageAnnouncements, compression)))
+}
+
+func newNegotiationPacket(messageAnnouncements, compression bool) *pb.Packet {
+	return &pb.Packet{Body: &pb.Packet_Negotiation{Negotiation: &pb.Negotiation{
+		MessageAnnouncements: messageAnnouncements,
+		Compression:          compression,
+	}}}
+}
+
+func receiveNegotiationMessage(ps *packetsStream) (negotiation *pb.Negotiation, err error) {
 	packet := &pb.Packet{}
 	if err := ps.readPacket(packet); err != nil {
-		return errors.WithStack(err)
+		return nil, errors.WithStack(err)
 	}
 	packetBody := packet.GetBody()
-	if _, ok := packetBody.(*pb.Packet_Negotiation); !ok {
-		return errors.Newf(
+	negotiationBody, ok := packetBody.(*pb.Packet_Negotiation)
+	if !ok {
+		return nil, errors.Newf(
 			"received packet isn't the negotiation packet; packet=%+v, packetBody=%T-%+v",
 			packet, packetBody, packetBody,
 		)
 	}
-	return nil
+	return negotiationBody.Negotiation, nil
 }
 
 func (m *Manager) matchNewStream(stream network.Stream) *acceptMatcher {
@@ -0,0 +1,665 @@
+package gossip
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/identity"
+
+	pb "github.com/iotaledger/goshimmer/packages/gossip/gossipproto"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+const (
+	// syncTipsTimeout defines how long the bulk sync waits for the neighbors to reply with their tips.
+	syncTipsTimeout = 5 * time.Second
+	// syncRequestTimeout defines how long the bulk sync waits for the reply to a batch request, before the batch is
+	// requested from another neighbor.
+	syncRequestTimeout = 10 * time.Second
+	// syncBatchSize defines the maximum number of messages that are requested with a single batch request.
+	syncBatchSize = 64
+	// syncMaxInFlightRequests defines the maximum number of unanswered batch requests per neighbor.
+	syncMaxInFlightRequests = 4
+	// syncMaxAttempts defines how often a message is requested, before the bulk sync leaves it to the solidifier.
+	syncMaxAttempts = 3
+	// syncLoopInterval defines the interval in which the bulk sync checks for timed out requests.
+	syncLoopInterval = 100 * time.Millisecond
+	// syncMaxPendingMessages defines the maximum number of received messages that wait for their parents. If it is
+	// exceeded, the oldest of them are passed on to the Tangle without waiting any longer.
+	syncMaxPendingMessages = 10000
+
+	// maxSyncTips defines the maximum number of tips that are sent to a syncing neighbor.
+	maxSyncTips = 256
+	// maxSyncBatchSize defines the maximum number of messages that are served in a single batch.
+	maxSyncBatchSize = 256
+	// maxSyncResponseSize defines the size in bytes after which no further messages are added to a batch. The packet
+	// must not exceed the maximum packet size of the gossip stream, so it leaves room for the field overhead of up to 4
+	// bytes per message and the packet header.
+	maxSyncResponseSize = tangle.MaxMessageSize - 4*maxSyncBatchSize - 64
+)
+
+// LoadTipsFunc defines a function that returns the current tips of the local Tangle.
+type LoadTipsFunc func() tangle.MessageIDs
+
+// ConfirmedTimeFunc defines a function that returns the issuing time up to which the local Tangle is confirmed, i.e.
+// the time of its last confirmed markers.
+type ConfirmedTimeFunc func() time.Time
+
+// WithBulkSync returns a ManagerOption that enables the bulk sync protocol. The manager serves the tips returned by
+// loadTips and batches of messages to syncing neighbors, and it can catch up with the neighbors itself by using
+// StartBulkSync. The past cone of the neighbors' tips is only fetched down to the time returned by confirmedTime, and
+// the hasMessage function defines the messages that are known already and therefore not requested.
+func WithBulkSync(hasMessage HasMessageFunc, loadTips LoadTipsFunc, confirmedTime ConfirmedTimeFunc) ManagerOption {
+	return func(m *Manager) {
+		m.syncHasMessageFunc = hasMessage
+		m.loadTipsFunc = loadTips
+		m.syncConfirmedTimeFunc = confirmedTime
+	}
+}
+
+// BulkSyncStatus contains the progress of a bulk sync.
+type BulkSyncStatus struct {
+	// Running is true while the bulk sync is fetching messages.
+	Running bool
+	// StartTime is the time when the bulk sync was started.
+	StartTime time.Time
+	// EndTime is the time when the bulk sync finished.
+	EndTime time.Time
+	// Peers is the number of neighbors that the messages are fetched from.
+	Peers int
+	// MissingMessages is the number of messages that are known to be missing and have not been received yet.
+	MissingMessages int
+	// InFlightRequests is the number of unanswered batch requests.
+	InFlightRequests int
+	// RequestedMessages is the number of requested messages, including repeated requests.
+	RequestedMessages uint64
+	// ReceivedMessages is the number of received missing messages.
+	ReceivedMessages uint64
+	// DeliveredMessages is the number of messages that were passed on to the Tangle.
+	DeliveredMessages uint64
+	// FailedMessages is the number of messages that could not be fetched from any neighbor.
+	FailedMessages uint64
+	// Err is the reason why the bulk sync stopped early.
+	Err error
+}
+
+// StartBulkSync starts to fetch the past cone of the neighbors' tips until it reaches the last confirmed markers of the
+// local node: the parents of messages that were issued before them are not requested, as they belong to the confirmed
+// history. The messages are requested in batches from all neighbors that support the bulk sync in parallel and they are
+// passed on to the Tangle in causal order, so that the solidifier does not need to request their parents one by one.
+// The sync runs in the background until all missing messages are fetched or the given context is done.
+func (m *Manager) StartBulkSync(ctx context.Context) error {
+	if !m.bulkSyncEnabled() {
+		return ErrBulkSyncDisabled
+	}
+
+	m.bulkSyncMutex.Lock()
+	defer m.bulkSyncMutex.Unlock()
+	if m.bulkSync != nil && m.bulkSync.running() {
+		return ErrBulkSyncRunning
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	m.bulkSync = newBulkSyncSession(m, cancel)
+	go m.bulkSync.run(ctx)
+
+	return nil
+}
+
+// BulkSyncStatus returns the progress of the running or the last bulk sync.
+func (m *Manager) BulkSyncStatus() BulkSyncStatus {
+	session := m.currentBulkSync()
+	if session == nil {
+		return BulkSyncStatus{}
+	}
+	return session.statusSnapshot()
+}
+
+// SyncWorkerPoolStatus returns the name and the load of the workerpool.
+func (m *Manager) SyncWorkerPoolStatus() (name string, load int) {
+	return "syncWorkerPool", m.syncWorkerPool.GetPendingQueueSize()
+}
+
+// bulkSyncEnabled returns whether the bulk sync protocol is enabled on the local node.
+func (m *Manager) bulkSyncEnabled() bool {
+	return m.loadTipsFunc != nil
+}
+
+func (m *Manager) currentBulkSync() *bulkSyncSession {
+	m.bulkSyncMutex.RLock()
+	defer m.bulkSyncMutex.RUnlock()
+	return m.bulkSync
+}
+
+// stopBulkSync stops the running bulk sync.
+func (m *Manager) stopBulkSync() {
+	if session := m.currentBulkSync(); session != nil {
+		session.cancel()
+	}
+}
+
+func (m *Manager) processSyncPacket(packetBody interface{}, nbr *Neighbor) {
+	if !m.bulkSyncEnabled() {
+		return
+	}
+
+	switch packetBody := packetBody.(type) {
+	case *pb.Packet_SyncTipsRequest:
+		m.processSyncTipsRequest(packetBody.SyncTipsRequest, nbr)
+	case *pb.Packet_SyncMessagesRequest:
+		m.processSyncMessagesRequest(packetBody.SyncMessagesRequest, nbr)
+	case *pb.Packet_SyncTips:
+		if session := m.currentBulkSync(); session != nil {
+			session.processTips(packetBody.SyncTips, nbr)
+		}
+	case *pb.Packet_SyncMessages:
+		if session := m.currentBulkSync(); session != nil {
+			session.processMessages(packetBody.SyncMessages, nbr)
+		}
+	}
+}
+
+func (m *Manager) processSyncTipsRequest(request *pb.SyncTipsRequest, nbr *Neighbor) {
+	tips := m.loadTipsFunc()
+	if len(tips) > maxSyncTips {
+		tips = tips[:maxSyncTips]
+	}
+
+	syncTips := &pb.SyncTips{RequestId: request.GetRequestId(), Ids: make([][]byte, 0, len(tips))}
+	for _, tip := range tips {
+		syncTips.Ids = append(syncTips.Ids, tip.Bytes())
+	}
+	m.sendPacket(nbr, &pb.Packet{Body: &pb.Packet_SyncTips{SyncTips: syncTips}})
+}
+
+// processSyncMessagesRequest replies with the requested messages followed by as much of their past cone as fits into
+// the batch, so that the syncing node does not need a round trip per message to walk long chains.
+func (m *Manager) processSyncMessagesRequest(request *pb.SyncMessagesRequest, nbr *Neighbor) {
+	ids := request.GetIds()
+	if len(ids) > maxSyncBatchSize {
+		ids = ids[:maxSyncBatchSize]
+	}
+	queue := make([]tangle.MessageID, 0, len(ids))
+	for _, id := range ids {
+		msgID, _, err := tangle.MessageIDFromBytes(id)
+		if err != nil {
+			m.log.Debugw("invalid message id:", "err", err)
+			return
+		}
+		queue = append(queue, msgID)
+	}
+
+	// messages that are unknown or exceed the batch are omitted and requested again by the syncing node
+	syncMessages := &pb.SyncMessages{RequestId: request.GetRequestId()}
+	seen := make(map[tangle.MessageID]bool)
+	responseSize := 0
+	for len(queue) > 0 && len(syncMessages.Messages) < maxSyncBatchSize {
+		msgID := queue[0]
+		queue = queue[1:]
+		if seen[msgID] {
+			continue
+		}
+		seen[msgID] = true
+
+		msgBytes, err := m.loadMessageFunc(msgID)
+		if err != nil {
+			continue
+		}
+		if responseSize += len(msgBytes); responseSize > maxSyncResponseSize {
+			break
+		}
+		syncMessages.Messages = append(syncMessages.Messages, msgBytes)
+
+		msg, _, err := tangle.MessageFromBytes(msgBytes)
+		if err != nil {
+			continue
+		}
+		msg.ForEachParent(func(parent tangle.Parent) {
+			queue = append(queue, parent.ID)
+		})
+	}
+	m.sendPacket(nbr, &pb.Packet{Body: &pb.Packet_SyncMessages{SyncMessages: syncMessages}})
+}
+
+// region bulkSyncSession //////////////////////////////////////////////////////////////////////////////////////////////
+
+// bulkSyncSession holds the state of a single bulk sync.
+type bulkSyncSession struct {
+	manager *Manager
+	cancel  context.CancelFunc
+	status  BulkSyncStatus
+	wakeup  chan struct{}
+
+	tipsRequests map[uint64]*Neighbor
+	tipsDeadline time.Time
+	peers        map[identity.ID]*syncPeer
+	requests     map[uint64]*syncRequest
+
+	// queue contains the missing messages that still need to be requested.
+	queue []tangle.MessageID
+	// attempts contains the number of requests of every missing message that has not been received yet.
+	attempts map[tangle.MessageID]int
+	// pending contains the received messages that wait for their parents to be delivered.
+	pending map[tangle.MessageID]*pendingSyncMessage
+	// maxPending is the number of pending messages after which the oldest of them are delivered right away.
+	maxPending int
+	// waiting maps a missing parent to the pending messages that reference it.
+	waiting   map[tangle.MessageID][]tangle.MessageID
+	delivered map[tangle.MessageID]bool
+	failed    map[tangle.MessageID]bool
+	// confirmedTime is the issuing time before which the parents of a message are not requested.
+	confirmedTime time.Time
+
+	mutex sync.Mutex
+}
+
+type syncPeer struct {
+	neighbor *Neighbor
+	inFlight int
+}
+
+type syncRequest struct {
+	peer     *syncPeer
+	ids      map[tangle.MessageID]bool
+	deadline time.Time
+}
+
+type pendingSyncMessage struct {
+	data           []byte
+	peer           *peer.Peer
+	issuingTime    time.Time
+	parents        []tangle.MessageID
+	missingParents int
+}
+
+func newBulkSyncSession(m *Manager, cancel context.CancelFunc) *bulkSyncSession {
+	return &bulkSyncSession{
+		manager:       m,
+		cancel:        cancel,
+		status:        BulkSyncStatus{Running: true, StartTime: time.Now()},
+		wakeup:        make(chan struct{}, 1),
+		tipsRequests:  map[uint64]*Neighbor{},
+		peers:         map[identity.ID]*syncPeer{},
+		requests:      map[uint64]*syncRequest{},
+		attempts:      map[tangle.MessageID]int{},
+		pending:       map[tangle.MessageID]*pendingSyncMessage{},
+		maxPending:    syncMaxPendingMessages,
+		waiting:       map[tangle.MessageID][]tangle.MessageID{},
+		delivered:     map[tangle.MessageID]bool{},
+		failed:        map[tangle.MessageID]bool{},
+		confirmedTime: m.syncConfirmedTimeFunc(),
+	}
+}
+
+func (s *bulkSyncSession) run(ctx context.Context) {
+	s.requestTips()
+
+	ticker := time.NewTicker(syncLoopInterval)
+	defer ticker.Stop()
+	for !s.step() {
+		select {
+		case <-ctx.Done():
+			s.finish(ctx.Err())
+			return
+		case <-ticker.C:
+		case <-s.wakeup:
+		}
+	}
+	s.finish(nil)
+}
+
+// requestTips asks all neighbors for their tips, which are the starting points of the sync.
+func (s *bulkSyncSession) requestTips() {
+	neighbors := s.manager.AllNeighbors()
+
+	s.mutex.Lock()
+	s.tipsDeadline = time.Now().Add(syncTipsTimeout)
+	packets := make(map[*Neighbor]*pb.Packet, len(neighbors))
+	for _, nbr := range neighbors {
+		requestID := s.manager.syncRequestIDCounter.Inc()
+		s.tipsRequests[requestID] = nbr
+		packets[nbr] = &pb.Packet{Body: &pb.Packet_SyncTipsRequest{SyncTipsRequest: &pb.SyncTipsRequest{RequestId: requestID}}}
+	}
+	s.mutex.Unlock()
+
+	for nbr, packet := range packets {
+		s.manager.sendPacket(nbr, packet)
+	}
+}
+
+// step expires the timed out requests and sends new batch requests to the neighbors with free capacity. It returns
+// true if the sync is finished.
+func (s *bulkSyncSession) step() (finished bool) {
+	s.mutex.Lock()
+	now := time.Now()
+	if len(s.tipsRequests) > 0 && now.After(s.tipsDeadline) {
+		// neighbors that don't reply in time do not support the bulk sync
+		s.tipsRequests = map[uint64]*Neighbor{}
+	}
+	for requestID, request := range s.requests {
+		if now.After(request.deadline) {
+			// an unresponsive neighbor is not asked again in this sync
+			delete(s.requests, requestID)
+			delete(s.peers, request.peer.neighbor.ID())
+			s.retry(request.ids)
+		}
+	}
+	if len(s.tipsRequests) == 0 && len(s.requests) == 0 && (len(s.queue) == 0 || len(s.peers) == 0) {
+		s.mutex.Unlock()
+		return true
+	}
+	// the batches are only distributed once all neighbors had the chance to reply, so that they are spread among them
+	var packets map[*Neighbor][]*pb.Packet
+	if len(s.tipsRequests) == 0 {
+		packets = s.dispatch(now)
+	}
+	s.mutex.Unlock()
+
+	for nbr, nbrPackets := range packets {
+		for _, packet := range nbrPackets {
+			s.manager.sendPacket(nbr, packet)
+		}
+	}
+	return false
+}
+
+// dispatch distributes the queued messages in batches among the neighbors, until every neighbor has reached the
+// maximum number of in-flight requests.
+func (s *bulkSyncSession) dispatch(now time.Time) (packets map[*Neighbor][]*pb.Packet) {
+	packets = make(map[*Neighbor][]*pb.Packet)
+	for dispatched := true; dispatched && len(s.queue) > 0; {
+		dispatched = false
+		for nbrID, target := range s.peers {
+			if len(s.queue) == 0 {
+				break
+			}
+			if !s.manager.neighborExists(nbrID) {
+				delete(s.peers, nbrID)
+				continue
+			}
+			if target.inFlight >= syncMaxInFlightRequests {
+				continue
+			}
+
+			batch := s.nextBatch()
+			if len(batch) == 0 {
+				break
+			}
+
+			requestID := s.manager.syncRequestIDCounter.Inc()
+			request := &syncRequest{peer: target, ids: make(map[tangle.MessageID]bool, len(batch)), deadline: now.Add(syncRequestTimeout)}
+			syncMessagesRequest := &pb.SyncMessagesRequest{RequestId: requestID, Ids: make([][]byte, 0, len(batch))}
+			for _, msgID := range batch {
+				request.ids[msgID] = true
+				syncMessagesRequest.Ids = append(syncMessagesRequest.Ids, msgID.Bytes())
+			}
+			s.requests[requestID] = request
+			target.inFlight++
+			s.status.RequestedMessages += uint64(len(batch))
+
+			packets[target.neighbor] = append(packets[target.neighbor], &pb.Packet{Body: &pb.Packet_SyncMessagesRequest{SyncMessagesRequest: syncMessagesRequest}})
+			dispatched = true
+		}
+	}
+	return packets
+}
+
+// nextBatch removes the next batch of missing messages from the queue. Messages that have been received as part of the
+// past cone of another message in the meantime are skipped.
+func (s *bulkSyncSession) nextBatch() (batch []tangle.MessageID) {
+	for len(s.queue) > 0 && len(batch) < syncBatchSize {
+		msgID := s.queue[0]
+		s.queue = s.queue[1:]
+		if _, missing := s.attempts[msgID]; missing {
+			batch = append(batch, msgID)
+		}
+	}
+	return batch
+}
+
+// processTips adds the neighbor to the peers of the sync and queues its unknown tips.
+func (s *bulkSyncSession) processTips(syncTips *pb.SyncTips, nbr *Neighbor) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	requestedNeighbor, requested := s.tipsRequests[syncTips.GetRequestId()]
+	if !requested || requestedNeighbor.ID() != nbr.ID() {
+		return
+	}
+	delete(s.tipsRequests, syncTips.GetRequestId())
+
+	s.peers[nbr.ID()] = &syncPeer{neighbor: nbr}
+	for _, id := range syncTips.GetIds() {
+		msgID, _, err := tangle.MessageIDFromBytes(id)
+		if err != nil {
+			continue
+		}
+		if !s.isKnown(msgID) {
+			s.enqueue(msgID)
+		}
+	}
+	s.notify()
+}
+
+// processMessages handles the reply to a batch request. Besides the requested messages, the reply contains parts of
+// their past cone, which are accepted if they are known to be missing, i.e. if they are referenced by a received
+// message. Messages that are not known to be missing are ignored and requested messages that are missing in the reply
+// are queued again.
+func (s *bulkSyncSession) processMessages(syncMessages *pb.SyncMessages, nbr *Neighbor) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	request, requested := s.requests[syncMessages.GetRequestId()]
+	if !requested || request.peer.neighbor.ID() != nbr.ID() {
+		return
+	}
+	delete(s.requests, syncMessages.GetRequestId())
+	request.peer.inFlight--
+
+	for _, data := range syncMessages.GetMessages() {
+		msg, _, err := tangle.MessageFromBytes(data)
+		if err != nil {
+			s.manager.log.Debugw("invalid synced message", "peer-id", nbr.ID(), "err", err)
+			continue
+		}
+		msgID := msg.ID()
+		delete(request.ids, msgID)
+		if _, missing := s.attempts[msgID]; !missing {
+			continue
+		}
+		delete(s.attempts, msgID)
+		s.status.ReceivedMessages++
+
+		s.addPending(msgID, msg, data, nbr.Peer)
+	}
+	s.retry(request.ids)
+	s.notify()
+}
+
+// addPending stores the received message until all its parents are delivered and queues its unknown parents. The
+// parents of a message that was issued before the last confirmed markers are left to the solidifier.
+func (s *bulkSyncSession) addPending(msgID tangle.MessageID, msg *tangle.Message, data []byte, p *peer.Peer) {
+	pendingMessage := &pendingSyncMessage{data: data, peer: p, issuingTime: msg.IssuingTime()}
+	confirmed := !msg.IssuingTime().After(s.confirmedTime)
+	seenParents := make(map[tangle.MessageID]bool)
+	msg.ForEachParent(func(parent tangle.Parent) {
+		if seenParents[parent.ID] {
+			return
+		}
+		seenParents[parent.ID] = true
+		pendingMessage.parents = append(pendingMessage.parents, parent.ID)
+
+		if confirmed || s.delivered[parent.ID] || s.manager.syncHasMessageFunc(parent.ID) {
+			return
+		}
+		pendingMessage.missingParents++
+		s.waiting[parent.ID] = append(s.waiting[parent.ID], msgID)
+		if !s.isKnown(parent.ID) {
+			s.enqueue(parent.ID)
+		}
+	})
+	s.pending[msgID] = pendingMessage
+
+	if pendingMessage.missingParents == 0 {
+		s.deliver(msgID)
+	} else if len(s.pending) > s.maxPending {
+		s.deliverOldest(len(s.pending) - s.maxPending*3/4)
+	}
+}
+
+// deliver passes the message and all pending messages that only waited for it on to the Tangle.
+func (s *bulkSyncSession) deliver(msgID tangle.MessageID) {
+	stack := []tangle.MessageID{msgID}
+	for len(stack) > 0 {
+		msgID, stack = stack[len(stack)-1], stack[:len(stack)-1]
+
+		s.trigger(msgID)
+		for _, childID := range s.waiting[msgID] {
+			if child, exists := s.pending[childID]; exists {
+				if child.missingParents--; child.missingParents == 0 {
+					stack = append(stack, childID)
+				}
+			}
+		}
+		delete(s.waiting, msgID)
+	}
+}
+
+func (s *bulkSyncSession) trigger(msgID tangle.MessageID) {
+	pendingMessage := s.pending[msgID]
+	delete(s.pending, msgID)
+	s.delivered[msgID] = true
+	s.status.DeliveredMessages++
+
+	s.manager.events.MessageReceived.Trigger(&MessageReceivedEvent{Data: pendingMessage.data, Peer: pendingMessage.peer})
+}
+
+// deliverOldest delivers the given number of pending messages with the oldest issuing time without waiting for their
+// missing parents any longer, so that the memory of the pending messages is bounded. The missing parents are still
+// fetched and delivered once they are received.
+func (s *bulkSyncSession) deliverOldest(count int) {
+	oldest := make([]tangle.MessageID, 0, len(s.pending))
+	for msgID := range s.pending {
+		oldest = append(oldest, msgID)
+	}
+	sort.Slice(oldest, func(i, j int) bool {
+		return s.pending[oldest[i]].issuingTime.Before(s.pending[oldest[j]].issuingTime)
+	})
+	if count > len(oldest) {
+		count = len(oldest)
+	}
+
+	for _, msgID := range oldest[:count] {
+		s.forceDeliver(msgID)
+	}
+}
+
+// flush delivers the pending messages whose parents could not be fetched, so that the solidifier takes care of the
+// rest.
+func (s *bulkSyncSession) flush() {
+	for msgID := range s.pending {
+		s.forceDeliver(msgID)
+	}
+}
+
+// forceDeliver delivers the pending message without waiting for its missing parents, after its pending parents.
+func (s *bulkSyncSession) forceDeliver(msgID tangle.MessageID) {
+	pendingMessage, exists := s.pending[msgID]
+	if !exists {
+		return
+	}
+	for _, parentID := range pendingMessage.parents {
+		s.forceDeliver(parentID)
+	}
+
+	// delivering the parents might have delivered the message already
+	if _, exists = s.pending[msgID]; exists {
+		s.deliver(msgID)
+	}
+}
+
+// finish ends the sync and releases the state that is only needed while it is running, so that only the status of a
+// finished sync is kept.
+func (s *bulkSyncSession) finish(err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.flush()
+	s.status.FailedMessages += uint64(len(s.attempts))
+	s.status.Running = false
+	s.status.EndTime = time.Now()
+	s.status.Err = err
+	s.cancel()
+
+	s.tipsRequests = nil
+	s.requests = nil
+	s.queue = nil
+	s.attempts = nil
+	s.pending = nil
+	s.waiting = nil
+	s.delivered = nil
+	s.failed = nil
+}
+
+// retry queues the given messages again, unless they have been requested too often already.
+func (s *bulkSyncSession) retry(msgIDs map[tangle.MessageID]bool) {
+	for msgID := range msgIDs {
+		if _, missing := s.attempts[msgID]; !missing {
+			continue
+		}
+		if s.attempts[msgID] >= syncMaxAttempts {
+			delete(s.attempts, msgID)
+			s.failed[msgID] = true
+			s.status.FailedMessages++
+			continue
+		}
+		s.attempts[msgID]++
+		s.queue = append(s.queue, msgID)
+	}
+}
+
+func (s *bulkSyncSession) enqueue(msgID tangle.MessageID) {
+	s.attempts[msgID] = 1
+	s.queue = append(s.queue, msgID)
+}
+
+// isKnown returns whether the message is known locally or is already handled by the sync.
+func (s *bulkSyncSession) isKnown(msgID tangle.MessageID) bool {
+	if _, missing := s.attempts[msgID]; missing {
+		return true
+	}
+	if _, pending := s.pending[msgID]; pending {
+		return true
+	}
+	return s.delivered[msgID] || s.failed[msgID] || s.manager.syncHasMessageFunc(msgID)
+}
+
+// notify wakes up the sync loop to dispatch new requests.
+func (s *bulkSyncSession) notify() {
+	select {
+	case s.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+func (s *bulkSyncSession) running() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.status.Running
+}
+
+func (s *bulkSyncSession) statusSnapshot() BulkSyncStatus {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	status := s.status
+	status.Peers = len(s.peers)
+	status.MissingMessages = len(s.attempts)
+	status.InFlightRequests = len(s.requests)
+	return status
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,279 @@
+package gossip
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+)
+
+func TestBulkSync(t *testing.T) {
+	// a chain that is longer than a single batch and a side branch that is attached to its middle
+	source := newTestSyncTangle()
+	chain := source.attachChain(t, tangle.EmptyMessageID, 2*maxSyncBatchSize+10)
+	branch := source.attachChain(t, chain[maxSyncBatchSize], 20)
+	source.tips = tangle.MessageIDs{chain[len(chain)-1], branch[len(branch)-1]}
+
+	opts := map[string][]ManagerOption{
+		t.Name() + "_A": {WithBulkSync(source.hasMessage, source.loadTips, noConfirmedHistory)},
+		t.Name() + "_B": {WithBulkSync(isGenesis, noTips, noConfirmedHistory)},
+	}
+	testMgrs := newTestManagersWithOptions(t, true /* doMock */, opts, t.Name()+"_A", t.Name()+"_B")
+	mgrA, closeA, peerA := testMgrs[0].mockManager, testMgrs[0].close, testMgrs[0].peer
+	mgrB, closeB, peerB := testMgrs[1].mockManager, testMgrs[1].close, testMgrs[1].peer
+	mgrA.loadMessageFunc = source.loadMessage
+
+	connectTestManagers(t, mgrA, peerA, mgrB, peerB)
+
+	received := newTestSyncReceiver()
+	mgrB.On("messageReceived", mock.Anything).Run(received.record)
+
+	require.NoError(t, mgrB.StartBulkSync(context.Background()))
+	assert.True(t, errors.Is(mgrB.StartBulkSync(context.Background()), ErrBulkSyncRunning))
+	require.Eventually(t, func() bool { return !mgrB.BulkSyncStatus().Running }, 5*time.Second, graceTime)
+
+	status := mgrB.BulkSyncStatus()
+	assert.NoError(t, status.Err)
+	assert.Equal(t, 1, status.Peers)
+	assert.Zero(t, status.MissingMessages)
+	assert.Zero(t, status.FailedMessages)
+	assert.EqualValues(t, len(source.messages), status.ReceivedMessages)
+	assert.EqualValues(t, len(source.messages), status.DeliveredMessages)
+	// the past cone is served with the requested messages, so that the chain does not need a request per message
+	assert.Less(t, status.RequestedMessages, uint64(len(source.messages)/10))
+	received.assertCausalOrder(t, source)
+
+	// only the status of a finished sync is kept
+	session := mgrB.currentBulkSync()
+	assert.Nil(t, session.pending)
+	assert.Nil(t, session.delivered)
+	assert.Nil(t, session.waiting)
+
+	mgrA.On("neighborRemoved", mock.Anything).Once()
+	mgrB.On("neighborRemoved", mock.Anything).Once()
+	closeA()
+	closeB()
+	time.Sleep(graceTime)
+}
+
+func TestBulkSyncConfirmedHistory(t *testing.T) {
+	// the parents of the messages that were issued before the confirmed time are not fetched
+	source := newTestSyncTangle()
+	confirmedChain := source.attachChain(t, tangle.EmptyMessageID, 10)
+	confirmedTime := time.Now()
+	chain := source.attachChain(t, confirmedChain[len(confirmedChain)-1], 10)
+	source.tips = tangle.MessageIDs{chain[len(chain)-1]}
+
+	opts := map[string][]ManagerOption{
+		t.Name() + "_A": {WithBulkSync(source.hasMessage, source.loadTips, noConfirmedHistory)},
+		t.Name() + "_B": {WithBulkSync(isGenesis, noTips, func() time.Time { return confirmedTime })},
+	}
+	testMgrs := newTestManagersWithOptions(t, true /* doMock */, opts, t.Name()+"_A", t.Name()+"_B")
+	mgrA, closeA, peerA := testMgrs[0].mockManager, testMgrs[0].close, testMgrs[0].peer
+	mgrB, closeB, peerB := testMgrs[1].mockManager, testMgrs[1].close, testMgrs[1].peer
+	mgrA.loadMessageFunc = source.loadMessage
+
+	connectTestManagers(t, mgrA, peerA, mgrB, peerB)
+
+	received := newTestSyncReceiver()
+	mgrB.On("messageReceived", mock.Anything).Run(received.record)
+
+	require.NoError(t, mgrB.StartBulkSync(context.Background()))
+	require.Eventually(t, func() bool { return !mgrB.BulkSyncStatus().Running }, 5*time.Second, graceTime)
+
+	// the last confirmed message is fetched as the parent of the first unconfirmed one
+	status := mgrB.BulkSyncStatus()
+	assert.Zero(t, status.FailedMessages)
+	assert.EqualValues(t, len(chain)+1, status.DeliveredMessages)
+	received.mutex.Lock()
+	assert.Equal(t, append(tangle.MessageIDs{confirmedChain[len(confirmedChain)-1]}, chain...), tangle.MessageIDs(received.received))
+	received.mutex.Unlock()
+
+	mgrA.On("neighborRemoved", mock.Anything).Once()
+	mgrB.On("neighborRemoved", mock.Anything).Once()
+	closeA()
+	closeB()
+	time.Sleep(graceTime)
+}
+
+func TestBulkSyncSession_MaxPending(t *testing.T) {
+	source := newTestSyncTangle()
+	chain := source.attachChain(t, tangle.EmptyMessageID, 50)
+
+	opts := map[string][]ManagerOption{t.Name(): {WithBulkSync(isGenesis, noTips, noConfirmedHistory)}}
+	testMgrs := newTestManagersWithOptions(t, true /* doMock */, opts, t.Name())
+	defer testMgrs[0].close()
+	received := newTestSyncReceiver()
+	testMgrs[0].mockManager.On("messageReceived", mock.Anything).Run(received.record)
+
+	// the chain is received from its tip, so every message waits for its parent
+	session := newBulkSyncSession(testMgrs[0].manager, func() {})
+	session.maxPending = 10
+	for i := len(chain) - 1; i >= 0; i-- {
+		msg := source.messages[chain[i]]
+		session.addPending(msg.ID(), msg, msg.Bytes(), testMgrs[0].peer)
+		assert.LessOrEqual(t, len(session.pending), session.maxPending)
+	}
+
+	// the oldest pending messages are delivered early and their parents once they are received
+	assert.Empty(t, session.pending)
+	assert.EqualValues(t, len(chain), session.status.DeliveredMessages)
+	received.mutex.Lock()
+	assert.ElementsMatch(t, chain, received.received)
+	received.mutex.Unlock()
+}
+
+func TestBulkSyncParallel(t *testing.T) {
+	// many independent tips that need to be fetched in several batches
+	source := newTestSyncTangle()
+	for i := 0; i < 4*syncBatchSize; i++ {
+		source.tips = append(source.tips, source.attachChain(t, tangle.EmptyMessageID, 1)...)
+	}
+
+	var servedA, servedC atomic.Uint64
+	countingLoader := func(served *atomic.Uint64) LoadMessageFunc {
+		return func(msgID tangle.MessageID) ([]byte, error) {
+			served.Inc()
+			return source.loadMessage(msgID)
+		}
+	}
+	opts := map[string][]ManagerOption{
+		t.Name() + "_A": {WithBulkSync(source.hasMessage, source.loadTips, noConfirmedHistory)},
+		t.Name() + "_B": {WithBulkSync(isGenesis, noTips, noConfirmedHistory)},
+		t.Name() + "_C": {WithBulkSync(source.hasMessage, source.loadTips, noConfirmedHistory)},
+	}
+	testMgrs := newTestManagersWithOptions(t, true /* doMock */, opts, t.Name()+"_A", t.Name()+"_B", t.Name()+"_C")
+	mgrA, closeA, peerA := testMgrs[0].mockManager, testMgrs[0].close, testMgrs[0].peer
+	mgrB, closeB, peerB := testMgrs[1].mockManager, testMgrs[1].close, testMgrs[1].peer
+	mgrC, closeC, peerC := testMgrs[2].mockManager, testMgrs[2].close, testMgrs[2].peer
+	mgrA.loadMessageFunc = countingLoader(&servedA)
+	mgrC.loadMessageFunc = countingLoader(&servedC)
+
+	connectTestManagers(t, mgrA, peerA, mgrB, peerB)
+	connectTestManagers(t, mgrC, peerC, mgrB, peerB)
+
+	received := newTestSyncReceiver()
+	mgrB.On("messageReceived", mock.Anything).Run(received.record)
+
+	require.NoError(t, mgrB.StartBulkSync(context.Background()))
+	require.Eventually(t, func() bool { return !mgrB.BulkSyncStatus().Running }, 5*time.Second, graceTime)
+
+	status := mgrB.BulkSyncStatus()
+	assert.Equal(t, 2, status.Peers)
+	assert.EqualValues(t, len(source.messages), status.DeliveredMessages)
+	assert.Zero(t, status.FailedMessages)
+	received.assertCausalOrder(t, source)
+
+	// the batches are spread among both neighbors
+	assert.Positive(t, servedA.Load())
+	assert.Positive(t, servedC.Load())
+
+	mgrA.On("neighborRemoved", mock.Anything).Once()
+	mgrB.On("neighborRemoved", mock.Anything).Twice()
+	mgrC.On("neighborRemoved", mock.Anything).Once()
+	closeA()
+	closeB()
+	closeC()
+	time.Sleep(graceTime)
+}
+
+func TestBulkSyncDisabled(t *testing.T) {
+	testMgrs := newTestManagers(t, false /* doMock */, t.Name()+"_A")
+	defer testMgrs[0].close()
+
+	assert.True(t, errors.Is(testMgrs[0].manager.StartBulkSync(context.Background()), ErrBulkSyncDisabled))
+	assert.False(t, testMgrs[0].manager.BulkSyncStatus().Running)
+}
+
+func isGenesis(msgID tangle.MessageID) bool { return msgID == tangle.EmptyMessageID }
+
+func noTips() tangle.MessageIDs { return nil }
+
+func noConfirmedHistory() time.Time { return time.Time{} }
+
+// testSyncTangle is a simple message store that serves the bulk sync.
+type testSyncTangle struct {
+	messages map[tangle.MessageID]*tangle.Message
+	tips     tangle.MessageIDs
+}
+
+func newTestSyncTangle() *testSyncTangle {
+	return &testSyncTangle{messages: make(map[tangle.MessageID]*tangle.Message)}
+}
+
+// attachChain attaches a chain of the given length to the given parent and returns the IDs of its messages.
+func (s *testSyncTangle) attachChain(t *testing.T, parent tangle.MessageID, length int) (chain tangle.MessageIDs) {
+	for i := 0; i < length; i++ {
+		msg, err := tangle.NewMessage(tangle.MessageIDs{parent}, nil, nil, nil, time.Now(), ed25519.PublicKey{},
+			uint64(len(s.messages)), payload.NewGenericDataPayload([]byte("test")), 0, ed25519.Signature{})
+		require.NoError(t, err)
+		s.messages[msg.ID()] = msg
+		chain = append(chain, msg.ID())
+		parent = msg.ID()
+	}
+	return chain
+}
+
+func (s *testSyncTangle) hasMessage(msgID tangle.MessageID) bool {
+	_, exists := s.messages[msgID]
+	return exists || isGenesis(msgID)
+}
+
+func (s *testSyncTangle) loadMessage(msgID tangle.MessageID) ([]byte, error) {
+	msg, exists := s.messages[msgID]
+	if !exists {
+		return nil, errors.New("message not found")
+	}
+	return msg.Bytes(), nil
+}
+
+func (s *testSyncTangle) loadTips() tangle.MessageIDs {
+	return s.tips
+}
+
+// testSyncReceiver records the messages that are received via the gossip protocol.
+type testSyncReceiver struct {
+	mutex    sync.Mutex
+	received []tangle.MessageID
+}
+
+func newTestSyncReceiver() *testSyncReceiver {
+	return &testSyncReceiver{}
+}
+
+func (r *testSyncReceiver) record(args mock.Arguments) {
+	msg, _, err := tangle.MessageFromBytes(args.Get(0).(*MessageReceivedEvent).Data)
+	if err != nil {
+		panic(err)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.received = append(r.received, msg.ID())
+}
+
+// assertCausalOrder asserts that every message of the source was received exactly once and after its parents.
+func (r *testSyncReceiver) assertCausalOrder(t *testing.T, source *testSyncTangle) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	require.Len(t, r.received, len(source.messages))
+	receivedBefore := map[tangle.MessageID]bool{tangle.EmptyMessageID: true}
+	for _, msgID := range r.received {
+		require.False(t, receivedBefore[msgID], "message %s received twice", msgID)
+		source.messages[msgID].ForEachParent(func(parent tangle.Parent) {
+			assert.True(t, receivedBefore[parent.ID], "message %s received before its parent %s", msgID, parent.ID)
+		})
+		receivedBefore[msgID] = true
+	}
+}
@@ -0,0 +1,77 @@
+// Package health provides a registry of readiness checks of the components of a node.
+package health
+
+import (
+	"sort"
+	"sync"
+)
+
+// Check returns an error describing why the component is not ready or nil if it is ready.
+type Check func() error
+
+// CheckResult contains the result of a single Check.
+type CheckResult struct {
+	// Name is the name the Check was registered with.
+	Name string
+	// Err is the error returned by the Check or nil if the component is ready.
+	Err error
+}
+
+// Ready returns true if the component is ready.
+func (c *CheckResult) Ready() bool {
+	return c.Err == nil
+}
+
+// Registry contains the readiness checks of the components of a node.
+type Registry struct {
+	checks      map[string]Check
+	checksMutex sync.RWMutex
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		checks: make(map[string]Check),
+	}
+}
+
+// Register adds a Check with the given name to the Registry. A Check that was registered with the same name before is
+// replaced.
+func (r *Registry) Register(name string, check Check) {
+	r.checksMutex.Lock()
+	defer r.checksMutex.Unlock()
+
+	r.checks[name] = check
+}
+
+// Unregister removes the Check with the given name from the Registry.
+func (r *Registry) Unregister(name string) {
+	r.checksMutex.Lock()
+	defer r.checksMutex.Unlock()
+
+	delete(r.checks, name)
+}
+
+// Evaluate runs all registered checks and returns their results sorted by name. The node is ready if all the checks
+// succeed.
+func (r *Registry) Evaluate() (ready bool, results []*CheckResult) {
+	r.checksMutex.RLock()
+	checks := make(map[string]Check, len(r.checks))
+	for name, check := range r.checks {
+		checks[name] = check
+	}
+	r.checksMutex.RUnlock()
+
+	ready = true
+	results = make([]*CheckResult, 0, len(checks))
+	for name, check := range checks {
+		result := &CheckResult{Name: name, Err: check()}
+		ready = ready && result.Ready()
+		results = append(results, result)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Name < results[j].Name
+	})
+
+	return ready, results
+}
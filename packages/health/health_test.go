@@ -0,0 +1,39 @@
+package health
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry()
+
+	ready, results := registry.Evaluate()
+	assert.True(t, ready)
+	assert.Empty(t, results)
+
+	registry.Register("b", func() error { return nil })
+	registry.Register("a", func() error { return errors.New("not synced") })
+
+	ready, results = registry.Evaluate()
+	assert.False(t, ready)
+	require.Len(t, results, 2)
+	assert.Equal(t, "a", results[0].Name)
+	assert.False(t, results[0].Ready())
+	assert.EqualError(t, results[0].Err, "not synced")
+	assert.Equal(t, "b", results[1].Name)
+	assert.True(t, results[1].Ready())
+
+	// checks can be replaced and removed
+	registry.Register("a", func() error { return nil })
+	ready, _ = registry.Evaluate()
+	assert.True(t, ready)
+
+	registry.Unregister("b")
+	_, results = registry.Evaluate()
+	require.Len(t, results, 1)
+	assert.Equal(t, "a", results[0].Name)
+}
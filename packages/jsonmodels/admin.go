@@ -0,0 +1,27 @@
+package jsonmodels
+
+// ConfigReloadResponse is the HTTP response of reloading the configuration.
+type ConfigReloadResponse struct {
+	// Reloaded contains the changed parameters that were applied.
+	Reloaded []string `json:"reloaded,omitempty"`
+	// Ignored contains the changed parameters that can't be reloaded and require a restart.
+	Ignored []string `json:"ignored,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// LogLevelRequest holds the log level to set.
+type LogLevelRequest struct {
+	// Plugin is the name of the plugin whose log level is changed. If it is empty, the default log level is changed.
+	Plugin string `json:"plugin,omitempty"`
+	// Level is the new log level. If it is empty, the log level of the plugin is reset to the default log level.
+	Level string `json:"level,omitempty"`
+}
+
+// LogLevelsResponse is the HTTP response containing the log levels of the node.
+type LogLevelsResponse struct {
+	// Level is the default log level.
+	Level string `json:"level,omitempty"`
+	// PluginLevels contains the log levels of the plugins that override the default log level.
+	PluginLevels map[string]string `json:"pluginLevels,omitempty"`
+	Error        string            `json:"error,omitempty"`
+}
@@ -13,6 +13,20 @@ type Neighbor struct {
 	ID        string        `json:"id"`        // comparable node identifier
 	PublicKey string        `json:"publicKey"` // public key used to verify signatures
 	Services  []PeerService `json:"services,omitempty"`
+	// Quality is only set for the neighbors that are connected via gossip.
+	Quality *NeighborQuality `json:"quality,omitempty"`
+}
+
+// NeighborQuality contains the quality of a gossip neighbor.
+type NeighborQuality struct {
+	DeliveredMessages uint64  `json:"deliveredMessages"`
+	FirstSeenMessages uint64  `json:"firstSeenMessages"`
+	FirstSeenShare    float64 `json:"firstSeenShare"`
+	AverageLatency    int64   `json:"averageLatency"` // average latency in milliseconds
+	Requests          uint64  `json:"requests"`
+	AnsweredRequests  uint64  `json:"answeredRequests"`
+	Scored            bool    `json:"scored"`
+	Score             float64 `json:"score"`
 }
 
 // PeerService contains information about a neighbor peer service.
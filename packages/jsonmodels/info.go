@@ -12,6 +12,8 @@ type InfoResponse struct {
 	NetworkVersion uint32 `json:"networkVersion,omitempty"`
 	// TangleTime sync status
 	TangleTime TangleTime `json:"tangleTime,omitempty"`
+	// BulkSync contains the progress of the running or the last bulk sync
+	BulkSync *BulkSync `json:"bulkSync,omitempty"`
 	// identity ID of the node encoded in base58
 	IdentityID string `json:"identityID,omitempty"`
 	// identity ID of the node encoded in base58 and truncated to its first 8 bytes
@@ -36,6 +38,8 @@ type InfoResponse struct {
 	ManaDecay float64 `json:"mana_decay"`
 	// Scheduler is the scheduler.
 	Scheduler Scheduler `json:"scheduler"`
+	// RateSetter is the rate setter.
+	RateSetter RateSetter `json:"rateSetter"`
 	// error of the response
 	Error string `json:"error,omitempty"`
 }
@@ -47,6 +51,21 @@ type TangleTime struct {
 	Synced    bool   `json:"synced"`
 }
 
+// BulkSync contains the progress of a bulk sync, which fetches the missing history in batches from the neighbors.
+type BulkSync struct {
+	Running           bool   `json:"running"`
+	StartTime         int64  `json:"startTime"`
+	EndTime           int64  `json:"endTime,omitempty"`
+	Peers             int    `json:"peers"`
+	MissingMessages   int    `json:"missingMessages"`
+	InFlightRequests  int    `json:"inFlightRequests"`
+	RequestedMessages uint64 `json:"requestedMessages"`
+	ReceivedMessages  uint64 `json:"receivedMessages"`
+	DeliveredMessages uint64 `json:"deliveredMessages"`
+	FailedMessages    uint64 `json:"failedMessages"`
+	Error             string `json:"error,omitempty"`
+}
+
 // Mana contains the different mana values of the node.
 type Mana struct {
 	Access             float64   `json:"access"`
@@ -66,6 +85,25 @@ type Scheduler struct {
 
 // RateSetter is the rate setter details.
 type RateSetter struct {
-	Rate float64 `json:"rate"`
-	Size int     `json:"size"`
+	Rate      float64        `json:"rate"`
+	Size      int            `json:"size"`
+	LaneSizes map[string]int `json:"laneSizes"`
+}
+
+// ReadinessResponse holds the response of the readiness check.
+type ReadinessResponse struct {
+	// Ready is true if all the checks succeeded.
+	Ready bool `json:"ready"`
+	// Checks contains the results of the readiness checks of the components.
+	Checks []ReadinessCheck `json:"checks"`
+}
+
+// ReadinessCheck holds the result of the readiness check of a component.
+type ReadinessCheck struct {
+	// Name is the name of the component.
+	Name string `json:"name"`
+	// Ready is true if the component is ready.
+	Ready bool `json:"ready"`
+	// Error describes why the component is not ready.
+	Error string `json:"error,omitempty"`
 }
@@ -258,6 +258,10 @@ type AliasOutput struct {
 
 	GoverningAddress   string `json:"governingAddress,omitempty"`
 	DelegationTimelock int64  `json:"delegationTimelock,omitempty"`
+
+	IsSupplyControlled bool   `json:"isSupplyControlled,omitempty"`
+	TokenColor         string `json:"tokenColor,omitempty"`
+	TokenSupply        uint64 `json:"tokenSupply,omitempty"`
 }
 
 // ToLedgerStateOutput builds a ledgerstate.Output from SigLockedSingleOutput with the given outputID.
@@ -301,6 +305,7 @@ func (a *AliasOutput) ToLedgerStateOutput(id ledgerstate.OutputID) (ledgerstate.
 	res.SetIsGovernanceUpdated(isGovernanceUpdate)
 	res.SetIsOrigin(isOrigin)
 	res.SetIsDelegated(isDelegated)
+	res.SetIsSupplyControlled(a.IsSupplyControlled)
 
 	// optional fields
 	if a.StateData != nil {
@@ -334,6 +339,12 @@ func (a *AliasOutput) ToLedgerStateOutput(id ledgerstate.OutputID) (ledgerstate.
 			return nil, err
 		}
 	}
+	if a.TokenSupply != 0 {
+		err = res.SetTokenSupply(a.TokenSupply)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return res, nil
 }
 
@@ -362,6 +373,11 @@ func AliasOutputFromLedgerstate(output ledgerstate.Output) (*AliasOutput, error)
 	if !castedOutput.DelegationTimelock().IsZero() {
 		res.DelegationTimelock = castedOutput.DelegationTimelock().Unix()
 	}
+	if castedOutput.IsSupplyControlled() {
+		res.IsSupplyControlled = true
+		res.TokenColor = castedOutput.TokenColor().Base58()
+		res.TokenSupply = castedOutput.TokenSupply()
+	}
 	return res, nil
 }
 
@@ -387,6 +403,8 @@ type ExtendedLockedOutput struct {
 	FallbackDeadline int64             `json:"fallbackDeadline,omitempty"`
 	TimeLock         int64             `json:"timelock,omitempty"`
 	Payload          []byte            `json:"payload,omitempty"`
+	HashLockType     string            `json:"hashLockType,omitempty"`
+	HashLock         string            `json:"hashLock,omitempty"`
 }
 
 // ToLedgerStateOutput builds a ledgerstate.Output from ExtendedLockedOutput with the given outputID.
@@ -418,6 +436,19 @@ func (e *ExtendedLockedOutput) ToLedgerStateOutput(id ledgerstate.OutputID) (led
 			return nil, rErr
 		}
 	}
+	if e.HashLockType != "" {
+		hashLockType, hErr := ledgerstate.HashLockTypeFromString(e.HashLockType)
+		if hErr != nil {
+			return nil, errors.Errorf("wrong hash lock type in ExtendedLockedOutput: %w", hErr)
+		}
+		hashLockBytes, hErr := base58.Decode(e.HashLock)
+		if hErr != nil || len(hashLockBytes) != ledgerstate.HashLockSize {
+			return nil, errors.Errorf("wrong hash lock in ExtendedLockedOutput: %s", e.HashLock)
+		}
+		var hashLock [ledgerstate.HashLockSize]byte
+		copy(hashLock[:], hashLockBytes)
+		res = res.WithHashLock(hashLockType, hashLock)
+	}
 	res.SetID(id)
 	return res, nil
 }
@@ -440,6 +471,10 @@ func ExtendedLockedOutputFromLedgerstate(output ledgerstate.Output) (*ExtendedLo
 	if !castedOutput.TimeLock().Equal(time.Time{}) {
 		res.TimeLock = castedOutput.TimeLock().Unix()
 	}
+	if hashLockType, hashLock := castedOutput.HashLock(); hashLockType != 0 {
+		res.HashLockType = hashLockType.String()
+		res.HashLock = base58.Encode(hashLock[:])
+	}
 	return res, nil
 }
 
@@ -703,6 +738,7 @@ type UnlockBlock struct {
 	SignatureType   ledgerstate.SignatureType `json:"signatureType,omitempty"`
 	PublicKey       string                    `json:"publicKey,omitempty"`
 	Signature       string                    `json:"signature,omitempty"`
+	Preimage        string                    `json:"preimage,omitempty"`
 }
 
 // NewUnlockBlock returns an UnlockBlock from the given ledgerstate.UnlockBlock.
@@ -728,6 +764,17 @@ func NewUnlockBlock(unlockBlock ledgerstate.UnlockBlock) *UnlockBlock {
 	case ledgerstate.ReferenceUnlockBlockType:
 		referenceUnlockBlock, _, _ := ledgerstate.ReferenceUnlockBlockFromBytes(unlockBlock.Bytes())
 		result.ReferencedIndex = referenceUnlockBlock.ReferencedIndex()
+	case ledgerstate.PreimageUnlockBlockType:
+		preimageUnlockBlock := unlockBlock.(*ledgerstate.PreimageUnlockBlock)
+		result.SignatureType = preimageUnlockBlock.Signature().Type()
+		switch signature := preimageUnlockBlock.Signature().(type) {
+		case *ledgerstate.ED25519Signature:
+			result.PublicKey = signature.PublicKey.String()
+			result.Signature = signature.Signature.String()
+		case *ledgerstate.BLSSignature:
+			result.Signature = signature.Signature.String()
+		}
+		result.Preimage = base58.Encode(preimageUnlockBlock.Preimage())
 	}
 
 	return result
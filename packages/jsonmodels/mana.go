@@ -79,6 +79,10 @@ type PastConsensusVectorMetadataResponse struct {
 // PastConsensusManaVectorRequest is the request.
 type PastConsensusManaVectorRequest struct {
 	Timestamp int64 `json:"timestamp"`
+	// NodeID optionally restricts the response to the consensus mana of a single node.
+	NodeID string `json:"nodeID,omitempty"`
+	// Number optionally restricts the response to the nodes with the highest consensus mana.
+	Number uint `json:"number,omitempty"`
 }
 
 // PastConsensusManaVectorResponse is the response.
@@ -129,3 +133,30 @@ type AllowedPledge struct {
 	IsFilterEnabled bool     `json:"isFilterEnabled"`
 	Allowed         []string `json:"allowed,omitempty"`
 }
+
+// ManaEvent is a pledge, revoke or update mana event in JSON, as it is exported by the mana event logger.
+type ManaEvent struct {
+	Type          string  `json:"type"`
+	ManaType      string  `json:"manaType"`
+	NodeID        string  `json:"nodeID"`
+	Time          int64   `json:"time"`
+	Amount        float64 `json:"amount,omitempty"`
+	TransactionID string  `json:"txID,omitempty"`
+	InputID       string  `json:"inputID,omitempty"`
+	OldBaseMana   float64 `json:"oldBaseMana,omitempty"`
+	NewBaseMana   float64 `json:"newBaseMana,omitempty"`
+}
+
+// GetManaEventsRequest is the request for the recorded mana events.
+type GetManaEventsRequest struct {
+	NodeIDs   []string `json:"nodeIDs"`
+	ManaType  string   `json:"manaType"`
+	StartTime int64    `json:"startTime"`
+	EndTime   int64    `json:"endTime"`
+}
+
+// GetManaEventsResponse is the response to a GetManaEventsRequest.
+type GetManaEventsResponse struct {
+	Events []*ManaEvent `json:"events"`
+	Error  string       `json:"error,omitempty"`
+}
@@ -24,3 +24,17 @@ type MissingAvailableResponse struct {
 	Availability map[string][]string `json:"msgavailability,omitempty"`
 	Count        int                 `json:"count"`
 }
+
+// PowRequest holds the bytes the node should perform the PoW for.
+type PowRequest struct {
+	// Data contains the bytes the nonce is appended to, i.e. the bytes the PoW is computed over without the nonce.
+	Data []byte `json:"data"`
+	// Difficulty is the number of leading zeros the PoW needs to reach.
+	Difficulty int `json:"difficulty"`
+}
+
+// PowResponse is the HTTP response containing the nonce of the PoW.
+type PowResponse struct {
+	Nonce uint64 `json:"nonce,omitempty"`
+	Error string `json:"error,omitempty"`
+}
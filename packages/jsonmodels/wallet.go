@@ -10,6 +10,9 @@ import (
 type WalletOutputsOnAddress struct {
 	Address Address        `json:"address"`
 	Outputs []WalletOutput `json:"outputs"`
+	// SpentOutputs is the number of outputs on the address that were consumed already. Together with the unspent
+	// Outputs it tells wallets which addresses were used before.
+	SpentOutputs int `json:"spentOutputs"`
 }
 
 // WalletOutput represents an output as expected by the wallet lib.
@@ -1,6 +1,8 @@
 package jsonmodels
 
 import (
+	"github.com/iotaledger/goshimmer/packages/assetregistry"
+	"github.com/iotaledger/goshimmer/packages/consensus/gof"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/tangle"
 )
@@ -11,6 +13,8 @@ import (
 type GetAddressResponse struct {
 	Address *Address  `json:"address"`
 	Outputs []*Output `json:"outputs"`
+	// NextCursor is the cursor that returns the next page of outputs (empty if there are no further outputs).
+	NextCursor string `json:"nextCursor,omitempty"`
 }
 
 // NewGetAddressResponse returns a GetAddressResponse from the given details.
@@ -32,6 +36,33 @@ func NewGetAddressResponse(address ledgerstate.Address, outputs ledgerstate.Outp
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region GetAddressBalanceResponse ////////////////////////////////////////////////////////////////////////////////////
+
+// GetAddressBalanceResponse represents the JSON model of a response from the GetAddressBalance endpoint.
+type GetAddressBalanceResponse struct {
+	Address        *Address          `json:"address"`
+	Balances       map[string]uint64 `json:"balances"`
+	UnspentOutputs int               `json:"unspentOutputs"`
+}
+
+// NewGetAddressBalanceResponse returns a GetAddressBalanceResponse from the given details.
+func NewGetAddressBalanceResponse(address ledgerstate.Address, balances map[ledgerstate.Color]uint64, unspentOutputs int) *GetAddressBalanceResponse {
+	return &GetAddressBalanceResponse{
+		Address: NewAddress(address),
+		Balances: func() (mappedBalances map[string]uint64) {
+			mappedBalances = make(map[string]uint64, len(balances))
+			for color, balance := range balances {
+				mappedBalances[color.Base58()] = balance
+			}
+
+			return
+		}(),
+		UnspentOutputs: unspentOutputs,
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region PostAddressesUnspentOutputsRequest
 
 // PostAddressesUnspentOutputsRequest is a the request object for the /ledgerstate/addresses/unspentOutputs endpoint.
@@ -50,6 +81,40 @@ type PostAddressesUnspentOutputsResponse struct {
 
 // endregion
 
+// region GetAssetResponse /////////////////////////////////////////////////////////////////////////////////////////////
+
+// GetAssetResponse represents the JSON model of a response from the GetAsset endpoint.
+type GetAssetResponse struct {
+	Color           string    `json:"color"`
+	Name            string    `json:"name"`
+	Symbol          string    `json:"symbol"`
+	Precision       uint32    `json:"precision"`
+	Supply          uint64    `json:"supply"`
+	MintingOutputID *OutputID `json:"mintingOutputID"`
+	TransactionID   string    `json:"transactionID"`
+	Issuer          string    `json:"issuer"`
+	SigningTime     int64     `json:"signingTime"`
+	Timestamp       int64     `json:"timestamp"`
+}
+
+// NewGetAssetResponse returns a GetAssetResponse from the given assetregistry.Asset.
+func NewGetAssetResponse(asset *assetregistry.Asset) *GetAssetResponse {
+	return &GetAssetResponse{
+		Color:           asset.Color.Base58(),
+		Name:            asset.Name,
+		Symbol:          asset.Symbol,
+		Precision:       asset.Precision,
+		Supply:          asset.Supply,
+		MintingOutputID: NewOutputID(asset.MintingOutputID),
+		TransactionID:   asset.MintingOutputID.TransactionID().Base58(),
+		Issuer:          asset.Issuer.Base58(),
+		SigningTime:     asset.SigningTime.Unix(),
+		Timestamp:       asset.Timestamp.Unix(),
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region GetBranchChildrenResponse ////////////////////////////////////////////////////////////////////////////////////
 
 // GetBranchChildrenResponse represents the JSON model of a response from the GetBranchChildren endpoint.
@@ -151,6 +216,62 @@ func NewGetOutputConsumersResponse(outputID ledgerstate.OutputID, consumers []*l
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region GetAliasHistoryResponse //////////////////////////////////////////////////////////////////////////////////////
+
+// GetAliasHistoryResponse represents the JSON model of a response from the GetAliasHistory endpoint.
+type GetAliasHistoryResponse struct {
+	AliasID string               `json:"aliasID"`
+	History []*AliasHistoryEntry `json:"history"`
+	Limit   int                  `json:"limit"`
+	// NextStateIndex is the fromStateIndex that returns the next page of the history (0 if there are no further
+	// entries).
+	NextStateIndex uint32 `json:"nextStateIndex,omitempty"`
+}
+
+// AliasHistoryEntry represents the JSON model of an AliasOutput in the chain of an alias.
+type AliasHistoryEntry struct {
+	OutputID           *OutputID           `json:"outputID"`
+	TransactionID      string              `json:"transactionID"`
+	StateIndex         uint32              `json:"stateIndex"`
+	IsGovernanceUpdate bool                `json:"isGovernanceUpdate"`
+	Timestamp          int64               `json:"timestamp"`
+	BranchID           string              `json:"branchID"`
+	GradeOfFinality    gof.GradeOfFinality `json:"gradeOfFinality"`
+	Spent              bool                `json:"spent"`
+	Output             *Output             `json:"output"`
+}
+
+// NewAliasHistoryEntry returns an AliasHistoryEntry from the given ledgerstate.AliasHistoryEntry, the AliasOutput it
+// refers to and its ledgerstate.OutputMetadata.
+func NewAliasHistoryEntry(aliasHistoryEntry *ledgerstate.AliasHistoryEntry, aliasOutput *ledgerstate.AliasOutput, outputMetadata *ledgerstate.OutputMetadata) *AliasHistoryEntry {
+	return &AliasHistoryEntry{
+		OutputID:           NewOutputID(aliasHistoryEntry.OutputID()),
+		TransactionID:      aliasHistoryEntry.OutputID().TransactionID().Base58(),
+		StateIndex:         aliasHistoryEntry.StateIndex(),
+		IsGovernanceUpdate: aliasOutput.GetIsGovernanceUpdated(),
+		Timestamp:          aliasHistoryEntry.Timestamp().Unix(),
+		BranchID:           aliasHistoryEntry.BranchID().Base58(),
+		GradeOfFinality:    outputMetadata.GradeOfFinality(),
+		Spent:              outputMetadata.ConsumerCount() > 0,
+		Output:             NewOutput(aliasOutput),
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region GetOutputPreimageResponse ////////////////////////////////////////////////////////////////////////////////////
+
+// GetOutputPreimageResponse represents the JSON model of a response from the GetOutputPreimage endpoint.
+type GetOutputPreimageResponse struct {
+	OutputID      string `json:"outputID"`
+	TransactionID string `json:"transactionID"`
+	HashLockType  string `json:"hashLockType"`
+	HashLock      string `json:"hashLock"`
+	Preimage      string `json:"preimage"`
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region GetTransactionAttachmentsResponse ////////////////////////////////////////////////////////////////////////////
 
 // GetTransactionAttachmentsResponse represents the JSON model of a response from the GetTransactionAttachments endpoint.
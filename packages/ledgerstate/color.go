@@ -10,6 +10,7 @@ import (
 	"github.com/iotaledger/hive.go/marshalutil"
 	"github.com/iotaledger/hive.go/stringify"
 	"github.com/mr-tron/base58"
+	"golang.org/x/crypto/blake2b"
 )
 
 // region Color ////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -54,6 +55,12 @@ func ColorFromBase58EncodedString(base58String string) (color Color, err error)
 	return
 }
 
+// AliasTokenColor returns the supply-controlled token Color of the alias with the given AliasAddress. It is derived
+// from the serialized address (including its type byte) so it can never collide with a Color created by ColorMint.
+func AliasTokenColor(aliasAddress *AliasAddress) Color {
+	return blake2b.Sum256(aliasAddress.Bytes())
+}
+
 // ColorFromMarshalUtil unmarshals a Color using a MarshalUtil (for easier unmarshaling).
 func ColorFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (color Color, err error) {
 	colorBytes, err := marshalUtil.ReadBytes(ColorLength)
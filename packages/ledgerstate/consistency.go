@@ -0,0 +1,145 @@
+package ledgerstate
+
+import (
+	"fmt"
+
+	"github.com/iotaledger/hive.go/objectstorage"
+	"github.com/iotaledger/hive.go/types"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+)
+
+// region UTXODAG //////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// CheckConsistency checks the invariants of the UTXODAG and adds the broken ones to the report. If repair is true, the
+// inconsistent entries are deleted and the consumer counts of the outputs are corrected accordingly. The full mode
+// additionally checks the metadata of the transactions and outputs.
+func (u *UTXODAG) CheckConsistency(report *database.ConsistencyReport, repair bool) {
+	// every consumer needs to reference a known transaction
+	var invalidConsumers []*Consumer
+	u.consumerStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		(&CachedConsumer{CachedObject: cachedObject}).Consume(func(consumer *Consumer) {
+			report.EntryChecked("consumer")
+			if !u.transactionStorage.Contains(consumer.TransactionID().Bytes()) {
+				invalidConsumers = append(invalidConsumers, consumer)
+			}
+		})
+		return true
+	})
+	for _, consumer := range invalidConsumers {
+		issue := report.AddIssue("consumer", consumer.ConsumedInput().Base58(),
+			fmt.Sprintf("output consumed by unknown transaction %s", consumer.TransactionID().Base58()))
+		if repair {
+			u.consumerStorage.Delete(consumer.ObjectStorageKey())
+			// only the valid consumers were counted when they were booked
+			if consumer.Valid() == types.True {
+				u.CachedOutputMetadata(consumer.ConsumedInput()).Consume(func(outputMetadata *OutputMetadata) {
+					outputMetadata.UnregisterConsumer()
+				})
+			}
+			issue.Repaired = true
+		}
+	}
+
+	// every address mapping needs to reference a known output
+	var invalidMappings []*AddressOutputMapping
+	u.addressOutputMappingStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		(&CachedAddressOutputMapping{CachedObject: cachedObject}).Consume(func(mapping *AddressOutputMapping) {
+			report.EntryChecked("addressOutputMapping")
+			if !u.outputStorage.Contains(mapping.OutputID().Bytes()) {
+				invalidMappings = append(invalidMappings, mapping)
+			}
+		})
+		return true
+	})
+	for _, mapping := range invalidMappings {
+		issue := report.AddIssue("addressOutputMapping", mapping.Address().Base58(),
+			fmt.Sprintf("address mapped to missing output %s", mapping.OutputID().Base58()))
+		if repair {
+			u.addressOutputMappingStorage.Delete(mapping.ObjectStorageKey())
+			issue.Repaired = true
+		}
+	}
+
+	if report.Mode != database.FullConsistencyCheck {
+		return
+	}
+
+	// every metadata needs to belong to a known transaction or output
+	u.checkOrphanedMetadata(report, repair, "transactionMetadata", u.transactionMetadataStorage, u.transactionStorage, func(key []byte) string {
+		transactionID, _, err := TransactionIDFromBytes(key)
+		if err != nil {
+			return fmt.Sprintf("%x", key)
+		}
+		return transactionID.Base58()
+	})
+	u.checkOrphanedMetadata(report, repair, "outputMetadata", u.outputMetadataStorage, u.outputStorage, func(key []byte) string {
+		outputID, _, err := OutputIDFromBytes(key)
+		if err != nil {
+			return fmt.Sprintf("%x", key)
+		}
+		return outputID.Base58()
+	})
+}
+
+// checkOrphanedMetadata reports the entries of the metadata storage whose key is missing in the object storage.
+func (u *UTXODAG) checkOrphanedMetadata(report *database.ConsistencyReport, repair bool, storageName string, metadataStorage, objectStorage *objectstorage.ObjectStorage, keyString func(key []byte) string) {
+	var orphanedKeys [][]byte
+	metadataStorage.ForEachKeyOnly(func(key []byte) bool {
+		report.EntryChecked(storageName)
+		if !objectStorage.Contains(key) {
+			orphanedKeys = append(orphanedKeys, append([]byte{}, key...))
+		}
+		return true
+	})
+	for _, key := range orphanedKeys {
+		issue := report.AddIssue(storageName, keyString(key), "metadata without object")
+		if repair {
+			metadataStorage.Delete(key)
+			issue.Repaired = true
+		}
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region BranchDAG ////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// CheckConsistency checks the invariants of the BranchDAG and adds the broken ones to the report. If repair is true,
+// the child branch references of missing branches are deleted. Branches with missing parents can't be repaired, as
+// their position in the DAG is unknown. The BranchDAG is only checked in the full mode.
+func (b *BranchDAG) CheckConsistency(report *database.ConsistencyReport, repair bool) {
+	if report.Mode != database.FullConsistencyCheck {
+		return
+	}
+
+	b.ForEachBranch(func(branch Branch) {
+		report.EntryChecked("branch")
+		for parentBranchID := range branch.Parents() {
+			if !b.branchStorage.Contains(parentBranchID.Bytes()) {
+				report.AddIssue("branch", branch.ID().Base58(), fmt.Sprintf("missing parent branch %s", parentBranchID.Base58()))
+			}
+		}
+	})
+
+	var invalidChildBranches []*ChildBranch
+	b.childBranchStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		(&CachedChildBranch{CachedObject: cachedObject}).Consume(func(childBranch *ChildBranch) {
+			report.EntryChecked("childBranch")
+			if !b.branchStorage.Contains(childBranch.ParentBranchID().Bytes()) || !b.branchStorage.Contains(childBranch.ChildBranchID().Bytes()) {
+				invalidChildBranches = append(invalidChildBranches, childBranch)
+			}
+		})
+		return true
+	})
+	for _, childBranch := range invalidChildBranches {
+		issue := report.AddIssue("childBranch", childBranch.ParentBranchID().Base58(),
+			fmt.Sprintf("child branch reference %s to a missing branch", childBranch.ChildBranchID().Base58()))
+		if repair {
+			b.childBranchStorage.Delete(childBranch.ObjectStorageKey())
+			issue.Repaired = true
+		}
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,85 @@
+package ledgerstate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+)
+
+func TestUTXODAG_CheckConsistency(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+	defer utxoDAG.Shutdown()
+
+	address := NewED25519Address(ed25519.GenerateKeyPair().PublicKey)
+	essence := NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{},
+		NewInputs(NewUTXOInput(EmptyOutputID)),
+		NewOutputs(NewSigLockedSingleOutput(1, address)),
+	)
+	knownTransaction := NewTransaction(essence, UnlockBlocks{NewReferenceUnlockBlock(0)})
+	utxoDAG.transactionStorage.Store(knownTransaction).Release()
+
+	// the output is consumed by the known transaction and by two transactions that were lost, one of them is invalid
+	consumedOutputID := NewOutputID(TransactionID{1}, 0)
+	outputMetadata := NewOutputMetadata(consumedOutputID)
+	utxoDAG.outputMetadataStorage.Store(outputMetadata).Release()
+	unknownTransactionID := TransactionID{2}
+	invalidTransactionID := TransactionID{3}
+	for transactionID, valid := range map[TransactionID]types.TriBool{
+		knownTransaction.ID(): types.True,
+		unknownTransactionID:  types.True,
+		invalidTransactionID:  types.False,
+	} {
+		if valid == types.True {
+			outputMetadata.RegisterConsumer(transactionID)
+		}
+		utxoDAG.consumerStorage.Store(NewConsumer(consumedOutputID, transactionID, valid)).Release()
+	}
+	utxoDAG.StoreAddressOutputMapping(address, consumedOutputID)
+
+	report := database.NewConsistencyReport(database.FastConsistencyCheck)
+	utxoDAG.CheckConsistency(report, false)
+	require.Len(t, report.Issues, 3)
+	assert.Len(t, report.Unrepaired(), 3)
+	assert.Equal(t, 3, report.CheckedEntries["consumer"])
+
+	report = database.NewConsistencyReport(database.FastConsistencyCheck)
+	utxoDAG.CheckConsistency(report, true)
+	require.Len(t, report.Issues, 3)
+	assert.Empty(t, report.Unrepaired())
+
+	// only the valid consumer of the lost transaction was counted
+	assert.True(t, utxoDAG.CachedOutputMetadata(consumedOutputID).Consume(func(outputMetadata *OutputMetadata) {
+		assert.Equal(t, 1, outputMetadata.ConsumerCount())
+	}))
+	consumers := utxoDAG.CachedConsumers(consumedOutputID)
+	defer consumers.Release()
+	require.Len(t, consumers, 1)
+	assert.Equal(t, knownTransaction.ID(), consumers[0].Unwrap().TransactionID())
+	assert.Empty(t, utxoDAG.AddressOutputIDs(address, nil, 0))
+
+	report = database.NewConsistencyReport(database.FastConsistencyCheck)
+	utxoDAG.CheckConsistency(report, false)
+	assert.True(t, report.Consistent())
+}
+
+func TestOutputMetadata_UnregisterConsumer(t *testing.T) {
+	outputMetadata := NewOutputMetadata(NewOutputID(TransactionID{1}, 0))
+	outputMetadata.RegisterConsumer(TransactionID{2})
+	outputMetadata.SetModified(false)
+
+	outputMetadata.UnregisterConsumer()
+	assert.Zero(t, outputMetadata.ConsumerCount())
+	assert.True(t, outputMetadata.IsModified())
+
+	// the count does not become negative
+	outputMetadata.UnregisterConsumer()
+	assert.Zero(t, outputMetadata.ConsumerCount())
+}
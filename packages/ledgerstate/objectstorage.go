@@ -5,6 +5,7 @@ import (
 
 	"github.com/iotaledger/goshimmer/packages/database"
 
+	"github.com/iotaledger/hive.go/marshalutil"
 	"github.com/iotaledger/hive.go/objectstorage"
 )
 
@@ -38,6 +39,15 @@ const (
 
 	// PrefixAddressOutputMappingStorage defines the storage prefix for the AddressOutputMapping object storage.
 	PrefixAddressOutputMappingStorage
+
+	// PrefixSupplyControlledColorStorage defines the storage prefix for the SupplyControlledColor object storage.
+	PrefixSupplyControlledColorStorage
+
+	// PrefixAliasHistoryStorage defines the storage prefix for the AliasHistoryEntry object storage.
+	PrefixAliasHistoryStorage
+
+	// PrefixAliasHistoryStartStorage defines the storage prefix for the AliasHistoryStart object storage.
+	PrefixAliasHistoryStartStorage
 )
 
 // block of default cache time.
@@ -50,6 +60,16 @@ const (
 	addressCacheTime     = 10 * time.Second
 )
 
+const (
+	// addressOutputIDPartitionBytes defines the number of leading bytes of the OutputIDs that partition the
+	// address-output mappings, so that the mappings of an address can be iterated in smaller chunks.
+	addressOutputIDPartitionBytes = 2
+
+	// maxAddressOutputIDsPerPrefix defines the number of address-output mappings of a prefix that are sorted in
+	// memory before the prefix is split into longer ones.
+	maxAddressOutputIDsPerPrefix = 1000
+)
+
 type storageOptions struct {
 	// branchStorageOptions contains a list of default settings for the Branch object storage.
 	branchStorageOptions []objectstorage.Option
@@ -80,6 +100,15 @@ type storageOptions struct {
 
 	// addressOutputMappingStorageOptions contains a list of default settings for the AddressOutputMapping object storage.
 	addressOutputMappingStorageOptions []objectstorage.Option
+
+	// supplyControlledColorStorageOptions contains a list of default settings for the SupplyControlledColor object storage.
+	supplyControlledColorStorageOptions []objectstorage.Option
+
+	// aliasHistoryStorageOptions contains a list of default settings for the AliasHistoryEntry object storage.
+	aliasHistoryStorageOptions []objectstorage.Option
+
+	// aliasHistoryStartStorageOptions contains a list of default settings for the AliasHistoryStart object storage.
+	aliasHistoryStartStorageOptions []objectstorage.Option
 }
 
 func buildObjectStorageOptions(cacheProvider *database.CacheTimeProvider) *storageOptions {
@@ -139,7 +168,26 @@ func buildObjectStorageOptions(cacheProvider *database.CacheTimeProvider) *stora
 
 	options.addressOutputMappingStorageOptions = []objectstorage.Option{
 		cacheProvider.CacheTime(addressCacheTime),
-		objectstorage.PartitionKey(AddressLength, OutputIDLength),
+		objectstorage.PartitionKey(AddressLength, 1, 1, OutputIDLength-addressOutputIDPartitionBytes),
+		objectstorage.LeakDetectionEnabled(false),
+		objectstorage.StoreOnCreation(true),
+	}
+
+	options.supplyControlledColorStorageOptions = []objectstorage.Option{
+		cacheProvider.CacheTime(addressCacheTime),
+		objectstorage.LeakDetectionEnabled(false),
+		objectstorage.StoreOnCreation(true),
+	}
+
+	options.aliasHistoryStorageOptions = []objectstorage.Option{
+		cacheProvider.CacheTime(addressCacheTime),
+		objectstorage.PartitionKey(AddressLength, marshalutil.Uint32Size, OutputIDLength),
+		objectstorage.LeakDetectionEnabled(false),
+		objectstorage.StoreOnCreation(true),
+	}
+
+	options.aliasHistoryStartStorageOptions = []objectstorage.Option{
+		cacheProvider.CacheTime(addressCacheTime),
 		objectstorage.LeakDetectionEnabled(false),
 		objectstorage.StoreOnCreation(true),
 	}
@@ -2,10 +2,12 @@ package ledgerstate
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/binary"
 	"fmt"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -968,6 +970,16 @@ const (
 	flagAliasOutputDelegationTimelockPresent
 )
 
+// extended flags use to compress serialized bytes (the first flags byte is fully used). They are only serialized if
+// one of them is set, so that outputs without the extended fields keep their original encoding. Their presence is
+// signaled by flagAliasOutputDelegationTimelockPresent without flagAliasOutputDelegationConstraint, a combination that
+// is invalid otherwise. The delegation flags are moved to the extended flags in that case.
+const (
+	flagAliasOutputExtendedDelegationConstraint = uint(iota)
+	flagAliasOutputExtendedDelegationTimelockPresent
+	flagAliasOutputTokenSupplyPresent
+)
+
 // AliasOutput represents output which defines as AliasAddress.
 // It can only be used in a chained manner.
 type AliasOutput struct {
@@ -1006,6 +1018,14 @@ type AliasOutput struct {
 	// delegation timelock (optional). Before the timelock, only state transition is permitted, after the timelock, only
 	// governance transition
 	delegationTimelock time.Time
+	// true if the alias controls the supply of its token color: only transactions that unlock the alias for state
+	// transition can mint or burn tokens of that color
+	isSupplyControlled bool
+	// circulating supply of the token color controlled by the alias. The constraint is:
+	// - start at 0 when supply control is enabled
+	// - change by exactly the amount of tokens that are minted or burned in a state transition
+	// - do not change with any new chained output with isGovernanceUpdate == true
+	tokenSupply uint64
 
 	objectstorage.StorableObjectFlags
 }
@@ -1059,6 +1079,12 @@ func (a *AliasOutput) WithDelegationAndTimelock(lockUntil time.Time) *AliasOutpu
 	return a
 }
 
+// WithSupplyControl returns the output as an alias output that controls the supply of its token color.
+func (a *AliasOutput) WithSupplyControl() *AliasOutput {
+	a.isSupplyControlled = true
+	return a
+}
+
 // AliasOutputFromMarshalUtil unmarshals a AliasOutput using a MarshalUtil (for easier unmarshaling).
 func AliasOutputFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (*AliasOutput, error) {
 	var ret *AliasOutput
@@ -1075,6 +1101,19 @@ func AliasOutputFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (*AliasOut
 		return nil, errors.Errorf("aliasOutput: failed to parse AliasOutput flags (%v): %w", err1, cerrors.ErrParseBytesFailed)
 	}
 	flags := bitmask.BitMask(flagsByte)
+	var extendedFlags bitmask.BitMask
+	if flags.HasBit(flagAliasOutputDelegationTimelockPresent) && !flags.HasBit(flagAliasOutputDelegationConstraint) {
+		extendedFlagsByte, err8 := marshalUtil.ReadByte()
+		if err8 != nil {
+			return nil, errors.Errorf("aliasOutput: failed to parse AliasOutput extended flags (%v): %w", err8, cerrors.ErrParseBytesFailed)
+		}
+		extendedFlags = bitmask.BitMask(extendedFlagsByte)
+		if !extendedFlags.HasBit(flagAliasOutputTokenSupplyPresent) {
+			return nil, errors.Errorf("aliasOutput: extended flags are present without extended fields: %w", cerrors.ErrParseBytesFailed)
+		}
+		flags = flags.ModifyBit(flagAliasOutputDelegationConstraint, extendedFlags.HasBit(flagAliasOutputExtendedDelegationConstraint)).
+			ModifyBit(flagAliasOutputDelegationTimelockPresent, extendedFlags.HasBit(flagAliasOutputExtendedDelegationTimelockPresent))
+	}
 	ret.isOrigin = flags.HasBit(flagAliasOutputIsOrigin)
 	ret.isGovernanceUpdate = flags.HasBit(flagAliasOutputGovernanceUpdate)
 	ret.isDelegated = flags.HasBit(flagAliasOutputDelegationConstraint)
@@ -1139,6 +1178,13 @@ func AliasOutputFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (*AliasOut
 			return nil, errors.Errorf("aliasOutput: failed to parse delegation timelock (%v): %w", err, cerrors.ErrParseBytesFailed)
 		}
 	}
+	if extendedFlags.HasBit(flagAliasOutputTokenSupplyPresent) {
+		ret.isSupplyControlled = true
+		ret.tokenSupply, err = marshalUtil.ReadUint64()
+		if err != nil {
+			return nil, errors.Errorf("aliasOutput: failed to parse token supply (%v): %w", err, cerrors.ErrParseBytesFailed)
+		}
+	}
 	if err7 := ret.checkBasicValidity(); err7 != nil {
 		return nil, err7
 	}
@@ -1318,6 +1364,35 @@ func (a *AliasOutput) DelegationTimeLockedNow(nowis time.Time) bool {
 	return a.delegationTimelock.After(nowis)
 }
 
+// IsSupplyControlled returns true if the alias controls the supply of its token color.
+func (a *AliasOutput) IsSupplyControlled() bool {
+	return a.isSupplyControlled
+}
+
+// SetIsSupplyControlled sets the supply control flag.
+func (a *AliasOutput) SetIsSupplyControlled(isSupplyControlled bool) {
+	a.isSupplyControlled = isSupplyControlled
+}
+
+// TokenSupply returns the circulating supply of the token color controlled by the alias.
+func (a *AliasOutput) TokenSupply() uint64 {
+	return a.tokenSupply
+}
+
+// SetTokenSupply sets the circulating supply of the token color controlled by the alias.
+func (a *AliasOutput) SetTokenSupply(supply uint64) error {
+	if !a.isSupplyControlled {
+		return errors.New("aliasOutput: can't set token supply of an alias that does not control its token supply")
+	}
+	a.tokenSupply = supply
+	return nil
+}
+
+// TokenColor returns the supply-controlled token color of the alias.
+func (a *AliasOutput) TokenColor() Color {
+	return AliasTokenColor(a.GetAliasAddress())
+}
+
 // Clone clones the structure.
 func (a *AliasOutput) Clone() Output {
 	return a.clone()
@@ -1338,6 +1413,8 @@ func (a *AliasOutput) clone() *AliasOutput {
 		isOrigin:           a.isOrigin,
 		isDelegated:        a.isDelegated,
 		isGovernanceUpdate: a.isGovernanceUpdate,
+		isSupplyControlled: a.isSupplyControlled,
+		tokenSupply:        a.tokenSupply,
 	}
 	if a.governingAddress != nil {
 		ret.governingAddress = a.governingAddress.Clone()
@@ -1404,6 +1481,9 @@ func (a *AliasOutput) String() string {
 	ret += fmt.Sprintf("   stateAddress: %s\n", a.stateAddress)
 	ret += fmt.Sprintf("   stateMetadataSize: %d\n", len(a.stateData))
 	ret += fmt.Sprintf("   governingAddress (self-governed=%v): %s\n", a.IsSelfGoverned(), a.GetGoverningAddress())
+	if a.isSupplyControlled {
+		ret += fmt.Sprintf("   tokenSupply: %d\n", a.tokenSupply)
+	}
 	return ret
 }
 
@@ -1425,10 +1505,15 @@ func (a *AliasOutput) ObjectStorageKey() []byte {
 // ObjectStorageValue binary form.
 func (a *AliasOutput) ObjectStorageValue() []byte {
 	flags := a.mustFlags()
-	ret := marshalutil.New().
-		WriteByte(byte(AliasOutputType)).
-		WriteByte(byte(flags)).
-		WriteBytes(a.aliasAddress.Bytes()).
+	extendedFlags := a.extendedFlags()
+	ret := marshalutil.New().WriteByte(byte(AliasOutputType))
+	if extendedFlags == 0 {
+		ret.WriteByte(byte(flags))
+	} else {
+		ret.WriteByte(byte(flags.ClearBit(flagAliasOutputDelegationConstraint).SetBit(flagAliasOutputDelegationTimelockPresent))).
+			WriteByte(byte(extendedFlags))
+	}
+	ret.WriteBytes(a.aliasAddress.Bytes()).
 		WriteBytes(a.balances.Bytes()).
 		WriteBytes(a.stateAddress.Bytes()).
 		WriteUint32(a.stateIndex)
@@ -1450,6 +1535,9 @@ func (a *AliasOutput) ObjectStorageValue() []byte {
 	if flags.HasBit(flagAliasOutputDelegationTimelockPresent) {
 		ret.WriteTime(a.delegationTimelock)
 	}
+	if extendedFlags.HasBit(flagAliasOutputTokenSupplyPresent) {
+		ret.WriteUint64(a.tokenSupply)
+	}
 	return ret.Bytes()
 }
 
@@ -1584,6 +1672,12 @@ func (a *AliasOutput) checkBasicValidity() error {
 	if !a.isDelegated && !a.delegationTimelock.IsZero() {
 		return errors.Errorf("aliasOutput: delegation timelock is present, but output is not delegated")
 	}
+	if !a.isSupplyControlled && a.tokenSupply != 0 {
+		return errors.New("aliasOutput: token supply is present, but output does not control its token supply")
+	}
+	if a.IsOrigin() && a.tokenSupply != 0 {
+		return errors.New("aliasOutput: origin must have tokenSupply == 0")
+	}
 	return nil
 }
 
@@ -1625,6 +1719,22 @@ func (a *AliasOutput) mustFlags() bitmask.BitMask {
 	return ret
 }
 
+// extendedFlags produces the extended flags for serialization.
+func (a *AliasOutput) extendedFlags() bitmask.BitMask {
+	var ret bitmask.BitMask
+	if !a.isSupplyControlled {
+		return ret
+	}
+	ret = ret.SetBit(flagAliasOutputTokenSupplyPresent)
+	if a.isDelegated {
+		ret = ret.SetBit(flagAliasOutputExtendedDelegationConstraint)
+	}
+	if !a.delegationTimelock.IsZero() {
+		ret = ret.SetBit(flagAliasOutputExtendedDelegationTimelockPresent)
+	}
+	return ret
+}
+
 // findChainedOutputAndCheckFork finds corresponding chained output.
 // If it is not unique, returns an error
 // If there's no such output, return nil and no error.
@@ -1713,6 +1823,10 @@ func (a *AliasOutput) validateTransition(chained *AliasOutput, tx *Transaction)
 		if !equalColoredBalances(a.balances, chained.balances) {
 			return errors.New("aliasOutput: tokens are not unlocked for modification")
 		}
+		// should not modify token supply
+		if a.isSupplyControlled != chained.isSupplyControlled || a.tokenSupply != chained.tokenSupply {
+			return errors.New("aliasOutput: token supply is not unlocked for modification")
+		}
 		// if delegation timelock is set and active, governance transition is invalid
 		// It means delegating party can't take funds back before timelock deadline
 		if a.IsDelegated() && a.DelegationTimeLockedNow(tx.Essence().Timestamp()) {
@@ -1749,6 +1863,11 @@ func (a *AliasOutput) validateTransition(chained *AliasOutput, tx *Transaction)
 		if a.IsDelegated() && !equalColoredBalances(a.balances, chained.balances) {
 			return errors.New("aliasOutput: delegated output funds can't be changed")
 		}
+		// can modify token supply (it is checked against the minted and burned tokens by TokenSuppliesValid)
+		// should not give up supply control
+		if a.isSupplyControlled && !chained.isSupplyControlled {
+			return errors.New("aliasOutput: supply control can't be removed")
+		}
 		// should not modify delegation status in state transition
 		if a.IsDelegated() != chained.IsDelegated() {
 			return errors.New("aliasOutput: delegation status can't be changed")
@@ -1777,6 +1896,9 @@ func (a *AliasOutput) validateDestroyTransitionNow(nowis time.Time) error {
 	if a.IsDelegated() && a.DelegationTimeLockedNow(nowis) {
 		return errors.New("aliasOutput: didn't find expected chained output for delegated output")
 	}
+	if a.tokenSupply != 0 {
+		return errors.Errorf("aliasOutput: can't destroy alias while %d tokens of its supply are in circulation", a.tokenSupply)
+	}
 	return nil
 }
 
@@ -1840,6 +1962,68 @@ var _ Output = &AliasOutput{}
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region HashLockType ////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// SHA256HashLock represents a hash lock that is satisfied by a preimage with the given SHA-256 digest.
+	SHA256HashLock HashLockType = iota + 1
+
+	// BLAKE2bHashLock represents a hash lock that is satisfied by a preimage with the given BLAKE2b-256 digest.
+	BLAKE2bHashLock
+)
+
+// HashLockSize defines the length of the digest a hash lock commits to.
+const HashLockSize = 32
+
+// MaxPreimageSize defines the maximum length of a preimage revealed in a PreimageUnlockBlock.
+const MaxPreimageSize = 64
+
+// HashLockType represents the hash function that is used to check the preimage of a hash lock.
+type HashLockType uint8
+
+// HashLockTypeFromString returns the HashLockType with the given name ("sha256" or "blake2b").
+func HashLockTypeFromString(name string) (HashLockType, error) {
+	switch strings.ToLower(name) {
+	case "sha256":
+		return SHA256HashLock, nil
+	case "blake2b":
+		return BLAKE2bHashLock, nil
+	default:
+		return 0, errors.Errorf("unsupported hash lock type %s", name)
+	}
+}
+
+// Valid returns true if the HashLockType is supported.
+func (h HashLockType) Valid() bool {
+	return h == SHA256HashLock || h == BLAKE2bHashLock
+}
+
+// Hash returns the digest of the preimage that is computed with the hash function of the HashLockType.
+func (h HashLockType) Hash(preimage []byte) (digest [HashLockSize]byte) {
+	switch h {
+	case SHA256HashLock:
+		return sha256.Sum256(preimage)
+	case BLAKE2bHashLock:
+		return blake2b.Sum256(preimage)
+	default:
+		panic(fmt.Sprintf("unsupported hash lock type %d", h))
+	}
+}
+
+// String returns a human readable representation of the HashLockType.
+func (h HashLockType) String() string {
+	switch h {
+	case SHA256HashLock:
+		return "sha256"
+	case BLAKE2bHashLock:
+		return "blake2b"
+	default:
+		return fmt.Sprintf("HashLockType(%d)", h)
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region ExtendedLockedOutput /////////////////////////////////////////////////////////////////////////////////////////////////
 
 // ExtendedLockedOutput is an Extension of SigLockedColoredOutput. If extended options not enabled,
@@ -1848,7 +2032,8 @@ var _ Output = &AliasOutput{}
 // - fallback address and fallback timeout
 // - can be unlocked by AliasUnlockBlock (if address is of AliasAddress type)
 // - can be time locked until deadline
-// - data payload for arbitrary metadata (size limits apply).
+// - data payload for arbitrary metadata (size limits apply)
+// - can be hash locked, so that the address has to reveal the preimage in a PreimageUnlockBlock (HTLC).
 type ExtendedLockedOutput struct {
 	id       OutputID
 	idMutex  sync.RWMutex
@@ -1866,6 +2051,10 @@ type ExtendedLockedOutput struct {
 	// any attached data (subject to size limits)
 	payload []byte
 
+	// Hash function and digest of the hash lock. If the type is 0, hash lock is not set
+	hashLockType HashLockType
+	hashLock     [HashLockSize]byte
+
 	objectstorage.StorableObjectFlags
 }
 
@@ -1873,6 +2062,7 @@ const (
 	flagExtendedLockedOutputFallbackPresent = uint(iota)
 	flagExtendedLockedOutputTimeLockPresent
 	flagExtendedLockedOutputPayloadPresent
+	flagExtendedLockedOutputHashLockPresent
 )
 
 // NewExtendedLockedOutput is the constructor for a ExtendedLockedOutput.
@@ -1900,6 +2090,18 @@ func (o *ExtendedLockedOutput) WithTimeLock(timelock time.Time) *ExtendedLockedO
 	return o
 }
 
+// WithHashLock adds a hash lock to the output and returns the updated version. A zero HashLockType removes the hash
+// lock.
+func (o *ExtendedLockedOutput) WithHashLock(hashLockType HashLockType, hashLock [HashLockSize]byte) *ExtendedLockedOutput {
+	if hashLockType == 0 {
+		o.hashLockType, o.hashLock = 0, [HashLockSize]byte{}
+		return o
+	}
+	o.hashLockType = hashLockType
+	o.hashLock = hashLock
+	return o
+}
+
 // SetPayload sets the payload field of the output.
 func (o *ExtendedLockedOutput) SetPayload(data []byte) error {
 	if len(data) > MaxOutputPayloadSize {
@@ -1978,6 +2180,23 @@ func ExtendedOutputFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (output
 			return
 		}
 	}
+	if flags.HasBit(flagExtendedLockedOutputHashLockPresent) {
+		var hashLockType byte
+		if hashLockType, err = marshalUtil.ReadByte(); err != nil {
+			err = errors.Errorf("failed to parse hash lock type (%v): %w", err, cerrors.ErrParseBytesFailed)
+			return
+		}
+		if output.hashLockType = HashLockType(hashLockType); !output.hashLockType.Valid() {
+			err = errors.Errorf("unsupported hash lock type (%X): %w", hashLockType, cerrors.ErrParseBytesFailed)
+			return
+		}
+		var hashLock []byte
+		if hashLock, err = marshalUtil.ReadBytes(HashLockSize); err != nil {
+			err = errors.Errorf("failed to parse hash lock (%v): %w", err, cerrors.ErrParseBytesFailed)
+			return
+		}
+		copy(output.hashLock[:], hashLock)
+	}
 	return output, nil
 }
 
@@ -1993,6 +2212,9 @@ func (o *ExtendedLockedOutput) compressFlags() bitmask.BitMask {
 	if len(o.payload) > 0 {
 		ret = ret.SetBit(flagExtendedLockedOutputPayloadPresent)
 	}
+	if o.hashLockType != 0 {
+		ret = ret.SetBit(flagExtendedLockedOutputHashLockPresent)
+	}
 	return ret
 }
 
@@ -2033,16 +2255,33 @@ func (o *ExtendedLockedOutput) UnlockValid(tx *Transaction, unlockBlock UnlockBl
 		return false, nil
 	}
 	addr := o.UnlockAddressNow(tx.Essence().Timestamp())
+	hashLocked := o.HashLockedNow(tx.Essence().Timestamp())
 
 	switch blk := unlockBlock.(type) {
 	case *SignatureUnlockBlock:
 		// unlocking by signature
+		if hashLocked {
+			return false, errors.New("extendedLockedOutput: hash locked output requires a preimage unlock block")
+		}
+		unlockValid = blk.AddressSignatureValid(addr, tx.Essence().Bytes())
+
+	case *PreimageUnlockBlock:
+		// unlocking by signature and the revealed preimage of the hash lock
+		if !hashLocked {
+			return false, errors.New("extendedLockedOutput: output is not hash locked for the current moment")
+		}
+		if !o.PreimageValid(blk.Preimage()) {
+			return false, errors.New("extendedLockedOutput: preimage does not match the hash lock")
+		}
 		unlockValid = blk.AddressSignatureValid(addr, tx.Essence().Bytes())
 
 	case *AliasUnlockBlock:
 		// unlocking by alias reference. The unlock is valid if:
 		// - referenced alias output has same alias address
 		// - it is not unlocked for governance
+		if hashLocked {
+			return false, errors.New("extendedLockedOutput: hash locked output can't be unlocked by alias reference")
+		}
 		if addr.Type() != AliasAddressType {
 			return false, errors.Errorf("extendedLockedOutput: %s address can't be unlocked by alias reference", addr.Type().String())
 		}
@@ -2103,6 +2342,7 @@ func (o *ExtendedLockedOutput) Clone() Output {
 		ret.payload = make([]byte, len(o.payload))
 		copy(ret.payload, o.payload)
 	}
+	ret.hashLockType, ret.hashLock = o.hashLockType, o.hashLock
 	return ret
 }
 
@@ -2116,7 +2356,8 @@ func (o *ExtendedLockedOutput) UpdateMintingColor() Output {
 	}
 	updatedOutput := NewExtendedLockedOutput(coloredBalances, o.Address()).
 		WithFallbackOptions(o.fallbackAddress, o.fallbackDeadline).
-		WithTimeLock(o.timelock)
+		WithTimeLock(o.timelock).
+		WithHashLock(o.hashLockType, o.hashLock)
 	if err := updatedOutput.SetPayload(o.payload); err != nil {
 		panic(errors.Errorf("UpdateMintingColor: %v", err))
 	}
@@ -2161,6 +2402,10 @@ func (o *ExtendedLockedOutput) ObjectStorageValue() []byte {
 		ret.WriteUint16(uint16(len(o.payload))).
 			WriteBytes(o.payload)
 	}
+	if flags.HasBit(flagExtendedLockedOutputHashLockPresent) {
+		ret.WriteByte(byte(o.hashLockType)).
+			WriteBytes(o.hashLock[:])
+	}
 	return ret.Bytes()
 }
 
@@ -2179,6 +2424,8 @@ func (o *ExtendedLockedOutput) String() string {
 		stringify.StructField("fallbackAddress", o.fallbackAddress),
 		stringify.StructField("fallbackDeadline", o.fallbackDeadline),
 		stringify.StructField("timelock", o.timelock),
+		stringify.StructField("hashLockType", o.hashLockType),
+		stringify.StructField("hashLock", base58.Encode(o.hashLock[:])),
 	)
 }
 
@@ -2213,6 +2460,28 @@ func (o *ExtendedLockedOutput) UnlockAddressNow(nowis time.Time) Address {
 	return o.address
 }
 
+// HashLock returns the hash lock of the output. The HashLockType is 0 if the output is not hash locked.
+func (o *ExtendedLockedOutput) HashLock() (HashLockType, [HashLockSize]byte) {
+	return o.hashLockType, o.hashLock
+}
+
+// HashLockedNow checks if a preimage is needed to unlock the output at the specific moment. The hash lock only binds
+// the address, so it does not apply anymore once the fallback address can unlock the output.
+func (o *ExtendedLockedOutput) HashLockedNow(nowis time.Time) bool {
+	if o.hashLockType == 0 {
+		return false
+	}
+	return o.fallbackAddress == nil || !nowis.After(o.fallbackDeadline)
+}
+
+// PreimageValid checks if the preimage satisfies the hash lock of the output.
+func (o *ExtendedLockedOutput) PreimageValid(preimage []byte) bool {
+	if o.hashLockType == 0 {
+		return false
+	}
+	return o.hashLockType.Hash(preimage) == o.hashLock
+}
+
 // code contract (make sure the type implements all required methods).
 var _ Output = &ExtendedLockedOutput{}
 
@@ -2494,6 +2763,19 @@ func (o *OutputMetadata) RegisterConsumer(consumer TransactionID) (previousConsu
 	return
 }
 
+// UnregisterConsumer decreases the consumer count of an Output after the Consumer of an unknown Transaction was removed.
+func (o *OutputMetadata) UnregisterConsumer() {
+	o.consumerMutex.Lock()
+	defer o.consumerMutex.Unlock()
+
+	if o.consumerCount == 0 {
+		return
+	}
+
+	o.consumerCount--
+	o.SetModified()
+}
+
 // GradeOfFinality returns the grade of finality.
 func (o *OutputMetadata) GradeOfFinality() gof.GradeOfFinality {
 	o.gradeOfFinalityMutex.RLock()
@@ -3,6 +3,7 @@ package ledgerstate
 import (
 	"bytes"
 	"encoding/binary"
+	"encoding/hex"
 	"math/rand"
 	"sync"
 	"testing"
@@ -1650,6 +1651,89 @@ func TestAliasOutput_Clone(t *testing.T) {
 	assert.EqualValues(t, out.Bytes(), outBack.Bytes())
 }
 
+func TestAliasOutput_TokenSupply(t *testing.T) {
+	t.Run("CASE: Serialization", func(t *testing.T) {
+		out := dummyAliasOutput().WithSupplyControl()
+		require.NoError(t, out.SetTokenSupply(1337))
+		outBack, err := AliasOutputFromMarshalUtil(marshalutil.New(out.Bytes()))
+		require.NoError(t, err)
+		assert.True(t, outBack.IsSupplyControlled())
+		assert.Equal(t, uint64(1337), outBack.TokenSupply())
+		assert.Equal(t, out.TokenColor(), outBack.TokenColor())
+		assert.EqualValues(t, out.Bytes(), outBack.Bytes())
+	})
+
+	t.Run("CASE: Serialization of delegated output", func(t *testing.T) {
+		out := dummyAliasOutput().WithDelegationAndTimelock(time.Unix(1600000000, 0)).WithSupplyControl()
+		require.NoError(t, out.SetTokenSupply(42))
+		outBack, err := AliasOutputFromMarshalUtil(marshalutil.New(out.Bytes()))
+		require.NoError(t, err)
+		assert.True(t, outBack.IsDelegated())
+		assert.True(t, outBack.DelegationTimelock().Equal(out.DelegationTimelock()))
+		assert.Equal(t, uint64(42), outBack.TokenSupply())
+		assert.EqualValues(t, out.Bytes(), outBack.Bytes())
+	})
+
+	t.Run("CASE: Outputs without supply control keep the original encoding", func(t *testing.T) {
+		// alias outputs encoded before supply control was added
+		for _, encoded := range []string{
+			"021e021f31ddace2841d9f79ede96e372edcb568d0dbdb23c5baf5e8fa2d6e4dc7d26b01000000000000000000000000000000000000" +
+				"0000000000000000000000000000000000e80300000000000000d487326614f066416308bf6aa4e5041d1949928e4b26ede98e3cebb3" +
+				"6a3b172605000000050073746174650a00676f7665726e616e63650900696d6d757461626c6500c1b015945a4aabe78a183640eeb6" +
+				"33897aa7b105757ed17012de04f23b653f76",
+			"02de021f31ddace2841d9f79ede96e372edcb568d0dbdb23c5baf5e8fa2d6e4dc7d26b01000000000000000000000000000000000000" +
+				"0000000000000000000000000000000000e80300000000000000d487326614f066416308bf6aa4e5041d1949928e4b26ede98e3cebb3" +
+				"6a3b172605000000050073746174650a00676f7665726e616e63650900696d6d757461626c6500c1b015945a4aabe78a183640eeb6" +
+				"33897aa7b105757ed17012de04f23b653f760000a0d885573416",
+		} {
+			outputBytes, err := hex.DecodeString(encoded)
+			require.NoError(t, err)
+			marshalUtil := marshalutil.New(outputBytes)
+			out, err := AliasOutputFromMarshalUtil(marshalUtil)
+			require.NoError(t, err)
+			assert.Equal(t, len(outputBytes), marshalUtil.ReadOffset())
+			assert.False(t, out.IsSupplyControlled())
+			assert.Equal(t, uint32(5), out.GetStateIndex())
+			assert.Equal(t, []byte("state"), out.GetStateData())
+			assert.Equal(t, []byte("governance"), out.GetGovernanceMetadata())
+			assert.Equal(t, []byte("immutable"), out.GetImmutableData())
+			assert.True(t, out.GetAliasAddress().Equals(NewAliasAddress([]byte("alias"))))
+			assert.EqualValues(t, outputBytes, out.Bytes())
+		}
+	})
+
+	t.Run("CASE: Supply without supply control", func(t *testing.T) {
+		out := dummyAliasOutput()
+		assert.Error(t, out.SetTokenSupply(1))
+	})
+
+	t.Run("CASE: Origin with supply", func(t *testing.T) {
+		out := dummyAliasOutput(true).WithSupplyControl()
+		out.tokenSupply = 1
+		assert.Error(t, out.checkBasicValidity())
+	})
+
+	t.Run("CASE: Supply can't be changed by governance update", func(t *testing.T) {
+		prev := dummyAliasOutput().WithSupplyControl()
+		next := prev.NewAliasOutputNext(true)
+		require.NoError(t, next.SetTokenSupply(1))
+		assert.Error(t, prev.validateTransition(next, &Transaction{}))
+	})
+
+	t.Run("CASE: Supply control can't be removed", func(t *testing.T) {
+		prev := dummyAliasOutput().WithSupplyControl()
+		next := prev.NewAliasOutputNext(false)
+		next.SetIsSupplyControlled(false)
+		assert.Error(t, prev.validateTransition(next, &Transaction{}))
+	})
+
+	t.Run("CASE: Can't destroy alias with circulating supply", func(t *testing.T) {
+		out := dummyAliasOutput().WithSupplyControl()
+		require.NoError(t, out.SetTokenSupply(1))
+		assert.Error(t, out.validateDestroyTransitionNow(time.Time{}))
+	})
+}
+
 // endregion
 
 // region ExtendedLockedOutput Tests
@@ -2325,6 +2409,101 @@ func TestExtendedLockedOutput_UnlockValid(t *testing.T) {
 	})
 }
 
+func TestExtendedLockedOutput_HashLock(t *testing.T) {
+	preimage := []byte("secret")
+
+	for _, hashLockType := range []HashLockType{SHA256HashLock, BLAKE2bHashLock} {
+		output := NewExtendedLockedOutput(map[Color]uint64{ColorIOTA: 1}, randEd25119Address()).
+			WithFallbackOptions(randEd25119Address(), time.Now().Add(time.Hour)).
+			WithHashLock(hashLockType, hashLockType.Hash(preimage))
+		output.SetID(randOutputID())
+
+		restored, _, err := OutputFromBytes(output.Bytes())
+		require.NoError(t, err)
+		restoredType, restoredLock := restored.(*ExtendedLockedOutput).HashLock()
+		assert.Equal(t, hashLockType, restoredType)
+		assert.Equal(t, hashLockType.Hash(preimage), restoredLock)
+
+		assert.True(t, output.PreimageValid(preimage))
+		assert.False(t, output.PreimageValid([]byte("wrong")))
+		assert.Equal(t, output.Bytes(), output.Clone().Bytes())
+		assert.Equal(t, output.Bytes(), output.UpdateMintingColor().Bytes())
+	}
+}
+
+func TestExtendedLockedOutput_UnlockValidHashLock(t *testing.T) {
+	preimage := []byte("secret")
+	receiver, sender := genRandomWallet(), genRandomWallet()
+	deadline := time.Now().Add(time.Hour)
+	htlc := NewExtendedLockedOutput(map[Color]uint64{ColorIOTA: 1}, receiver.address).
+		WithFallbackOptions(sender.address, deadline).
+		WithHashLock(SHA256HashLock, SHA256HashLock.Hash(preimage))
+	htlc.SetID(randOutputID())
+
+	unlock := func(timestamp time.Time, unlockBlock func(essence *TransactionEssence) UnlockBlock) (bool, error) {
+		output := NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{ColorIOTA: 1}), randEd25119Address())
+		essence := NewTransactionEssence(0, timestamp, identity.ID{}, identity.ID{}, NewInputs(htlc.Input()), NewOutputs(output))
+		blk := unlockBlock(essence)
+		return htlc.UnlockValid(NewTransaction(essence, UnlockBlocks{blk}), blk, Outputs{htlc})
+	}
+
+	t.Run("CASE: Claim with preimage", func(t *testing.T) {
+		valid, err := unlock(time.Now(), func(essence *TransactionEssence) UnlockBlock {
+			return NewPreimageUnlockBlock(receiver.sign(essence), preimage)
+		})
+		assert.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("CASE: Claim with wrong preimage", func(t *testing.T) {
+		valid, err := unlock(time.Now(), func(essence *TransactionEssence) UnlockBlock {
+			return NewPreimageUnlockBlock(receiver.sign(essence), []byte("wrong"))
+		})
+		assert.Error(t, err)
+		assert.False(t, valid)
+	})
+
+	t.Run("CASE: Claim without preimage", func(t *testing.T) {
+		valid, err := unlock(time.Now(), func(essence *TransactionEssence) UnlockBlock {
+			return NewSignatureUnlockBlock(receiver.sign(essence))
+		})
+		assert.Error(t, err)
+		assert.False(t, valid)
+	})
+
+	t.Run("CASE: Claim with preimage signed by wrong address", func(t *testing.T) {
+		valid, err := unlock(time.Now(), func(essence *TransactionEssence) UnlockBlock {
+			return NewPreimageUnlockBlock(sender.sign(essence), preimage)
+		})
+		assert.NoError(t, err)
+		assert.False(t, valid)
+	})
+
+	t.Run("CASE: Claim after deadline", func(t *testing.T) {
+		valid, err := unlock(deadline.Add(time.Second), func(essence *TransactionEssence) UnlockBlock {
+			return NewPreimageUnlockBlock(receiver.sign(essence), preimage)
+		})
+		assert.Error(t, err)
+		assert.False(t, valid)
+	})
+
+	t.Run("CASE: Refund after deadline", func(t *testing.T) {
+		valid, err := unlock(deadline.Add(time.Second), func(essence *TransactionEssence) UnlockBlock {
+			return NewSignatureUnlockBlock(sender.sign(essence))
+		})
+		assert.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("CASE: Refund before deadline", func(t *testing.T) {
+		valid, err := unlock(time.Now(), func(essence *TransactionEssence) UnlockBlock {
+			return NewSignatureUnlockBlock(sender.sign(essence))
+		})
+		assert.Error(t, err)
+		assert.False(t, valid)
+	})
+}
+
 func TestExtendedLockedOutput_Clone(t *testing.T) {
 	out := dummyExtendedLockedOutput()
 	outBack := out.Clone()
@@ -257,7 +257,7 @@ func TransactionFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (transacti
 	maxReferencedUnlockIndex := len(transaction.essence.Inputs()) - 1
 	for i, unlockBlock := range transaction.unlockBlocks {
 		switch unlockBlock.Type() {
-		case SignatureUnlockBlockType:
+		case SignatureUnlockBlockType, PreimageUnlockBlockType:
 			continue
 		case ReferenceUnlockBlockType:
 			if unlockBlock.(*ReferenceUnlockBlock).ReferencedIndex() > uint16(maxReferencedUnlockIndex) {
@@ -353,6 +353,29 @@ func (t *Transaction) UnlockBlocks() UnlockBlocks {
 	return t.unlockBlocks
 }
 
+// RevealedPreimage returns the preimage that the Transaction reveals in the PreimageUnlockBlock of the Input that
+// references the given Output.
+func (t *Transaction) RevealedPreimage(outputID OutputID) (preimage []byte, revealed bool) {
+	for i, input := range t.essence.Inputs() {
+		utxoInput, isUTXOInput := input.(*UTXOInput)
+		if !isUTXOInput || utxoInput.ReferencedOutputID() != outputID {
+			continue
+		}
+
+		unlockBlock := t.unlockBlocks[i]
+		if referenceUnlockBlock, isReference := unlockBlock.(*ReferenceUnlockBlock); isReference {
+			unlockBlock = t.unlockBlocks[referenceUnlockBlock.ReferencedIndex()]
+		}
+		preimageUnlockBlock, isPreimage := unlockBlock.(*PreimageUnlockBlock)
+		if !isPreimage {
+			return nil, false
+		}
+		return preimageUnlockBlock.Preimage(), true
+	}
+
+	return nil, false
+}
+
 // ReferencedTransactionIDs returns a set of TransactionIDs whose Outputs were used as Inputs in this Transaction.
 func (t *Transaction) ReferencedTransactionIDs() (referencedTransactionIDs TransactionIDs) {
 	referencedTransactionIDs = make(TransactionIDs)
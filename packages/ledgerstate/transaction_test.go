@@ -171,3 +171,44 @@ func signTransaction(transaction *Transaction, unspentOutputsDB OutputsByID, key
 		}
 	}
 }
+
+func TestTransaction_RevealedPreimage(t *testing.T) {
+	w := genRandomWallet()
+	preimage := []byte("secret")
+	htlcs := make([]*ExtendedLockedOutput, 2)
+	for i := range htlcs {
+		htlcs[i] = NewExtendedLockedOutput(map[Color]uint64{ColorIOTA: 1}, w.address).
+			WithHashLock(BLAKE2bHashLock, BLAKE2bHashLock.Hash(preimage))
+		htlcs[i].SetID(randOutputID())
+	}
+	other := NewSigLockedSingleOutput(1, w.address)
+	other.SetID(randOutputID())
+
+	output := NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{ColorIOTA: 3}), randEd25119Address())
+	essence := NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{}, NewInputs(htlcs[0].Input(), htlcs[1].Input(), other.Input()), NewOutputs(output))
+	unlockBlocks := make(UnlockBlocks, len(essence.Inputs()))
+	preimageIndex := -1
+	for i, input := range essence.Inputs() {
+		if input.(*UTXOInput).ReferencedOutputID() == other.ID() {
+			unlockBlocks[i] = NewSignatureUnlockBlock(w.sign(essence))
+			continue
+		}
+		if preimageIndex == -1 {
+			preimageIndex = i
+			unlockBlocks[i] = NewPreimageUnlockBlock(w.sign(essence), preimage)
+			continue
+		}
+		unlockBlocks[i] = NewReferenceUnlockBlock(uint16(preimageIndex))
+	}
+	tx := NewTransaction(essence, unlockBlocks)
+
+	for _, htlc := range htlcs {
+		revealedPreimage, revealed := tx.RevealedPreimage(htlc.ID())
+		assert.True(t, revealed)
+		assert.Equal(t, preimage, revealedPreimage)
+	}
+	_, revealed := tx.RevealedPreimage(other.ID())
+	assert.False(t, revealed)
+	_, revealed = tx.RevealedPreimage(randOutputID())
+	assert.False(t, revealed)
+}
@@ -22,6 +22,9 @@ const (
 
 	// AliasUnlockBlockType represents the type of a AliasUnlockBlock.
 	AliasUnlockBlockType
+
+	// PreimageUnlockBlockType represents the type of a PreimageUnlockBlock.
+	PreimageUnlockBlockType
 )
 
 // UnlockBlockType represents the type of the UnlockBlock. Different types of UnlockBlocks can unlock different types of
@@ -34,6 +37,7 @@ func (a UnlockBlockType) String() string {
 		"SignatureUnlockBlockType",
 		"ReferenceUnlockBlockType",
 		"AliasUnlockBlockType",
+		"PreimageUnlockBlockType",
 	}[a]
 }
 
@@ -91,6 +95,11 @@ func UnlockBlockFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (unlockBlo
 			err = errors.Errorf("failed to parse AliasUnlockBlock from MarshalUtil: %w", err)
 			return
 		}
+	case PreimageUnlockBlockType:
+		if unlockBlock, err = PreimageUnlockBlockFromMarshalUtil(marshalUtil); err != nil {
+			err = errors.Errorf("failed to parse PreimageUnlockBlock from MarshalUtil: %w", err)
+			return
+		}
 
 	default:
 		err = errors.Errorf("unsupported UnlockBlockType (%X): %w", unlockBlockType, cerrors.ErrParseBytesFailed)
@@ -409,3 +418,108 @@ func (r *AliasUnlockBlock) String() string {
 var _ UnlockBlock = &AliasUnlockBlock{}
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region PreimageUnlockBlock //////////////////////////////////////////////////////////////////////////////////////////
+
+// PreimageUnlockBlock represents an UnlockBlock that contains a Signature for an Address together with the preimage
+// that satisfies the hash lock of an ExtendedLockedOutput. Once the transaction is published, the preimage is public.
+type PreimageUnlockBlock struct {
+	signature Signature
+	preimage  []byte
+}
+
+// NewPreimageUnlockBlock is the constructor for PreimageUnlockBlock objects.
+func NewPreimageUnlockBlock(signature Signature, preimage []byte) *PreimageUnlockBlock {
+	return &PreimageUnlockBlock{
+		signature: signature,
+		preimage:  byteutils.ConcatBytes(preimage),
+	}
+}
+
+// PreimageUnlockBlockFromBytes unmarshals a PreimageUnlockBlock from a sequence of bytes.
+func PreimageUnlockBlockFromBytes(bytes []byte) (unlockBlock *PreimageUnlockBlock, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if unlockBlock, err = PreimageUnlockBlockFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse PreimageUnlockBlock from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// PreimageUnlockBlockFromMarshalUtil unmarshals a PreimageUnlockBlock using a MarshalUtil (for easier unmarshaling).
+func PreimageUnlockBlockFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (unlockBlock *PreimageUnlockBlock, err error) {
+	unlockBlockType, err := marshalUtil.ReadByte()
+	if err != nil {
+		err = errors.Errorf("failed to parse UnlockBlockType (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if UnlockBlockType(unlockBlockType) != PreimageUnlockBlockType {
+		err = errors.Errorf("invalid UnlockBlockType (%X): %w", unlockBlockType, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	unlockBlock = &PreimageUnlockBlock{}
+	if unlockBlock.signature, err = SignatureFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse Signature from MarshalUtil: %w", err)
+		return
+	}
+	preimageSize, err := marshalUtil.ReadUint16()
+	if err != nil {
+		err = errors.Errorf("failed to parse preimage size (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if preimageSize == 0 || preimageSize > MaxPreimageSize {
+		err = errors.Errorf("preimage size (%d bytes) must be between 1 and %d bytes: %w", preimageSize, MaxPreimageSize, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if unlockBlock.preimage, err = marshalUtil.ReadBytes(int(preimageSize)); err != nil {
+		err = errors.Errorf("failed to parse preimage (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	return
+}
+
+// AddressSignatureValid returns true if the UnlockBlock correctly signs the given Address.
+func (p *PreimageUnlockBlock) AddressSignatureValid(address Address, signedData []byte) bool {
+	return p.signature.AddressSignatureValid(address, signedData)
+}
+
+// Signature return the signature itself.
+func (p *PreimageUnlockBlock) Signature() Signature {
+	return p.signature
+}
+
+// Preimage returns the revealed preimage.
+func (p *PreimageUnlockBlock) Preimage() []byte {
+	return p.preimage
+}
+
+// Type returns the UnlockBlockType of the UnlockBlock.
+func (p *PreimageUnlockBlock) Type() UnlockBlockType {
+	return PreimageUnlockBlockType
+}
+
+// Bytes returns a marshaled version of the UnlockBlock.
+func (p *PreimageUnlockBlock) Bytes() []byte {
+	return marshalutil.New().
+		WriteByte(byte(PreimageUnlockBlockType)).
+		WriteBytes(p.signature.Bytes()).
+		WriteUint16(uint16(len(p.preimage))).
+		WriteBytes(p.preimage).
+		Bytes()
+}
+
+// String returns a human readable version of the UnlockBlock.
+func (p *PreimageUnlockBlock) String() string {
+	return stringify.Struct("PreimageUnlockBlock",
+		stringify.StructField("signature", p.signature),
+		stringify.StructField("preimage", p.preimage),
+	)
+}
+
+// code contract (make sure the type implements all required methods).
+var _ UnlockBlock = &PreimageUnlockBlock{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -34,3 +34,23 @@ func TestUnlockBlockFromMarshalUtil(t *testing.T) {
 		assert.Error(t, err)
 	}
 }
+
+func TestPreimageUnlockBlockFromMarshalUtil(t *testing.T) {
+	keyPair := ed25519.GenerateKeyPair()
+	signature := NewED25519Signature(keyPair.PublicKey, keyPair.PrivateKey.Sign([]byte("testdata")))
+
+	unlockBlocks := UnlockBlocks{
+		NewPreimageUnlockBlock(signature, []byte("secret")),
+		NewReferenceUnlockBlock(0),
+	}
+	marshaledUnlockBlocks := unlockBlocks.Bytes()
+	parsedUnlockBlocks, consumedBytes, err := UnlockBlocksFromBytes(marshaledUnlockBlocks)
+	assert.NoError(t, err)
+	assert.Equal(t, len(marshaledUnlockBlocks), consumedBytes)
+	assert.Equal(t, unlockBlocks, parsedUnlockBlocks)
+	assert.Equal(t, []byte("secret"), parsedUnlockBlocks[0].(*PreimageUnlockBlock).Preimage())
+
+	// preimages exceeding the maximum size are rejected
+	_, _, err = PreimageUnlockBlockFromBytes(NewPreimageUnlockBlock(signature, make([]byte, MaxPreimageSize+1)).Bytes())
+	assert.Error(t, err)
+}
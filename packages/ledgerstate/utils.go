@@ -22,6 +22,9 @@ func TransactionBalancesValid(inputs Outputs, outputs Outputs) (valid bool) {
 		}
 	}
 
+	// tokens of supply-controlled colors can be minted like ColorMint (TokenSuppliesValid checks the supply)
+	mintableColors := supplyControlledAliases(outputs)
+
 	recoloredCoins := uint64(0)
 	for _, output := range outputs {
 		output.Balances().ForEach(func(color Color, balance uint64) bool {
@@ -29,6 +32,11 @@ func TransactionBalancesValid(inputs Outputs, outputs Outputs) (valid bool) {
 			case ColorIOTA, ColorMint:
 				recoloredCoins, valid = SafeAddUint64(recoloredCoins, balance)
 			default:
+				if _, mintable := mintableColors[color]; mintable && consumedCoins[color] < balance {
+					recoloredCoins, valid = SafeAddUint64(recoloredCoins, balance-consumedCoins[color])
+					consumedCoins[color] = 0
+					break
+				}
 				consumedCoins[color], valid = SafeSubUint64(consumedCoins[color], balance)
 			}
 
@@ -50,6 +58,94 @@ func TransactionBalancesValid(inputs Outputs, outputs Outputs) (valid bool) {
 	return unspentCoins == recoloredCoins
 }
 
+// TokenSuppliesValid is an internal utility function that checks if tokens of supply-controlled colors are only minted
+// or burned by a state transition of their controlling AliasOutput and if the circulating supply that is tracked in the
+// chained AliasOutput changes by exactly the amount of minted or burned tokens. The controller callback returns the
+// AliasAddress that controls the given Color or nil if the Color is not supply-controlled.
+func TokenSuppliesValid(inputs Outputs, transaction *Transaction, controller func(color Color) *AliasAddress) (err error) {
+	consumedCoins := make(map[Color]uint64)
+	for _, input := range inputs {
+		input.Balances().ForEach(func(color Color, balance uint64) bool {
+			consumedCoins[color] += balance
+			return true
+		})
+	}
+	createdCoins := make(map[Color]uint64)
+	for _, output := range transaction.Essence().Outputs() {
+		output.Balances().ForEach(func(color Color, balance uint64) bool {
+			createdCoins[color] += balance
+			return true
+		})
+	}
+
+	// check the supply transitions of the aliases that are chained in the transaction
+	checkedColors := make(map[Color]types.Empty)
+	for _, input := range inputs {
+		alias, ok := input.(*AliasOutput)
+		if !ok {
+			continue
+		}
+		chained, chainedErr := alias.findChainedOutputAndCheckFork(transaction)
+		if chainedErr != nil {
+			return chainedErr
+		}
+		if chained == nil || !chained.IsSupplyControlled() {
+			continue
+		}
+
+		tokenColor := alias.TokenColor()
+		switch consumed, created := consumedCoins[tokenColor], createdCoins[tokenColor]; {
+		case created >= consumed:
+			minted := created - consumed
+			if supply, valid := SafeAddUint64(alias.TokenSupply(), minted); !valid || chained.TokenSupply() != supply {
+				return errors.Errorf("token supply of alias %s does not match after minting %d tokens", alias.GetAliasAddress().Base58(), minted)
+			}
+		default:
+			if burned := consumed - created; burned > alias.TokenSupply() || chained.TokenSupply() != alias.TokenSupply()-burned {
+				return errors.Errorf("token supply of alias %s does not match after burning %d tokens", alias.GetAliasAddress().Base58(), burned)
+			}
+		}
+		checkedColors[tokenColor] = types.Void
+	}
+
+	// every other change of a supply-controlled color is invalid
+	mintableColors := supplyControlledAliases(transaction.Essence().Outputs())
+	for color := range consumedCoins {
+		if _, exists := createdCoins[color]; !exists {
+			createdCoins[color] = 0
+		}
+	}
+	for color, created := range createdCoins {
+		if color == ColorIOTA || color == ColorMint || created == consumedCoins[color] {
+			continue
+		}
+		if _, checked := checkedColors[color]; checked {
+			continue
+		}
+		if _, mintable := mintableColors[color]; mintable {
+			return errors.Errorf("tokens of color %s can only be minted by a state transition of their alias", color)
+		}
+		if aliasAddress := controller(color); aliasAddress != nil {
+			return errors.Errorf("supply of color %s can only be changed by a state transition of alias %s", color, aliasAddress.Base58())
+		}
+	}
+
+	return nil
+}
+
+// supplyControlledAliases is an internal utility function that returns the (non-origin) AliasOutputs that control the
+// supply of their token color indexed by that color.
+func supplyControlledAliases(outputs Outputs) (aliases map[Color]*AliasOutput) {
+	aliases = make(map[Color]*AliasOutput)
+	for _, output := range outputs {
+		if alias, ok := output.(*AliasOutput); ok && alias.IsSupplyControlled() && !alias.IsOrigin() {
+			aliases[alias.TokenColor()] = alias
+		}
+	}
+
+	return aliases
+}
+
 // UnlockBlocksValid is an internal utility function that checks if the UnlockBlocks are matching the referenced Inputs.
 func UnlockBlocksValid(inputs Outputs, transaction *Transaction) (valid bool) {
 	unlockValid, unlockErr := UnlockBlocksValidWithError(inputs, transaction)
@@ -199,8 +295,8 @@ func NewUnlockGraph(blocks UnlockBlocks) (*UnlockGraph, error) {
 	for i, block := range blocks {
 		g.Vertices[i] = uint16(i)
 		switch block.Type() {
-		case SignatureUnlockBlockType:
-			// no adjacent vertex as a SignatureUnlockBlockType can't reference an other one
+		case SignatureUnlockBlockType, PreimageUnlockBlockType:
+			// no adjacent vertex as a SignatureUnlockBlockType or PreimageUnlockBlockType can't reference an other one
 		case ReferenceUnlockBlockType:
 			// a reference unlock block can not point to another reference unlock block
 			refIndex := block.(*ReferenceUnlockBlock).ReferencedIndex()
@@ -1,10 +1,15 @@
 package ledgerstate
 
 import (
+	"bytes"
 	"container/list"
+	"encoding/binary"
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/byteutils"
@@ -54,6 +59,10 @@ type IUTXODAG interface {
 	LoadSnapshot(snapshot *Snapshot)
 	// CachedAddressOutputMapping retrieves the outputs for the given address.
 	CachedAddressOutputMapping(address Address) (cachedAddressOutputMappings CachedAddressOutputMappings)
+	// AddressOutputIDs returns a page of the OutputIDs of the outputs on the given address in a stable order.
+	AddressOutputIDs(address Address, cursor *OutputID, limit int) (outputIDs []OutputID)
+	// ForEachAddressOutputID passes the OutputIDs of the outputs on the given address to the consumer in a stable order.
+	ForEachAddressOutputID(address Address, consumer func(outputID OutputID) bool)
 	// ConsumedOutputs returns the consumed (cached)Outputs of the given Transaction.
 	ConsumedOutputs(transaction *Transaction) (cachedInputs CachedOutputs)
 	// ManageStoreAddressOutputMapping mangages how to store the address-output mapping dependent on which type of output it is.
@@ -66,6 +75,15 @@ type IUTXODAG interface {
 	BranchGradeOfFinality(branchID BranchID) (gradeOfFinality gof.GradeOfFinality, err error)
 	// ConflictingTransactions returns the TransactionIDs that are conflicting with the given Transaction.
 	ConflictingTransactions(transaction *Transaction) (conflictingTransactions TransactionIDs)
+	// SupplyController returns the AliasAddress that controls the supply of the given Color (nil if there is none).
+	SupplyController(color Color) (aliasAddress *AliasAddress)
+	// AliasHistory returns a page of the AliasHistoryEntries of the chain of the given AliasAddress within the given state
+	// index range and the state index at which the next page starts.
+	AliasHistory(aliasAddress *AliasAddress, fromStateIndex, toStateIndex uint32, limit int) (aliasHistory []*AliasHistoryEntry, nextStateIndex uint32)
+	// CheckConsistency checks the invariants of the UTXODAG and optionally deletes the inconsistent entries.
+	CheckConsistency(report *database.ConsistencyReport, repair bool)
+	// RollbackTransaction removes a booked Transaction whose Outputs are unspent from the ledger state.
+	RollbackTransaction(transactionID TransactionID) (rolledBack bool)
 }
 
 // UTXODAG represents the DAG that is formed by Transactions consuming Inputs and creating Outputs. It forms the core of
@@ -73,14 +91,17 @@ type IUTXODAG interface {
 type UTXODAG struct {
 	events *UTXODAGEvents
 
-	transactionStorage          *objectstorage.ObjectStorage
-	transactionMetadataStorage  *objectstorage.ObjectStorage
-	outputStorage               *objectstorage.ObjectStorage
-	outputMetadataStorage       *objectstorage.ObjectStorage
-	consumerStorage             *objectstorage.ObjectStorage
-	addressOutputMappingStorage *objectstorage.ObjectStorage
-	branchDAG                   *BranchDAG
-	shutdownOnce                sync.Once
+	transactionStorage           *objectstorage.ObjectStorage
+	transactionMetadataStorage   *objectstorage.ObjectStorage
+	outputStorage                *objectstorage.ObjectStorage
+	outputMetadataStorage        *objectstorage.ObjectStorage
+	consumerStorage              *objectstorage.ObjectStorage
+	addressOutputMappingStorage  *objectstorage.ObjectStorage
+	supplyControlledColorStorage *objectstorage.ObjectStorage
+	aliasHistoryStorage          *objectstorage.ObjectStorage
+	aliasHistoryStartStorage     *objectstorage.ObjectStorage
+	branchDAG                    *BranchDAG
+	shutdownOnce                 sync.Once
 }
 
 // NewUTXODAG create a new UTXODAG from the given details.
@@ -91,13 +112,16 @@ func NewUTXODAG(store kvstore.KVStore, cacheProvider *database.CacheTimeProvider
 		events: &UTXODAGEvents{
 			TransactionBranchIDUpdatedByFork: events.NewEvent(TransactionBranchIDUpdatedByForkEventHandler),
 		},
-		transactionStorage:          osFactory.New(PrefixTransactionStorage, TransactionFromObjectStorage, options.transactionStorageOptions...),
-		transactionMetadataStorage:  osFactory.New(PrefixTransactionMetadataStorage, TransactionMetadataFromObjectStorage, options.transactionMetadataStorageOptions...),
-		outputStorage:               osFactory.New(PrefixOutputStorage, OutputFromObjectStorage, options.outputStorageOptions...),
-		outputMetadataStorage:       osFactory.New(PrefixOutputMetadataStorage, OutputMetadataFromObjectStorage, options.outputMetadataStorageOptions...),
-		consumerStorage:             osFactory.New(PrefixConsumerStorage, ConsumerFromObjectStorage, options.consumerStorageOptions...),
-		addressOutputMappingStorage: osFactory.New(PrefixAddressOutputMappingStorage, AddressOutputMappingFromObjectStorage, options.addressOutputMappingStorageOptions...),
-		branchDAG:                   branchDAG,
+		transactionStorage:           osFactory.New(PrefixTransactionStorage, TransactionFromObjectStorage, options.transactionStorageOptions...),
+		transactionMetadataStorage:   osFactory.New(PrefixTransactionMetadataStorage, TransactionMetadataFromObjectStorage, options.transactionMetadataStorageOptions...),
+		outputStorage:                osFactory.New(PrefixOutputStorage, OutputFromObjectStorage, options.outputStorageOptions...),
+		outputMetadataStorage:        osFactory.New(PrefixOutputMetadataStorage, OutputMetadataFromObjectStorage, options.outputMetadataStorageOptions...),
+		consumerStorage:              osFactory.New(PrefixConsumerStorage, ConsumerFromObjectStorage, options.consumerStorageOptions...),
+		addressOutputMappingStorage:  osFactory.New(PrefixAddressOutputMappingStorage, AddressOutputMappingFromObjectStorage, options.addressOutputMappingStorageOptions...),
+		supplyControlledColorStorage: osFactory.New(PrefixSupplyControlledColorStorage, SupplyControlledColorFromObjectStorage, options.supplyControlledColorStorageOptions...),
+		aliasHistoryStorage:          osFactory.New(PrefixAliasHistoryStorage, AliasHistoryEntryFromObjectStorage, options.aliasHistoryStorageOptions...),
+		aliasHistoryStartStorage:     osFactory.New(PrefixAliasHistoryStartStorage, AliasHistoryStartFromObjectStorage, options.aliasHistoryStartStorageOptions...),
+		branchDAG:                    branchDAG,
 	}
 	return
 }
@@ -116,6 +140,9 @@ func (u *UTXODAG) Shutdown() {
 		u.outputMetadataStorage.Shutdown()
 		u.consumerStorage.Shutdown()
 		u.addressOutputMappingStorage.Shutdown()
+		u.supplyControlledColorStorage.Shutdown()
+		u.aliasHistoryStorage.Shutdown()
+		u.aliasHistoryStartStorage.Shutdown()
 	})
 }
 
@@ -138,6 +165,9 @@ func (u *UTXODAG) CheckTransaction(transaction *Transaction) (err error) {
 	if !AliasInitialStateValid(consumedOutputs, transaction) {
 		return errors.Errorf("initial state of created alias output is invalid: %w", ErrTransactionInvalid)
 	}
+	if supplyErr := TokenSuppliesValid(consumedOutputs, transaction, u.SupplyController); supplyErr != nil {
+		return errors.Errorf("token supply is invalid (%v): %w", supplyErr, ErrTransactionInvalid)
+	}
 
 	return nil
 }
@@ -217,6 +247,52 @@ func (u *UTXODAG) BookTransaction(transaction *Transaction) (targetBranch Branch
 	return
 }
 
+// RollbackTransaction removes a booked Transaction from the ledger state by deleting its Consumers, its Outputs and
+// their address mappings. The consumer counts of the consumed Outputs are decreased accordingly. Transactions whose
+// Outputs are consumed can't be rolled back, so false is returned for them. ConflictBranches that were created for the
+// Transaction are kept, as they are part of the conflict sets of the other consumers.
+func (u *UTXODAG) RollbackTransaction(transactionID TransactionID) (rolledBack bool) {
+	createdOutputIDs := u.createdOutputIDsOfTransaction(transactionID)
+	for _, outputID := range createdOutputIDs {
+		cachedConsumers := u.CachedConsumers(outputID)
+		consumed := len(cachedConsumers) != 0
+		cachedConsumers.Release()
+		if consumed {
+			return false
+		}
+	}
+
+	for _, inputID := range u.consumedOutputIDsOfTransaction(transactionID) {
+		u.consumerStorage.Load(NewConsumer(inputID, transactionID, types.Maybe).ObjectStorageKey()).Consume(func(object objectstorage.StorableObject) {
+			// only the valid consumers were counted when they were booked
+			if object.(*Consumer).Valid() == types.True {
+				u.CachedOutputMetadata(inputID).Consume(func(outputMetadata *OutputMetadata) {
+					outputMetadata.UnregisterConsumer()
+				})
+			}
+			object.Delete()
+		})
+	}
+
+	for _, outputID := range createdOutputIDs {
+		u.CachedOutput(outputID).Consume(func(output Output) {
+			for _, address := range mappedAddresses(output) {
+				u.addressOutputMappingStorage.Delete(NewAddressOutputMapping(address, outputID).ObjectStorageKey())
+			}
+			if alias, ok := output.(*AliasOutput); ok {
+				u.aliasHistoryStorage.Delete(byteutils.ConcatBytes(aliasHistoryEntryPrefix(alias.GetAliasAddress(), alias.GetStateIndex()), outputID.Bytes()))
+			}
+		})
+		u.outputStorage.Delete(outputID.Bytes())
+		u.outputMetadataStorage.Delete(outputID.Bytes())
+	}
+
+	u.transactionMetadataStorage.Delete(transactionID.Bytes())
+	u.transactionStorage.Delete(transactionID.Bytes())
+
+	return true
+}
+
 // ConflictingTransactions returns the TransactionIDs that are conflicting with the given Transaction.
 func (u *UTXODAG) ConflictingTransactions(transaction *Transaction) (conflictingTransactions TransactionIDs) {
 	conflictingTransactions = make(TransactionIDs)
@@ -342,6 +418,8 @@ func (u *UTXODAG) LoadSnapshot(snapshot *Snapshot) {
 
 			// store addressOutputMapping
 			u.ManageStoreAddressOutputMapping(output)
+			u.storeSupplyControlledColor(output)
+			u.storeAliasHistoryEntry(output, record.Essence.Timestamp(), MasterBranchID)
 
 			// store OutputMetadata
 			metadata := NewOutputMetadata(output.ID())
@@ -368,6 +446,49 @@ func (u *UTXODAG) LoadSnapshot(snapshot *Snapshot) {
 	}
 }
 
+// SupplyController returns the AliasAddress that controls the supply of the given Color (nil if there is none).
+func (u *UTXODAG) SupplyController(color Color) (aliasAddress *AliasAddress) {
+	u.supplyControlledColorStorage.Load(color.Bytes()).Consume(func(object objectstorage.StorableObject) {
+		aliasAddress = object.(*SupplyControlledColor).AliasAddress()
+	})
+
+	return aliasAddress
+}
+
+// AliasHistory returns the AliasHistoryEntries of the chain of the given AliasAddress whose state index lies within
+// the given (inclusive) range. The entries are ordered by their state index and, since governance updates do not
+// increase the state index, by the time of the Transaction that created them.
+// Only the state indices up to the one at which limit entries are reached are read (all if limit is 0). The entries of
+// a state index are never split, so the page ends after that state index. The returned nextStateIndex is the state
+// index at which the next page starts, or 0 if the range does not contain any further entry.
+func (u *UTXODAG) AliasHistory(aliasAddress *AliasAddress, fromStateIndex, toStateIndex uint32, limit int) (aliasHistory []*AliasHistoryEntry, nextStateIndex uint32) {
+	if !u.aliasHistoryStartStorage.Load(aliasAddress.Bytes()).Consume(func(object objectstorage.StorableObject) {
+		if firstStateIndex := object.(*AliasHistoryStart).StateIndex(); fromStateIndex < firstStateIndex {
+			fromStateIndex = firstStateIndex
+		}
+	}) {
+		return
+	}
+
+	for stateIndex := uint64(fromStateIndex); stateIndex <= uint64(toStateIndex); stateIndex++ {
+		aliasHistoryEntries := u.aliasHistoryEntries(aliasAddress, uint32(stateIndex))
+		// every state transition increases the state index by one, so the chain ends at the first missing index
+		if len(aliasHistoryEntries) == 0 {
+			break
+		}
+
+		aliasHistory = append(aliasHistory, aliasHistoryEntries...)
+		if limit > 0 && len(aliasHistory) >= limit {
+			if stateIndex < uint64(toStateIndex) && u.hasAliasHistoryEntries(aliasAddress, uint32(stateIndex+1)) {
+				nextStateIndex = uint32(stateIndex + 1)
+			}
+			break
+		}
+	}
+
+	return aliasHistory, nextStateIndex
+}
+
 // CachedAddressOutputMapping retrieves the outputs for the given address.
 func (u *UTXODAG) CachedAddressOutputMapping(address Address) (cachedAddressOutputMappings CachedAddressOutputMappings) {
 	u.addressOutputMappingStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
@@ -377,6 +498,80 @@ func (u *UTXODAG) CachedAddressOutputMapping(address Address) (cachedAddressOutp
 	return
 }
 
+// AddressOutputIDs returns the OutputIDs of the outputs on the given address that follow the given cursor (nil for
+// the first page) in the order of their bytes. At most limit OutputIDs are returned, or all of them if limit is 0.
+// Only the keys of the address-output mappings are read, and only as many of them as needed for the requested page.
+func (u *UTXODAG) AddressOutputIDs(address Address, cursor *OutputID, limit int) (outputIDs []OutputID) {
+	addressBytes := address.Bytes()
+
+	var cursorKey []byte
+	if cursor != nil {
+		cursorKey = byteutils.ConcatBytes(addressBytes, cursor.Bytes())
+	}
+
+	u.walkAddressOutputIDs(addressBytes, len(addressBytes), cursorKey, func(outputID OutputID) bool {
+		outputIDs = append(outputIDs, outputID)
+
+		return limit == 0 || len(outputIDs) < limit
+	})
+
+	return outputIDs
+}
+
+// ForEachAddressOutputID passes the OutputIDs of the outputs on the given address to the consumer in the order of their
+// bytes until the consumer returns false. Unlike AddressOutputIDs it doesn't collect the OutputIDs, so it can be used
+// to aggregate the outputs of addresses with many outputs.
+func (u *UTXODAG) ForEachAddressOutputID(address Address, consumer func(outputID OutputID) bool) {
+	addressBytes := address.Bytes()
+	u.walkAddressOutputIDs(addressBytes, len(addressBytes), nil, consumer)
+}
+
+// walkAddressOutputIDs passes the OutputIDs of the address-output mappings with the given key prefix that follow the
+// cursorKey to the consumer in the order of their bytes. The storage can only be iterated by prefix and not from a
+// given key, so a prefix with more than maxAddressOutputIDsPerPrefix keys is split into the 256 prefixes that are one
+// byte longer (as far as the partitions of the storage allow it) and the prefixes before the cursorKey are skipped.
+// It returns false if the consumer stopped the walk.
+func (u *UTXODAG) walkAddressOutputIDs(prefix []byte, addressLength int, cursorKey []byte, consumer func(outputID OutputID) bool) bool {
+	if cursorKey != nil && bytes.Compare(prefix, cursorKey[:len(prefix)]) < 0 {
+		return true
+	}
+
+	splittable := len(prefix) < addressLength+addressOutputIDPartitionBytes
+	keys := make([][]byte, 0)
+	u.addressOutputMappingStorage.ForEachKeyOnly(func(key []byte) bool {
+		keys = append(keys, byteutils.ConcatBytes(key))
+
+		return !splittable || len(keys) <= maxAddressOutputIDsPerPrefix
+	}, objectstorage.WithIteratorPrefix(prefix))
+
+	if splittable && len(keys) > maxAddressOutputIDsPerPrefix {
+		for i := 0; i <= math.MaxUint8; i++ {
+			if !u.walkAddressOutputIDs(byteutils.ConcatBytes(prefix, []byte{byte(i)}), addressLength, cursorKey, consumer) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i], keys[j]) < 0
+	})
+	for _, key := range keys {
+		if cursorKey != nil && bytes.Compare(key, cursorKey) <= 0 {
+			continue
+		}
+
+		var outputID OutputID
+		copy(outputID[:], key[addressLength:])
+		if !consumer(outputID) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // region booking functions ////////////////////////////////////////////////////////////////////////////////////////////
 
 // bookInvalidTransaction is an internal utility function that books the given Transaction into the Branch identified by
@@ -477,6 +672,7 @@ func (u *UTXODAG) forkConsumer(transactionID TransactionID, conflictingInputs Ou
 			}) {
 				panic("failed to load OutputMetadata")
 			}
+			u.updateAliasHistoryBranchID(outputID, conflictBranchID)
 		}
 
 		u.walkFutureCone(outputIds, func(transactionID TransactionID) (updatedOutputs []OutputID) {
@@ -525,6 +721,7 @@ func (u *UTXODAG) updateBranchOfTransaction(transactionID TransactionID, newBran
 				}) {
 					panic(fmt.Errorf("failed to load OutputMetadata with %s", outputID))
 				}
+				u.updateAliasHistoryBranchID(outputID, newBranchID)
 			}
 
 			u.Events().TransactionBranchIDUpdatedByFork.Trigger(&TransactionBranchIDUpdatedByForkEvent{
@@ -570,6 +767,8 @@ func (u *UTXODAG) bookOutputs(transaction *Transaction, targetBranch BranchID) {
 
 		// store Output
 		u.outputStorage.Store(updatedOutput).Release()
+		u.storeSupplyControlledColor(updatedOutput)
+		u.storeAliasHistoryEntry(updatedOutput, transaction.Essence().Timestamp(), targetBranch)
 
 		// store OutputMetadata
 		metadata := NewOutputMetadata(updatedOutput.ID())
@@ -795,33 +994,122 @@ func (u *UTXODAG) consumedBranchIDs(transactionID TransactionID) (branchIDs Bran
 
 // ManageStoreAddressOutputMapping mangages how to store the address-output mapping dependent on which type of output it is.
 func (u *UTXODAG) ManageStoreAddressOutputMapping(output Output) {
+	for _, address := range mappedAddresses(output) {
+		u.StoreAddressOutputMapping(address, output.ID())
+	}
+}
+
+// StoreAddressOutputMapping stores the address-output mapping.
+func (u *UTXODAG) StoreAddressOutputMapping(address Address, outputID OutputID) {
+	result, stored := u.addressOutputMappingStorage.StoreIfAbsent(NewAddressOutputMapping(address, outputID))
+	if stored {
+		result.Release()
+	}
+}
+
+// storeSupplyControlledColor stores the color-alias mapping if the output is an alias that controls its token supply.
+func (u *UTXODAG) storeSupplyControlledColor(output Output) {
+	alias, ok := output.(*AliasOutput)
+	if !ok || !alias.IsSupplyControlled() {
+		return
+	}
+
+	result, stored := u.supplyControlledColorStorage.StoreIfAbsent(NewSupplyControlledColor(alias.GetAliasAddress()))
+	if stored {
+		result.Release()
+	}
+}
+
+// storeAliasHistoryEntry adds the output that was booked into the given Branch to the history of its alias chain if
+// it is an AliasOutput.
+func (u *UTXODAG) storeAliasHistoryEntry(output Output, timestamp time.Time, branchID BranchID) {
+	alias, ok := output.(*AliasOutput)
+	if !ok {
+		return
+	}
+
+	result, stored := u.aliasHistoryStorage.StoreIfAbsent(NewAliasHistoryEntry(alias, timestamp, branchID))
+	if !stored {
+		return
+	}
+	result.Release()
+
+	// the predecessors of an AliasOutput are always booked first, so the first stored entry starts the chain
+	if result, stored = u.aliasHistoryStartStorage.StoreIfAbsent(NewAliasHistoryStart(alias.GetAliasAddress(), alias.GetStateIndex())); stored {
+		result.Release()
+	}
+}
+
+// aliasHistoryEntries returns the AliasHistoryEntries of the given alias with the given state index ordered by the time
+// of the Transaction that created them.
+// hasAliasHistoryEntries returns true if the chain of the given AliasAddress contains an output with the given state
+// index.
+func (u *UTXODAG) hasAliasHistoryEntries(aliasAddress *AliasAddress, stateIndex uint32) (hasEntries bool) {
+	u.aliasHistoryStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		hasEntries = cachedObject.Consume(func(objectstorage.StorableObject) {})
+
+		return !hasEntries
+	}, objectstorage.WithIteratorPrefix(aliasHistoryEntryPrefix(aliasAddress, stateIndex)))
+
+	return hasEntries
+}
+
+func (u *UTXODAG) aliasHistoryEntries(aliasAddress *AliasAddress, stateIndex uint32) (aliasHistoryEntries []*AliasHistoryEntry) {
+	u.aliasHistoryStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		cachedObject.Consume(func(object objectstorage.StorableObject) {
+			aliasHistoryEntries = append(aliasHistoryEntries, object.(*AliasHistoryEntry))
+		})
+
+		return true
+	}, objectstorage.WithIteratorPrefix(aliasHistoryEntryPrefix(aliasAddress, stateIndex)))
+
+	sort.Slice(aliasHistoryEntries, func(i, j int) bool {
+		if !aliasHistoryEntries[i].Timestamp().Equal(aliasHistoryEntries[j].Timestamp()) {
+			return aliasHistoryEntries[i].Timestamp().Before(aliasHistoryEntries[j].Timestamp())
+		}
+
+		return bytes.Compare(aliasHistoryEntries[i].OutputID().Bytes(), aliasHistoryEntries[j].OutputID().Bytes()) < 0
+	})
+
+	return aliasHistoryEntries
+}
+
+// updateAliasHistoryBranchID updates the Branch of the AliasHistoryEntry of the given Output if it is an AliasOutput.
+func (u *UTXODAG) updateAliasHistoryBranchID(outputID OutputID, branchID BranchID) {
+	u.CachedOutput(outputID).Consume(func(output Output) {
+		alias, ok := output.(*AliasOutput)
+		if !ok {
+			return
+		}
+
+		u.aliasHistoryStorage.Load(byteutils.ConcatBytes(aliasHistoryEntryPrefix(alias.GetAliasAddress(), alias.GetStateIndex()), outputID.Bytes())).Consume(func(object objectstorage.StorableObject) {
+			object.(*AliasHistoryEntry).SetBranchID(branchID)
+		})
+	})
+}
+
+// mappedAddresses returns the addresses that the given Output is mapped to in the address-output mappings.
+func mappedAddresses(output Output) (addresses []Address) {
 	switch output.Type() {
 	case AliasOutputType:
 		castedOutput := output.(*AliasOutput)
 		// if it is an origin alias output, we don't have the aliasaddress from the parsed bytes.
 		// that happens in utxodag output booking, so we calculate the alias address here
-		u.StoreAddressOutputMapping(castedOutput.GetAliasAddress(), output.ID())
-		u.StoreAddressOutputMapping(castedOutput.GetStateAddress(), output.ID())
+		addresses = append(addresses, castedOutput.GetAliasAddress(), castedOutput.GetStateAddress())
 		if !castedOutput.IsSelfGoverned() {
-			u.StoreAddressOutputMapping(castedOutput.GetGoverningAddress(), output.ID())
+			addresses = append(addresses, castedOutput.GetGoverningAddress())
 		}
 	case ExtendedLockedOutputType:
 		castedOutput := output.(*ExtendedLockedOutput)
 		if castedOutput.FallbackAddress() != nil {
-			u.StoreAddressOutputMapping(castedOutput.FallbackAddress(), output.ID())
+			addresses = append(addresses, castedOutput.FallbackAddress())
 		}
-		u.StoreAddressOutputMapping(output.Address(), output.ID())
+		addresses = append(addresses, output.Address())
 	default:
-		u.StoreAddressOutputMapping(output.Address(), output.ID())
+		addresses = append(addresses, output.Address())
 	}
-}
 
-// StoreAddressOutputMapping stores the address-output mapping.
-func (u *UTXODAG) StoreAddressOutputMapping(address Address, outputID OutputID) {
-	result, stored := u.addressOutputMappingStorage.StoreIfAbsent(NewAddressOutputMapping(address, outputID))
-	if stored {
-		result.Release()
-	}
+	return addresses
 }
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -861,6 +1149,389 @@ func TransactionBranchIDUpdatedByForkEventHandler(handler interface{}, params ..
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region SupplyControlledColor ////////////////////////////////////////////////////////////////////////////////////////
+
+// SupplyControlledColor represents the mapping between a supply-controlled token Color and the AliasAddress that
+// controls it. Since the Color is derived from the AliasAddress, it can not be reversed and we need to store it to be
+// able to reject transactions that change the supply of the Color without unlocking the alias.
+type SupplyControlledColor struct {
+	color        Color
+	aliasAddress *AliasAddress
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewSupplyControlledColor returns a new SupplyControlledColor for the token Color of the given AliasAddress.
+func NewSupplyControlledColor(aliasAddress *AliasAddress) *SupplyControlledColor {
+	return &SupplyControlledColor{
+		color:        AliasTokenColor(aliasAddress),
+		aliasAddress: aliasAddress,
+	}
+}
+
+// SupplyControlledColorFromBytes unmarshals a SupplyControlledColor from a sequence of bytes.
+func SupplyControlledColorFromBytes(bytes []byte) (supplyControlledColor *SupplyControlledColor, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if supplyControlledColor, err = SupplyControlledColorFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse SupplyControlledColor from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// SupplyControlledColorFromMarshalUtil unmarshals a SupplyControlledColor using a MarshalUtil (for easier unmarshaling).
+func SupplyControlledColorFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (supplyControlledColor *SupplyControlledColor, err error) {
+	supplyControlledColor = &SupplyControlledColor{}
+	if supplyControlledColor.color, err = ColorFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse Color from MarshalUtil: %w", err)
+		return
+	}
+	if supplyControlledColor.aliasAddress, err = AliasAddressFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse AliasAddress from MarshalUtil: %w", err)
+		return
+	}
+
+	return
+}
+
+// SupplyControlledColorFromObjectStorage is a factory method that creates a new SupplyControlledColor instance from a
+// storage key of the object storage. It is used by the object storage, to create new instances of this entity.
+func SupplyControlledColorFromObjectStorage(key []byte, data []byte) (result objectstorage.StorableObject, err error) {
+	if result, _, err = SupplyControlledColorFromBytes(byteutils.ConcatBytes(key, data)); err != nil {
+		err = errors.Errorf("failed to parse SupplyControlledColor from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// Color returns the supply-controlled Color.
+func (s *SupplyControlledColor) Color() Color {
+	return s.color
+}
+
+// AliasAddress returns the AliasAddress that controls the supply of the Color.
+func (s *SupplyControlledColor) AliasAddress() *AliasAddress {
+	return s.aliasAddress
+}
+
+// Bytes marshals the SupplyControlledColor into a sequence of bytes.
+func (s *SupplyControlledColor) Bytes() []byte {
+	return byteutils.ConcatBytes(s.ObjectStorageKey(), s.ObjectStorageValue())
+}
+
+// String returns a human readable version of the SupplyControlledColor.
+func (s *SupplyControlledColor) String() string {
+	return stringify.Struct("SupplyControlledColor",
+		stringify.StructField("color", s.color),
+		stringify.StructField("aliasAddress", s.aliasAddress),
+	)
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (s *SupplyControlledColor) Update(objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (s *SupplyControlledColor) ObjectStorageKey() []byte {
+	return s.color.Bytes()
+}
+
+// ObjectStorageValue marshals the SupplyControlledColor into a sequence of bytes that are used as the value part in the
+// object storage.
+func (s *SupplyControlledColor) ObjectStorageValue() []byte {
+	return s.aliasAddress.Bytes()
+}
+
+// code contract (make sure the struct implements all required methods)
+var _ objectstorage.StorableObject = &SupplyControlledColor{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region AliasHistoryEntry ////////////////////////////////////////////////////////////////////////////////////////////
+
+// AliasHistoryEntry represents an AliasOutput in the chain of an alias. Since the chain of an alias can grow
+// indefinitely, we store every element as a separate k/v pair keyed by the AliasAddress and the big endian encoded
+// state index, so that a range of the history can be retrieved without loading the rest of the chain.
+type AliasHistoryEntry struct {
+	aliasAddress  *AliasAddress
+	stateIndex    uint32
+	outputID      OutputID
+	timestamp     time.Time
+	branchID      BranchID
+	branchIDMutex sync.RWMutex
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewAliasHistoryEntry returns a new AliasHistoryEntry for the given AliasOutput that was created at the given time and
+// booked into the given Branch.
+func NewAliasHistoryEntry(aliasOutput *AliasOutput, timestamp time.Time, branchID BranchID) *AliasHistoryEntry {
+	return &AliasHistoryEntry{
+		aliasAddress: aliasOutput.GetAliasAddress(),
+		stateIndex:   aliasOutput.GetStateIndex(),
+		outputID:     aliasOutput.ID(),
+		timestamp:    timestamp,
+		branchID:     branchID,
+	}
+}
+
+// AliasHistoryEntryFromBytes unmarshals an AliasHistoryEntry from a sequence of bytes.
+func AliasHistoryEntryFromBytes(bytes []byte) (aliasHistoryEntry *AliasHistoryEntry, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if aliasHistoryEntry, err = AliasHistoryEntryFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse AliasHistoryEntry from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// AliasHistoryEntryFromMarshalUtil unmarshals an AliasHistoryEntry using a MarshalUtil (for easier unmarshaling).
+func AliasHistoryEntryFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (aliasHistoryEntry *AliasHistoryEntry, err error) {
+	aliasHistoryEntry = &AliasHistoryEntry{}
+	if aliasHistoryEntry.aliasAddress, err = AliasAddressFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse AliasAddress from MarshalUtil: %w", err)
+		return
+	}
+	stateIndexBytes, err := marshalUtil.ReadBytes(marshalutil.Uint32Size)
+	if err != nil {
+		err = errors.Errorf("failed to parse state index (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	aliasHistoryEntry.stateIndex = binary.BigEndian.Uint32(stateIndexBytes)
+	if aliasHistoryEntry.outputID, err = OutputIDFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse OutputID from MarshalUtil: %w", err)
+		return
+	}
+	if aliasHistoryEntry.timestamp, err = marshalUtil.ReadTime(); err != nil {
+		err = errors.Errorf("failed to parse timestamp (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if aliasHistoryEntry.branchID, err = BranchIDFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse BranchID from MarshalUtil: %w", err)
+		return
+	}
+
+	return
+}
+
+// AliasHistoryEntryFromObjectStorage is a factory method that creates a new AliasHistoryEntry instance from a storage
+// key of the object storage. It is used by the object storage, to create new instances of this entity.
+func AliasHistoryEntryFromObjectStorage(key []byte, data []byte) (result objectstorage.StorableObject, err error) {
+	if result, _, err = AliasHistoryEntryFromBytes(byteutils.ConcatBytes(key, data)); err != nil {
+		err = errors.Errorf("failed to parse AliasHistoryEntry from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// AliasAddress returns the AliasAddress of the chain that the AliasHistoryEntry belongs to.
+func (a *AliasHistoryEntry) AliasAddress() *AliasAddress {
+	return a.aliasAddress
+}
+
+// StateIndex returns the state index of the AliasOutput.
+func (a *AliasHistoryEntry) StateIndex() uint32 {
+	return a.stateIndex
+}
+
+// OutputID returns the OutputID of the AliasOutput.
+func (a *AliasHistoryEntry) OutputID() OutputID {
+	return a.outputID
+}
+
+// Timestamp returns the timestamp of the Transaction that created the AliasOutput.
+func (a *AliasHistoryEntry) Timestamp() time.Time {
+	return a.timestamp
+}
+
+// BranchID returns the identifier of the Branch that the AliasOutput is booked into.
+func (a *AliasHistoryEntry) BranchID() BranchID {
+	a.branchIDMutex.RLock()
+	defer a.branchIDMutex.RUnlock()
+
+	return a.branchID
+}
+
+// SetBranchID updates the identifier of the Branch that the AliasOutput is booked into. It returns true if the value
+// was modified.
+func (a *AliasHistoryEntry) SetBranchID(branchID BranchID) (modified bool) {
+	a.branchIDMutex.Lock()
+	defer a.branchIDMutex.Unlock()
+
+	if a.branchID == branchID {
+		return false
+	}
+
+	a.branchID = branchID
+	a.SetModified()
+	modified = true
+
+	return
+}
+
+// Bytes marshals the AliasHistoryEntry into a sequence of bytes.
+func (a *AliasHistoryEntry) Bytes() []byte {
+	return byteutils.ConcatBytes(a.ObjectStorageKey(), a.ObjectStorageValue())
+}
+
+// String returns a human readable version of the AliasHistoryEntry.
+func (a *AliasHistoryEntry) String() string {
+	return stringify.Struct("AliasHistoryEntry",
+		stringify.StructField("aliasAddress", a.aliasAddress),
+		stringify.StructField("stateIndex", a.stateIndex),
+		stringify.StructField("outputID", a.outputID),
+		stringify.StructField("timestamp", a.timestamp),
+		stringify.StructField("branchID", a.BranchID()),
+	)
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (a *AliasHistoryEntry) Update(objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (a *AliasHistoryEntry) ObjectStorageKey() []byte {
+	return byteutils.ConcatBytes(aliasHistoryEntryPrefix(a.aliasAddress, a.stateIndex), a.outputID.Bytes())
+}
+
+// ObjectStorageValue marshals the AliasHistoryEntry into a sequence of bytes that are used as the value part in the
+// object storage.
+func (a *AliasHistoryEntry) ObjectStorageValue() []byte {
+	return marshalutil.New(marshalutil.TimeSize + BranchIDLength).
+		WriteTime(a.timestamp).
+		Write(a.BranchID()).
+		Bytes()
+}
+
+// aliasHistoryEntryPrefix returns the common key prefix of the AliasHistoryEntries of the given alias with the given
+// state index. The state index is encoded in big endian, so that the keys of a chain are sorted by their state index.
+func aliasHistoryEntryPrefix(aliasAddress *AliasAddress, stateIndex uint32) []byte {
+	stateIndexBytes := make([]byte, marshalutil.Uint32Size)
+	binary.BigEndian.PutUint32(stateIndexBytes, stateIndex)
+
+	return byteutils.ConcatBytes(aliasAddress.Bytes(), stateIndexBytes)
+}
+
+// code contract (make sure the struct implements all required methods)
+var _ objectstorage.StorableObject = &AliasHistoryEntry{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region AliasHistoryStart ////////////////////////////////////////////////////////////////////////////////////////////
+
+// AliasHistoryStart stores the state index of the first AliasHistoryEntry of an alias. It is usually 0, but the chains
+// of the aliases that were loaded from a snapshot start with the state index of their unspent AliasOutput.
+type AliasHistoryStart struct {
+	aliasAddress *AliasAddress
+	stateIndex   uint32
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewAliasHistoryStart returns a new AliasHistoryStart for the chain of the given alias that starts with the given
+// state index.
+func NewAliasHistoryStart(aliasAddress *AliasAddress, stateIndex uint32) *AliasHistoryStart {
+	return &AliasHistoryStart{
+		aliasAddress: aliasAddress,
+		stateIndex:   stateIndex,
+	}
+}
+
+// AliasHistoryStartFromBytes unmarshals an AliasHistoryStart from a sequence of bytes.
+func AliasHistoryStartFromBytes(bytes []byte) (aliasHistoryStart *AliasHistoryStart, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if aliasHistoryStart, err = AliasHistoryStartFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse AliasHistoryStart from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// AliasHistoryStartFromMarshalUtil unmarshals an AliasHistoryStart using a MarshalUtil (for easier unmarshaling).
+func AliasHistoryStartFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (aliasHistoryStart *AliasHistoryStart, err error) {
+	aliasHistoryStart = &AliasHistoryStart{}
+	if aliasHistoryStart.aliasAddress, err = AliasAddressFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse AliasAddress from MarshalUtil: %w", err)
+		return
+	}
+	if aliasHistoryStart.stateIndex, err = marshalUtil.ReadUint32(); err != nil {
+		err = errors.Errorf("failed to parse state index (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// AliasHistoryStartFromObjectStorage is a factory method that creates a new AliasHistoryStart instance from a storage
+// key of the object storage. It is used by the object storage, to create new instances of this entity.
+func AliasHistoryStartFromObjectStorage(key []byte, data []byte) (result objectstorage.StorableObject, err error) {
+	if result, _, err = AliasHistoryStartFromBytes(byteutils.ConcatBytes(key, data)); err != nil {
+		err = errors.Errorf("failed to parse AliasHistoryStart from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// AliasAddress returns the AliasAddress of the chain.
+func (a *AliasHistoryStart) AliasAddress() *AliasAddress {
+	return a.aliasAddress
+}
+
+// StateIndex returns the state index of the first AliasHistoryEntry of the chain.
+func (a *AliasHistoryStart) StateIndex() uint32 {
+	return a.stateIndex
+}
+
+// Bytes marshals the AliasHistoryStart into a sequence of bytes.
+func (a *AliasHistoryStart) Bytes() []byte {
+	return byteutils.ConcatBytes(a.ObjectStorageKey(), a.ObjectStorageValue())
+}
+
+// String returns a human readable version of the AliasHistoryStart.
+func (a *AliasHistoryStart) String() string {
+	return stringify.Struct("AliasHistoryStart",
+		stringify.StructField("aliasAddress", a.aliasAddress),
+		stringify.StructField("stateIndex", a.stateIndex),
+	)
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (a *AliasHistoryStart) Update(objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (a *AliasHistoryStart) ObjectStorageKey() []byte {
+	return a.aliasAddress.Bytes()
+}
+
+// ObjectStorageValue marshals the AliasHistoryStart into a sequence of bytes that are used as the value part in the
+// object storage.
+func (a *AliasHistoryStart) ObjectStorageValue() []byte {
+	return marshalutil.New(marshalutil.Uint32Size).
+		WriteUint32(a.stateIndex).
+		Bytes()
+}
+
+// code contract (make sure the struct implements all required methods)
+var _ objectstorage.StorableObject = &AliasHistoryStart{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region AddressOutputMapping /////////////////////////////////////////////////////////////////////////////////////////
 
 // AddressOutputMapping represents a mapping between Addresses and their corresponding Outputs. Since an Address can have a
@@ -1,7 +1,9 @@
 package ledgerstate
 
 import (
+	"bytes"
 	"math"
+	"sort"
 	"testing"
 	"time"
 
@@ -628,6 +630,73 @@ func TestAddressOutputMapping(t *testing.T) {
 	assert.Equal(t, 1, len(res))
 }
 
+func TestUTXODAG_AddressOutputIDs(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+	defer utxoDAG.Shutdown()
+
+	address := NewED25519Address(ed25519.GenerateKeyPair().PublicKey)
+	otherAddress := NewED25519Address(ed25519.GenerateKeyPair().PublicKey)
+	outputIDs := []OutputID{
+		NewOutputID(TransactionID{3}, 0),
+		NewOutputID(TransactionID{1}, 1),
+		NewOutputID(TransactionID{1}, 0),
+		NewOutputID(TransactionID{2}, 5),
+	}
+	for _, outputID := range outputIDs {
+		utxoDAG.StoreAddressOutputMapping(address, outputID)
+	}
+	utxoDAG.StoreAddressOutputMapping(otherAddress, NewOutputID(TransactionID{1}, 2))
+
+	assert.Equal(t, []OutputID{outputIDs[2], outputIDs[1], outputIDs[3], outputIDs[0]}, utxoDAG.AddressOutputIDs(address, nil, 0))
+	assert.Len(t, utxoDAG.AddressOutputIDs(otherAddress, nil, 0), 1)
+	assert.Empty(t, utxoDAG.AddressOutputIDs(NewED25519Address(ed25519.GenerateKeyPair().PublicKey), nil, 0))
+
+	// pages start after the cursor, which doesn't need to exist
+	assert.Equal(t, []OutputID{outputIDs[2], outputIDs[1]}, utxoDAG.AddressOutputIDs(address, nil, 2))
+	assert.Equal(t, []OutputID{outputIDs[3], outputIDs[0]}, utxoDAG.AddressOutputIDs(address, &outputIDs[1], 2))
+	assert.Equal(t, []OutputID{outputIDs[3]}, utxoDAG.AddressOutputIDs(address, &outputIDs[1], 1))
+	cursor := NewOutputID(TransactionID{2}, 0)
+	assert.Equal(t, []OutputID{outputIDs[3], outputIDs[0]}, utxoDAG.AddressOutputIDs(address, &cursor, 0))
+	assert.Empty(t, utxoDAG.AddressOutputIDs(address, &outputIDs[0], 0))
+
+	// the walk stops as soon as the consumer returns false
+	walkedOutputIDs := make([]OutputID, 0)
+	utxoDAG.ForEachAddressOutputID(address, func(outputID OutputID) bool {
+		walkedOutputIDs = append(walkedOutputIDs, outputID)
+		return outputID != outputIDs[3]
+	})
+	assert.Equal(t, []OutputID{outputIDs[2], outputIDs[1], outputIDs[3]}, walkedOutputIDs)
+}
+
+func TestUTXODAG_AddressOutputIDsOfLargeAddress(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+	defer utxoDAG.Shutdown()
+
+	// enough mappings for the prefixes to be split on both partition levels
+	address := NewED25519Address(ed25519.GenerateKeyPair().PublicKey)
+	outputIDs := make([]OutputID, 0)
+	for i := 0; i < 3*maxAddressOutputIDsPerPrefix; i++ {
+		outputID := NewOutputID(TransactionID{0, byte(i % 2), byte(i / 256), byte(i)}, uint16(i%MaxOutputCount))
+		outputIDs = append(outputIDs, outputID)
+		utxoDAG.StoreAddressOutputMapping(address, outputID)
+	}
+	sort.Slice(outputIDs, func(i, j int) bool {
+		return bytes.Compare(outputIDs[i][:], outputIDs[j][:]) < 0
+	})
+
+	assert.Equal(t, outputIDs, utxoDAG.AddressOutputIDs(address, nil, 0))
+
+	var pages []OutputID
+	var cursor *OutputID
+	for page := utxoDAG.AddressOutputIDs(address, nil, 700); len(page) != 0; page = utxoDAG.AddressOutputIDs(address, cursor, 700) {
+		pages = append(pages, page...)
+		cursor = &page[len(page)-1]
+	}
+	assert.Equal(t, outputIDs, pages)
+}
+
 func TestUTXODAG_CheckTransaction(t *testing.T) {
 	branchDAG, utxoDAG := setupDependencies(t)
 	defer branchDAG.Shutdown()
@@ -744,6 +813,237 @@ func TestUTXODAG_CheckTransaction(t *testing.T) {
 	})
 }
 
+func TestUTXODAG_RollbackTransaction(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+
+	wallets := createWallets(2)
+	input := generateOutput(utxoDAG, wallets[0].address, 0)
+
+	tx := buildTransaction(utxoDAG, wallets[0], wallets[1], []*SigLockedSingleOutput{input})
+	_, err := utxoDAG.BookTransaction(tx)
+	require.NoError(t, err)
+	txOutputID := NewOutputID(tx.ID(), 0)
+	utxoDAG.CachedOutput(txOutputID).Consume(utxoDAG.ManageStoreAddressOutputMapping)
+
+	childTx := buildTransaction(utxoDAG, wallets[1], wallets[0], []*SigLockedSingleOutput{tx.Essence().Outputs()[0].(*SigLockedSingleOutput)})
+	_, err = utxoDAG.BookTransaction(childTx)
+	require.NoError(t, err)
+
+	// the outputs of the transaction are consumed by the child transaction
+	assert.False(t, utxoDAG.RollbackTransaction(tx.ID()))
+	assert.True(t, utxoDAG.RollbackTransaction(childTx.ID()))
+	assert.Nil(t, utxoDAG.Transaction(childTx.ID()))
+	assert.False(t, utxoDAG.CachedOutput(NewOutputID(childTx.ID(), 0)).Consume(func(Output) {}))
+	assert.True(t, utxoDAG.CachedOutputMetadata(txOutputID).Consume(func(outputMetadata *OutputMetadata) {
+		assert.Zero(t, outputMetadata.ConsumerCount())
+	}))
+
+	assert.True(t, utxoDAG.RollbackTransaction(tx.ID()))
+	assert.False(t, utxoDAG.CachedTransactionMetadata(tx.ID()).Consume(func(*TransactionMetadata) {}))
+	assert.False(t, utxoDAG.CachedOutputMetadata(txOutputID).Consume(func(*OutputMetadata) {}))
+	assert.Empty(t, utxoDAG.AddressOutputIDs(wallets[1].address, nil, 0))
+	assert.True(t, utxoDAG.CachedOutputMetadata(input.ID()).Consume(func(outputMetadata *OutputMetadata) {
+		assert.Zero(t, outputMetadata.ConsumerCount())
+	}))
+	cachedConsumers := utxoDAG.CachedConsumers(input.ID())
+	defer cachedConsumers.Release()
+	assert.Empty(t, cachedConsumers)
+}
+
+func TestUTXODAG_AliasHistory(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+	defer utxoDAG.Shutdown()
+
+	w := genRandomWallet()
+	alias := &AliasOutput{
+		outputID:     randOutputID(),
+		balances:     NewColoredBalances(map[Color]uint64{ColorIOTA: DustThresholdAliasOutputIOTA}),
+		aliasAddress: *randAliasAddress(),
+		stateAddress: w.address,
+		stateIndex:   10,
+	}
+	otherAlias := &AliasOutput{
+		outputID:     randOutputID(),
+		balances:     NewColoredBalances(map[Color]uint64{ColorIOTA: DustThresholdAliasOutputIOTA}),
+		aliasAddress: *randAliasAddress(),
+		stateAddress: w.address,
+		stateIndex:   10,
+	}
+	// book manually the outputs into utxoDAG
+	for _, output := range []Output{alias, otherAlias} {
+		utxoDAG.outputStorage.Store(output).Release()
+		utxoDAG.storeAliasHistoryEntry(output, time.Now().Add(-time.Minute), MasterBranchID)
+
+		metadata := NewOutputMetadata(output.ID())
+		metadata.SetBranchID(MasterBranchID)
+		metadata.SetSolid(true)
+		utxoDAG.outputMetadataStorage.Store(metadata).Release()
+	}
+
+	essence := NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{}, NewInputs(alias.Input()), NewOutputs(alias.NewAliasOutputNext()))
+	tx := NewTransaction(essence, w.unlockBlocks(essence))
+	_, err := utxoDAG.BookTransaction(tx)
+	require.NoError(t, err)
+
+	next := tx.Essence().Outputs()[0].(*AliasOutput)
+	nextEssence := NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{}, NewInputs(next.Input()), NewOutputs(next.NewAliasOutputNext()))
+	nextTx := NewTransaction(nextEssence, w.unlockBlocks(nextEssence))
+	_, err = utxoDAG.BookTransaction(nextTx)
+	require.NoError(t, err)
+
+	aliasHistory, nextStateIndex := utxoDAG.AliasHistory(alias.GetAliasAddress(), 0, math.MaxUint32, 0)
+	assert.Zero(t, nextStateIndex)
+	require.Len(t, aliasHistory, 3)
+	assert.Equal(t, alias.ID(), aliasHistory[0].OutputID())
+	assert.Equal(t, uint32(10), aliasHistory[0].StateIndex())
+	assert.Equal(t, NewOutputID(tx.ID(), 0), aliasHistory[1].OutputID())
+	assert.Equal(t, uint32(11), aliasHistory[1].StateIndex())
+	assert.Equal(t, alias.GetAliasAddress(), aliasHistory[1].AliasAddress())
+	assert.Equal(t, MasterBranchID, aliasHistory[1].BranchID())
+	assert.True(t, essence.Timestamp().Equal(aliasHistory[1].Timestamp()))
+	assert.Equal(t, NewOutputID(nextTx.ID(), 0), aliasHistory[2].OutputID())
+	assert.Equal(t, uint32(12), aliasHistory[2].StateIndex())
+
+	aliasHistory, _ = utxoDAG.AliasHistory(alias.GetAliasAddress(), 11, 11, 0)
+	require.Len(t, aliasHistory, 1)
+	assert.Equal(t, uint32(11), aliasHistory[0].StateIndex())
+
+	aliasHistoryPage := func(aliasAddress *AliasAddress, fromStateIndex, toStateIndex uint32, limit int) []*AliasHistoryEntry {
+		page, _ := utxoDAG.AliasHistory(aliasAddress, fromStateIndex, toStateIndex, limit)
+		return page
+	}
+	assert.Len(t, aliasHistoryPage(alias.GetAliasAddress(), 11, 20, 0), 2)
+	assert.Empty(t, aliasHistoryPage(alias.GetAliasAddress(), 13, 20, 0))
+	assert.Empty(t, aliasHistoryPage(alias.GetAliasAddress(), 0, 9, 0))
+
+	// the pages start at the returned state index
+	aliasHistory, nextStateIndex = utxoDAG.AliasHistory(alias.GetAliasAddress(), 0, math.MaxUint32, 2)
+	require.Len(t, aliasHistory, 2)
+	assert.Equal(t, uint32(11), aliasHistory[1].StateIndex())
+	assert.Equal(t, uint32(12), nextStateIndex)
+	aliasHistory, nextStateIndex = utxoDAG.AliasHistory(alias.GetAliasAddress(), nextStateIndex, math.MaxUint32, 2)
+	require.Len(t, aliasHistory, 1)
+	assert.Equal(t, NewOutputID(nextTx.ID(), 0), aliasHistory[0].OutputID())
+	assert.Zero(t, nextStateIndex)
+	_, nextStateIndex = utxoDAG.AliasHistory(alias.GetAliasAddress(), 0, 11, 2)
+	assert.Zero(t, nextStateIndex)
+	aliasHistory, _ = utxoDAG.AliasHistory(alias.GetAliasAddress(), 11, 11, 0)
+
+	// forks update the Branch of the entries
+	forkedBranchID := BranchIDFromRandomness()
+	utxoDAG.updateAliasHistoryBranchID(aliasHistory[0].OutputID(), forkedBranchID)
+	assert.Equal(t, forkedBranchID, aliasHistoryPage(alias.GetAliasAddress(), 11, 11, 0)[0].BranchID())
+	assert.Len(t, aliasHistoryPage(otherAlias.GetAliasAddress(), 0, math.MaxUint32, 0), 1)
+	assert.Empty(t, aliasHistoryPage(randAliasAddress(), 0, math.MaxUint32, 0))
+
+	restoredEntry, _, err := AliasHistoryEntryFromBytes(aliasHistory[0].Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, aliasHistory[0].AliasAddress(), restoredEntry.AliasAddress())
+	assert.Equal(t, aliasHistory[0].StateIndex(), restoredEntry.StateIndex())
+	assert.Equal(t, aliasHistory[0].OutputID(), restoredEntry.OutputID())
+	assert.True(t, aliasHistory[0].Timestamp().Equal(restoredEntry.Timestamp()))
+	assert.Equal(t, forkedBranchID, restoredEntry.BranchID())
+}
+
+func TestUTXODAG_CheckTransactionTokenSupply(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+	defer utxoDAG.Shutdown()
+
+	w := genRandomWallet()
+	alias := &AliasOutput{
+		outputID:           randOutputID(),
+		balances:           NewColoredBalances(map[Color]uint64{ColorIOTA: DustThresholdAliasOutputIOTA}),
+		aliasAddress:       *randAliasAddress(),
+		stateAddress:       w.address,
+		stateIndex:         10,
+		isSupplyControlled: true,
+		tokenSupply:        50,
+	}
+	tokenColor := alias.TokenColor()
+	funds := NewSigLockedSingleOutput(100, w.address)
+	funds.SetID(randOutputID())
+	tokens := NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{tokenColor: 30}), w.address)
+	tokens.SetID(randOutputID())
+	// book manually the outputs into utxoDAG
+	for _, output := range []Output{alias, funds, tokens} {
+		utxoDAG.outputStorage.Store(output).Release()
+		utxoDAG.storeSupplyControlledColor(output)
+
+		metadata := NewOutputMetadata(output.ID())
+		metadata.SetBranchID(MasterBranchID)
+		metadata.SetSolid(true)
+		utxoDAG.outputMetadataStorage.Store(metadata).Release()
+	}
+	assert.Equal(t, alias.GetAliasAddress(), utxoDAG.SupplyController(tokenColor))
+	assert.Nil(t, utxoDAG.SupplyController(ColorIOTA))
+
+	nextAlias := func(supply uint64, governanceUpdate ...bool) *AliasOutput {
+		next := alias.NewAliasOutputNext(governanceUpdate...)
+		require.NoError(t, next.SetTokenSupply(supply))
+		return next
+	}
+	signedTransaction := func(inputs Inputs, outputs ...Output) *Transaction {
+		essence := NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{}, inputs, NewOutputs(outputs...))
+		return NewTransaction(essence, w.unlockBlocks(essence))
+	}
+
+	t.Run("CASE: Mint tokens", func(t *testing.T) {
+		tx := signedTransaction(NewInputs(alias.Input(), funds.Input()),
+			nextAlias(70),
+			NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{tokenColor: 20, ColorIOTA: 80}), w.address),
+		)
+		assert.NoError(t, utxoDAG.CheckTransaction(tx))
+	})
+
+	t.Run("CASE: Mint tokens with wrong supply", func(t *testing.T) {
+		tx := signedTransaction(NewInputs(alias.Input(), funds.Input()),
+			nextAlias(60),
+			NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{tokenColor: 20, ColorIOTA: 80}), w.address),
+		)
+		assert.Error(t, utxoDAG.CheckTransaction(tx))
+	})
+
+	t.Run("CASE: Mint tokens with governance update", func(t *testing.T) {
+		tx := signedTransaction(NewInputs(alias.Input(), funds.Input()),
+			nextAlias(70, true),
+			NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{tokenColor: 20, ColorIOTA: 80}), w.address),
+		)
+		assert.Error(t, utxoDAG.CheckTransaction(tx))
+	})
+
+	t.Run("CASE: Mint tokens without alias", func(t *testing.T) {
+		tx := signedTransaction(NewInputs(funds.Input()),
+			NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{tokenColor: 20, ColorIOTA: 80}), w.address),
+		)
+		assert.Error(t, utxoDAG.CheckTransaction(tx))
+	})
+
+	t.Run("CASE: Burn tokens", func(t *testing.T) {
+		tx := signedTransaction(NewInputs(alias.Input(), tokens.Input()),
+			nextAlias(40),
+			NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{tokenColor: 20, ColorIOTA: 10}), w.address),
+		)
+		assert.NoError(t, utxoDAG.CheckTransaction(tx))
+	})
+
+	t.Run("CASE: Burn tokens without alias", func(t *testing.T) {
+		tx := signedTransaction(NewInputs(tokens.Input()),
+			NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{tokenColor: 20, ColorIOTA: 10}), w.address),
+		)
+		assert.Error(t, utxoDAG.CheckTransaction(tx))
+	})
+
+	t.Run("CASE: Transfer tokens without alias", func(t *testing.T) {
+		tx := signedTransaction(NewInputs(tokens.Input()),
+			NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{tokenColor: 30}), randEd25119Address()),
+		)
+		assert.NoError(t, utxoDAG.CheckTransaction(tx))
+	})
+}
+
 func setupDependencies(t *testing.T) (*BranchDAG, *UTXODAG) {
 	store := mapdb.NewMapDB()
 	cacheTimeProvider := database.NewCacheTimeProvider(0)
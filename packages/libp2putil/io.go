@@ -23,12 +23,16 @@ func NewDelimitedWriter(w io.Writer) *UvarintWriter {
 
 // WriteMsg writes protobuf message.
 func (uw *UvarintWriter) WriteMsg(msg proto.Message) (err error) {
-	var data []byte
-	lenBuf := make([]byte, varint.MaxLenUvarint63)
-	data, err = proto.Marshal(msg)
+	data, err := proto.Marshal(msg)
 	if err != nil {
 		return err
 	}
+	return uw.WriteFrame(data)
+}
+
+// WriteFrame writes the given bytes prefixed with their length.
+func (uw *UvarintWriter) WriteFrame(data []byte) (err error) {
+	lenBuf := make([]byte, varint.MaxLenUvarint63)
 	length := uint64(len(data))
 	n := varint.PutUvarint(lenBuf, length)
 	_, err = uw.w.Write(lenBuf[:n])
@@ -51,16 +55,25 @@ func NewDelimitedReader(r io.Reader) *UvarintReader {
 
 // ReadMsg read protobuf messages.
 func (ur *UvarintReader) ReadMsg(msg proto.Message) error {
-	length64, err := varint.ReadUvarint(ur.r)
+	buf, err := ur.ReadFrame()
 	if err != nil {
 		return err
 	}
+	return proto.Unmarshal(buf, msg)
+}
+
+// ReadFrame reads the bytes of a frame that is prefixed with its length.
+func (ur *UvarintReader) ReadFrame() ([]byte, error) {
+	length64, err := varint.ReadUvarint(ur.r)
+	if err != nil {
+		return nil, err
+	}
 	if length64 > tangle.MaxMessageSize {
-		return fmt.Errorf("max message size exceeded: %d", length64)
+		return nil, fmt.Errorf("max message size exceeded: %d", length64)
 	}
 	buf := make([]byte, length64)
 	if _, err := io.ReadFull(ur.r, buf); err != nil {
-		return err
+		return nil, err
 	}
-	return proto.Unmarshal(buf, msg)
+	return buf, nil
 }
@@ -0,0 +1,387 @@
+package mana
+
+import (
+	"encoding/binary"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/byteutils"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
+)
+
+// eventBucketDuration defines the time span of the events that share the same key prefix in the event store.
+// Replaying the events between two points in time only needs to iterate over the buckets that cover that span.
+const eventBucketDuration = time.Hour
+
+// ConsensusManaHistory keeps a compact history of the consensus base mana vector.
+// It periodically stores checkpoints of the whole vector and logs the pledge and revoke events in between,
+// so that the vector can be reconstructed for any point in time after the oldest checkpoint.
+type ConsensusManaHistory struct {
+	checkpointStore kvstore.KVStore
+	eventStore      kvstore.KVStore
+	// checkpointTimes holds the timestamps of all stored checkpoints in ascending order.
+	checkpointTimes []time.Time
+	mutex           sync.RWMutex
+}
+
+// NewConsensusManaHistory creates a new ConsensusManaHistory that persists its data in the given store.
+func NewConsensusManaHistory(store kvstore.KVStore) (*ConsensusManaHistory, error) {
+	h := &ConsensusManaHistory{
+		checkpointStore: store.WithRealm(byteutils.ConcatBytes(store.Realm(), []byte{PrefixConsensusHistoryCheckpoint})),
+		eventStore:      store.WithRealm(byteutils.ConcatBytes(store.Realm(), []byte{PrefixConsensusHistoryEvent})),
+	}
+	if err := h.checkpointStore.IterateKeys(kvstore.EmptyPrefix, func(key kvstore.Key) bool {
+		h.checkpointTimes = append(h.checkpointTimes, timeFromKey(key))
+		return true
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to read the consensus mana checkpoints")
+	}
+	sort.Slice(h.checkpointTimes, func(i, j int) bool {
+		return h.checkpointTimes[i].Before(h.checkpointTimes[j])
+	})
+	return h, nil
+}
+
+// RecordEvent logs a pledge or revoke event of the consensus mana vector. Other events are ignored.
+// If the event is older than existing checkpoints, these checkpoints are corrected accordingly.
+func (h *ConsensusManaHistory) RecordEvent(ev Event) error {
+	if ev.Type() != EventTypePledge && ev.Type() != EventTypeRevoke {
+		return nil
+	}
+	persistable := ev.ToPersistable()
+	if persistable.ManaType != ConsensusMana {
+		return nil
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if err := h.eventStore.Set(eventKey(persistable), kvstore.Value{}); err != nil {
+		return errors.Wrapf(err, "failed to store consensus mana event %s", ev)
+	}
+	for i := len(h.checkpointTimes) - 1; i >= 0 && !h.checkpointTimes[i].Before(ev.Timestamp()); i-- {
+		vector, err := h.loadCheckpoint(h.checkpointTimes[i])
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		vector.apply(persistable)
+		if err := h.storeCheckpoint(h.checkpointTimes[i], vector); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// CreateCheckpoint stores the consensus mana vector at time t. The vector is derived from the latest checkpoint and
+// the events logged since then. If there is no checkpoint yet, currentVector is stored as the first checkpoint.
+func (h *ConsensusManaHistory) CreateCheckpoint(t time.Time, currentVector NodeMap) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if len(h.checkpointTimes) == 0 {
+		return h.addCheckpoint(t, currentVector)
+	}
+	if !t.After(h.checkpointTimes[len(h.checkpointTimes)-1]) {
+		return errors.Errorf("checkpoint at %s is not newer than the latest checkpoint", t)
+	}
+	vector, err := h.vectorAt(t)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return h.addCheckpoint(t, vector)
+}
+
+// Prune removes the checkpoints and events that are not needed to answer queries for points in time after
+// the given one.
+func (h *ConsensusManaHistory) Prune(before time.Time) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	// the latest checkpoint before the cutoff is still needed as a base to reconstruct the vectors after it
+	keepIndex := sort.Search(len(h.checkpointTimes), func(i int) bool {
+		return h.checkpointTimes[i].After(before)
+	}) - 1
+	if keepIndex < 0 {
+		return nil
+	}
+	oldestCheckpoint := h.checkpointTimes[keepIndex]
+
+	batch := h.checkpointStore.Batched()
+	for _, checkpointTime := range h.checkpointTimes[:keepIndex] {
+		if err := batch.Delete(timeKey(checkpointTime)); err != nil {
+			batch.Cancel()
+			return errors.Wrap(err, "failed to delete consensus mana checkpoint")
+		}
+	}
+	if err := batch.Commit(); err != nil {
+		return errors.Wrap(err, "failed to delete consensus mana checkpoints")
+	}
+	h.checkpointTimes = h.checkpointTimes[keepIndex:]
+
+	batch = h.eventStore.Batched()
+	if err := h.eventStore.IterateKeys(kvstore.EmptyPrefix, func(key kvstore.Key) bool {
+		if !timeFromKey(key[8:]).After(oldestCheckpoint) {
+			if err := batch.Delete(key); err != nil {
+				return false
+			}
+		}
+		return true
+	}); err != nil {
+		batch.Cancel()
+		return errors.Wrap(err, "failed to iterate over consensus mana events")
+	}
+	return errors.Wrap(batch.Commit(), "failed to delete consensus mana events")
+}
+
+// OldestAvailableTime returns the oldest point in time the consensus mana vector can be reconstructed for.
+func (h *ConsensusManaHistory) OldestAvailableTime() (time.Time, bool) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	if len(h.checkpointTimes) == 0 {
+		return time.Time{}, false
+	}
+	return h.checkpointTimes[0], true
+}
+
+// Metadata returns the metadata of the history, holding the oldest point in time that can be queried.
+func (h *ConsensusManaHistory) Metadata() *ConsensusBasePastManaVectorMetadata {
+	oldest, ok := h.OldestAvailableTime()
+	if !ok {
+		return nil
+	}
+	return &ConsensusBasePastManaVectorMetadata{Timestamp: oldest}
+}
+
+// ManaMapAt returns the consensus mana of all nodes at time t.
+func (h *ConsensusManaHistory) ManaMapAt(t time.Time) (NodeMap, error) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	return h.vectorAt(t)
+}
+
+// ManaAt returns the consensus mana of the given node at time t.
+func (h *ConsensusManaHistory) ManaAt(nodeID identity.ID, t time.Time) (float64, error) {
+	manaMap, err := h.ManaMapAt(t)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	value, exists := manaMap[nodeID]
+	if !exists {
+		return 0, ErrNodeNotFoundInBaseManaVector
+	}
+	return value, nil
+}
+
+// HighestManaNodesAt returns the n nodes with the highest consensus mana at time t in descending order.
+// If n is zero, it returns all nodes.
+func (h *ConsensusManaHistory) HighestManaNodesAt(t time.Time, n uint) ([]Node, error) {
+	manaMap, err := h.ManaMapAt(t)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	nodes := make([]Node, 0, len(manaMap))
+	for nodeID, value := range manaMap {
+		nodes = append(nodes, Node{ID: nodeID, Mana: value})
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Mana > nodes[j].Mana
+	})
+	if n == 0 || int(n) >= len(nodes) {
+		return nodes, nil
+	}
+	return nodes[:n], nil
+}
+
+// Events returns the logged events of the given nodes within [startTime, endTime], sorted by time.
+// If no node is given, the events of all nodes are returned.
+func (h *ConsensusManaHistory) Events(nodeIDs []identity.ID, startTime, endTime time.Time) (events EventSlice, err error) {
+	lookup := make(map[identity.ID]bool, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		lookup[nodeID] = true
+	}
+
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	if iterErr := h.eventStore.IterateKeys(kvstore.EmptyPrefix, func(key kvstore.Key) bool {
+		var persistable *PersistableEvent
+		if persistable, err = parseEvent(marshalutil.New(key[16:])); err != nil {
+			return false
+		}
+		if persistable.Time.Before(startTime) || persistable.Time.After(endTime) {
+			return true
+		}
+		if len(lookup) > 0 && !lookup[persistable.NodeID] {
+			return true
+		}
+		var ev Event
+		if ev, err = FromPersistableEvent(persistable); err != nil {
+			return false
+		}
+		events = append(events, ev)
+		return true
+	}); iterErr != nil {
+		return nil, errors.Wrap(iterErr, "failed to iterate over consensus mana events")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse consensus mana event")
+	}
+	events.Sort()
+	return events, nil
+}
+
+// vectorAt reconstructs the consensus mana vector at time t from the latest checkpoint before it.
+func (h *ConsensusManaHistory) vectorAt(t time.Time) (NodeMap, error) {
+	index := sort.Search(len(h.checkpointTimes), func(i int) bool {
+		return h.checkpointTimes[i].After(t)
+	}) - 1
+	if index < 0 {
+		return nil, errors.Errorf("no consensus mana history available for %s: %w", t, ErrConsensusHistoryUnavailable)
+	}
+	checkpointTime := h.checkpointTimes[index]
+	vector, err := h.loadCheckpoint(checkpointTime)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if err := h.forEachEventInRange(checkpointTime, t, vector.apply); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return vector.toNodeMap(), nil
+}
+
+// forEachEventInRange calls the consumer for every logged event with a timestamp within (startTime, endTime].
+func (h *ConsensusManaHistory) forEachEventInRange(startTime, endTime time.Time, consumer func(*PersistableEvent)) (err error) {
+	for bucket := eventBucket(startTime); bucket <= eventBucket(endTime); bucket++ {
+		prefix := make([]byte, 8)
+		binary.BigEndian.PutUint64(prefix, bucket)
+		if iterErr := h.eventStore.IterateKeys(prefix, func(key kvstore.Key) bool {
+			eventTime := timeFromKey(key[8:])
+			if !eventTime.After(startTime) || eventTime.After(endTime) {
+				return true
+			}
+			var persistable *PersistableEvent
+			if persistable, err = parseEvent(marshalutil.New(key[16:])); err != nil {
+				return false
+			}
+			consumer(persistable)
+			return true
+		}); iterErr != nil {
+			return errors.Wrap(iterErr, "failed to iterate over consensus mana events")
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to parse consensus mana event")
+		}
+	}
+	return nil
+}
+
+func (h *ConsensusManaHistory) addCheckpoint(t time.Time, vector NodeMap) error {
+	if err := h.storeCheckpoint(t, checkpointVector(vector)); err != nil {
+		return errors.WithStack(err)
+	}
+	h.checkpointTimes = append(h.checkpointTimes, t)
+	return nil
+}
+
+func (h *ConsensusManaHistory) storeCheckpoint(t time.Time, vector checkpointVector) error {
+	if err := h.checkpointStore.Set(timeKey(t), vector.Bytes()); err != nil {
+		return errors.Wrapf(err, "failed to store consensus mana checkpoint at %s", t)
+	}
+	return nil
+}
+
+func (h *ConsensusManaHistory) loadCheckpoint(t time.Time) (checkpointVector, error) {
+	value, err := h.checkpointStore.Get(timeKey(t))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load consensus mana checkpoint at %s", t)
+	}
+	return checkpointVectorFromBytes(value)
+}
+
+// checkpointVector is the consensus mana vector stored in a checkpoint.
+type checkpointVector NodeMap
+
+func (c checkpointVector) apply(ev *PersistableEvent) {
+	switch ev.Type {
+	case EventTypePledge:
+		c[ev.NodeID] += ev.Amount
+	case EventTypeRevoke:
+		c[ev.NodeID] -= ev.Amount
+	}
+	if c[ev.NodeID] == 0 {
+		delete(c, ev.NodeID)
+	}
+}
+
+func (c checkpointVector) toNodeMap() NodeMap {
+	return NodeMap(c)
+}
+
+// Bytes returns a compact binary representation of the vector, nodes without mana are omitted.
+func (c checkpointVector) Bytes() []byte {
+	var count uint32
+	for _, value := range c {
+		if value != 0 {
+			count++
+		}
+	}
+	marshalUtil := marshalutil.New(4 + int(count)*(identity.IDLength+8))
+	marshalUtil.WriteUint32(count)
+	for nodeID, value := range c {
+		if value == 0 {
+			continue
+		}
+		marshalUtil.WriteBytes(nodeID.Bytes())
+		marshalUtil.WriteUint64(math.Float64bits(value))
+	}
+	return marshalUtil.Bytes()
+}
+
+func checkpointVectorFromBytes(bytes []byte) (checkpointVector, error) {
+	marshalUtil := marshalutil.New(bytes)
+	count, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse node count of consensus mana checkpoint")
+	}
+	vector := make(checkpointVector, count)
+	for i := uint32(0); i < count; i++ {
+		nodeIDBytes, err := marshalUtil.ReadBytes(identity.IDLength)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse node ID of consensus mana checkpoint")
+		}
+		var nodeID identity.ID
+		copy(nodeID[:], nodeIDBytes)
+		value, err := marshalUtil.ReadUint64()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse mana of consensus mana checkpoint")
+		}
+		vector[nodeID] = math.Float64frombits(value)
+	}
+	return vector, nil
+}
+
+// eventKey returns the key of an event in the event store: bucket || time || event bytes.
+func eventKey(ev *PersistableEvent) []byte {
+	key := make([]byte, 16, 16+len(ev.Bytes()))
+	binary.BigEndian.PutUint64(key[:8], eventBucket(ev.Time))
+	copy(key[8:16], timeKey(ev.Time))
+	return append(key, ev.Bytes()...)
+}
+
+func eventBucket(t time.Time) uint64 {
+	return uint64(t.UnixNano() / int64(eventBucketDuration))
+}
+
+func timeKey(t time.Time) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(t.UnixNano()))
+	return key
+}
+
+func timeFromKey(key []byte) time.Time {
+	return time.Unix(0, int64(binary.BigEndian.Uint64(key[:8])))
+}
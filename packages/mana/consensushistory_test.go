@@ -0,0 +1,74 @@
+package mana
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsensusManaHistory(t *testing.T) {
+	store := mapdb.NewMapDB()
+	history, err := NewConsensusManaHistory(store)
+	require.NoError(t, err)
+
+	nodeA, nodeB := randNodeID(), randNodeID()
+	start := time.Unix(1600000000, 0)
+
+	_, err = history.ManaMapAt(start)
+	assert.ErrorIs(t, err, ErrConsensusHistoryUnavailable)
+
+	require.NoError(t, history.CreateCheckpoint(start, NodeMap{nodeA: 100}))
+	require.NoError(t, history.RecordEvent(&PledgedEvent{NodeID: nodeB, Amount: 40, Time: start.Add(30 * time.Minute), ManaType: ConsensusMana}))
+	require.NoError(t, history.RecordEvent(&RevokedEvent{NodeID: nodeA, Amount: 40, Time: start.Add(30 * time.Minute), ManaType: ConsensusMana}))
+	// access mana events are not part of the history
+	require.NoError(t, history.RecordEvent(&PledgedEvent{NodeID: nodeB, Amount: 1000, Time: start.Add(30 * time.Minute), ManaType: AccessMana}))
+	require.NoError(t, history.RecordEvent(&PledgedEvent{NodeID: nodeB, Amount: 10, Time: start.Add(2 * time.Hour), ManaType: ConsensusMana}))
+
+	manaMap, err := history.ManaMapAt(start.Add(10 * time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, NodeMap{nodeA: 100}, manaMap)
+
+	manaMap, err = history.ManaMapAt(start.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, NodeMap{nodeA: 60, nodeB: 40}, manaMap)
+
+	value, err := history.ManaAt(nodeB, start.Add(3*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 50.0, value)
+
+	require.NoError(t, history.CreateCheckpoint(start.Add(time.Hour), nil))
+	assert.Error(t, history.CreateCheckpoint(start.Add(time.Hour), nil))
+
+	// an event that is confirmed late corrects the checkpoints after it
+	require.NoError(t, history.RecordEvent(&PledgedEvent{NodeID: nodeA, Amount: 5, Time: start.Add(45 * time.Minute), ManaType: ConsensusMana}))
+	nodes, err := history.HighestManaNodesAt(start.Add(time.Hour), 1)
+	require.NoError(t, err)
+	assert.Equal(t, []Node{{ID: nodeA, Mana: 65}}, nodes)
+
+	events, err := history.Events(nil, start, start.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Len(t, events, 3)
+	events, err = history.Events([]identity.ID{nodeB}, start, start.Add(3*time.Hour))
+	require.NoError(t, err)
+	assert.Len(t, events, 2)
+
+	require.NoError(t, history.Prune(start.Add(90*time.Minute)))
+	oldest, ok := history.OldestAvailableTime()
+	require.True(t, ok)
+	assert.Equal(t, start.Add(time.Hour), oldest)
+	_, err = history.ManaMapAt(start.Add(30 * time.Minute))
+	assert.ErrorIs(t, err, ErrConsensusHistoryUnavailable)
+	value, err = history.ManaAt(nodeB, start.Add(3*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 50.0, value)
+
+	// the history is restored from the store
+	restored, err := NewConsensusManaHistory(store)
+	require.NoError(t, err)
+	_, ok = restored.OldestAvailableTime()
+	assert.True(t, ok)
+}
@@ -19,4 +19,6 @@ var (
 	ErrInvalidTargetManaType = errors.New("invalid target mana type")
 	// ErrUnknownManaEvent is returned if mana event type could not be identified.
 	ErrUnknownManaEvent = errors.New("unknown mana event")
+	// ErrConsensusHistoryUnavailable is returned if the consensus mana history does not cover the requested time.
+	ErrConsensusHistoryUnavailable = errors.New("consensus mana history not available")
 )
@@ -21,4 +21,10 @@ const (
 
 	// PrefixConsensusPastMetadata is the storage prefix for consensus mana past vector metadata storage.
 	PrefixConsensusPastMetadata
+
+	// PrefixConsensusHistoryCheckpoint is the storage prefix for the checkpoints of the consensus mana history.
+	PrefixConsensusHistoryCheckpoint
+
+	// PrefixConsensusHistoryEvent is the storage prefix for the events of the consensus mana history.
+	PrefixConsensusHistoryEvent
 )
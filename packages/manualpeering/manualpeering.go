@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"net"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -16,12 +17,17 @@ import (
 	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore"
 	"github.com/iotaledger/hive.go/logger"
 
 	"github.com/iotaledger/goshimmer/packages/gossip"
 )
 
-const defaultReconnectInterval = 5 * time.Second
+const (
+	defaultReconnectInterval = 5 * time.Second
+	// maxDisconnectRecords defines how many of the most recent disconnects are kept in the connection history of a peer.
+	maxDisconnectRecords = 10
+)
 
 // ConnectionDirection is an enum for the type of connection between local peer and the other peer in the gossip layer.
 type ConnectionDirection string
@@ -47,14 +53,49 @@ const (
 type KnownPeerToAdd struct {
 	PublicKey ed25519.PublicKey `json:"publicKey"`
 	Address   string            `json:"address"`
+	// Labels are free-form tags that can be used to group and filter known peers.
+	Labels []string `json:"labels,omitempty"`
+	// Priority defines the order in which the known peers are connected, peers with a higher priority come first.
+	Priority int `json:"priority,omitempty"`
+	// Trusted peers are excluded from the autopeering selection, their connection is only managed by the manual peering layer.
+	Trusted bool `json:"trusted,omitempty"`
 }
 
 // KnownPeer defines a peer record in the manual peering layer.
 type KnownPeer struct {
 	PublicKey     ed25519.PublicKey   `json:"publicKey"`
 	Address       string              `json:"address"`
+	Labels        []string            `json:"labels,omitempty"`
+	Priority      int                 `json:"priority"`
+	Trusted       bool                `json:"trusted"`
 	ConnDirection ConnectionDirection `json:"connectionDirection"`
 	ConnStatus    ConnectionStatus    `json:"connectionStatus"`
+	ConnHistory   *ConnectionHistory  `json:"connectionHistory"`
+}
+
+// ConnectionHistory holds the information about past connections of a known peer since the node started.
+type ConnectionHistory struct {
+	LastConnected       time.Time           `json:"lastConnected"`
+	LastDisconnected    time.Time           `json:"lastDisconnected"`
+	LastConnectionError string              `json:"lastConnectionError,omitempty"`
+	Disconnects         []*DisconnectRecord `json:"disconnects"`
+}
+
+// DisconnectRecord describes a single disconnect of a known peer in the gossip layer.
+type DisconnectRecord struct {
+	Time   time.Time `json:"time"`
+	Reason string    `json:"reason"`
+}
+
+// ManagerOption defines an option for the Manager.
+type ManagerOption func(m *Manager)
+
+// WithStore returns a ManagerOption that makes the manager persist its known peers in the given store.
+// The persisted peers are loaded when the manager is started.
+func WithStore(store kvstore.KVStore) ManagerOption {
+	return func(m *Manager) {
+		m.peerStore = newPeerStore(store)
+	}
 }
 
 // Manager is the core entity in the manual peering package.
@@ -77,13 +118,14 @@ type Manager struct {
 	reconnectInterval time.Duration
 	knownPeersMutex   sync.RWMutex
 	knownPeers        map[identity.ID]*knownPeer
+	peerStore         *peerStore
 
 	onGossipNeighborRemovedClosure *events.Closure
 	onGossipNeighborAddedClosure   *events.Closure
 }
 
 // NewManager initializes a new Manager instance.
-func NewManager(gm *gossip.Manager, local *peer.Local, log *logger.Logger) *Manager {
+func NewManager(gm *gossip.Manager, local *peer.Local, log *logger.Logger, opts ...ManagerOption) *Manager {
 	m := &Manager{
 		gm:                gm,
 		local:             local,
@@ -91,16 +133,38 @@ func NewManager(gm *gossip.Manager, local *peer.Local, log *logger.Logger) *Mana
 		reconnectInterval: defaultReconnectInterval,
 		knownPeers:        map[identity.ID]*knownPeer{},
 	}
+	for _, opt := range opts {
+		opt(m)
+	}
 	m.onGossipNeighborRemovedClosure = events.NewClosure(m.onGossipNeighborRemoved)
 	m.onGossipNeighborAddedClosure = events.NewClosure(m.onGossipNeighborAdded)
 	return m
 }
 
-// AddPeer adds multiple peers to the list of known peers.
+// AddPeer adds multiple peers to the list of known peers and persists them.
+// If a peer is already known, its labels, priority and trusted flag are updated.
+// Peers are connected in the order of their priority.
 func (m *Manager) AddPeer(peers ...*KnownPeerToAdd) error {
+	return m.addPeers(peers, false)
+}
+
+// AddConfigPeer adds multiple peers from the node configuration to the list of known peers.
+// Config peers are not persisted, as the configuration provides them again on every start.
+// If a peer is already known from the store or the API, it is left untouched, so that its labels,
+// priority and trusted flag are not overwritten by the configuration.
+func (m *Manager) AddConfigPeer(peers ...*KnownPeerToAdd) error {
+	return m.addPeers(peers, true)
+}
+
+func (m *Manager) addPeers(peers []*KnownPeerToAdd, fromConfig bool) error {
+	sortedPeers := make([]*KnownPeerToAdd, len(peers))
+	copy(sortedPeers, peers)
+	sort.SliceStable(sortedPeers, func(i, j int) bool {
+		return sortedPeers[i].Priority > sortedPeers[j].Priority
+	})
 	var resultErr error
-	for _, p := range peers {
-		if err := m.addPeer(p); err != nil {
+	for _, p := range sortedPeers {
+		if err := m.addPeer(p, fromConfig); err != nil {
 			resultErr = errors.CombineErrors(resultErr, err)
 		}
 	}
@@ -118,10 +182,31 @@ func (m *Manager) RemovePeer(keys ...ed25519.PublicKey) error {
 	return resultErr
 }
 
+// RemoveConfigPeer removes multiple peers that were added from the node configuration.
+// Peers that were added or updated through AddPeer in the meantime are kept.
+func (m *Manager) RemoveConfigPeer(keys ...ed25519.PublicKey) error {
+	m.knownPeersMutex.Lock()
+	defer m.knownPeersMutex.Unlock()
+	var resultErr error
+	for _, key := range keys {
+		peerID := identity.NewID(key)
+		if kp, exists := m.knownPeers[peerID]; !exists || !kp.isFromConfig() {
+			continue
+		}
+		m.log.Infow("Removing config peer from the list of known peers in manual peering", "publicKey", key)
+		if err := m.removePeerByID(peerID); err != nil {
+			resultErr = errors.CombineErrors(resultErr, err)
+		}
+	}
+	return resultErr
+}
+
 // GetPeersConfig holds optional parameters for the GetPeers method.
 type GetPeersConfig struct {
 	// If true, GetPeers returns peers that have actual connection established in the gossip layer.
 	OnlyConnected bool `json:"onlyConnected"`
+	// If not empty, GetPeers returns only peers that are tagged with this label.
+	Label string `json:"label,omitempty"`
 }
 
 // GetPeersOption defines a single option for GetPeers method.
@@ -141,6 +226,9 @@ func (c *GetPeersConfig) ToOptions() (opts []GetPeersOption) {
 	if c.OnlyConnected {
 		opts = append(opts, WithOnlyConnectedPeers())
 	}
+	if c.Label != "" {
+		opts = append(opts, WithLabel(c.Label))
+	}
 	return opts
 }
 
@@ -151,7 +239,14 @@ func WithOnlyConnectedPeers() GetPeersOption {
 	}
 }
 
-// GetPeers returns the list of known peers.
+// WithLabel returns a GetPeersOption that only selects peers tagged with the given label.
+func WithLabel(label string) GetPeersOption {
+	return func(conf *GetPeersConfig) {
+		conf.Label = label
+	}
+}
+
+// GetPeers returns the list of known peers ordered by their priority.
 func (m *Manager) GetPeers(opts ...GetPeersOption) []*KnownPeer {
 	conf := BuildGetPeersConfig(opts)
 	m.knownPeersMutex.RLock()
@@ -159,18 +254,31 @@ func (m *Manager) GetPeers(opts ...GetPeersOption) []*KnownPeer {
 	peers := make([]*KnownPeer, 0, len(m.knownPeers))
 	for _, kp := range m.knownPeers {
 		connStatus := kp.getConnStatus()
-		if !conf.OnlyConnected || connStatus == ConnStatusConnected {
-			peers = append(peers, &KnownPeer{
-				PublicKey:     kp.peer.PublicKey(),
-				Address:       kp.peerAddress,
-				ConnDirection: kp.connDirection,
-				ConnStatus:    connStatus,
-			})
+		if conf.OnlyConnected && connStatus != ConnStatusConnected {
+			continue
+		}
+		if conf.Label != "" && !kp.hasLabel(conf.Label) {
+			continue
 		}
+		peers = append(peers, kp.toKnownPeer())
 	}
+	sort.SliceStable(peers, func(i, j int) bool {
+		return peers[i].Priority > peers[j].Priority
+	})
 	return peers
 }
 
+// IsTrusted returns true if the peer with the given ID is a known peer that is marked as trusted.
+func (m *Manager) IsTrusted(peerID identity.ID) bool {
+	m.knownPeersMutex.RLock()
+	defer m.knownPeersMutex.RUnlock()
+	kp, exists := m.knownPeers[peerID]
+	if !exists {
+		return false
+	}
+	return kp.getInfo().Trusted
+}
+
 // Start subscribes to the gossip layer events and starts internal background workers.
 // Calling multiple times has no effect.
 func (m *Manager) Start() {
@@ -178,9 +286,27 @@ func (m *Manager) Start() {
 		m.gm.NeighborsEvents(gossip.NeighborsGroupManual).NeighborRemoved.Attach(m.onGossipNeighborRemovedClosure)
 		m.gm.NeighborsEvents(gossip.NeighborsGroupManual).NeighborAdded.Attach(m.onGossipNeighborAddedClosure)
 		m.isStarted.Set()
+		m.addPeersFromStore()
 	})
 }
 
+func (m *Manager) addPeersFromStore() {
+	if m.peerStore == nil {
+		return
+	}
+	peers, err := m.peerStore.loadAll()
+	if err != nil {
+		m.log.Errorw("Failed to load some of the known peers from the store", "err", err)
+	}
+	if len(peers) == 0 {
+		return
+	}
+	m.log.Infow("Adding known peers from the store", "peers", peers)
+	if err := m.AddPeer(peers...); err != nil {
+		m.log.Errorw("Failed to add known peers from the store", "err", err)
+	}
+}
+
 // Stop terminates internal background workers. Calling multiple times has no effect.
 func (m *Manager) Stop() (err error) {
 	if !m.isStarted.IsSet() {
@@ -204,9 +330,14 @@ type knownPeer struct {
 	connStatus    *atomic.Value
 	removeCh      chan struct{}
 	doneCh        chan struct{}
+
+	infoMutex   sync.RWMutex
+	info        *KnownPeerToAdd
+	fromConfig  bool
+	connHistory ConnectionHistory
 }
 
-func newKnownPeer(p *KnownPeerToAdd, connDirection ConnectionDirection) (*knownPeer, error) {
+func newKnownPeer(p *KnownPeerToAdd, connDirection ConnectionDirection, fromConfig bool) (*knownPeer, error) {
 	tcpAddress, err := net.ResolveTCPAddr("tcp", p.Address)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to parse peer address")
@@ -223,11 +354,87 @@ func newKnownPeer(p *KnownPeerToAdd, connDirection ConnectionDirection) (*knownP
 		connStatus:    &atomic.Value{},
 		removeCh:      make(chan struct{}),
 		doneCh:        make(chan struct{}),
+		info:          p,
+		fromConfig:    fromConfig,
 	}
 	kp.setConnStatus(ConnStatusDisconnected)
 	return kp, nil
 }
 
+func (kp *knownPeer) getInfo() *KnownPeerToAdd {
+	kp.infoMutex.RLock()
+	defer kp.infoMutex.RUnlock()
+	return kp.info
+}
+
+func (kp *knownPeer) setInfo(p *KnownPeerToAdd, fromConfig bool) {
+	kp.infoMutex.Lock()
+	defer kp.infoMutex.Unlock()
+	kp.info = p
+	kp.fromConfig = fromConfig
+}
+
+func (kp *knownPeer) isFromConfig() bool {
+	kp.infoMutex.RLock()
+	defer kp.infoMutex.RUnlock()
+	return kp.fromConfig
+}
+
+func (kp *knownPeer) hasLabel(label string) bool {
+	for _, l := range kp.getInfo().Labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+func (kp *knownPeer) toKnownPeer() *KnownPeer {
+	kp.infoMutex.RLock()
+	defer kp.infoMutex.RUnlock()
+	info := kp.info
+	connHistory := kp.connHistory
+	connHistory.Disconnects = make([]*DisconnectRecord, len(kp.connHistory.Disconnects))
+	copy(connHistory.Disconnects, kp.connHistory.Disconnects)
+	return &KnownPeer{
+		PublicKey:     kp.peer.PublicKey(),
+		Address:       kp.peerAddress,
+		Labels:        info.Labels,
+		Priority:      info.Priority,
+		Trusted:       info.Trusted,
+		ConnDirection: kp.connDirection,
+		ConnStatus:    kp.getConnStatus(),
+		ConnHistory:   &connHistory,
+	}
+}
+
+func (kp *knownPeer) recordConnected() {
+	kp.infoMutex.Lock()
+	defer kp.infoMutex.Unlock()
+	kp.connHistory.LastConnected = time.Now()
+	kp.connHistory.LastConnectionError = ""
+}
+
+func (kp *knownPeer) recordDisconnected(reason error) {
+	kp.infoMutex.Lock()
+	defer kp.infoMutex.Unlock()
+	record := &DisconnectRecord{Time: time.Now(), Reason: "unknown"}
+	if reason != nil {
+		record.Reason = reason.Error()
+	}
+	kp.connHistory.LastDisconnected = record.Time
+	kp.connHistory.Disconnects = append(kp.connHistory.Disconnects, record)
+	if len(kp.connHistory.Disconnects) > maxDisconnectRecords {
+		kp.connHistory.Disconnects = kp.connHistory.Disconnects[len(kp.connHistory.Disconnects)-maxDisconnectRecords:]
+	}
+}
+
+func (kp *knownPeer) recordConnectionError(err error) {
+	kp.infoMutex.Lock()
+	defer kp.infoMutex.Unlock()
+	kp.connHistory.LastConnectionError = err.Error()
+}
+
 func (kp *knownPeer) getConnStatus() ConnectionStatus {
 	return kp.connStatus.Load().(ConnectionStatus)
 }
@@ -236,7 +443,7 @@ func (kp *knownPeer) setConnStatus(cs ConnectionStatus) {
 	kp.connStatus.Store(cs)
 }
 
-func (m *Manager) addPeer(p *KnownPeerToAdd) error {
+func (m *Manager) addPeer(p *KnownPeerToAdd, fromConfig bool) error {
 	if !m.isStarted.IsSet() {
 		return errors.New("manual peering manager hasn't been started yet")
 	}
@@ -251,12 +458,26 @@ func (m *Manager) addPeer(p *KnownPeerToAdd) error {
 	if err != nil {
 		return errors.WithStack(err)
 	}
-	kp, err := newKnownPeer(p, connDirection)
+	kp, err := newKnownPeer(p, connDirection, fromConfig)
 	if err != nil {
 		return errors.WithStack(err)
 	}
-	if _, exists := m.knownPeers[kp.peer.ID()]; exists {
-		return nil
+	if existing, exists := m.knownPeers[kp.peer.ID()]; exists {
+		// the metadata of peers that were added through the API takes precedence over the configuration
+		if fromConfig && !existing.isFromConfig() {
+			return nil
+		}
+		if existing.peerAddress == p.Address {
+			existing.setInfo(p, fromConfig)
+			return errors.WithStack(m.savePeer(p, fromConfig))
+		}
+		m.log.Infow("Address of a known peer changed, reconnecting", "peer", p)
+		if err := m.removePeerByID(kp.peer.ID()); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	if err := m.savePeer(p, fromConfig); err != nil {
+		return errors.WithStack(err)
 	}
 	m.log.Infow("Adding new peer to the list of known peers in manual peering", "peer", p)
 	m.knownPeers[kp.peer.ID()] = kp
@@ -273,8 +494,20 @@ func (m *Manager) removePeer(key ed25519.PublicKey) error {
 	m.log.Infow("Removing peer from from the list of known peers in manual peering",
 		"publicKey", key)
 	peerID := identity.NewID(key)
-	err := m.removePeerByID(peerID)
-	return errors.WithStack(err)
+	if err := m.removePeerByID(peerID); err != nil {
+		return errors.WithStack(err)
+	}
+	if m.peerStore == nil {
+		return nil
+	}
+	return errors.WithStack(m.peerStore.delete(key))
+}
+
+func (m *Manager) savePeer(p *KnownPeerToAdd, fromConfig bool) error {
+	if m.peerStore == nil || fromConfig {
+		return nil
+	}
+	return m.peerStore.save(p)
 }
 
 func (m *Manager) removeAllKnownPeers() error {
@@ -328,6 +561,7 @@ func (m *Manager) keepPeerConnected(kp *knownPeer) {
 				err = m.gm.AddInbound(ctx, kp.peer, gossip.NeighborsGroupManual, gossip.WithNoDefaultTimeout())
 			}
 			if err != nil && !errors.Is(err, gossip.ErrDuplicateNeighbor) && !errors.Is(err, context.Canceled) {
+				kp.recordConnectionError(err)
 				m.log.Errorw(
 					"Failed to connect a neighbor in the gossip layer",
 					"peerID", peerID, "connectionDirection", kp.connDirection, "err", err,
@@ -363,6 +597,11 @@ func (m *Manager) changeNeighborStatus(neighbor *gossip.Neighbor, connStatus Con
 		return
 	}
 	kp.setConnStatus(connStatus)
+	if connStatus == ConnStatusConnected {
+		kp.recordConnected()
+	} else {
+		kp.recordDisconnected(neighbor.DisconnectReason())
+	}
 }
 
 func (m *Manager) connectionDirection(peerPK ed25519.PublicKey) (ConnectionDirection, error) {
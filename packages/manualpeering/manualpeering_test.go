@@ -0,0 +1,173 @@
+package manualpeering
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/autopeering/peer/service"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/hive.go/logger"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/gossip"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+func TestManager_Labels(t *testing.T) {
+	mgr := newTestManager(t)
+	peerA := newTestPeer(0, "exchange", "eu")
+	peerB := newTestPeer(0, "eu")
+	require.NoError(t, mgr.AddPeer(peerA, peerB))
+
+	assert.ElementsMatch(t, []ed25519.PublicKey{peerA.PublicKey, peerB.PublicKey}, publicKeys(mgr.GetPeers(WithLabel("eu"))))
+	assert.Equal(t, []ed25519.PublicKey{peerA.PublicKey}, publicKeys(mgr.GetPeers(WithLabel("exchange"))))
+	assert.Empty(t, mgr.GetPeers(WithLabel("us")))
+
+	// updating a known peer replaces its labels
+	require.NoError(t, mgr.AddPeer(&KnownPeerToAdd{PublicKey: peerA.PublicKey, Address: peerA.Address, Labels: []string{"us"}}))
+	assert.Equal(t, []ed25519.PublicKey{peerB.PublicKey}, publicKeys(mgr.GetPeers(WithLabel("eu"))))
+	assert.Equal(t, []ed25519.PublicKey{peerA.PublicKey}, publicKeys(mgr.GetPeers(WithLabel("us"))))
+}
+
+func TestManager_Priority(t *testing.T) {
+	mgr := newTestManager(t)
+	low := newTestPeer(-1)
+	medium := newTestPeer(0)
+	high := newTestPeer(5)
+	require.NoError(t, mgr.AddPeer(medium, low, high))
+
+	assert.Equal(t, []ed25519.PublicKey{high.PublicKey, medium.PublicKey, low.PublicKey}, publicKeys(mgr.GetPeers()))
+
+	require.NoError(t, mgr.AddPeer(&KnownPeerToAdd{PublicKey: low.PublicKey, Address: low.Address, Priority: 10}))
+	assert.Equal(t, []ed25519.PublicKey{low.PublicKey, high.PublicKey, medium.PublicKey}, publicKeys(mgr.GetPeers()))
+}
+
+func TestManager_Trusted(t *testing.T) {
+	mgr := newTestManager(t)
+	trusted := newTestPeer(0)
+	trusted.Trusted = true
+	untrusted := newTestPeer(0)
+	require.NoError(t, mgr.AddPeer(trusted, untrusted))
+
+	assert.True(t, mgr.IsTrusted(identity.NewID(trusted.PublicKey)))
+	assert.False(t, mgr.IsTrusted(identity.NewID(untrusted.PublicKey)))
+	assert.False(t, mgr.IsTrusted(identity.NewID(ed25519.GenerateKeyPair().PublicKey)))
+
+	require.NoError(t, mgr.AddPeer(&KnownPeerToAdd{PublicKey: trusted.PublicKey, Address: trusted.Address}))
+	assert.False(t, mgr.IsTrusted(identity.NewID(trusted.PublicKey)))
+
+	require.NoError(t, mgr.RemovePeer(untrusted.PublicKey))
+	assert.False(t, mgr.IsTrusted(identity.NewID(untrusted.PublicKey)))
+}
+
+func TestManager_ConfigPeers(t *testing.T) {
+	store := mapdb.NewMapDB()
+	mgr := newTestManager(t, WithStore(store))
+	apiPeer := newTestPeer(3, "api")
+	apiPeer.Trusted = true
+	configPeer := newTestPeer(0, "config")
+	require.NoError(t, mgr.AddPeer(apiPeer))
+
+	// the config doesn't overwrite the metadata of a peer that was added through the API
+	require.NoError(t, mgr.AddConfigPeer(&KnownPeerToAdd{PublicKey: apiPeer.PublicKey, Address: apiPeer.Address}, configPeer))
+	assert.True(t, mgr.IsTrusted(identity.NewID(apiPeer.PublicKey)))
+	assert.Equal(t, []ed25519.PublicKey{apiPeer.PublicKey}, publicKeys(mgr.GetPeers(WithLabel("api"))))
+	assert.Equal(t, []ed25519.PublicKey{configPeer.PublicKey}, publicKeys(mgr.GetPeers(WithLabel("config"))))
+
+	// config peers are not persisted
+	stored, err := newPeerStore(store).loadAll()
+	require.NoError(t, err)
+	assert.Equal(t, []*KnownPeerToAdd{apiPeer}, stored)
+
+	// only the peers that were added from the config are removed with it
+	require.NoError(t, mgr.RemoveConfigPeer(apiPeer.PublicKey, configPeer.PublicKey))
+	assert.Equal(t, []ed25519.PublicKey{apiPeer.PublicKey}, publicKeys(mgr.GetPeers()))
+
+	// the stored peers are loaded by a restarted manager
+	require.NoError(t, mgr.Stop())
+	restarted := newTestManager(t, WithStore(store))
+	assert.Equal(t, []ed25519.PublicKey{apiPeer.PublicKey}, publicKeys(restarted.GetPeers()))
+	assert.True(t, restarted.IsTrusted(identity.NewID(apiPeer.PublicKey)))
+}
+
+func TestKnownPeer_ConnectionHistory(t *testing.T) {
+	kp, err := newKnownPeer(newTestPeer(0), ConnDirectionOutbound, false)
+	require.NoError(t, err)
+
+	kp.recordConnectionError(errors.New("dial failed"))
+	assert.Equal(t, "dial failed", kp.toKnownPeer().ConnHistory.LastConnectionError)
+
+	kp.recordConnected()
+	history := kp.toKnownPeer().ConnHistory
+	assert.False(t, history.LastConnected.IsZero())
+	assert.Empty(t, history.LastConnectionError)
+
+	kp.recordDisconnected(nil)
+	assert.Equal(t, "unknown", kp.toKnownPeer().ConnHistory.Disconnects[0].Reason)
+	for i := 0; i < maxDisconnectRecords; i++ {
+		kp.recordDisconnected(gossip.ErrNeighborDropped)
+	}
+	history = kp.toKnownPeer().ConnHistory
+	require.Len(t, history.Disconnects, maxDisconnectRecords)
+	assert.Equal(t, history.Disconnects[maxDisconnectRecords-1].Time, history.LastDisconnected)
+	for _, record := range history.Disconnects {
+		assert.Equal(t, gossip.ErrNeighborDropped.Error(), record.Reason)
+	}
+
+	// the returned history is a copy
+	history.Disconnects = history.Disconnects[:0]
+	assert.Len(t, kp.toKnownPeer().ConnHistory.Disconnects, maxDisconnectRecords)
+}
+
+func newTestPeer(priority int, labels ...string) *KnownPeerToAdd {
+	return &KnownPeerToAdd{
+		PublicKey: ed25519.GenerateKeyPair().PublicKey,
+		Address:   "127.0.0.1:14666",
+		Labels:    labels,
+		Priority:  priority,
+	}
+}
+
+// newTestManager returns a started manager whose gossip layer can't connect to any of the known peers.
+func newTestManager(t *testing.T, opts ...ManagerOption) *Manager {
+	log := logger.NewExampleLogger("manualpeering")
+	services := service.New()
+	services.Update(service.PeeringKey, "peering", 0)
+	local, err := peer.NewLocal(net.ParseIP("127.0.0.1"), services, peerDB(t))
+	require.NoError(t, err)
+	host, err := mocknet.New(context.Background()).GenPeer()
+	require.NoError(t, err)
+
+	gm := gossip.NewManager(host, local, func(tangle.MessageID) ([]byte, error) {
+		return nil, errors.New("not found")
+	}, log)
+	mgr := NewManager(gm, local, log, opts...)
+	mgr.Start()
+	t.Cleanup(func() {
+		_ = mgr.Stop()
+		gm.Stop()
+		_ = host.Close()
+	})
+	return mgr
+}
+
+func peerDB(t *testing.T) *peer.DB {
+	db, err := peer.NewDB(mapdb.NewMapDB())
+	require.NoError(t, err)
+	return db
+}
+
+func publicKeys(peers []*KnownPeer) []ed25519.PublicKey {
+	keys := make([]ed25519.PublicKey, len(peers))
+	for i, p := range peers {
+		keys[i] = p.PublicKey
+	}
+	return keys
+}
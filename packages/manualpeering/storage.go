@@ -0,0 +1,110 @@
+package manualpeering
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
+)
+
+// peerStore persists the known peers of the manual peering layer in the node database.
+type peerStore struct {
+	store kvstore.KVStore
+}
+
+func newPeerStore(store kvstore.KVStore) *peerStore {
+	return &peerStore{store: store}
+}
+
+// save stores the given peer and overwrites a previously stored peer with the same public key.
+func (ps *peerStore) save(p *KnownPeerToAdd) error {
+	if err := ps.store.Set(identity.NewID(p.PublicKey).Bytes(), p.Bytes()); err != nil {
+		return errors.Wrapf(err, "failed to store known peer %s", p.PublicKey)
+	}
+	return nil
+}
+
+// delete removes the peer with the given public key from the store.
+func (ps *peerStore) delete(key ed25519.PublicKey) error {
+	if err := ps.store.Delete(identity.NewID(key).Bytes()); err != nil && !errors.Is(err, kvstore.ErrKeyNotFound) {
+		return errors.Wrapf(err, "failed to delete known peer %s", key)
+	}
+	return nil
+}
+
+// loadAll returns all the peers that are stored.
+func (ps *peerStore) loadAll() (peers []*KnownPeerToAdd, err error) {
+	if iterErr := ps.store.Iterate(kvstore.EmptyPrefix, func(_ kvstore.Key, value kvstore.Value) bool {
+		p, parseErr := KnownPeerToAddFromBytes(value)
+		if parseErr != nil {
+			err = errors.CombineErrors(err, parseErr)
+			return true
+		}
+		peers = append(peers, p)
+		return true
+	}); iterErr != nil {
+		return nil, errors.Wrap(iterErr, "failed to iterate over the stored known peers")
+	}
+	return peers, err
+}
+
+// Bytes returns a marshaled version of the KnownPeerToAdd.
+func (p *KnownPeerToAdd) Bytes() []byte {
+	marshalUtil := marshalutil.New().
+		Write(p.PublicKey).
+		WriteUint16(uint16(len(p.Address))).
+		WriteBytes([]byte(p.Address)).
+		WriteInt64(int64(p.Priority)).
+		WriteBool(p.Trusted).
+		WriteUint16(uint16(len(p.Labels)))
+	for _, label := range p.Labels {
+		marshalUtil.WriteUint16(uint16(len(label))).WriteBytes([]byte(label))
+	}
+	return marshalUtil.Bytes()
+}
+
+// KnownPeerToAddFromBytes unmarshals a KnownPeerToAdd from a sequence of bytes.
+func KnownPeerToAddFromBytes(bytes []byte) (p *KnownPeerToAdd, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	p = &KnownPeerToAdd{}
+	if p.PublicKey, err = ed25519.ParsePublicKey(marshalUtil); err != nil {
+		return nil, errors.Wrap(err, "failed to parse public key")
+	}
+	if p.Address, err = readString(marshalUtil); err != nil {
+		return nil, errors.Wrap(err, "failed to parse address")
+	}
+	priority, err := marshalUtil.ReadInt64()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse priority")
+	}
+	p.Priority = int(priority)
+	if p.Trusted, err = marshalUtil.ReadBool(); err != nil {
+		return nil, errors.Wrap(err, "failed to parse trusted flag")
+	}
+	labelsCount, err := marshalUtil.ReadUint16()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse labels count")
+	}
+	if labelsCount > 0 {
+		p.Labels = make([]string, labelsCount)
+	}
+	for i := range p.Labels {
+		if p.Labels[i], err = readString(marshalUtil); err != nil {
+			return nil, errors.Wrap(err, "failed to parse label")
+		}
+	}
+	return p, nil
+}
+
+func readString(marshalUtil *marshalutil.MarshalUtil) (string, error) {
+	length, err := marshalUtil.ReadUint16()
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	bytes, err := marshalUtil.ReadBytes(int(length))
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return string(bytes), nil
+}
@@ -0,0 +1,46 @@
+package manualpeering
+
+import (
+	"testing"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKnownPeerToAdd_Bytes(t *testing.T) {
+	keyPair := ed25519.GenerateKeyPair()
+	p := &KnownPeerToAdd{
+		PublicKey: keyPair.PublicKey,
+		Address:   "127.0.0.1:14666",
+		Labels:    []string{"exchange", "eu"},
+		Priority:  -3,
+		Trusted:   true,
+	}
+
+	restored, err := KnownPeerToAddFromBytes(p.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, p, restored)
+}
+
+func TestPeerStore(t *testing.T) {
+	ps := newPeerStore(mapdb.NewMapDB())
+	peerA := &KnownPeerToAdd{PublicKey: ed25519.GenerateKeyPair().PublicKey, Address: "127.0.0.1:14666"}
+	peerB := &KnownPeerToAdd{PublicKey: ed25519.GenerateKeyPair().PublicKey, Address: "127.0.0.2:14666", Priority: 1}
+
+	require.NoError(t, ps.save(peerA))
+	require.NoError(t, ps.save(peerB))
+	peerB.Trusted = true
+	require.NoError(t, ps.save(peerB))
+
+	peers, err := ps.loadAll()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*KnownPeerToAdd{peerA, peerB}, peers)
+
+	require.NoError(t, ps.delete(peerA.PublicKey))
+	require.NoError(t, ps.delete(peerA.PublicKey))
+	peers, err = ps.loadAll()
+	require.NoError(t, err)
+	assert.Equal(t, []*KnownPeerToAdd{peerB}, peers)
+}
@@ -0,0 +1,192 @@
+package pow
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// errors returned by the Service
+var (
+	ErrDifficultyTooHigh   = errors.New("difficulty exceeds the maximum difficulty")
+	ErrInvalidRequest      = errors.New("invalid PoW request")
+	ErrQueueFull           = errors.New("PoW queue is full")
+	ErrClientLimitExceeded = errors.New("PoW client limit exceeded")
+	ErrServiceStopped      = errors.New("PoW service stopped")
+)
+
+// ServiceParams represents the parameters of a Service.
+type ServiceParams struct {
+	// MaxDifficulty is the maximum difficulty the Service performs the PoW for.
+	MaxDifficulty int
+	// QueueSize is the number of requests that can wait for the worker.
+	QueueSize int
+	// MaxPendingPerClient is the number of requests of a single client that can be queued or processed at once.
+	MaxPendingPerClient int
+	// MaxRequestsPerClient is the number of requests a single client can send within the ClientInterval.
+	MaxRequestsPerClient int
+	// ClientInterval is the interval the MaxRequestsPerClient refers to.
+	ClientInterval time.Duration
+}
+
+// Service performs the PoW for remote clients. The requests are queued and processed one after another by the Worker,
+// so that the threads used for the PoW are limited by the Worker.
+type Service struct {
+	worker *Worker
+	params ServiceParams
+	queue  chan *serviceJob
+
+	clients      map[string]*serviceClient
+	clientsMutex sync.Mutex
+
+	stopped     chan struct{}
+	stoppedOnce sync.Once
+}
+
+// NewService creates a new Service that uses the given Worker.
+func NewService(worker *Worker, params ServiceParams) *Service {
+	return &Service{
+		worker:  worker,
+		params:  params,
+		queue:   make(chan *serviceJob, params.QueueSize),
+		clients: make(map[string]*serviceClient),
+		stopped: make(chan struct{}),
+	}
+}
+
+// Run processes the queued requests until the context is canceled.
+func (s *Service) Run(ctx context.Context) {
+	defer s.stoppedOnce.Do(func() { close(s.stopped) })
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-s.queue:
+			job.process(s.worker)
+		}
+	}
+}
+
+// Mine queues the PoW for the given data on behalf of the client and waits for the nonce. It behaves like Worker.Mine.
+func (s *Service) Mine(ctx context.Context, clientID string, data []byte, difficulty int) (uint64, error) {
+	select {
+	case <-s.stopped:
+		return 0, ErrServiceStopped
+	default:
+	}
+	if len(data) == 0 || difficulty < 0 {
+		return 0, ErrInvalidRequest
+	}
+	if difficulty > s.params.MaxDifficulty {
+		return 0, errors.Errorf("%w: %d > %d", ErrDifficultyTooHigh, difficulty, s.params.MaxDifficulty)
+	}
+	if err := s.acquire(clientID); err != nil {
+		return 0, err
+	}
+	defer s.release(clientID)
+
+	job := &serviceJob{
+		ctx:        ctx,
+		data:       data,
+		difficulty: difficulty,
+		result:     make(chan serviceResult, 1),
+	}
+	select {
+	case s.queue <- job:
+	default:
+		return 0, ErrQueueFull
+	}
+
+	select {
+	case result := <-job.result:
+		return result.nonce, result.err
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	case <-s.stopped:
+		return 0, ErrServiceStopped
+	}
+}
+
+// acquire checks the limits of the client and counts the request.
+func (s *Service) acquire(clientID string) error {
+	s.clientsMutex.Lock()
+	defer s.clientsMutex.Unlock()
+
+	now := time.Now()
+	client, exists := s.clients[clientID]
+	if !exists {
+		client = &serviceClient{}
+		s.clients[clientID] = client
+	}
+	client.prune(now.Add(-s.params.ClientInterval))
+
+	if s.params.MaxPendingPerClient > 0 && client.pending >= s.params.MaxPendingPerClient {
+		return errors.Errorf("%w: %d pending requests", ErrClientLimitExceeded, client.pending)
+	}
+	if s.params.MaxRequestsPerClient > 0 && len(client.requests) >= s.params.MaxRequestsPerClient {
+		return errors.Errorf("%w: %d requests within %v", ErrClientLimitExceeded, len(client.requests), s.params.ClientInterval)
+	}
+	client.pending++
+	client.requests = append(client.requests, now)
+
+	return nil
+}
+
+// release marks the request of the client as finished and forgets idle clients.
+func (s *Service) release(clientID string) {
+	s.clientsMutex.Lock()
+	defer s.clientsMutex.Unlock()
+
+	client := s.clients[clientID]
+	client.pending--
+
+	// forget the clients that can't be limited anymore
+	now := time.Now()
+	for id, c := range s.clients {
+		if c.prune(now.Add(-s.params.ClientInterval)); c.pending == 0 && len(c.requests) == 0 {
+			delete(s.clients, id)
+		}
+	}
+}
+
+// serviceClient contains the requests of a single client.
+type serviceClient struct {
+	pending  int
+	requests []time.Time
+}
+
+// prune removes the requests that were sent before the given time.
+func (c *serviceClient) prune(before time.Time) {
+	index := 0
+	for index < len(c.requests) && c.requests[index].Before(before) {
+		index++
+	}
+	c.requests = c.requests[index:]
+}
+
+// serviceJob is a queued request of the Service.
+type serviceJob struct {
+	ctx        context.Context
+	data       []byte
+	difficulty int
+	result     chan serviceResult
+}
+
+// process performs the PoW of the job unless it was canceled while it was queued.
+func (j *serviceJob) process(worker *Worker) {
+	if err := j.ctx.Err(); err != nil {
+		j.result <- serviceResult{err: err}
+		return
+	}
+	nonce, err := worker.Mine(j.ctx, j.data, j.difficulty)
+	j.result <- serviceResult{nonce: nonce, err: err}
+}
+
+// serviceResult is the result of a serviceJob.
+type serviceResult struct {
+	nonce uint64
+	err   error
+}
@@ -0,0 +1,100 @@
+package pow
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Mine(t *testing.T) {
+	service := NewService(testWorker, ServiceParams{
+		MaxDifficulty:        64,
+		QueueSize:            1,
+		MaxPendingPerClient:  1,
+		MaxRequestsPerClient: 3,
+		ClientInterval:       time.Minute,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go service.Run(ctx)
+
+	nonce, err := service.Mine(context.Background(), "a", []byte("test"), target)
+	require.NoError(t, err)
+	difficulty, err := testWorker.LeadingZerosWithNonce([]byte("test"), nonce)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, difficulty, target)
+
+	_, err = service.Mine(context.Background(), "a", []byte("test"), 65)
+	assert.ErrorIs(t, err, ErrDifficultyTooHigh)
+	_, err = service.Mine(context.Background(), "a", nil, target)
+	assert.ErrorIs(t, err, ErrInvalidRequest)
+
+	// block the worker with a PoW that doesn't finish
+	blockingCtx, cancelBlocking := context.WithCancel(context.Background())
+	blockingErr := make(chan error, 1)
+	go func() {
+		_, err := service.Mine(blockingCtx, "b", []byte("blocking"), 64)
+		blockingErr <- err
+	}()
+	require.Eventually(t, func() bool { return service.pendingRequests("b") == 1 && len(service.queue) == 0 }, time.Second, time.Millisecond)
+
+	// the pending request of the client counts against its limit
+	_, err = service.Mine(context.Background(), "b", []byte("test"), target)
+	assert.ErrorIs(t, err, ErrClientLimitExceeded)
+
+	// the next request waits in the queue, which is then full
+	queuedCtx, cancelQueued := context.WithCancel(context.Background())
+	queuedErr := make(chan error, 1)
+	go func() {
+		_, err := service.Mine(queuedCtx, "c", []byte("queued"), target)
+		queuedErr <- err
+	}()
+	require.Eventually(t, func() bool { return len(service.queue) == 1 }, time.Second, time.Millisecond)
+	_, err = service.Mine(context.Background(), "d", []byte("test"), target)
+	assert.ErrorIs(t, err, ErrQueueFull)
+
+	cancelQueued()
+	assert.ErrorIs(t, <-queuedErr, context.Canceled)
+	cancelBlocking()
+	assert.ErrorIs(t, <-blockingErr, context.Canceled)
+	// the canceled request is dropped by the worker
+	require.Eventually(t, func() bool { return len(service.queue) == 0 }, time.Second, time.Millisecond)
+
+	// the client "a" can only send 3 valid requests within the interval
+	for i := 0; i < 2; i++ {
+		_, err = service.Mine(context.Background(), "a", []byte("test"), target)
+		assert.NoError(t, err)
+	}
+	_, err = service.Mine(context.Background(), "a", []byte("test"), target)
+	assert.ErrorIs(t, err, ErrClientLimitExceeded)
+
+	// the requests that exceeded the limits are not counted
+	_, err = service.Mine(context.Background(), "d", []byte("test"), target)
+	assert.NoError(t, err)
+
+	cancel()
+	require.Eventually(t, func() bool {
+		select {
+		case <-service.stopped:
+			return true
+		default:
+			return false
+		}
+	}, time.Second, time.Millisecond)
+	_, err = service.Mine(context.Background(), "e", []byte("test"), target)
+	assert.ErrorIs(t, err, ErrServiceStopped)
+}
+
+// pendingRequests returns the number of queued or processed requests of the client.
+func (s *Service) pendingRequests(clientID string) int {
+	s.clientsMutex.Lock()
+	defer s.clientsMutex.Unlock()
+
+	if client, exists := s.clients[clientID]; exists {
+		return client.pending
+	}
+	return 0
+}
@@ -45,6 +45,8 @@ const (
 	PriorityBootstrap
 	// PriorityTXStream defines the shutdown priority for realtime.
 	PriorityTXStream
+	// PriorityConfig defines the shutdown priority for the config reload.
+	PriorityConfig
 	// PriorityHealthz defines the shutdown priority of the healthz endpoint. It should always be last.
 	PriorityHealthz
 )
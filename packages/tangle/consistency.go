@@ -0,0 +1,191 @@
+package tangle
+
+import (
+	"fmt"
+
+	"github.com/iotaledger/hive.go/datastructure/walker"
+	"github.com/iotaledger/hive.go/objectstorage"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/markers"
+)
+
+// CheckConsistency checks the invariants of the messages, the markers and the ledger state and returns the broken ones.
+// If repair is true, inconsistent entries are deleted and booked messages that can't be trusted anymore are rolled back
+// together with their future cone, so that they can be solidified again. The Tangle must not process messages while it
+// is checked.
+func (t *Tangle) CheckConsistency(mode database.ConsistencyCheckMode, repair bool) (report *database.ConsistencyReport) {
+	report = database.NewConsistencyReport(mode)
+
+	t.Storage.checkConsistency(report, repair)
+	t.LedgerState.UTXODAG.CheckConsistency(report, repair)
+	t.LedgerState.BranchDAG.CheckConsistency(report, repair)
+
+	return report
+}
+
+// checkConsistency checks the invariants of the message related storages.
+func (s *Storage) checkConsistency(report *database.ConsistencyReport, repair bool) {
+	var orphanedMetadata, unbookedMessages MessageIDs
+	unbookedProblems := make(map[MessageID]string)
+	existingSequences := make(map[markers.SequenceID]bool)
+
+	s.messageMetadataStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		(&CachedMessageMetadata{CachedObject: cachedObject}).Consume(func(messageMetadata *MessageMetadata) {
+			report.EntryChecked("messageMetadata")
+
+			messageID := messageMetadata.ID()
+			// the genesis only has metadata
+			if messageID != EmptyMessageID && !s.messageStorage.Contains(messageID.Bytes()) {
+				orphanedMetadata = append(orphanedMetadata, messageID)
+				return
+			}
+
+			if problem := s.bookingProblem(messageMetadata, existingSequences); problem != "" {
+				unbookedMessages = append(unbookedMessages, messageID)
+				unbookedProblems[messageID] = problem
+			}
+		})
+		return true
+	})
+
+	for _, messageID := range orphanedMetadata {
+		issue := report.AddIssue("messageMetadata", messageID.Base58(), "metadata without message")
+		if repair {
+			s.messageMetadataStorage.Delete(messageID.Bytes())
+			issue.Repaired = true
+		}
+	}
+
+	for _, messageID := range unbookedMessages {
+		issue := report.AddIssue("messageMetadata", messageID.Base58(), unbookedProblems[messageID])
+		if repair {
+			s.deleteFutureCone(messageID)
+			issue.Repaired = true
+		}
+	}
+
+	if report.Mode == database.FullConsistencyCheck {
+		s.checkApprovers(report, repair)
+	}
+}
+
+// bookingProblem returns why the booking of the message is inconsistent or an empty string if it is consistent.
+func (s *Storage) bookingProblem(messageMetadata *MessageMetadata, existingSequences map[markers.SequenceID]bool) (problem string) {
+	if !messageMetadata.IsBooked() {
+		return ""
+	}
+
+	structureDetails := messageMetadata.StructureDetails()
+	if structureDetails == nil {
+		return "booked message without structure details"
+	}
+
+	structureDetails.PastMarkers.ForEach(func(sequenceID markers.SequenceID, index markers.Index) bool {
+		exists, checked := existingSequences[sequenceID]
+		if !checked {
+			exists = s.tangle.Booker.MarkersManager.Sequence(sequenceID).Consume(func(*markers.Sequence) {})
+			existingSequences[sequenceID] = exists
+		}
+		if !exists {
+			problem = fmt.Sprintf("booked message with missing marker sequence %d", sequenceID)
+		}
+		return exists
+	})
+
+	return problem
+}
+
+// deleteFutureCone deletes the message and all the messages that directly or indirectly approve it. The booking of
+// the deleted messages is rolled back and the transactions that lose their last attachment are removed from the
+// ledger state, unless their outputs are spent by transactions outside of the future cone.
+func (s *Storage) deleteFutureCone(messageID MessageID) {
+	var futureCone MessageIDs
+	messageWalker := walker.New(false)
+	messageWalker.Push(messageID)
+	for messageWalker.HasNext() {
+		currentMessageID := messageWalker.Next().(MessageID)
+		futureCone = append(futureCone, currentMessageID)
+		s.Approvers(currentMessageID).Consume(func(approver *Approver) {
+			messageWalker.Push(approver.ApproverMessageID())
+			s.approverStorage.Delete(approver.ObjectStorageKey())
+		})
+	}
+
+	var detachedTransactions []ledgerstate.TransactionID
+	for i := len(futureCone) - 1; i >= 0; i-- {
+		if transactionID, detached := s.rollbackBooking(futureCone[i]); detached {
+			detachedTransactions = append(detachedTransactions, transactionID)
+		}
+		s.DeleteMessage(futureCone[i])
+		// DeleteMessage only removes the metadata of existing messages
+		s.messageMetadataStorage.Delete(futureCone[i].Bytes())
+	}
+
+	// transactions can only be rolled back after the transactions that spend their outputs
+	for rolledBack := true; rolledBack; {
+		rolledBack = false
+		for i := 0; i < len(detachedTransactions); i++ {
+			if s.tangle.LedgerState.UTXODAG.RollbackTransaction(detachedTransactions[i]) {
+				detachedTransactions = append(detachedTransactions[:i], detachedTransactions[i+1:]...)
+				rolledBack = true
+				i--
+			}
+		}
+	}
+}
+
+// rollbackBooking deletes the branch mappings of the message and the attachment of its transaction. It returns the
+// transaction if the message was its last attachment.
+func (s *Storage) rollbackBooking(messageID MessageID) (transactionID ledgerstate.TransactionID, detached bool) {
+	s.MessageMetadata(messageID).Consume(func(messageMetadata *MessageMetadata) {
+		if branchID := messageMetadata.BranchID(); branchID != ledgerstate.UndefinedBranchID {
+			s.DeleteIndividuallyMappedMessage(branchID, messageID)
+		}
+
+		if structureDetails := messageMetadata.StructureDetails(); structureDetails != nil && structureDetails.IsPastMarker {
+			marker := structureDetails.PastMarkers.Marker()
+			s.markerMessageMappingStorage.Delete(marker.Bytes())
+			s.MarkerIndexBranchIDMapping(marker.SequenceID()).Consume(func(markerIndexBranchIDMapping *MarkerIndexBranchIDMapping) {
+				markerIndexBranchIDMapping.DeleteBranchID(marker.Index())
+			})
+		}
+	})
+
+	s.Message(messageID).Consume(func(message *Message) {
+		transaction, isTransaction := message.Payload().(*ledgerstate.Transaction)
+		if !isTransaction {
+			return
+		}
+
+		transactionID = transaction.ID()
+		s.attachmentStorage.Delete(NewAttachment(transactionID, messageID).ObjectStorageKey())
+		detached = len(s.AttachmentMessageIDs(transactionID)) == 0
+	})
+
+	return transactionID, detached
+}
+
+// checkApprovers checks that the approvers reference existing messages.
+func (s *Storage) checkApprovers(report *database.ConsistencyReport, repair bool) {
+	var invalidApprovers []*Approver
+	s.approverStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		(&CachedApprover{CachedObject: cachedObject}).Consume(func(approver *Approver) {
+			report.EntryChecked("approver")
+			if !s.messageStorage.Contains(approver.ApproverMessageID().Bytes()) {
+				invalidApprovers = append(invalidApprovers, approver)
+			}
+		})
+		return true
+	})
+
+	for _, approver := range invalidApprovers {
+		issue := report.AddIssue("approver", approver.ReferencedMessageID().Base58(),
+			fmt.Sprintf("approved by missing message %s", approver.ApproverMessageID().Base58()))
+		if repair {
+			s.approverStorage.Delete(approver.ObjectStorageKey())
+			issue.Repaired = true
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package tangle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/markers"
+)
+
+func TestTangle_CheckConsistency(t *testing.T) {
+	tangle := NewTestTangle()
+	defer tangle.Shutdown()
+
+	message1 := newTestDataMessage("message1")
+	message2 := newTestParentsDataMessage("message2", []MessageID{message1.ID()}, nil, nil, nil)
+	message3 := newTestDataMessage("message3")
+	for _, message := range []*Message{message1, message2, message3} {
+		tangle.Storage.StoreMessage(message)
+	}
+
+	// message1 is booked on a sequence that does not exist
+	tangle.Storage.MessageMetadata(message1.ID()).Consume(func(messageMetadata *MessageMetadata) {
+		messageMetadata.SetStructureDetails(&markers.StructureDetails{
+			PastMarkers:   markers.NewMarkers(markers.NewMarker(99, 1)),
+			FutureMarkers: markers.NewMarkers(),
+		})
+		messageMetadata.SetBooked(true)
+	})
+	// message3 was lost, but its metadata and approver were written
+	tangle.Storage.messageStorage.Delete(message3.ID().Bytes())
+
+	report := tangle.CheckConsistency(database.FastConsistencyCheck, false)
+	require.Len(t, report.Issues, 2)
+	assert.Len(t, report.Unrepaired(), 2)
+	// the genesis only has metadata
+	assert.Equal(t, 4, report.CheckedEntries["messageMetadata"])
+
+	report = tangle.CheckConsistency(database.FastConsistencyCheck, true)
+	require.Len(t, report.Issues, 2)
+	assert.Empty(t, report.Unrepaired())
+
+	// message1 is rolled back together with its future cone
+	for _, messageID := range []MessageID{message1.ID(), message2.ID(), message3.ID()} {
+		assert.False(t, tangle.Storage.Message(messageID).Consume(func(*Message) {}))
+		assert.False(t, tangle.Storage.MessageMetadata(messageID).Consume(func(*MessageMetadata) {}))
+	}
+
+	// the approver of the lost message is only found by the full check
+	assert.True(t, tangle.CheckConsistency(database.FastConsistencyCheck, true).Consistent())
+	report = tangle.CheckConsistency(database.FullConsistencyCheck, true)
+	require.Len(t, report.Issues, 1)
+	assert.Equal(t, "approver", report.Issues[0].Storage)
+	assert.True(t, report.Issues[0].Repaired)
+
+	assert.True(t, tangle.CheckConsistency(database.FullConsistencyCheck, false).Consistent())
+}
+
+func TestTangle_CheckConsistencyRollsBackBooking(t *testing.T) {
+	tangle := NewTestTangle()
+	defer tangle.Shutdown()
+
+	testFramework := NewMessageTestFramework(tangle, WithGenesisOutput("G", 3))
+	tangle.Setup()
+
+	testFramework.CreateMessage("Message1", WithStrongParents("Genesis"), WithInputs("G"), WithOutput("A", 1), WithOutput("B", 2))
+	testFramework.CreateMessage("Message2", WithStrongParents("Genesis"), WithReattachment("Message1"))
+	testFramework.CreateMessage("Message3", WithStrongParents("Message1"), WithInputs("A"), WithOutput("C", 1))
+	testFramework.CreateMessage("Message4", WithStrongParents("Message3"), WithLikeParents("Message2"))
+	testFramework.IssueMessages("Message1", "Message2").WaitMessagesBooked()
+	testFramework.IssueMessages("Message3").WaitMessagesBooked()
+	testFramework.IssueMessages("Message4").WaitMessagesBooked()
+
+	message4Marker := testFramework.MessageMetadata("Message4").StructureDetails().PastMarkers.Marker()
+	require.True(t, testFramework.MessageMetadata("Message4").StructureDetails().IsPastMarker)
+	require.Equal(t, testFramework.Message("Message4").ID(), tangle.Booker.MarkersManager.MessageID(message4Marker))
+
+	// Message3 is booked on a sequence that does not exist
+	tangle.Storage.MessageMetadata(testFramework.Message("Message3").ID()).Consume(func(messageMetadata *MessageMetadata) {
+		messageMetadata.structureDetails = &markers.StructureDetails{
+			PastMarkers:   markers.NewMarkers(markers.NewMarker(99, 1)),
+			FutureMarkers: markers.NewMarkers(),
+		}
+		messageMetadata.SetModified()
+	})
+
+	report := tangle.CheckConsistency(database.FastConsistencyCheck, true)
+	require.Len(t, report.Issues, 1)
+	assert.Empty(t, report.Unrepaired())
+
+	// the approvers of the remaining messages are deleted, including the one of the like reference
+	for _, messageAlias := range []string{"Message1", "Message2"} {
+		cachedApprovers := tangle.Storage.Approvers(testFramework.Message(messageAlias).ID())
+		assert.Empty(t, cachedApprovers, messageAlias)
+		cachedApprovers.Release()
+	}
+	assert.Equal(t, EmptyMessageID, tangle.Booker.MarkersManager.MessageID(message4Marker))
+
+	// the transaction of Message3 lost its only attachment, so it is removed from the ledger state
+	assert.Empty(t, tangle.Storage.AttachmentMessageIDs(testFramework.TransactionID("Message3")))
+	assert.False(t, tangle.LedgerState.Transaction(testFramework.TransactionID("Message3")).Consume(func(*ledgerstate.Transaction) {}))
+	for _, output := range testFramework.Transaction("Message1").Essence().Outputs() {
+		assert.Zero(t, testFramework.OutputMetadata(output.ID()).ConsumerCount())
+	}
+	assert.Len(t, tangle.Storage.AttachmentMessageIDs(testFramework.TransactionID("Message1")), 2)
+
+	assert.True(t, tangle.CheckConsistency(database.FullConsistencyCheck, false).Consistent())
+}
@@ -75,8 +75,8 @@ func (f *MessageFactory) SetTimeout(timeout time.Duration) {
 }
 
 // IssuePayload creates a new message including sequence number and tip selection and returns it.
-// It also triggers the MessageConstructed event once it's done, which is for example used by the plugins to listen for
-// messages that shall be attached to the tangle.
+// The message is submitted to the RateSetter, which passes it on with its MessageIssued event at the rate of the node,
+// and an error is returned if the RateSetter discards it. It also triggers the MessageConstructed event once it's done.
 func (f *MessageFactory) IssuePayload(p payload.Payload, parentsCount ...int) (*Message, error) {
 	payloadLen := len(p.Bytes())
 	if payloadLen > payload.MaxSize {
@@ -165,6 +165,12 @@ func (f *MessageFactory) IssuePayload(p payload.Payload, parentsCount ...int) (*
 		return nil, err
 	}
 
+	if err = f.tangle.RateSetter.Issue(msg); err != nil {
+		err = errors.Errorf("failed to issue message: %w", err)
+		f.Events.Error.Trigger(err)
+		return nil, err
+	}
+
 	f.Events.MessageConstructed.Trigger(msg)
 	return msg, nil
 }
@@ -0,0 +1,155 @@
+package tangle
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/datastructure/walker"
+	"github.com/iotaledger/hive.go/identity"
+
+	"github.com/iotaledger/goshimmer/packages/pow"
+)
+
+// maxAdaptivePowWalkedMessages defines how many messages of the past cone are at most visited to count the previous
+// messages of an issuer. As the past cone is walked in a fixed order, the limit does not affect the determinism.
+const maxAdaptivePowWalkedMessages = 10000
+
+// region AdaptivePowParams ////////////////////////////////////////////////////////////////////////////////////////////
+
+// AdaptivePowParams represents the parameters of the AdaptivePowDifficulty.
+type AdaptivePowParams struct {
+	// BaseDifficulty is the difficulty required from issuers that did not issue any message within the Window.
+	BaseDifficulty int
+	// MaxDifficulty caps the required difficulty.
+	MaxDifficulty int
+	// Window is the time window before the issuing time of a message in which the previous messages of the issuer
+	// are counted. It is at least the maximum age of the parents of a message, so that the parents are always within
+	// the Window and issuers can't leave their previous messages out of the count by choosing old parents.
+	Window time.Duration
+	// Rate is the number of messages an issuer can issue within the Window before the difficulty increases by one.
+	Rate int
+	// ManaThreshold is the consensus mana from which issuers are only required to meet the BaseDifficulty. Zero
+	// disables the mana fallback.
+	ManaThreshold float64
+	// ManaEpoch is the duration of the epochs of the mana fallback. The consensus mana of an issuer is taken at the
+	// start of the epoch before the one in which the message was issued, so that all nodes use the same mana.
+	ManaEpoch time.Duration
+	// ConsensusManaRetrieveFunc returns the consensus mana of a node at the given time. It is only used if the
+	// ManaThreshold is set.
+	ConsensusManaRetrieveFunc func(nodeID identity.ID, t time.Time) (float64, error)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region AdaptivePowDifficulty ////////////////////////////////////////////////////////////////////////////////////////
+
+// AdaptivePowDifficulty determines the PoW difficulty that is required for a message. The difficulty grows with the
+// number of messages of the same issuer in the past cone of the message that were issued within a window before it,
+// unless the issuer had enough consensus mana at the start of the previous epoch. As the past cone of a message is
+// fixed by its parents and the past consensus mana by the confirmed transactions, all nodes agree on the difficulty.
+type AdaptivePowDifficulty struct {
+	tangle *Tangle
+	worker *pow.Worker
+	params AdaptivePowParams
+}
+
+// NewAdaptivePowDifficulty creates a new AdaptivePowDifficulty that uses the given worker to validate the nonces.
+func NewAdaptivePowDifficulty(tangle *Tangle, worker *pow.Worker, params AdaptivePowParams) *AdaptivePowDifficulty {
+	if params.Rate <= 0 {
+		panic("adaptive PoW: the rate must be greater than zero")
+	}
+	if params.MaxDifficulty < params.BaseDifficulty {
+		params.MaxDifficulty = params.BaseDifficulty
+	}
+	if params.Window < maxParentsTimeDifference {
+		params.Window = maxParentsTimeDifference
+	}
+	if params.ManaThreshold > 0 && (params.ManaEpoch <= 0 || params.ConsensusManaRetrieveFunc == nil) {
+		panic("adaptive PoW: the mana fallback requires a mana epoch and a consensus mana retrieve function")
+	}
+
+	return &AdaptivePowDifficulty{
+		tangle: tangle,
+		worker: worker,
+		params: params,
+	}
+}
+
+// RequiredDifficulty returns the PoW difficulty the given message requires. The parents of the message need to be
+// stored, so that its past cone can be walked.
+func (a *AdaptivePowDifficulty) RequiredDifficulty(message *Message) int {
+	if a.manaFallback(message) {
+		return a.params.BaseDifficulty
+	}
+
+	difficulty := a.params.BaseDifficulty + a.previousMessagesCount(message)/a.params.Rate
+	if difficulty > a.params.MaxDifficulty {
+		return a.params.MaxDifficulty
+	}
+	return difficulty
+}
+
+// CheckMessage returns an error if the PoW nonce of the given message does not meet its required difficulty.
+func (a *AdaptivePowDifficulty) CheckMessage(message *Message) error {
+	content, err := powData(message.Bytes())
+	if err != nil {
+		return err
+	}
+	zeros, err := a.worker.LeadingZeros(content)
+	if err != nil {
+		return err
+	}
+	if difficulty := a.RequiredDifficulty(message); zeros < difficulty {
+		return errors.Errorf("leading zeros %d for adaptive difficulty %d: %w", zeros, difficulty, ErrInvalidPOWDifficultly)
+	}
+	return nil
+}
+
+// manaFallback returns true if the issuer of the given message had at least the ManaThreshold of consensus mana at the
+// start of the epoch before the one in which the message was issued. If the consensus mana at that time is unknown,
+// e.g. because the history was pruned, the issuer is treated as if it had no mana.
+func (a *AdaptivePowDifficulty) manaFallback(message *Message) bool {
+	if a.params.ManaThreshold <= 0 {
+		return false
+	}
+
+	manaTime := message.IssuingTime().Truncate(a.params.ManaEpoch).Add(-a.params.ManaEpoch)
+	consensusMana, err := a.params.ConsensusManaRetrieveFunc(identity.NewID(message.IssuerPublicKey()), manaTime)
+
+	return err == nil && consensusMana >= a.params.ManaThreshold
+}
+
+// previousMessagesCount counts the messages of the issuer of the given message in its past cone that were issued
+// within the window before it. The count stops once it reaches the maximum difficulty.
+func (a *AdaptivePowDifficulty) previousMessagesCount(message *Message) (count int) {
+	windowStart := message.IssuingTime().Add(-a.params.Window)
+	maxCount := (a.params.MaxDifficulty - a.params.BaseDifficulty + 1) * a.params.Rate
+
+	messageWalker := walker.New(false)
+	message.ForEachParent(func(parent Parent) {
+		messageWalker.Push(parent.ID)
+	})
+	for walked := 0; messageWalker.HasNext() && walked < maxAdaptivePowWalkedMessages && count < maxCount; walked++ {
+		messageID := messageWalker.Next().(MessageID)
+		if messageID == EmptyMessageID {
+			continue
+		}
+
+		a.tangle.Storage.Message(messageID).Consume(func(pastMessage *Message) {
+			// the parents of a valid message are never issued after it, so the walk ends at the start of the window
+			if pastMessage.IssuingTime().Before(windowStart) {
+				return
+			}
+			if pastMessage.IssuerPublicKey() == message.IssuerPublicKey() {
+				count++
+			}
+			pastMessage.ForEachParent(func(parent Parent) {
+				messageWalker.Push(parent.ID)
+			})
+		})
+	}
+
+	return count
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,158 @@
+package tangle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdaptivePowDifficulty_RequiredDifficulty(t *testing.T) {
+	tangle := NewTestTangle()
+	defer tangle.Shutdown()
+
+	adaptive := NewAdaptivePowDifficulty(tangle, testWorker, AdaptivePowParams{
+		BaseDifficulty: 10,
+		MaxDifficulty:  12,
+		Window:         maxParentsTimeDifference,
+		Rate:           2,
+	})
+
+	issuer := ed25519.GenerateKeyPair().PublicKey
+	otherIssuer := ed25519.GenerateKeyPair().PublicKey
+	start := time.Now()
+	message1 := newTestParentsDataMessageTimestampIssuer("message1", []MessageID{EmptyMessageID}, nil, nil, nil, issuer, start)
+	message2 := newTestParentsDataMessageTimestampIssuer("message2", []MessageID{message1.ID()}, nil, nil, nil, issuer, start.Add(time.Second))
+	message3 := newTestParentsDataMessageTimestampIssuer("message3", []MessageID{message2.ID()}, nil, nil, nil, otherIssuer, start.Add(2*time.Second))
+	message4 := newTestParentsDataMessageTimestampIssuer("message4", []MessageID{message3.ID()}, nil, nil, nil, issuer, start.Add(3*time.Second))
+	for _, message := range []*Message{message1, message2, message3, message4} {
+		tangle.Storage.StoreMessage(message)
+	}
+
+	requiredDifficulty := func(parent MessageID, issuingTime time.Time) int {
+		return adaptive.RequiredDifficulty(newTestParentsDataMessageTimestampIssuer("new", []MessageID{parent}, nil, nil, nil, issuer, issuingTime))
+	}
+
+	// only the messages of the issuer in the past cone are counted
+	assert.Equal(t, 11, requiredDifficulty(message4.ID(), start.Add(4*time.Second)))
+	assert.Equal(t, 11, requiredDifficulty(message2.ID(), start.Add(4*time.Second)))
+	assert.Equal(t, 10, requiredDifficulty(message1.ID(), start.Add(4*time.Second)))
+	assert.Equal(t, 10, requiredDifficulty(EmptyMessageID, start.Add(4*time.Second)))
+	// the messages leave the window
+	assert.Equal(t, 10, requiredDifficulty(message4.ID(), start.Add(maxParentsTimeDifference+1500*time.Millisecond)))
+	assert.Equal(t, 10, requiredDifficulty(message4.ID(), start.Add(2*maxParentsTimeDifference)))
+
+	// the difficulty is capped
+	parent := message4.ID()
+	for i := 0; i < 10; i++ {
+		message := newTestParentsDataMessageTimestampIssuer("spam", []MessageID{parent}, nil, nil, nil, issuer, start.Add(5*time.Second))
+		tangle.Storage.StoreMessage(message)
+		parent = message.ID()
+	}
+	assert.Equal(t, 12, requiredDifficulty(parent, start.Add(6*time.Second)))
+}
+
+func TestAdaptivePowDifficulty_Window(t *testing.T) {
+	tangle := NewTestTangle()
+	defer tangle.Shutdown()
+
+	// the window covers the maximum age of the parents
+	adaptive := NewAdaptivePowDifficulty(tangle, testWorker, AdaptivePowParams{
+		BaseDifficulty: 10,
+		MaxDifficulty:  20,
+		Window:         time.Minute,
+		Rate:           1,
+	})
+
+	issuer := ed25519.GenerateKeyPair().PublicKey
+	start := time.Now()
+	parent := newTestParentsDataMessageTimestampIssuer("previous", []MessageID{EmptyMessageID}, nil, nil, nil, issuer, start)
+	tangle.Storage.StoreMessage(parent)
+
+	assert.Equal(t, 11, adaptive.RequiredDifficulty(newTestParentsDataMessageTimestampIssuer("new", []MessageID{parent.ID()}, nil, nil, nil, issuer, start.Add(10*time.Minute))))
+}
+
+func TestAdaptivePowDifficulty_ManaFallback(t *testing.T) {
+	tangle := NewTestTangle()
+	defer tangle.Shutdown()
+
+	issuer := ed25519.GenerateKeyPair().PublicKey
+	epochStart := time.Now().Truncate(time.Hour)
+	var retrievedTimes []time.Time
+	adaptive := NewAdaptivePowDifficulty(tangle, testWorker, AdaptivePowParams{
+		BaseDifficulty: 10,
+		MaxDifficulty:  20,
+		Window:         maxParentsTimeDifference,
+		Rate:           1,
+		ManaThreshold:  100,
+		ManaEpoch:      time.Hour,
+		ConsensusManaRetrieveFunc: func(nodeID identity.ID, manaTime time.Time) (float64, error) {
+			assert.Equal(t, identity.NewID(issuer), nodeID)
+			retrievedTimes = append(retrievedTimes, manaTime)
+			switch {
+			case manaTime.Before(epochStart):
+				return 0, errors.New("consensus mana history was pruned")
+			case manaTime.Before(epochStart.Add(time.Hour)):
+				return 100, nil
+			default:
+				return 0, nil
+			}
+		},
+	})
+
+	requiredDifficulty := func(issuingTime time.Time) int {
+		parent := newTestParentsDataMessageTimestampIssuer("previous", []MessageID{EmptyMessageID}, nil, nil, nil, issuer, issuingTime.Add(-time.Second))
+		tangle.Storage.StoreMessage(parent)
+		return adaptive.RequiredDifficulty(newTestParentsDataMessageTimestampIssuer("new", []MessageID{parent.ID()}, nil, nil, nil, issuer, issuingTime))
+	}
+
+	// the consensus mana at the start of the previous epoch counts, regardless of the current one
+	assert.Equal(t, 10, requiredDifficulty(epochStart.Add(time.Hour+time.Minute)))
+	assert.Equal(t, []time.Time{epochStart}, retrievedTimes)
+	assert.Equal(t, 11, requiredDifficulty(epochStart.Add(2*time.Hour+time.Minute)))
+	assert.Equal(t, epochStart.Add(time.Hour), retrievedTimes[1])
+	// unknown mana doesn't allow the fallback
+	assert.Equal(t, 11, requiredDifficulty(epochStart.Add(time.Hour-time.Minute)))
+}
+
+func TestAdaptivePowDifficulty_CheckMessage(t *testing.T) {
+	tangle := NewTestTangle()
+	defer tangle.Shutdown()
+
+	adaptive := NewAdaptivePowDifficulty(tangle, testWorker, AdaptivePowParams{
+		BaseDifficulty: 0,
+		MaxDifficulty:  30,
+		Window:         time.Minute,
+		Rate:           1,
+	})
+	tangle.AdaptivePowDifficulty = adaptive
+
+	issuer := ed25519.GenerateKeyPair().PublicKey
+	start := time.Now()
+	parent := EmptyMessageID
+	for i := 0; i < 20; i++ {
+		message := newTestParentsDataMessageTimestampIssuer("previous", []MessageID{parent}, nil, nil, nil, issuer, start)
+		tangle.Storage.StoreMessage(message)
+		tangle.Storage.MessageMetadata(message.ID()).Consume(func(messageMetadata *MessageMetadata) {
+			messageMetadata.SetSolid(true)
+		})
+		parent = message.ID()
+	}
+
+	assert.NoError(t, adaptive.CheckMessage(newTestParentsDataMessageTimestampIssuer("first", []MessageID{EmptyMessageID}, nil, nil, nil, issuer, start)))
+
+	message := newTestParentsDataMessageTimestampIssuer("spam", []MessageID{parent}, nil, nil, nil, issuer, start.Add(time.Second))
+	assert.True(t, errors.Is(adaptive.CheckMessage(message), ErrInvalidPOWDifficultly))
+
+	// the Solidifier marks the message as invalid once its past cone is known
+	tangle.Storage.StoreMessage(message)
+	tangle.Solidifier.Solidify(message.ID())
+	require.True(t, tangle.Storage.MessageMetadata(message.ID()).Consume(func(messageMetadata *MessageMetadata) {
+		assert.True(t, messageMetadata.IsInvalid())
+		assert.False(t, messageMetadata.IsSolid())
+	}))
+}
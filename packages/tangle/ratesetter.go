@@ -5,6 +5,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/tangle/schedulerutils"
 
 	"github.com/cockroachdb/errors"
@@ -31,6 +32,8 @@ var (
 	ErrInvalidIssuer = errors.New("message not issued by local node")
 	// ErrStopped is returned when a message is passed to a stopped rate setter.
 	ErrStopped = errors.New("rate setter stopped")
+	// ErrMessageDiscarded is returned when a message does not fit into the issuing queue of the rate setter.
+	ErrMessageDiscarded = errors.New("message discarded by the rate setter")
 )
 
 // Initial is the rate in bytes per second.
@@ -41,10 +44,99 @@ var Initial = 20000.0
 // RateSetterParams represents the parameters for RateSetter.
 type RateSetterParams struct {
 	Initial *float64
+	// DequeueStrategy defines how the next message is selected from the priority lanes.
+	DequeueStrategy DequeueStrategy
+	// Lanes contains the parameters of the priority lanes. Lanes that are missing use DefaultLaneParams.
+	Lanes map[MessagePriority]LaneParams
+	// PriorityFunc assigns a locally issued message to a priority lane. If nil, DefaultMessagePriority is used.
+	PriorityFunc func(*Message) MessagePriority
+}
+
+// LaneParams represents the parameters of a single priority lane of the RateSetter.
+type LaneParams struct {
+	// MaxSize is the maximum size of the messages in the lane in bytes.
+	MaxSize int
+	// Weight is the share of the lane when messages are dequeued with WeightedDequeue.
+	Weight int
+	// DropPolicy defines which message is discarded when the lane is full.
+	DropPolicy DropPolicy
+}
+
+// DefaultLaneParams are the parameters used for lanes that are not configured explicitly.
+var DefaultLaneParams = LaneParams{
+	MaxSize:    MaxLocalQueueSize,
+	Weight:     1,
+	DropPolicy: DropNewest,
 }
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region MessagePriority //////////////////////////////////////////////////////////////////////////////////////////////
+
+// MessagePriority is the priority lane a locally issued message is queued in by the RateSetter. Lower values are
+// dequeued first.
+type MessagePriority uint8
+
+const (
+	// ValuePriority is the priority of value transactions.
+	ValuePriority MessagePriority = iota
+	// FaucetPriority is the priority of faucet requests.
+	FaucetPriority
+	// DataPriority is the priority of data messages.
+	DataPriority
+	// ActivityPriority is the priority of activity messages and spam.
+	ActivityPriority
+
+	// MessagePriorityCount is the number of priority lanes.
+	MessagePriorityCount = int(ActivityPriority) + 1
+)
+
+// String returns a human readable version of the MessagePriority.
+func (p MessagePriority) String() string {
+	switch p {
+	case ValuePriority:
+		return "value"
+	case FaucetPriority:
+		return "faucet"
+	case DataPriority:
+		return "data"
+	case ActivityPriority:
+		return "activity"
+	default:
+		return "unknown"
+	}
+}
+
+// DefaultMessagePriority puts transactions in the ValuePriority lane and all other messages in the DataPriority lane.
+func DefaultMessagePriority(message *Message) MessagePriority {
+	if message.Payload().Type() == ledgerstate.TransactionType {
+		return ValuePriority
+	}
+	return DataPriority
+}
+
+// DequeueStrategy defines how the RateSetter selects the lane of the next message to issue.
+type DequeueStrategy uint8
+
+const (
+	// StrictDequeue always issues from the non-empty lane with the highest priority.
+	StrictDequeue DequeueStrategy = iota
+	// WeightedDequeue shares the issuing rate between the non-empty lanes according to their weights.
+	WeightedDequeue
+)
+
+// DropPolicy defines which message a full lane of the RateSetter discards.
+type DropPolicy uint8
+
+const (
+	// DropNewest discards the message that is being added.
+	DropNewest DropPolicy = iota
+	// DropOldest discards the oldest messages in the lane to make room for the message that is being added.
+	DropOldest
+)
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region RateSetter ///////////////////////////////////////////////////////////////////////////////////////////////////
 
 // RateSetter is a Tangle component that takes care of congestion control of local node.
@@ -52,8 +144,11 @@ type RateSetter struct {
 	tangle         *Tangle
 	Events         *RateSetterEvents
 	self           identity.ID
-	issuingQueue   *schedulerutils.NodeQueue
-	issueChan      chan *Message
+	lanes          [MessagePriorityCount]*rateSetterLane
+	lanesMutex     sync.RWMutex
+	strategy       DequeueStrategy
+	priorityFunc   func(*Message) MessagePriority
+	issueChan      chan *issueRequest
 	ownRate        *atomic.Float64
 	pauseUpdates   uint
 	shutdownSignal chan struct{}
@@ -62,14 +157,22 @@ type RateSetter struct {
 
 // NewRateSetter returns a new RateSetter.
 func NewRateSetter(tangle *Tangle) *RateSetter {
+	rateSetter := newRateSetter(tangle)
+	go rateSetter.issuerLoop()
+	return rateSetter
+}
+
+func newRateSetter(tangle *Tangle) *RateSetter {
 	rateSetter := &RateSetter{
 		tangle: tangle,
 		Events: &RateSetterEvents{
+			MessageIssued:    events.NewEvent(messageEventHandler),
 			MessageDiscarded: events.NewEvent(MessageIDCaller),
 		},
 		self:           tangle.Options.Identity.ID(),
-		issuingQueue:   schedulerutils.NewNodeQueue(tangle.Options.Identity.ID()),
-		issueChan:      make(chan *Message),
+		strategy:       tangle.Options.RateSetterParams.DequeueStrategy,
+		priorityFunc:   tangle.Options.RateSetterParams.PriorityFunc,
+		issueChan:      make(chan *issueRequest),
 		ownRate:        atomic.NewFloat64(Initial),
 		pauseUpdates:   0,
 		shutdownSignal: make(chan struct{}),
@@ -78,8 +181,19 @@ func NewRateSetter(tangle *Tangle) *RateSetter {
 	if tangle.Options.RateSetterParams.Initial != nil {
 		Initial = *tangle.Options.RateSetterParams.Initial
 	}
-
-	go rateSetter.issuerLoop()
+	if rateSetter.priorityFunc == nil {
+		rateSetter.priorityFunc = DefaultMessagePriority
+	}
+	for i := range rateSetter.lanes {
+		params, exists := tangle.Options.RateSetterParams.Lanes[MessagePriority(i)]
+		if !exists {
+			params = DefaultLaneParams
+		}
+		rateSetter.lanes[i] = &rateSetterLane{
+			queue:  schedulerutils.NewNodeQueue(rateSetter.self),
+			params: params,
+		}
+	}
 	return rateSetter
 }
 
@@ -91,21 +205,25 @@ func (r *RateSetter) Setup() {
 			r.pauseUpdates--
 			return
 		}
-		if r.issuingQueue.Size() > 0 {
+		if r.Size() > 0 {
 			r.rateSetting()
 		}
 	}))
 }
 
-// Issue submits a message to the local issuing queue.
+// Issue submits a message to the local issuing queue. The message is passed on with the MessageIssued event as soon as
+// the rate of the node allows it. ErrMessageDiscarded is returned if the message does not fit into the queue. A queued
+// message can still be discarded later to make room for a message of a higher priority, which triggers the
+// MessageDiscarded event.
 func (r *RateSetter) Issue(message *Message) error {
 	if identity.NewID(message.IssuerPublicKey()) != r.self {
 		return ErrInvalidIssuer
 	}
 
+	request := &issueRequest{message: message, result: make(chan error, 1)}
 	select {
-	case r.issueChan <- message:
-		return nil
+	case r.issueChan <- request:
+		return <-request.result
 	case <-r.shutdownSignal:
 		return ErrStopped
 	}
@@ -125,13 +243,35 @@ func (r *RateSetter) Rate() float64 {
 
 // Size returns the size of the issuing queue.
 func (r *RateSetter) Size() int {
-	return r.issuingQueue.Size()
+	r.lanesMutex.RLock()
+	defer r.lanesMutex.RUnlock()
+
+	return r.size()
+}
+
+// LaneSizes returns the size of the messages queued in each priority lane in bytes.
+func (r *RateSetter) LaneSizes() map[MessagePriority]int {
+	r.lanesMutex.RLock()
+	defer r.lanesMutex.RUnlock()
+
+	sizes := make(map[MessagePriority]int, len(r.lanes))
+	for i, lane := range r.lanes {
+		sizes[MessagePriority(i)] = lane.queue.Size()
+	}
+	return sizes
+}
+
+func (r *RateSetter) size() (size int) {
+	for _, lane := range r.lanes {
+		size += lane.queue.Size()
+	}
+	return size
 }
 
 // rateSetting updates the rate ownRate at which messages can be issued by the node.
 func (r *RateSetter) rateSetting() {
-	ownMana := r.tangle.Options.SchedulerParams.AccessManaRetrieveFunc(r.self)
-	totalMana := r.tangle.Options.SchedulerParams.TotalAccessManaRetrieveFunc()
+	ownMana := r.accessMana(r.tangle.Options.SchedulerParams.AccessManaRetrieveFunc(r.self))
+	totalMana := math.Max(r.accessMana(r.tangle.Options.SchedulerParams.TotalAccessManaRetrieveFunc()), ownMana)
 
 	ownRate := r.ownRate.Load()
 	if float64(r.tangle.Scheduler.NodeQueueSize(r.self))/ownMana > Backoff {
@@ -143,6 +283,15 @@ func (r *RateSetter) rateSetting() {
 	r.ownRate.Store(ownRate)
 }
 
+// accessMana returns the given access mana, but at least the MinMana of the Scheduler, which treats nodes with less
+// mana the same way. This keeps the rate finite for nodes without access mana and for invalid mana values.
+func (r *RateSetter) accessMana(mana float64) float64 {
+	if math.IsNaN(mana) || math.IsInf(mana, 0) || mana < r.tangle.Scheduler.minMana {
+		return r.tangle.Scheduler.minMana
+	}
+	return mana
+}
+
 func (r *RateSetter) issuerLoop() {
 	var (
 		issueTimer    = time.NewTimer(0) // setting this to 0 will cause a trigger right away
@@ -157,38 +306,35 @@ loop:
 		// a new message can be submitted to the scheduler
 		case <-issueTimer.C:
 			timerStopped = true
-			if r.issuingQueue.Front() == nil {
+			if r.front() == nil {
 				continue
 			}
 
-			msg := r.issuingQueue.PopFront().(*Message)
-			if err := r.tangle.Scheduler.SubmitAndReady(msg.ID()); err != nil {
-				r.Events.MessageDiscarded.Trigger(msg.ID())
-			}
+			msg := r.popFront()
+			r.Events.MessageIssued.Trigger(msg)
 			lastIssueTime = time.Now()
 
-			if next := r.issuingQueue.Front(); next != nil {
-				issueTimer.Reset(time.Until(lastIssueTime.Add(r.issueInterval(next.(*Message)))))
+			if next := r.front(); next != nil {
+				issueTimer.Reset(time.Until(lastIssueTime.Add(r.issueInterval(next))))
 				timerStopped = false
 			}
 
 		// add a new message to the local issuer queue
-		case msg := <-r.issueChan:
-			if r.issuingQueue.Size()+msg.Size() > MaxLocalQueueSize {
-				r.Events.MessageDiscarded.Trigger(msg.ID())
+		case request := <-r.issueChan:
+			if !r.enqueue(request.message) {
+				request.result <- ErrMessageDiscarded
+				r.Events.MessageDiscarded.Trigger(request.message.ID())
 				continue
 			}
-			// add to queue
-			r.issuingQueue.Submit(msg)
-			r.issuingQueue.Ready(msg)
+			request.result <- nil
 
 			// set a new timer if needed
 			// if a timer is already running it is not updated, even if the ownRate has changed
 			if !timerStopped {
 				break
 			}
-			if next := r.issuingQueue.Front(); next != nil {
-				issueTimer.Reset(time.Until(lastIssueTime.Add(r.issueInterval(next.(*Message)))))
+			if next := r.front(); next != nil {
+				issueTimer.Reset(time.Until(lastIssueTime.Add(r.issueInterval(next))))
 			}
 
 		// on close, exit the loop
@@ -198,22 +344,160 @@ loop:
 	}
 
 	// discard all remaining messages at shutdown
-	for _, id := range r.issuingQueue.IDs() {
-		r.Events.MessageDiscarded.Trigger(id)
+	r.lanesMutex.RLock()
+	var remaining []schedulerutils.ElementID
+	for _, lane := range r.lanes {
+		remaining = append(remaining, lane.queue.IDs()...)
 	}
+	r.lanesMutex.RUnlock()
+	for _, id := range remaining {
+		r.Events.MessageDiscarded.Trigger(MessageID(id))
+	}
+}
+
+// enqueue adds the message to its priority lane. If the lane or the whole issuing queue is full, older messages of the
+// lane or messages of lower priority lanes are discarded according to the DropPolicy. It returns false if the message
+// itself has to be discarded.
+func (r *RateSetter) enqueue(msg *Message) bool {
+	var discarded []*Message
+	defer func() {
+		// the events are triggered after the lanes were unlocked, so that the handlers can query the sizes
+		for _, discardedMsg := range discarded {
+			r.Events.MessageDiscarded.Trigger(discardedMsg.ID())
+		}
+	}()
+	r.lanesMutex.Lock()
+	defer r.lanesMutex.Unlock()
+
+	priority := r.priorityFunc(msg)
+	if int(priority) >= len(r.lanes) {
+		priority = MessagePriority(len(r.lanes) - 1)
+	}
+	lane := r.lanes[priority]
+	if msg.Size() > lane.params.MaxSize || msg.Size() > MaxLocalQueueSize {
+		return false
+	}
+
+	if lane.queue.Size()+msg.Size() > lane.params.MaxSize {
+		if lane.params.DropPolicy != DropOldest {
+			return false
+		}
+		for lane.queue.Size()+msg.Size() > lane.params.MaxSize {
+			discarded = append(discarded, lane.queue.PopFront().(*Message))
+		}
+	}
+
+	// make room in the lower priority lanes, starting with the lowest one
+	for i := len(r.lanes) - 1; r.size()+msg.Size() > MaxLocalQueueSize; i-- {
+		if i <= int(priority) {
+			return false
+		}
+		for r.lanes[i].queue.Front() != nil && r.size()+msg.Size() > MaxLocalQueueSize {
+			discarded = append(discarded, r.lanes[i].queue.PopFront().(*Message))
+		}
+	}
+
+	lane.queue.Submit(msg)
+	lane.queue.Ready(msg)
+	return true
+}
+
+// front returns the message that is issued next without removing it.
+func (r *RateSetter) front() *Message {
+	r.lanesMutex.RLock()
+	defer r.lanesMutex.RUnlock()
+
+	if lane := r.nextLane(); lane != nil {
+		return lane.queue.Front().(*Message)
+	}
+	return nil
+}
+
+// popFront removes and returns the message that is issued next.
+func (r *RateSetter) popFront() *Message {
+	r.lanesMutex.Lock()
+	defer r.lanesMutex.Unlock()
+
+	lane := r.nextLane()
+	if r.strategy == WeightedDequeue {
+		// smooth weighted round robin: the selected lane pays for the credit that all non-empty lanes received
+		totalWeight := 0
+		for _, l := range r.lanes {
+			if l.queue.Front() == nil {
+				l.credit = 0
+				continue
+			}
+			l.credit += l.weight()
+			totalWeight += l.weight()
+		}
+		lane.credit -= totalWeight
+	}
+	return lane.queue.PopFront().(*Message)
+}
+
+// nextLane returns the lane of the message that is issued next or nil if all lanes are empty. The lanes must be locked.
+func (r *RateSetter) nextLane() (next *rateSetterLane) {
+	for _, lane := range r.lanes {
+		if lane.queue.Front() == nil {
+			continue
+		}
+		if r.strategy != WeightedDequeue {
+			return lane
+		}
+		if next == nil || lane.credit+lane.weight() > next.credit+next.weight() {
+			next = lane
+		}
+	}
+	return next
 }
 
 func (r *RateSetter) issueInterval(msg *Message) time.Duration {
-	wait := time.Duration(math.Ceil(float64(len(msg.Bytes())) / r.ownRate.Load() * float64(time.Second)))
+	ownRate := r.ownRate.Load()
+	if !(ownRate > 0) || math.IsInf(ownRate, 0) {
+		ownRate = Initial
+	}
+	wait := time.Duration(math.Ceil(float64(len(msg.Bytes())) / ownRate * float64(time.Second)))
 	return wait
 }
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region issueRequest ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// issueRequest is a message submitted to the issuer loop of the RateSetter together with the channel that receives
+// whether it was queued.
+type issueRequest struct {
+	message *Message
+	result  chan error
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region rateSetterLane ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// rateSetterLane is a priority lane of the RateSetter.
+type rateSetterLane struct {
+	queue  *schedulerutils.NodeQueue
+	params LaneParams
+	credit int
+}
+
+func (l *rateSetterLane) weight() int {
+	if l.params.Weight < 1 {
+		return 1
+	}
+	return l.params.Weight
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region RateSetterEvents /////////////////////////////////////////////////////////////////////////////////////////////
 
 // RateSetterEvents represents events happening in the rate setter.
 type RateSetterEvents struct {
+	// MessageIssued is triggered when a message leaves the issuing queue at the rate of the node.
+	MessageIssued *events.Event
+	// MessageDiscarded is triggered when a message is dropped from the issuing queue.
 	MessageDiscarded *events.Event
 }
 
@@ -1,15 +1,18 @@
 package tangle
 
 import (
+	"math"
 	"testing"
 	"time"
 
 	"github.com/iotaledger/goshimmer/packages/tangle/payload"
 
+	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/identity"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -36,9 +39,20 @@ func TestRateSetter_Submit(t *testing.T) {
 	rateSetter := NewRateSetter(tangle)
 	defer rateSetter.Shutdown()
 
+	messageIssued := make(chan *Message, 1)
+	rateSetter.Events.MessageIssued.Attach(events.NewClosure(func(msg *Message) { messageIssued <- msg }))
+
 	msg := newMessage(localNode.PublicKey())
 	assert.NoError(t, rateSetter.Issue(msg))
-	time.Sleep(100 * time.Millisecond)
+	assert.Eventually(t, func() bool {
+		select {
+		case issued := <-messageIssued:
+			return assert.Equal(t, msg.ID(), issued.ID())
+		default:
+			return false
+		}
+	}, 1*time.Second, 10*time.Millisecond)
+	assert.Zero(t, rateSetter.Size())
 }
 
 func TestRateSetter_ErrorHandling(t *testing.T) {
@@ -66,7 +80,7 @@ func TestRateSetter_ErrorHandling(t *testing.T) {
 		0,
 		ed25519.Signature{},
 	)
-	assert.NoError(t, rateSetter.Issue(msg))
+	assert.True(t, errors.Is(rateSetter.Issue(msg), ErrMessageDiscarded))
 
 	assert.Eventually(t, func() bool {
 		select {
@@ -77,3 +91,104 @@ func TestRateSetter_ErrorHandling(t *testing.T) {
 		}
 	}, 1*time.Second, 10*time.Millisecond)
 }
+
+func TestRateSetter_ZeroMana(t *testing.T) {
+	localID := identity.GenerateLocalIdentity()
+	localNode := identity.New(localID.PublicKey())
+
+	tangle := NewTestTangle(Identity(localID), RateSetterConfig(testRateSetterParams))
+	defer tangle.Shutdown()
+	rateSetter := newRateSetter(tangle)
+
+	for _, mana := range []float64{0, math.NaN(), math.Inf(1)} {
+		mana := mana
+		tangle.Options.SchedulerParams.AccessManaRetrieveFunc = func(identity.ID) float64 { return mana }
+		tangle.Options.SchedulerParams.TotalAccessManaRetrieveFunc = func() float64 { return mana }
+
+		rateSetter.ownRate.Store(testInitial)
+		rateSetter.rateSetting()
+		rate := rateSetter.Rate()
+		assert.False(t, math.IsNaN(rate) || math.IsInf(rate, 0), "mana %f", mana)
+		assert.Greater(t, rate, 0.0)
+		assert.Greater(t, rateSetter.issueInterval(newMessage(localNode.PublicKey())), time.Duration(0))
+	}
+
+	rateSetter.ownRate.Store(math.NaN())
+	assert.Less(t, rateSetter.issueInterval(newMessage(localNode.PublicKey())), time.Second)
+}
+
+func TestRateSetter_PriorityLanes(t *testing.T) {
+	localID := identity.GenerateLocalIdentity()
+	localNode := identity.New(localID.PublicKey())
+
+	priorities := make(map[MessageID]MessagePriority)
+	newPrioritizedMessage := func(priority MessagePriority) *Message {
+		msg := newMessageWithTimestamp(localNode.PublicKey(), time.Now().Add(time.Duration(len(priorities))*time.Millisecond))
+		priorities[msg.ID()] = priority
+		return msg
+	}
+	newTestRateSetter := func(strategy DequeueStrategy, lanes map[MessagePriority]LaneParams) *RateSetter {
+		tangle := NewTestTangle(Identity(localID), RateSetterConfig(RateSetterParams{
+			Initial:         &testInitial,
+			DequeueStrategy: strategy,
+			Lanes:           lanes,
+			PriorityFunc:    func(msg *Message) MessagePriority { return priorities[msg.ID()] },
+		}))
+		t.Cleanup(tangle.Shutdown)
+		return newRateSetter(tangle)
+	}
+
+	t.Run("strict", func(t *testing.T) {
+		rateSetter := newTestRateSetter(StrictDequeue, nil)
+		activity := newPrioritizedMessage(ActivityPriority)
+		data := newPrioritizedMessage(DataPriority)
+		value := newPrioritizedMessage(ValuePriority)
+		for _, msg := range []*Message{activity, data, value} {
+			require.True(t, rateSetter.enqueue(msg))
+		}
+		assert.Equal(t, value.Size(), rateSetter.LaneSizes()[ValuePriority])
+
+		for _, expected := range []*Message{value, data, activity} {
+			assert.Equal(t, expected.ID(), rateSetter.front().ID())
+			assert.Equal(t, expected.ID(), rateSetter.popFront().ID())
+		}
+		assert.Nil(t, rateSetter.front())
+	})
+
+	t.Run("weighted", func(t *testing.T) {
+		rateSetter := newTestRateSetter(WeightedDequeue, map[MessagePriority]LaneParams{
+			ValuePriority: {MaxSize: MaxLocalQueueSize, Weight: 3},
+			DataPriority:  {MaxSize: MaxLocalQueueSize, Weight: 1},
+		})
+		for i := 0; i < 4; i++ {
+			require.True(t, rateSetter.enqueue(newPrioritizedMessage(ValuePriority)))
+			require.True(t, rateSetter.enqueue(newPrioritizedMessage(DataPriority)))
+		}
+
+		issued := make(map[MessagePriority]int)
+		for i := 0; i < 4; i++ {
+			issued[priorities[rateSetter.popFront().ID()]]++
+		}
+		assert.Equal(t, map[MessagePriority]int{ValuePriority: 3, DataPriority: 1}, issued)
+	})
+
+	t.Run("drop policies", func(t *testing.T) {
+		msgSize := newPrioritizedMessage(DataPriority).Size()
+		rateSetter := newTestRateSetter(StrictDequeue, map[MessagePriority]LaneParams{
+			DataPriority:     {MaxSize: msgSize, DropPolicy: DropNewest},
+			ActivityPriority: {MaxSize: msgSize, DropPolicy: DropOldest},
+		})
+		discarded := make([]MessageID, 0)
+		rateSetter.Events.MessageDiscarded.Attach(events.NewClosure(func(id MessageID) { discarded = append(discarded, id) }))
+
+		firstData, secondData := newPrioritizedMessage(DataPriority), newPrioritizedMessage(DataPriority)
+		assert.True(t, rateSetter.enqueue(firstData))
+		assert.False(t, rateSetter.enqueue(secondData))
+
+		firstActivity, secondActivity := newPrioritizedMessage(ActivityPriority), newPrioritizedMessage(ActivityPriority)
+		assert.True(t, rateSetter.enqueue(firstActivity))
+		assert.True(t, rateSetter.enqueue(secondActivity))
+		assert.Equal(t, []MessageID{firstActivity.ID()}, discarded)
+		assert.Equal(t, secondActivity.ID(), rateSetter.lanes[ActivityPriority].queue.Front().(*Message).ID())
+	})
+}
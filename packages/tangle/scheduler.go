@@ -35,6 +35,10 @@ type SchedulerParams struct {
 	TotalAccessManaRetrieveFunc       func() float64
 	AccessManaMapRetrieverFunc        func() map[identity.ID]float64
 	ConfirmedMessageScheduleThreshold time.Duration
+	// MaxDeficit overrides the default MaxDeficit if set. It must be >= MaxMessageSize.
+	MaxDeficit float64
+	// MinMana overrides the default MinMana if set.
+	MinMana float64
 }
 
 // Scheduler is a Tangle component that takes care of scheduling the messages that shall be booked.
@@ -49,6 +53,8 @@ type Scheduler struct {
 	mu                    sync.RWMutex
 	buffer                *schedulerutils.BufferQueue
 	deficits              map[identity.ID]float64
+	maxDeficit            float64
+	minMana               float64
 	rate                  *atomic.Duration
 	confirmedMsgThreshold time.Duration
 	shutdownSignal        chan struct{}
@@ -70,7 +76,16 @@ func NewScheduler(tangle *Tangle) *Scheduler {
 	// maximum access mana-scaled inbox length
 	maxQueue := float64(maxBuffer) / float64(tangle.LedgerState.TotalSupply())
 
-	accessManaCache := schedulerutils.NewAccessManaCache(tangle.Options.SchedulerParams.AccessManaMapRetrieverFunc, MinMana)
+	maxDeficit := float64(MaxDeficit)
+	if tangle.Options.SchedulerParams.MaxDeficit != 0 {
+		maxDeficit = math.Max(tangle.Options.SchedulerParams.MaxDeficit, MaxMessageSize)
+	}
+	minMana := MinMana
+	if tangle.Options.SchedulerParams.MinMana != 0 {
+		minMana = tangle.Options.SchedulerParams.MinMana
+	}
+
+	accessManaCache := schedulerutils.NewAccessManaCache(tangle.Options.SchedulerParams.AccessManaMapRetrieverFunc, minMana)
 
 	return &Scheduler{
 		Events: &SchedulerEvents{
@@ -87,6 +102,8 @@ func NewScheduler(tangle *Tangle) *Scheduler {
 		buffer:                schedulerutils.NewBufferQueue(maxBuffer, maxQueue),
 		confirmedMsgThreshold: confirmedMessageScheduleThreshold,
 		deficits:              make(map[identity.ID]float64),
+		maxDeficit:            maxDeficit,
+		minMana:               minMana,
 		shutdownSignal:        make(chan struct{}),
 	}
 }
@@ -462,7 +479,7 @@ func (s *Scheduler) updateActiveNodesList(manaCache map[identity.ID]float64) {
 	// this allows nodes with zero mana to issue messages, however those nodes will only accumulate their deficit
 	// when there are messages in the node's queue
 	for i := 0; i < activeNodes; i++ {
-		if nodeMana, exists := manaCache[currentNode.NodeID()]; (!exists || nodeMana < MinMana) && currentNode.Size() == 0 {
+		if nodeMana, exists := manaCache[currentNode.NodeID()]; (!exists || nodeMana < s.minMana) && currentNode.Size() == 0 {
 			s.buffer.RemoveNode(currentNode.NodeID())
 			delete(s.deficits, currentNode.NodeID())
 			currentNode = s.buffer.Current()
@@ -473,7 +490,7 @@ func (s *Scheduler) updateActiveNodesList(manaCache map[identity.ID]float64) {
 
 	// update list of active nodes with accumulating deficit
 	for nodeID, nodeMana := range manaCache {
-		if nodeMana < MinMana {
+		if nodeMana < s.minMana {
 			continue
 		}
 		if _, exists := s.deficits[nodeID]; !exists {
@@ -493,13 +510,7 @@ loop:
 		// every rate time units
 		case <-s.ticker.C:
 			// TODO: pause the ticker, if there are no ready messages
-			if msg := s.schedule(); msg != nil {
-				s.tangle.Storage.MessageMetadata(msg.ID()).Consume(func(messageMetadata *MessageMetadata) {
-					if messageMetadata.SetScheduled(true) {
-						s.Events.MessageScheduled.Trigger(msg.ID())
-					}
-				})
-			}
+			s.Step()
 
 		// on close, exit the loop
 		case <-s.shutdownSignal:
@@ -511,6 +522,22 @@ loop:
 	s.Clear()
 }
 
+// Step schedules the next ready message, if there is one, and returns it.
+// It is called by the main loop at every tick and allows to drive a scheduler that has not been started, e.g. to
+// simulate it on a virtual clock.
+func (s *Scheduler) Step() (scheduled *Message) {
+	msg := s.schedule()
+	if msg == nil {
+		return nil
+	}
+	s.tangle.Storage.MessageMetadata(msg.ID()).Consume(func(messageMetadata *MessageMetadata) {
+		if messageMetadata.SetScheduled(true) {
+			s.Events.MessageScheduled.Trigger(msg.ID())
+		}
+	})
+	return msg
+}
+
 func (s *Scheduler) getDeficit(nodeID identity.ID) float64 {
 	return s.deficits[nodeID]
 }
@@ -521,7 +548,7 @@ func (s *Scheduler) updateDeficit(nodeID identity.ID, d float64) {
 		// this will never happen and is just here for debugging purposes
 		panic("scheduler: deficit is less than 0")
 	}
-	s.deficits[nodeID] = math.Min(deficit, MaxDeficit)
+	s.deficits[nodeID] = math.Min(deficit, s.maxDeficit)
 }
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -8,9 +8,11 @@ import (
 	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/atomic"
 
+	"github.com/iotaledger/goshimmer/packages/database"
 	"github.com/iotaledger/goshimmer/packages/tangle/payload"
 	"github.com/iotaledger/goshimmer/packages/tangle/schedulerutils"
 )
@@ -167,6 +169,52 @@ func TestScheduler_Schedule(t *testing.T) {
 	}, 1*time.Second, 10*time.Millisecond)
 }
 
+func TestScheduler_Step(t *testing.T) {
+	tangle := NewTestTangle(Identity(selfLocalIdentity))
+	defer tangle.Shutdown()
+
+	// the scheduler is driven manually without starting its main loop
+	assert.Nil(t, tangle.Scheduler.Step())
+
+	msg := newMessage(peerNode.PublicKey())
+	tangle.Storage.StoreMessage(msg)
+	assert.NoError(t, tangle.Scheduler.SubmitAndReady(msg.ID()))
+
+	scheduled := tangle.Scheduler.Step()
+	if assert.NotNil(t, scheduled) {
+		assert.Equal(t, msg.ID(), scheduled.ID())
+	}
+	assert.True(t, tangle.Storage.MessageMetadata(msg.ID()).Consume(func(messageMetadata *MessageMetadata) {
+		assert.True(t, messageMetadata.Scheduled())
+	}))
+	assert.Nil(t, tangle.Scheduler.Step())
+}
+
+func TestScheduler_ParamsOverride(t *testing.T) {
+	newScheduler := func(maxDeficit, minMana float64) *Scheduler {
+		params := testSchedulerParams
+		params.MaxDeficit = maxDeficit
+		params.MinMana = minMana
+		tangle := New(Store(mapdb.NewMapDB()), SchedulerConfig(params), CacheTimeProvider(database.NewCacheTimeProvider(0)))
+		t.Cleanup(tangle.Shutdown)
+		return tangle.Scheduler
+	}
+
+	scheduler := newScheduler(0, 0)
+	assert.Equal(t, float64(MaxDeficit), scheduler.maxDeficit)
+	assert.Equal(t, MinMana, scheduler.minMana)
+
+	scheduler = newScheduler(2*MaxMessageSize, 10)
+	assert.Equal(t, float64(2*MaxMessageSize), scheduler.maxDeficit)
+	assert.Equal(t, 10.0, scheduler.minMana)
+	// nodes with less mana are treated as having the minimum mana
+	assert.Equal(t, 10.0, scheduler.accessManaCache.GetCachedMana(identity.GenerateIdentity().ID()))
+
+	// the deficit must allow to schedule a message of the maximum size
+	scheduler = newScheduler(1, 0)
+	assert.Equal(t, float64(MaxMessageSize), scheduler.maxDeficit)
+}
+
 // MockConfirmationOracleConfirmed mocks ConfirmationOracle marking all messages as confirmed.
 type MockConfirmationOracleConfirmed struct {
 	ConfirmationOracle
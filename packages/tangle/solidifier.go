@@ -79,6 +79,17 @@ func (s *Solidifier) checkMessageSolidity(message *Message, messageMetadata *Mes
 		return
 	}
 
+	// the required adaptive PoW difficulty depends on the past cone, so it can only be checked once it is solid
+	if s.tangle.AdaptivePowDifficulty != nil {
+		if err := s.tangle.AdaptivePowDifficulty.CheckMessage(message); err != nil {
+			if !messageMetadata.SetInvalid(true) {
+				return
+			}
+			s.tangle.Events.MessageInvalid.Trigger(&MessageInvalidEvent{MessageID: message.ID(), Error: err})
+			return
+		}
+	}
+
 	lockBuilder := syncutils.MultiMutexLockBuilder{}
 	lockBuilder.AddLock(messageMetadata.ID())
 
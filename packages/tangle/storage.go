@@ -264,6 +264,10 @@ func (s *Storage) DeleteMessage(messageID MessageID) {
 		currentMsg.ForEachParentByType(StrongParentType, func(parentMessageID MessageID) {
 			s.deleteStrongApprover(parentMessageID, messageID)
 		})
+		// like parents are stored as strong approvers
+		currentMsg.ForEachParentByType(LikeParentType, func(parentMessageID MessageID) {
+			s.deleteStrongApprover(parentMessageID, messageID)
+		})
 		currentMsg.ForEachParentByType(WeakParentType, func(parentMessageID MessageID) {
 			s.deleteWeakApprover(parentMessageID, messageID)
 		})
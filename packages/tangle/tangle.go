@@ -36,6 +36,7 @@ type Tangle struct {
 	Storage               *Storage
 	Solidifier            *Solidifier
 	Scheduler             *Scheduler
+	RateSetter            *RateSetter
 	Dispatcher            *Dispatcher
 	Booker                *Booker
 	ApprovalWeightManager *ApprovalWeightManager
@@ -49,6 +50,7 @@ type Tangle struct {
 	WeightProvider        WeightProvider
 	Events                *Events
 	ConfirmationOracle    ConfirmationOracle
+	AdaptivePowDifficulty *AdaptivePowDifficulty
 
 	setupParserOnce sync.Once
 }
@@ -86,6 +88,7 @@ func New(options ...Option) (tangle *Tangle) {
 	tangle.LedgerState = NewLedgerState(tangle)
 	tangle.Solidifier = NewSolidifier(tangle)
 	tangle.Scheduler = NewScheduler(tangle)
+	tangle.RateSetter = NewRateSetter(tangle)
 	tangle.Booker = NewBooker(tangle)
 	tangle.ApprovalWeightManager = NewApprovalWeightManager(tangle)
 	tangle.TimeManager = NewTimeManager(tangle)
@@ -121,6 +124,7 @@ func (t *Tangle) Setup() {
 	t.Solidifier.Setup()
 	t.Requester.Setup()
 	t.Scheduler.Setup()
+	t.RateSetter.Setup()
 	t.Dispatcher.Setup()
 	t.Booker.Setup()
 	t.ApprovalWeightManager.Setup()
@@ -172,6 +176,7 @@ func (t *Tangle) Shutdown() {
 	t.Requester.Shutdown()
 	t.Parser.Shutdown()
 	t.MessageFactory.Shutdown()
+	t.RateSetter.Shutdown()
 	t.Scheduler.Shutdown()
 	t.Dispatcher.Shutdown()
 	t.Booker.Shutdown()
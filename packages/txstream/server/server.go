@@ -195,6 +195,27 @@ func (c *Connection) txSubscribedAddresses(tx *ledgerstate.Transaction) map[[led
 			ret[addr.Array()] = addr
 		}
 	}
+	// a transaction that reveals a preimage is also sent to the fallback address of the hash time-locked output it
+	// claims, so that the counterparty of an atomic swap learns the preimage
+	for _, input := range tx.Essence().Inputs() {
+		utxoInput, ok := input.(*ledgerstate.UTXOInput)
+		if !ok {
+			continue
+		}
+		outputID := utxoInput.ReferencedOutputID()
+		if _, revealed := tx.RevealedPreimage(outputID); !revealed {
+			continue
+		}
+		c.ledger.GetOutput(outputID, func(output ledgerstate.Output) {
+			htlc, ok := output.(*ledgerstate.ExtendedLockedOutput)
+			if !ok {
+				return
+			}
+			if addr := htlc.FallbackAddress(); addr != nil && ret[addr.Array()] == nil && c.isSubscribed(addr) {
+				ret[addr.Array()] = addr
+			}
+		})
+	}
 	return ret
 }
 
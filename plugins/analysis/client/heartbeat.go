@@ -35,7 +35,7 @@ func sendHeartbeat(w io.Writer, hb *packet.Heartbeat) {
 		"inboundIDs", in.String(),
 	)
 
-	data, err := packet.NewHeartbeatMessage(hb)
+	data, err := packet.NewHeartbeatMessage(hb, deps.Local)
 	if err != nil {
 		log.Info(err, " - heartbeat message skipped")
 		return
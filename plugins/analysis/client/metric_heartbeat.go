@@ -14,7 +14,7 @@ import (
 )
 
 func sendMetricHeartbeat(w io.Writer, hb *packet.MetricHeartbeat) {
-	data, err := packet.NewMetricHeartbeatMessage(hb)
+	data, err := packet.NewMetricHeartbeatMessage(hb, deps.Local)
 	if err != nil {
 		log.Debugw("metric heartbeat message skipped", "err", err)
 		return
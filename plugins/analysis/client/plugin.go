@@ -14,6 +14,7 @@ import (
 	"go.uber.org/dig"
 
 	"github.com/iotaledger/goshimmer/packages/shutdown"
+	logger_plugin "github.com/iotaledger/goshimmer/plugins/logger"
 )
 
 const (
@@ -47,7 +48,7 @@ var (
 )
 
 func run(_ *node.Plugin) {
-	log = logger.NewLogger(PluginName)
+	log = logger_plugin.NewLogger(PluginName)
 	conn = NewConnector("tcp", deps.Config.String(CfgServerAddress))
 
 	if err := daemon.BackgroundWorker(PluginName, func(ctx context.Context) {
@@ -0,0 +1,192 @@
+package dashboard
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/labstack/echo"
+
+	analysisserver "github.com/iotaledger/goshimmer/plugins/analysis/server"
+	"github.com/iotaledger/goshimmer/plugins/banner"
+)
+
+// defaultUptimeRange is the time range of the uptime statistics if no start is given.
+const defaultUptimeRange = 24 * time.Hour
+
+// TopologyResponse defines the response of the historic topology route.
+type TopologyResponse struct {
+	NetworkVersion string          `json:"networkVersion"`
+	Time           int64           `json:"time"`
+	Nodes          []*TopologyNode `json:"nodes"`
+	Links          []*TopologyLink `json:"links"`
+}
+
+// TopologyNode defines a node of a TopologyResponse.
+type TopologyNode struct {
+	ID          string       `json:"id"`
+	OnlineSince int64        `json:"onlineSince"`
+	Metrics     *NodeMetrics `json:"metrics,omitempty"`
+}
+
+// NodeMetrics defines the latest reported metrics of a TopologyNode.
+type NodeMetrics struct {
+	Version     string  `json:"version"`
+	OS          string  `json:"os"`
+	Arch        string  `json:"arch"`
+	NumCPU      int     `json:"numCPU"`
+	CPUUsage    float64 `json:"cpuUsage"`
+	MemoryUsage uint64  `json:"memoryUsage"`
+}
+
+// TopologyLink defines a link of a TopologyResponse.
+type TopologyLink struct {
+	SourceID       string `json:"sourceID"`
+	TargetID       string `json:"targetID"`
+	ConnectedSince int64  `json:"connectedSince"`
+}
+
+// UptimeResponse defines the response of the node uptime route.
+type UptimeResponse struct {
+	NetworkVersion string           `json:"networkVersion"`
+	NodeID         string           `json:"nodeID"`
+	From           int64            `json:"from"`
+	To             int64            `json:"to"`
+	UptimeSeconds  float64          `json:"uptimeSeconds"`
+	Ratio          float64          `json:"ratio"`
+	Sessions       []*UptimeSession `json:"sessions"`
+}
+
+// UptimeSession defines a period a node was online.
+type UptimeSession struct {
+	Start   int64 `json:"start"`
+	End     int64 `json:"end"`
+	Ongoing bool  `json:"ongoing"`
+}
+
+func setupHistoryRoutes(e *echo.Echo) {
+	e.GET("/api/history/topology", topologyRoute)
+	e.GET("/api/history/uptime/:nodeID", uptimeRoute)
+}
+
+// topologyRoute returns the topology of a network at a past point in time.
+// Query parameters: networkVersion (default: version of this node), time (unix seconds, default: now).
+func topologyRoute(c echo.Context) error {
+	history, err := networkHistory()
+	if err != nil {
+		return err
+	}
+	t, err := timeQueryParam(c, "time", time.Now())
+	if err != nil {
+		return err
+	}
+
+	topology, err := history.TopologyAt(networkVersionQueryParam(c), t)
+	if err != nil {
+		return historyError(err)
+	}
+
+	response := &TopologyResponse{
+		NetworkVersion: topology.NetworkVersion,
+		Time:           topology.Time.Unix(),
+		Nodes:          make([]*TopologyNode, 0, len(topology.Nodes)),
+		Links:          make([]*TopologyLink, 0, len(topology.Links)),
+	}
+	for _, node := range topology.Nodes {
+		responseNode := &TopologyNode{ID: node.ID, OnlineSince: node.OnlineSince.Unix()}
+		if node.Metrics != nil {
+			responseNode.Metrics = &NodeMetrics{
+				Version:     node.Metrics.Version,
+				OS:          node.Metrics.OS,
+				Arch:        node.Metrics.Arch,
+				NumCPU:      node.Metrics.NumCPU,
+				CPUUsage:    node.Metrics.CPUUsage,
+				MemoryUsage: node.Metrics.MemoryUsage,
+			}
+		}
+		response.Nodes = append(response.Nodes, responseNode)
+	}
+	for _, link := range topology.Links {
+		response.Links = append(response.Links, &TopologyLink{SourceID: link.SourceID, TargetID: link.TargetID, ConnectedSince: link.ConnectedSince.Unix()})
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// uptimeRoute returns the uptime statistics of a node within a time range.
+// Query parameters: networkVersion (default: version of this node), from (unix seconds, default: 24 hours before to),
+// to (unix seconds, default: now).
+func uptimeRoute(c echo.Context) error {
+	history, err := networkHistory()
+	if err != nil {
+		return err
+	}
+	to, err := timeQueryParam(c, "to", time.Now())
+	if err != nil {
+		return err
+	}
+	from, err := timeQueryParam(c, "from", to.Add(-defaultUptimeRange))
+	if err != nil {
+		return err
+	}
+	if to.Before(from) {
+		return fmt.Errorf("%w: to is before from", ErrInvalidParameter)
+	}
+
+	networkVersion := networkVersionQueryParam(c)
+	uptime, err := history.NodeUptime(networkVersion, c.Param("nodeID"), from, to)
+	if err != nil {
+		return historyError(err)
+	}
+
+	response := &UptimeResponse{
+		NetworkVersion: networkVersion,
+		NodeID:         uptime.NodeID,
+		From:           uptime.From.Unix(),
+		To:             uptime.To.Unix(),
+		UptimeSeconds:  uptime.Uptime.Seconds(),
+		Ratio:          uptime.Ratio(),
+		Sessions:       make([]*UptimeSession, 0, len(uptime.Sessions)),
+	}
+	for _, session := range uptime.Sessions {
+		response.Sessions = append(response.Sessions, &UptimeSession{Start: session.Start.Unix(), End: session.End.Unix(), Ongoing: session.Ongoing})
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func networkHistory() (*analysisserver.NetworkHistory, error) {
+	history := analysisserver.History()
+	if history == nil {
+		return nil, fmt.Errorf("%w: the analysis server is not running", ErrNotFound)
+	}
+	return history, nil
+}
+
+func historyError(err error) error {
+	if errors.Is(err, analysisserver.ErrHistoryUnavailable) {
+		return fmt.Errorf("%w: %s", ErrNotFound, err)
+	}
+	return fmt.Errorf("%w: %s", ErrInternalError, err)
+}
+
+func networkVersionQueryParam(c echo.Context) string {
+	if networkVersion := c.QueryParam("networkVersion"); networkVersion != "" {
+		return networkVersion
+	}
+	return banner.SimplifiedAppVersion
+}
+
+func timeQueryParam(c echo.Context, name string, defaultTime time.Time) (time.Time, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return defaultTime, nil
+	}
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s is not a unix timestamp: %s", ErrInvalidParameter, name, value)
+	}
+	return time.Unix(seconds, 0), nil
+}
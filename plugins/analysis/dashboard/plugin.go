@@ -15,6 +15,7 @@ import (
 	"go.uber.org/dig"
 
 	"github.com/iotaledger/goshimmer/packages/shutdown"
+	logger_plugin "github.com/iotaledger/goshimmer/plugins/logger"
 )
 
 // PluginName is the name of the dashboard plugin.
@@ -35,7 +36,7 @@ type dependencies struct {
 }
 
 func configure(plugin *node.Plugin) {
-	log = logger.NewLogger(plugin.Name)
+	log = logger_plugin.NewLogger(plugin.Name)
 	configureAutopeeringWorkerPool()
 	configureServer()
 }
@@ -82,6 +82,7 @@ func setupRoutes(e *echo.Echo) {
 	}
 
 	e.GET("/ws", websocketRoute)
+	setupHistoryRoutes(e)
 	e.GET("/", indexRoute)
 
 	// used to route into the dashboard index
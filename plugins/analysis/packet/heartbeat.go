@@ -1,15 +1,12 @@
 package packet
 
 import (
-	"bytes"
 	"crypto/sha256"
-	"encoding/binary"
 	"fmt"
 	"strings"
 
 	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/protocol/message"
-	"github.com/iotaledger/hive.go/protocol/tlv"
 )
 
 var (
@@ -65,7 +62,7 @@ func HeartBeatMessageDefinition() *message.Definition {
 	heartBeatOnce.Do(func() {
 		heartbeatMessageDefinition = &message.Definition{
 			ID:             MessageTypeHeartbeat,
-			MaxBytesLength: uint16(HeartbeatPacketMaxSize),
+			MaxBytesLength: uint16(HeartbeatPacketMaxSize + SignatureBlockSize),
 			VariableLength: true,
 		}
 	})
@@ -152,9 +149,10 @@ func ParseHeartbeat(data []byte) (*Heartbeat, error) {
 	return &Heartbeat{NetworkID: networkID, OwnID: ownID, OutboundIDs: outboundIDs, InboundIDs: inboundIDs}, nil
 }
 
-// NewHeartbeatMessage serializes the given heartbeat into a byte slice and adds a tlv header to the packet.
-// message = tlv header + serialized packet
-func NewHeartbeatMessage(hb *Heartbeat) ([]byte, error) {
+// NewHeartbeatMessage serializes the given heartbeat into a byte slice, signs it with the given signer and adds a tlv
+// header to the packet.
+// message = tlv header + serialized packet + signature block
+func NewHeartbeatMessage(hb *Heartbeat, signer Signer) ([]byte, error) {
 	if len(hb.NetworkID) > HeartbeatPacketMaxNetworkIDBytesSize {
 		return nil, fmt.Errorf("%w: heartbeat exceeds maximum length of NetworkID of %d ", ErrInvalidHeartbeat, HeartbeatPacketMaxNetworkIDBytesSize)
 	}
@@ -205,16 +203,5 @@ func NewHeartbeatMessage(hb *Heartbeat) ([]byte, error) {
 		copy(packet[offset+i*HeartbeatPacketPeerIDSize:offset+(i+1)*HeartbeatPacketPeerIDSize], inboundID[:HeartbeatPacketPeerIDSize])
 	}
 
-	// create a buffer for tlv header plus the packet
-	buf := bytes.NewBuffer(make([]byte, 0, tlv.HeaderMessageDefinition.MaxBytesLength+uint16(packetSize)))
-	// write tlv header into buffer
-	if err := tlv.WriteHeader(buf, MessageTypeHeartbeat, uint16(packetSize)); err != nil {
-		return nil, err
-	}
-	// write serialized packet bytes into the buffer
-	if err := binary.Write(buf, binary.BigEndian, packet); err != nil {
-		return nil, err
-	}
-
-	return buf.Bytes(), nil
+	return newSignedMessage(MessageTypeHeartbeat, packet, signer)
 }
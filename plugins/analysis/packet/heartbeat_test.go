@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/protocol/tlv"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -15,8 +16,14 @@ import (
 var (
 	ownID     = sha256.Sum256([]byte{'A'})
 	networkID = []byte("v0.2.0")
+	signer    = identity.GenerateLocalIdentity()
 )
 
+// packetOf returns the serialized packet of the given message without its tlv header and signature block.
+func packetOf(message []byte) []byte {
+	return message[tlv.HeaderMessageDefinition.MaxBytesLength : len(message)-SignatureBlockSize]
+}
+
 func TestNewHeartbeatMessage(t *testing.T) {
 	testCases := []struct {
 		hb  *Heartbeat
@@ -112,7 +119,7 @@ func TestNewHeartbeatMessage(t *testing.T) {
 	}
 	for _, testCase := range testCases {
 		hb := testCase.hb
-		serializedHb, err := NewHeartbeatMessage(hb)
+		serializedHb, err := NewHeartbeatMessage(hb, signer)
 		tlvHeaderLength := int(tlv.HeaderMessageDefinition.MaxBytesLength)
 		if testCase.err != nil {
 			require.True(t, errors.Is(err, testCase.err))
@@ -154,8 +161,8 @@ func TestParseHeartbeat(t *testing.T) {
 			hb := &Heartbeat{NetworkID: networkID, OwnID: ownID[:], OutboundIDs: make([][]byte, 0), InboundIDs: make([][]byte, 0)}
 			// message = tlv header + packet
 			// ParseHeartbeat() expects only the packet, hence serializedHb[tlvHeaderLength:]
-			serializedHb, _ := NewHeartbeatMessage(hb)
-			return testcase{index: 0, source: serializedHb[tlvHeaderLength:], expected: hb, err: nil}
+			serializedHb, _ := NewHeartbeatMessage(hb, signer)
+			return testcase{index: 0, source: packetOf(serializedHb), expected: hb, err: nil}
 		}(),
 		// ok
 		func() testcase {
@@ -170,8 +177,8 @@ func TestParseHeartbeat(t *testing.T) {
 			hb := &Heartbeat{NetworkID: networkID, OwnID: ownID[:], OutboundIDs: outboundIDs, InboundIDs: inboundIDs}
 			// message = tlv header + packet
 			// ParseHeartbeat() expects only the packet, hence serializedHb[tlvHeaderLength:]
-			serializedHb, _ := NewHeartbeatMessage(hb)
-			return testcase{index: 1, source: serializedHb[tlvHeaderLength:], expected: hb, err: nil}
+			serializedHb, _ := NewHeartbeatMessage(hb, signer)
+			return testcase{index: 1, source: packetOf(serializedHb), expected: hb, err: nil}
 		}(),
 		// err, exceeds max inbound peer IDs
 		func() testcase {
@@ -189,10 +196,10 @@ func TestParseHeartbeat(t *testing.T) {
 			hb := &Heartbeat{NetworkID: networkID, OwnID: ownID[:], OutboundIDs: outboundIDs, InboundIDs: inboundIDs}
 			// message = tlv header + packet
 			// ParseHeartbeat() expects only the packet, hence serializedHb[tlvHeaderLength:]
-			serializedHb, _ := NewHeartbeatMessage(hb)
+			serializedHb, _ := NewHeartbeatMessage(hb, signer)
 			// add an additional peer id
-			serializedHb = append(serializedHb, ownID[:]...)
-			return testcase{index: 2, source: serializedHb[tlvHeaderLength:], expected: hb, err: ErrMalformedPacket}
+			serializedHb = append(serializedHb[:len(serializedHb)-SignatureBlockSize], append(ownID[:], make([]byte, SignatureBlockSize)...)...)
+			return testcase{index: 2, source: packetOf(serializedHb), expected: hb, err: ErrMalformedPacket}
 		}(),
 		// err, exceeds max outbound peer IDs
 		func() testcase {
@@ -204,12 +211,12 @@ func TestParseHeartbeat(t *testing.T) {
 			hb := &Heartbeat{NetworkID: networkID, OwnID: ownID[:], OutboundIDs: outboundIDs, InboundIDs: make([][]byte, 0)}
 			// NewHeartbeatMessage would return nil and and error for a malformed packet (too many outbound peer IDs)
 			// so we create a correct message(tlv header + packet)
-			serializedHb, _ := NewHeartbeatMessage(hb)
+			serializedHb, _ := NewHeartbeatMessage(hb, signer)
 			// and add an extra outbound ID (inbound IDs are zero)
-			serializedHb = append(serializedHb, ownID[:]...)
+			serializedHb = append(serializedHb[:len(serializedHb)-SignatureBlockSize], append(ownID[:], make([]byte, SignatureBlockSize)...)...)
 			// manually overwrite outboundIDCount
 			serializedHb[tlvHeaderLength+HeartbeatPacketMinSize+len(networkID)-1] = HeartbeatMaxOutboundPeersCount + 1
-			return testcase{index: 3, source: serializedHb[tlvHeaderLength:], expected: hb, err: ErrMalformedPacket}
+			return testcase{index: 3, source: packetOf(serializedHb), expected: hb, err: ErrMalformedPacket}
 		}(),
 		// err, advertised outbound ID count is bigger than remaining data
 		func() testcase {
@@ -221,11 +228,11 @@ func TestParseHeartbeat(t *testing.T) {
 			hb := &Heartbeat{NetworkID: networkID, OwnID: ownID[:], OutboundIDs: outboundIDs, InboundIDs: make([][]byte, 0)}
 			// message = tlv header + packet
 			// ParseHeartbeat() expects only the packet, hence serializedHb[tlvHeaderLength:]
-			serializedHb, _ := NewHeartbeatMessage(hb)
+			serializedHb, _ := NewHeartbeatMessage(hb, signer)
 			// we set the count to HeartbeatMaxOutboundPeersCount but we only have HeartbeatMaxOutboundPeersCount - 1
 			// actually serialized in the packet
 			serializedHb[tlvHeaderLength+HeartbeatPacketMinSize+len(networkID)-1] = HeartbeatMaxOutboundPeersCount
-			return testcase{index: 4, source: serializedHb[tlvHeaderLength:], expected: nil, err: ErrMalformedPacket}
+			return testcase{index: 4, source: packetOf(serializedHb), expected: nil, err: ErrMalformedPacket}
 		}(),
 		// err, doesn't reach minimum packet size
 		func() testcase {
@@ -248,18 +255,18 @@ func TestParseHeartbeat(t *testing.T) {
 		func() testcase {
 			hb := &Heartbeat{NetworkID: networkID, OwnID: ownID[:], OutboundIDs: make([][]byte, 0), InboundIDs: make([][]byte, 0)}
 			// ParseHeartbeat() expects only the packet, hence serializedHb[tlvHeaderLength:]
-			serializedHb, _ := NewHeartbeatMessage(hb)
+			serializedHb, _ := NewHeartbeatMessage(hb, signer)
 			// set networkIDByteSize to 0
 			serializedHb[tlvHeaderLength] = 0
-			return testcase{index: 8, source: serializedHb[tlvHeaderLength:], expected: nil, err: ErrInvalidHeartbeatNetworkVersion}
+			return testcase{index: 8, source: packetOf(serializedHb), expected: nil, err: ErrInvalidHeartbeatNetworkVersion}
 		}(),
 		// err network ID does not have the correct form (missing v)
 		func() testcase {
 			hb := &Heartbeat{NetworkID: []byte("0.2.1"), OwnID: ownID[:], OutboundIDs: make([][]byte, 0), InboundIDs: make([][]byte, 0)}
 			// message = tlv header + packet
 			// ParseHeartbeat() expects only the packet, hence serializedHb[tlvHeaderLength:]
-			serializedHb, _ := NewHeartbeatMessage(hb)
-			return testcase{index: 9, source: serializedHb[tlvHeaderLength:], expected: nil, err: ErrInvalidHeartbeatNetworkVersion}
+			serializedHb, _ := NewHeartbeatMessage(hb, signer)
+			return testcase{index: 9, source: packetOf(serializedHb), expected: nil, err: ErrInvalidHeartbeatNetworkVersion}
 		}(),
 		// receive an "old" heartbeat packet
 		func() testcase {
@@ -273,9 +280,9 @@ func TestParseHeartbeat(t *testing.T) {
 			}
 			hb := &Heartbeat{NetworkID: networkID, OwnID: ownID[:], OutboundIDs: outboundIDs, InboundIDs: inboundIDs}
 			// message = tlv header + packet
-			serializedHb, _ := NewHeartbeatMessage(hb)
+			serializedHb, _ := NewHeartbeatMessage(hb, signer)
 			// heartbeat without network ID: cut the network id size byte, and network ID
-			serializedHb = serializedHb[tlvHeaderLength+1+len(networkID):]
+			serializedHb = packetOf(serializedHb)[1+len(networkID):]
 			return testcase{index: 10, source: serializedHb, expected: nil, err: ErrInvalidHeartbeatNetworkVersion}
 		}(),
 	}
@@ -2,13 +2,11 @@ package packet
 
 import (
 	"bytes"
-	"encoding/binary"
 	"encoding/gob"
 
 	"github.com/cockroachdb/errors"
 
 	"github.com/iotaledger/hive.go/protocol/message"
-	"github.com/iotaledger/hive.go/protocol/tlv"
 
 	"github.com/iotaledger/goshimmer/plugins/banner"
 )
@@ -77,27 +75,14 @@ func (hb MetricHeartbeat) Bytes() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-// NewMetricHeartbeatMessage serializes the given Metric heartbeat into a byte slice and adds a TLV header to the packet.
-// message = TLV header + serialized packet.
-func NewMetricHeartbeatMessage(hb *MetricHeartbeat) ([]byte, error) {
+// NewMetricHeartbeatMessage serializes the given Metric heartbeat into a byte slice, signs it with the given signer and
+// adds a TLV header to the packet.
+// message = TLV header + serialized packet + signature block.
+func NewMetricHeartbeatMessage(hb *MetricHeartbeat, signer Signer) ([]byte, error) {
 	packet, err := hb.Bytes()
 	if err != nil {
 		return nil, err
 	}
 
-	// calculate total needed bytes based on packet
-	packetSize := len(packet)
-
-	// create a buffer for tlv header plus the packet
-	buf := bytes.NewBuffer(make([]byte, 0, tlv.HeaderMessageDefinition.MaxBytesLength+uint16(packetSize)))
-	// write tlv header into buffer
-	if err := tlv.WriteHeader(buf, MessageTypeMetricHeartbeat, uint16(packetSize)); err != nil {
-		return nil, err
-	}
-	// write serialized packet bytes into the buffer
-	if err := binary.Write(buf, binary.BigEndian, packet); err != nil {
-		return nil, err
-	}
-
-	return buf.Bytes(), nil
+	return newSignedMessage(MessageTypeMetricHeartbeat, packet, signer)
 }
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/protocol/message"
 	"github.com/iotaledger/hive.go/protocol/tlv"
 	"github.com/shirou/gopsutil/cpu"
@@ -56,12 +57,12 @@ func TestMetricHeartbeat(t *testing.T) {
 	require.Equal(t, hb, hbParsed)
 
 	tlvHeaderLength := int(tlv.HeaderMessageDefinition.MaxBytesLength)
-	msg, err := NewMetricHeartbeatMessage(hb)
+	msg, err := NewMetricHeartbeatMessage(hb, identity.GenerateLocalIdentity())
 	require.NoError(t, err)
 
 	require.Equal(t, MessageTypeMetricHeartbeat, message.Type(msg[0]))
 
-	hbParsed, err = ParseMetricHeartbeat(msg[tlvHeaderLength:])
+	hbParsed, err = ParseMetricHeartbeat(msg[tlvHeaderLength : len(msg)-SignatureBlockSize])
 	require.NoError(t, err)
 	require.Equal(t, hb, hbParsed)
 }
@@ -0,0 +1,103 @@
+package packet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/protocol/message"
+	"github.com/iotaledger/hive.go/protocol/tlv"
+)
+
+var (
+	// ErrMissingSignature is returned for packets that are too short to contain a signature block.
+	ErrMissingSignature = errors.New("packet is not signed")
+	// ErrInvalidSignature is returned for packets whose signature does not match their content.
+	ErrInvalidSignature = errors.New("invalid packet signature")
+	// ErrOutdatedPacket is returned for packets that were signed too long ago or too far in the future.
+	ErrOutdatedPacket = errors.New("packet was not signed within the accepted time window")
+)
+
+// SignatureBlockSize is the byte size of the block that is appended to every analysis packet. It contains the public
+// key of the issuing node, the time of issuance and the signature of the node.
+const SignatureBlockSize = ed25519.PublicKeySize + marshalutil.TimeSize + ed25519.SignatureSize
+
+// Signer is the interface of the local identity that signs the analysis packets.
+type Signer interface {
+	PublicKey() ed25519.PublicKey
+	Sign(data []byte) ed25519.Signature
+}
+
+// VerifyPacket verifies the signature block of the given packet of the given message type. It returns the packet
+// without its signature block and the ID of the node that signed it. Packets that were signed more than maxClockSkew
+// before or after the current time are rejected to prevent the replay of old packets.
+func VerifyPacket(messageType message.Type, data []byte, maxClockSkew time.Duration) (packet []byte, issuerID identity.ID, err error) {
+	if len(data) < SignatureBlockSize {
+		return nil, identity.ID{}, fmt.Errorf("%w: packet doesn't reach the size of the signature block of %d", ErrMissingSignature, SignatureBlockSize)
+	}
+
+	signatureOffset := len(data) - ed25519.SignatureSize
+	marshalUtil := marshalutil.New(data[len(data)-SignatureBlockSize:])
+	publicKey, err := ed25519.ParsePublicKey(marshalUtil)
+	if err != nil {
+		return nil, identity.ID{}, fmt.Errorf("%w: failed to parse public key: %s", ErrInvalidSignature, err)
+	}
+	issuingTime, err := marshalUtil.ReadTime()
+	if err != nil {
+		return nil, identity.ID{}, fmt.Errorf("%w: failed to parse issuing time: %s", ErrInvalidSignature, err)
+	}
+	signature, err := ed25519.ParseSignature(marshalUtil)
+	if err != nil {
+		return nil, identity.ID{}, fmt.Errorf("%w: failed to parse signature: %s", ErrInvalidSignature, err)
+	}
+
+	if !publicKey.VerifySignature(signedContent(messageType, data[:signatureOffset]), signature) {
+		return nil, identity.ID{}, ErrInvalidSignature
+	}
+	if skew := time.Since(issuingTime); skew > maxClockSkew || skew < -maxClockSkew {
+		return nil, identity.ID{}, fmt.Errorf("%w: packet was signed at %s", ErrOutdatedPacket, issuingTime)
+	}
+
+	return data[:len(data)-SignatureBlockSize], identity.NewID(publicKey), nil
+}
+
+// newSignedMessage appends the signature block of the given signer to the packet and adds a TLV header.
+// message = TLV header + serialized packet + signature block.
+func newSignedMessage(messageType message.Type, packet []byte, signer Signer) ([]byte, error) {
+	packetSize := len(packet) + SignatureBlockSize
+	if packetSize > math.MaxUint16 {
+		return nil, fmt.Errorf("%w: signed packet exceeds maximum size of %d", ErrMalformedPacket, math.MaxUint16)
+	}
+
+	signedPacket := marshalutil.New(packetSize).
+		WriteBytes(packet).
+		WriteBytes(signer.PublicKey().Bytes()).
+		WriteTime(time.Now()).
+		Bytes()
+	signature := signer.Sign(signedContent(messageType, signedPacket))
+
+	// create a buffer for tlv header plus the packet
+	buf := bytes.NewBuffer(make([]byte, 0, tlv.HeaderMessageDefinition.MaxBytesLength+uint16(packetSize)))
+	// write tlv header into buffer
+	if err := tlv.WriteHeader(buf, messageType, uint16(packetSize)); err != nil {
+		return nil, err
+	}
+	// write serialized packet and signature block into the buffer
+	if err := binary.Write(buf, binary.BigEndian, append(signedPacket, signature.Bytes()...)); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// signedContent returns the bytes that are covered by the signature. The message type is included, so that a signature
+// can not be reused for a packet of a different type.
+func signedContent(messageType message.Type, data []byte) []byte {
+	return append([]byte{byte(messageType)}, data...)
+}
@@ -0,0 +1,48 @@
+package packet_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/protocol/tlv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/iotaledger/goshimmer/plugins/analysis/packet"
+)
+
+func TestVerifyPacket(t *testing.T) {
+	nodeIdentity := identity.GenerateLocalIdentity()
+	ownID := nodeIdentity.ID()
+	hb := &Heartbeat{NetworkID: networkID, OwnID: ownID[:], OutboundIDs: make([][]byte, 0), InboundIDs: make([][]byte, 0)}
+	msg, err := NewHeartbeatMessage(hb, nodeIdentity)
+	require.NoError(t, err)
+	signedPacket := msg[tlv.HeaderMessageDefinition.MaxBytesLength:]
+
+	packet, issuerID, err := VerifyPacket(MessageTypeHeartbeat, signedPacket, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, nodeIdentity.ID(), issuerID)
+	parsedHb, err := ParseHeartbeat(packet)
+	require.NoError(t, err)
+	assert.Equal(t, hb, parsedHb)
+
+	// the signature does not cover packets of other types
+	_, _, err = VerifyPacket(MessageTypeMetricHeartbeat, signedPacket, time.Minute)
+	assert.True(t, errors.Is(err, ErrInvalidSignature))
+
+	// tampered packets are rejected
+	tamperedPacket := append([]byte{}, signedPacket...)
+	tamperedPacket[len(networkID)+1] ^= 1
+	_, _, err = VerifyPacket(MessageTypeHeartbeat, tamperedPacket, time.Minute)
+	assert.True(t, errors.Is(err, ErrInvalidSignature))
+
+	// unsigned packets are rejected
+	_, _, err = VerifyPacket(MessageTypeHeartbeat, signedPacket[:SignatureBlockSize-1], time.Minute)
+	assert.True(t, errors.Is(err, ErrMissingSignature))
+
+	// packets that were signed too long ago are rejected
+	_, _, err = VerifyPacket(MessageTypeHeartbeat, signedPacket, 0)
+	assert.True(t, errors.Is(err, ErrOutdatedPacket))
+}
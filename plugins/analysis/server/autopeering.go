@@ -135,17 +135,21 @@ func (nm *NetworkMap) update(hb *packet.Heartbeat) {
 	}
 }
 
-// starts record manager that initiates a record cleanup periodically
+// starts record manager that initiates a record cleanup and the pruning of the history periodically
 func runEventsRecordManager() {
 	if err := daemon.BackgroundWorker("Analysis Server Autopeering Record Manager", func(ctx context.Context) {
 		ticker := time.NewTicker(cleanUpPeriod)
 		defer ticker.Stop()
+		pruningTicker := time.NewTicker(historyPruningPeriod)
+		defer pruningTicker.Stop()
 		for {
 			select {
 			case <-ctx.Done():
 				return
 			case <-ticker.C:
 				cleanUp(cleanUpPeriod)
+			case <-pruningTicker.C:
+				pruneHistory()
 			}
 		}
 	}, shutdown.PriorityAnalysis); err != nil {
@@ -153,6 +157,17 @@ func runEventsRecordManager() {
 	}
 }
 
+// removes the history that is older than the configured retention.
+func pruneHistory() {
+	retention := deps.Config.Duration(CfgAnalysisServerHistoryRetention)
+	if retention <= 0 {
+		return
+	}
+	if err := history.Prune(time.Now().Add(-retention)); err != nil {
+		log.Errorf("failed to prune analysis history: %s", err)
+	}
+}
+
 // removes nodes and links we haven't seen for at least 3 times the heartbeat interval.
 func cleanUp(interval time.Duration) {
 	for _, networkMap := range Networks {
@@ -0,0 +1,588 @@
+package server
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/types"
+)
+
+// ErrHistoryUnavailable is returned if the history does not reach back to the queried point in time.
+var ErrHistoryUnavailable = errors.New("history not available")
+
+// HistoryEventType defines the type of an event recorded in the NetworkHistory.
+type HistoryEventType byte
+
+const (
+	// HistoryEventReset marks a restart of the analysis server. All nodes and links that are known before it are
+	// considered to be offline, as the analysis server did not track them during its downtime.
+	HistoryEventReset HistoryEventType = iota
+	// HistoryEventNodeAdded marks the appearance of a node.
+	HistoryEventNodeAdded
+	// HistoryEventNodeRemoved marks the disappearance of a node.
+	HistoryEventNodeRemoved
+	// HistoryEventNodesConnected marks the appearance of a link between two nodes.
+	HistoryEventNodesConnected
+	// HistoryEventNodesDisconnected marks the disappearance of a link between two nodes.
+	HistoryEventNodesDisconnected
+	// HistoryEventMetricHeartbeat marks the reception of a metric heartbeat of a node.
+	HistoryEventMetricHeartbeat
+)
+
+// HistoryEvent is an event that is recorded in the NetworkHistory.
+type HistoryEvent struct {
+	// Type is the type of the event.
+	Type HistoryEventType
+	// Time is the time the event happened.
+	Time time.Time
+	// NetworkVersion is the network the event belongs to. It is empty for metric heartbeats and resets.
+	NetworkVersion string
+	// SourceID is the ID of the node of a node or metric heartbeat event, or the source node of a link event.
+	SourceID string
+	// TargetID is the ID of the target node of a link event.
+	TargetID string
+	// Metrics holds the metrics of a metric heartbeat event.
+	Metrics *NodeMetrics
+}
+
+// NodeMetrics contains the metrics that a node reported in its metric heartbeat.
+type NodeMetrics struct {
+	Version     string
+	OS          string
+	Arch        string
+	NumCPU      int
+	CPUUsage    float64
+	MemoryUsage uint64
+}
+
+// Topology is the state of the network at a specific point in time.
+type Topology struct {
+	NetworkVersion string
+	Time           time.Time
+	// Nodes contains the online nodes sorted by their ID.
+	Nodes []*TopologyNode
+	// Links contains the links between the online nodes sorted by their source and target ID.
+	Links []*TopologyLink
+}
+
+// TopologyNode is a node of a Topology.
+type TopologyNode struct {
+	ID          string
+	OnlineSince time.Time
+	// Metrics holds the latest metrics the node reported before the time of the topology (nil if none).
+	Metrics *NodeMetrics
+}
+
+// TopologyLink is a link of a Topology.
+type TopologyLink struct {
+	SourceID       string
+	TargetID       string
+	ConnectedSince time.Time
+}
+
+// NodeUptime contains the uptime statistics of a node within a time range.
+type NodeUptime struct {
+	NodeID string
+	From   time.Time
+	To     time.Time
+	// Uptime is the total time the node was online within the time range.
+	Uptime time.Duration
+	// Sessions contains the periods the node was online within the time range in chronological order.
+	Sessions []*UptimeSession
+}
+
+// Ratio returns the share of the time range the node was online.
+func (n *NodeUptime) Ratio() float64 {
+	if !n.To.After(n.From) {
+		return 0
+	}
+	return float64(n.Uptime) / float64(n.To.Sub(n.From))
+}
+
+// UptimeSession is a period a node was online.
+type UptimeSession struct {
+	Start time.Time
+	End   time.Time
+	// Ongoing is true if the node was still online at the end of the queried time range.
+	Ongoing bool
+}
+
+// region NetworkHistory ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// NetworkHistory persists the events of the analysis server, so that the topology of the networks and the uptime of
+// their nodes can be reconstructed for past points in time.
+type NetworkHistory struct {
+	store kvstore.KVStore
+	// sequence distinguishes the keys of events that are recorded at the same time.
+	sequence uint32
+	mutex    sync.RWMutex
+}
+
+// NewNetworkHistory creates a new NetworkHistory that persists its events in the given store.
+func NewNetworkHistory(store kvstore.KVStore) *NetworkHistory {
+	return &NetworkHistory{
+		store: store,
+	}
+}
+
+// Record persists the given event.
+func (h *NetworkHistory) Record(event *HistoryEvent) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return h.record(h.store, event.Time, event)
+}
+
+// Events returns the recorded events within [from, to] in chronological order.
+func (h *NetworkHistory) Events(from, to time.Time) (events []*HistoryEvent, err error) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	if err = h.forEachEvent(to, func(event *HistoryEvent) {
+		if !event.Time.Before(from) {
+			events = append(events, event)
+		}
+	}); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// TopologyAt reconstructs the topology of the given network at time t.
+func (h *NetworkHistory) TopologyAt(networkVersion string, t time.Time) (*Topology, error) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	if err := h.checkAvailable(t); err != nil {
+		return nil, err
+	}
+
+	state := newHistoryState()
+	if err := h.forEachEvent(t, state.apply); err != nil {
+		return nil, err
+	}
+
+	return state.topology(networkVersion, t), nil
+}
+
+// NodeUptime calculates the uptime statistics of the given node of the given network within [from, to].
+func (h *NetworkHistory) NodeUptime(networkVersion, nodeID string, from, to time.Time) (*NodeUptime, error) {
+	if to.Before(from) {
+		return nil, errors.Errorf("end of time range %s is before its start %s", to, from)
+	}
+
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	if err := h.checkAvailable(from); err != nil {
+		return nil, err
+	}
+
+	uptime := &NodeUptime{NodeID: nodeID, From: from, To: to}
+	addSession := func(start, end time.Time, ongoing bool) {
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if !end.After(start) && !ongoing {
+			return
+		}
+		uptime.Sessions = append(uptime.Sessions, &UptimeSession{Start: start, End: end, Ongoing: ongoing})
+		uptime.Uptime += end.Sub(start)
+	}
+
+	var onlineSince *time.Time
+	if err := h.forEachEvent(to, func(event *HistoryEvent) {
+		switch {
+		case event.Type == HistoryEventReset:
+			if onlineSince != nil {
+				addSession(*onlineSince, event.Time, false)
+				onlineSince = nil
+			}
+		case event.NetworkVersion != networkVersion || event.SourceID != nodeID:
+			return
+		case event.Type == HistoryEventNodeAdded && onlineSince == nil:
+			since := event.Time
+			onlineSince = &since
+		case event.Type == HistoryEventNodeRemoved && onlineSince != nil:
+			addSession(*onlineSince, event.Time, false)
+			onlineSince = nil
+		}
+	}); err != nil {
+		return nil, err
+	}
+	if onlineSince != nil {
+		addSession(*onlineSince, to, true)
+	}
+
+	return uptime, nil
+}
+
+// OldestAvailableTime returns the oldest point in time the history can be queried for.
+func (h *NetworkHistory) OldestAvailableTime() (oldest time.Time, exists bool, err error) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	return h.oldestAvailableTime()
+}
+
+// Prune compacts all events up to the given time into a snapshot of the state at that time. Afterwards, the history
+// can no longer be queried for points in time before it.
+func (h *NetworkHistory) Prune(before time.Time) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	state := newHistoryState()
+	var prunedKeys []kvstore.Key
+	if err := h.store.IterateKeys(kvstore.EmptyPrefix, func(key kvstore.Key) bool {
+		if !timeFromHistoryKey(key).After(before) {
+			prunedKeys = append(prunedKeys, key)
+		}
+		return true
+	}); err != nil {
+		return errors.Wrap(err, "failed to iterate over analysis history")
+	}
+	if len(prunedKeys) == 0 {
+		return nil
+	}
+	if err := h.forEachEvent(before, state.apply); err != nil {
+		return err
+	}
+
+	batch := h.store.Batched()
+	for _, key := range prunedKeys {
+		if err := batch.Delete(key); err != nil {
+			batch.Cancel()
+			return errors.Wrap(err, "failed to delete analysis history event")
+		}
+	}
+	for _, event := range state.snapshot(before) {
+		if err := h.record(batch, before, event); err != nil {
+			batch.Cancel()
+			return err
+		}
+	}
+
+	return errors.Wrap(batch.Commit(), "failed to prune analysis history")
+}
+
+// historyWriter is the part of the store and its batches that is used to record events.
+type historyWriter interface {
+	Set(key kvstore.Key, value kvstore.Value) error
+}
+
+// record stores the event under a key that is ordered by the given time.
+func (h *NetworkHistory) record(writer historyWriter, t time.Time, event *HistoryEvent) error {
+	h.sequence++
+	if err := writer.Set(historyKey(t, h.sequence), event.Bytes()); err != nil {
+		return errors.Wrapf(err, "failed to store analysis history event of type %d", event.Type)
+	}
+
+	return nil
+}
+
+// forEachEvent calls the consumer for all events that were recorded until the given time in the order they were
+// recorded in.
+func (h *NetworkHistory) forEachEvent(until time.Time, consumer func(event *HistoryEvent)) (err error) {
+	type keyedEvent struct {
+		key   kvstore.Key
+		event *HistoryEvent
+	}
+
+	var keyedEvents []keyedEvent
+	if iterErr := h.store.Iterate(kvstore.EmptyPrefix, func(key kvstore.Key, value kvstore.Value) bool {
+		if timeFromHistoryKey(key).After(until) {
+			return true
+		}
+
+		var event *HistoryEvent
+		if event, err = HistoryEventFromBytes(value); err != nil {
+			return false
+		}
+		keyedEvents = append(keyedEvents, keyedEvent{key: key, event: event})
+
+		return true
+	}); iterErr != nil {
+		return errors.Wrap(iterErr, "failed to iterate over analysis history")
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to parse analysis history event")
+	}
+
+	// not all stores iterate in the order of their keys
+	sort.Slice(keyedEvents, func(i, j int) bool {
+		return bytes.Compare(keyedEvents[i].key, keyedEvents[j].key) < 0
+	})
+	for _, keyedEvent := range keyedEvents {
+		consumer(keyedEvent.event)
+	}
+
+	return nil
+}
+
+// checkAvailable returns an error if the history does not reach back to the given time.
+func (h *NetworkHistory) checkAvailable(t time.Time) error {
+	oldest, exists, err := h.oldestAvailableTime()
+	if err != nil {
+		return err
+	}
+	if !exists || t.Before(oldest) {
+		return errors.Errorf("no analysis history available for %s: %w", t, ErrHistoryUnavailable)
+	}
+
+	return nil
+}
+
+func (h *NetworkHistory) oldestAvailableTime() (oldest time.Time, exists bool, err error) {
+	if err = h.store.IterateKeys(kvstore.EmptyPrefix, func(key kvstore.Key) bool {
+		if keyTime := timeFromHistoryKey(key); !exists || keyTime.Before(oldest) {
+			oldest = keyTime
+			exists = true
+		}
+		return true
+	}); err != nil {
+		return time.Time{}, false, errors.Wrap(err, "failed to iterate over analysis history")
+	}
+
+	return oldest, exists, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region HistoryEvent serialization ///////////////////////////////////////////////////////////////////////////////////
+
+// Bytes returns a marshaled version of the HistoryEvent.
+func (e *HistoryEvent) Bytes() []byte {
+	marshalUtil := marshalutil.New().
+		WriteByte(byte(e.Type)).
+		WriteTime(e.Time)
+	writeHistoryString(marshalUtil, e.NetworkVersion)
+	writeHistoryString(marshalUtil, e.SourceID)
+	writeHistoryString(marshalUtil, e.TargetID)
+
+	marshalUtil.WriteBool(e.Metrics != nil)
+	if e.Metrics != nil {
+		writeHistoryString(marshalUtil, e.Metrics.Version)
+		writeHistoryString(marshalUtil, e.Metrics.OS)
+		writeHistoryString(marshalUtil, e.Metrics.Arch)
+		marshalUtil.
+			WriteUint32(uint32(e.Metrics.NumCPU)).
+			WriteFloat64(e.Metrics.CPUUsage).
+			WriteUint64(e.Metrics.MemoryUsage)
+	}
+
+	return marshalUtil.Bytes()
+}
+
+// HistoryEventFromBytes unmarshals a HistoryEvent from a sequence of bytes.
+func HistoryEventFromBytes(data []byte) (event *HistoryEvent, err error) {
+	marshalUtil := marshalutil.New(data)
+	event = &HistoryEvent{}
+
+	eventType, err := marshalUtil.ReadByte()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse event type")
+	}
+	event.Type = HistoryEventType(eventType)
+	if event.Time, err = marshalUtil.ReadTime(); err != nil {
+		return nil, errors.Wrap(err, "failed to parse event time")
+	}
+	if event.NetworkVersion, err = readHistoryString(marshalUtil); err != nil {
+		return nil, errors.Wrap(err, "failed to parse network version")
+	}
+	if event.SourceID, err = readHistoryString(marshalUtil); err != nil {
+		return nil, errors.Wrap(err, "failed to parse source ID")
+	}
+	if event.TargetID, err = readHistoryString(marshalUtil); err != nil {
+		return nil, errors.Wrap(err, "failed to parse target ID")
+	}
+
+	hasMetrics, err := marshalUtil.ReadBool()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse metrics flag")
+	}
+	if !hasMetrics {
+		return event, nil
+	}
+
+	event.Metrics = &NodeMetrics{}
+	if event.Metrics.Version, err = readHistoryString(marshalUtil); err != nil {
+		return nil, errors.Wrap(err, "failed to parse version")
+	}
+	if event.Metrics.OS, err = readHistoryString(marshalUtil); err != nil {
+		return nil, errors.Wrap(err, "failed to parse OS")
+	}
+	if event.Metrics.Arch, err = readHistoryString(marshalUtil); err != nil {
+		return nil, errors.Wrap(err, "failed to parse architecture")
+	}
+	numCPU, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse number of CPUs")
+	}
+	event.Metrics.NumCPU = int(numCPU)
+	if event.Metrics.CPUUsage, err = marshalUtil.ReadFloat64(); err != nil {
+		return nil, errors.Wrap(err, "failed to parse CPU usage")
+	}
+	if event.Metrics.MemoryUsage, err = marshalUtil.ReadUint64(); err != nil {
+		return nil, errors.Wrap(err, "failed to parse memory usage")
+	}
+
+	return event, nil
+}
+
+func writeHistoryString(marshalUtil *marshalutil.MarshalUtil, s string) {
+	marshalUtil.WriteUint16(uint16(len(s))).WriteBytes([]byte(s))
+}
+
+func readHistoryString(marshalUtil *marshalutil.MarshalUtil) (string, error) {
+	length, err := marshalUtil.ReadUint16()
+	if err != nil {
+		return "", err
+	}
+	s, err := marshalUtil.ReadBytes(int(length))
+	if err != nil {
+		return "", err
+	}
+
+	return string(s), nil
+}
+
+// historyKey returns the key of an event in the store: time || sequence.
+func historyKey(t time.Time, sequence uint32) []byte {
+	key := make([]byte, 12)
+	binary.BigEndian.PutUint64(key[:8], uint64(t.UnixNano()))
+	binary.BigEndian.PutUint32(key[8:], sequence)
+	return key
+}
+
+func timeFromHistoryKey(key []byte) time.Time {
+	return time.Unix(0, int64(binary.BigEndian.Uint64(key[:8])))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region historyState /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// historyState is the state of all networks that results from replaying the recorded events.
+type historyState struct {
+	// maps network version to node ID to the time the node appeared.
+	nodes map[string]map[string]time.Time
+	// maps network version to source ID to target ID to the time the link appeared.
+	links map[string]map[string]map[string]time.Time
+	// maps node ID to the latest metric heartbeat of the node.
+	metrics map[string]*HistoryEvent
+}
+
+func newHistoryState() *historyState {
+	return &historyState{
+		nodes:   make(map[string]map[string]time.Time),
+		links:   make(map[string]map[string]map[string]time.Time),
+		metrics: make(map[string]*HistoryEvent),
+	}
+}
+
+func (s *historyState) apply(event *HistoryEvent) {
+	switch event.Type {
+	case HistoryEventReset:
+		s.nodes = make(map[string]map[string]time.Time)
+		s.links = make(map[string]map[string]map[string]time.Time)
+	case HistoryEventNodeAdded:
+		if _, exists := s.nodes[event.NetworkVersion]; !exists {
+			s.nodes[event.NetworkVersion] = make(map[string]time.Time)
+		}
+		if _, exists := s.nodes[event.NetworkVersion][event.SourceID]; !exists {
+			s.nodes[event.NetworkVersion][event.SourceID] = event.Time
+		}
+	case HistoryEventNodeRemoved:
+		delete(s.nodes[event.NetworkVersion], event.SourceID)
+	case HistoryEventNodesConnected:
+		if _, exists := s.links[event.NetworkVersion]; !exists {
+			s.links[event.NetworkVersion] = make(map[string]map[string]time.Time)
+		}
+		if _, exists := s.links[event.NetworkVersion][event.SourceID]; !exists {
+			s.links[event.NetworkVersion][event.SourceID] = make(map[string]time.Time)
+		}
+		if _, exists := s.links[event.NetworkVersion][event.SourceID][event.TargetID]; !exists {
+			s.links[event.NetworkVersion][event.SourceID][event.TargetID] = event.Time
+		}
+	case HistoryEventNodesDisconnected:
+		delete(s.links[event.NetworkVersion][event.SourceID], event.TargetID)
+		if len(s.links[event.NetworkVersion][event.SourceID]) == 0 {
+			delete(s.links[event.NetworkVersion], event.SourceID)
+		}
+	case HistoryEventMetricHeartbeat:
+		s.metrics[event.SourceID] = event
+	}
+}
+
+func (s *historyState) topology(networkVersion string, t time.Time) *Topology {
+	topology := &Topology{
+		NetworkVersion: networkVersion,
+		Time:           t,
+		Nodes:          make([]*TopologyNode, 0, len(s.nodes[networkVersion])),
+		Links:          make([]*TopologyLink, 0),
+	}
+	for nodeID, onlineSince := range s.nodes[networkVersion] {
+		node := &TopologyNode{ID: nodeID, OnlineSince: onlineSince}
+		if metricHeartbeat, exists := s.metrics[nodeID]; exists {
+			node.Metrics = metricHeartbeat.Metrics
+		}
+		topology.Nodes = append(topology.Nodes, node)
+	}
+	for sourceID, targets := range s.links[networkVersion] {
+		for targetID, connectedSince := range targets {
+			topology.Links = append(topology.Links, &TopologyLink{SourceID: sourceID, TargetID: targetID, ConnectedSince: connectedSince})
+		}
+	}
+
+	sort.Slice(topology.Nodes, func(i, j int) bool {
+		return topology.Nodes[i].ID < topology.Nodes[j].ID
+	})
+	sort.Slice(topology.Links, func(i, j int) bool {
+		if topology.Links[i].SourceID != topology.Links[j].SourceID {
+			return topology.Links[i].SourceID < topology.Links[j].SourceID
+		}
+		return topology.Links[i].TargetID < topology.Links[j].TargetID
+	})
+
+	return topology
+}
+
+// snapshot returns the events that restore the state when they are recorded at the given time. The events keep the
+// time the nodes and links appeared at. Metrics are only kept for the nodes that are online.
+func (s *historyState) snapshot(t time.Time) (events []*HistoryEvent) {
+	// the reset marks the time the history is available from, even if no node is online at that time
+	events = append(events, &HistoryEvent{Type: HistoryEventReset, Time: t})
+	onlineNodes := make(map[string]types.Empty)
+	for networkVersion, nodes := range s.nodes {
+		for nodeID, onlineSince := range nodes {
+			events = append(events, &HistoryEvent{Type: HistoryEventNodeAdded, Time: onlineSince, NetworkVersion: networkVersion, SourceID: nodeID})
+			onlineNodes[nodeID] = types.Void
+		}
+	}
+	for networkVersion, sources := range s.links {
+		for sourceID, targets := range sources {
+			for targetID, connectedSince := range targets {
+				events = append(events, &HistoryEvent{Type: HistoryEventNodesConnected, Time: connectedSince, NetworkVersion: networkVersion, SourceID: sourceID, TargetID: targetID})
+			}
+		}
+	}
+	for nodeID, metricHeartbeat := range s.metrics {
+		if _, online := onlineNodes[nodeID]; online {
+			events = append(events, metricHeartbeat)
+		}
+	}
+
+	return events
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,155 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testNetwork = "v0.0.1"
+
+func TestNetworkHistory_TopologyAt(t *testing.T) {
+	history := NewNetworkHistory(mapdb.NewMapDB())
+	start := time.Unix(1000, 0)
+	recordTestHistory(t, history, start)
+
+	_, err := history.TopologyAt(testNetwork, start.Add(-time.Second))
+	assert.True(t, errors.Is(err, ErrHistoryUnavailable))
+
+	topology, err := history.TopologyAt(testNetwork, start.Add(25*time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A", "B", "C"}, topologyNodeIDs(topology))
+	assert.Equal(t, []string{"A->B", "C->A"}, topologyLinkIDs(topology))
+	assert.Equal(t, start.Add(10*time.Second), topology.Nodes[0].OnlineSince)
+	require.NotNil(t, topology.Nodes[0].Metrics)
+	assert.Equal(t, "linux", topology.Nodes[0].Metrics.OS)
+	assert.Nil(t, topology.Nodes[1].Metrics)
+
+	topology, err = history.TopologyAt(testNetwork, start.Add(45*time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A", "C"}, topologyNodeIDs(topology))
+	assert.Equal(t, []string{"C->A"}, topologyLinkIDs(topology))
+
+	// a restart of the analysis server clears the topology
+	topology, err = history.TopologyAt(testNetwork, start.Add(60*time.Second))
+	require.NoError(t, err)
+	assert.Empty(t, topology.Nodes)
+	assert.Empty(t, topology.Links)
+
+	// other networks are not mixed in
+	topology, err = history.TopologyAt("other", start.Add(25*time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"X"}, topologyNodeIDs(topology))
+}
+
+func TestNetworkHistory_NodeUptime(t *testing.T) {
+	history := NewNetworkHistory(mapdb.NewMapDB())
+	start := time.Unix(1000, 0)
+	recordTestHistory(t, history, start)
+
+	uptime, err := history.NodeUptime(testNetwork, "B", start, start.Add(100*time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, 20*time.Second, uptime.Uptime)
+	assert.InDelta(t, 0.2, uptime.Ratio(), 1e-9)
+	require.Len(t, uptime.Sessions, 1)
+	assert.Equal(t, &UptimeSession{Start: start.Add(20 * time.Second), End: start.Add(40 * time.Second)}, uptime.Sessions[0])
+
+	// the session of A is ended by the restart of the analysis server and continues after A reappears
+	uptime, err = history.NodeUptime(testNetwork, "A", start.Add(30*time.Second), start.Add(100*time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, 50*time.Second, uptime.Uptime)
+	require.Len(t, uptime.Sessions, 2)
+	assert.Equal(t, &UptimeSession{Start: start.Add(30 * time.Second), End: start.Add(50 * time.Second)}, uptime.Sessions[0])
+	assert.Equal(t, &UptimeSession{Start: start.Add(70 * time.Second), End: start.Add(100 * time.Second), Ongoing: true}, uptime.Sessions[1])
+
+	uptime, err = history.NodeUptime(testNetwork, "unknown", start, start.Add(100*time.Second))
+	require.NoError(t, err)
+	assert.Zero(t, uptime.Uptime)
+	assert.Empty(t, uptime.Sessions)
+}
+
+func TestNetworkHistory_Prune(t *testing.T) {
+	history := NewNetworkHistory(mapdb.NewMapDB())
+	start := time.Unix(1000, 0)
+	recordTestHistory(t, history, start)
+
+	expectedTopology, err := history.TopologyAt(testNetwork, start.Add(45*time.Second))
+	require.NoError(t, err)
+	expectedUptime, err := history.NodeUptime(testNetwork, "A", start.Add(35*time.Second), start.Add(100*time.Second))
+	require.NoError(t, err)
+
+	require.NoError(t, history.Prune(start.Add(35*time.Second)))
+
+	oldest, exists, err := history.OldestAvailableTime()
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, start.Add(35*time.Second), oldest)
+
+	_, err = history.TopologyAt(testNetwork, start.Add(30*time.Second))
+	assert.True(t, errors.Is(err, ErrHistoryUnavailable))
+
+	// the state after the pruned time is not affected
+	topology, err := history.TopologyAt(testNetwork, start.Add(45*time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, expectedTopology, topology)
+	uptime, err := history.NodeUptime(testNetwork, "A", start.Add(35*time.Second), start.Add(100*time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, expectedUptime, uptime)
+}
+
+func TestHistoryEvent_Bytes(t *testing.T) {
+	event := &HistoryEvent{
+		Type:     HistoryEventMetricHeartbeat,
+		Time:     time.Unix(1000, 0),
+		SourceID: "A",
+		Metrics:  &NodeMetrics{Version: "v0.0.1", OS: "linux", Arch: "amd64", NumCPU: 4, CPUUsage: 0.5, MemoryUsage: 1024},
+	}
+	restoredEvent, err := HistoryEventFromBytes(event.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, event, restoredEvent)
+
+	_, err = HistoryEventFromBytes(event.Bytes()[:10])
+	assert.Error(t, err)
+}
+
+// recordTestHistory records the following history:
+// 10s: A appears, 20s: B appears and connects to A, 25s: C appears and connects to A, 40s: B disappears,
+// 50s: the analysis server restarts, 70s: A appears again.
+func recordTestHistory(t *testing.T, history *NetworkHistory, start time.Time) {
+	events := []*HistoryEvent{
+		{Type: HistoryEventReset},
+		{Type: HistoryEventNodeAdded, NetworkVersion: testNetwork, SourceID: "A", Time: start.Add(10 * time.Second)},
+		{Type: HistoryEventMetricHeartbeat, SourceID: "A", Time: start.Add(15 * time.Second), Metrics: &NodeMetrics{OS: "linux"}},
+		{Type: HistoryEventNodeAdded, NetworkVersion: testNetwork, SourceID: "B", Time: start.Add(20 * time.Second)},
+		{Type: HistoryEventNodesConnected, NetworkVersion: testNetwork, SourceID: "A", TargetID: "B", Time: start.Add(20 * time.Second)},
+		{Type: HistoryEventNodeAdded, NetworkVersion: "other", SourceID: "X", Time: start.Add(20 * time.Second)},
+		{Type: HistoryEventNodeAdded, NetworkVersion: testNetwork, SourceID: "C", Time: start.Add(25 * time.Second)},
+		{Type: HistoryEventNodesConnected, NetworkVersion: testNetwork, SourceID: "C", TargetID: "A", Time: start.Add(25 * time.Second)},
+		{Type: HistoryEventNodesDisconnected, NetworkVersion: testNetwork, SourceID: "A", TargetID: "B", Time: start.Add(40 * time.Second)},
+		{Type: HistoryEventNodeRemoved, NetworkVersion: testNetwork, SourceID: "B", Time: start.Add(40 * time.Second)},
+		{Type: HistoryEventReset, Time: start.Add(50 * time.Second)},
+		{Type: HistoryEventNodeAdded, NetworkVersion: testNetwork, SourceID: "A", Time: start.Add(70 * time.Second)},
+	}
+	events[0].Time = start
+	for _, event := range events {
+		require.NoError(t, history.Record(event))
+	}
+}
+
+func topologyNodeIDs(topology *Topology) (nodeIDs []string) {
+	for _, node := range topology.Nodes {
+		nodeIDs = append(nodeIDs, node.ID)
+	}
+	return nodeIDs
+}
+
+func topologyLinkIDs(topology *Topology) (linkIDs []string) {
+	for _, link := range topology.Links {
+		linkIDs = append(linkIDs, link.SourceID+"->"+link.TargetID)
+	}
+	return linkIDs
+}
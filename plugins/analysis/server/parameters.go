@@ -6,3 +6,12 @@ import (
 
 // the period in which we scan and delete old data.
 const cleanUpPeriod = 15 * time.Second
+
+// the maximum difference between the time a packet was signed and the time it is received.
+const maxClockSkew = 1 * time.Minute
+
+// the minimum time between two metric heartbeats of a node that are recorded in the history.
+const metricHeartbeatHistoryInterval = 1 * time.Minute
+
+// the period in which we prune the history.
+const historyPruningPeriod = 1 * time.Hour
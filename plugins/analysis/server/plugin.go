@@ -1,27 +1,36 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"net"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/configuration"
 	"github.com/iotaledger/hive.go/daemon"
 	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/network"
 	"github.com/iotaledger/hive.go/network/tcp"
 	"github.com/iotaledger/hive.go/node"
 	"github.com/iotaledger/hive.go/protocol"
+	"github.com/iotaledger/hive.go/protocol/message"
+	"github.com/iotaledger/hive.go/types"
+	"github.com/mr-tron/base58"
 	flag "github.com/spf13/pflag"
 	"go.uber.org/dig"
 
+	"github.com/iotaledger/goshimmer/packages/database"
 	"github.com/iotaledger/goshimmer/packages/shutdown"
 	"github.com/iotaledger/goshimmer/plugins/analysis/packet"
+	logger_plugin "github.com/iotaledger/goshimmer/plugins/logger"
 )
 
 const (
@@ -31,6 +40,13 @@ const (
 	// CfgAnalysisServerBindAddress defines the bind address of the analysis server.
 	CfgAnalysisServerBindAddress = "analysis.server.bindAddress"
 
+	// CfgAnalysisServerAllowedNodes defines the base58 encoded IDs of the nodes that are allowed to report to the
+	// analysis server. If it is empty, every node with a valid signature is accepted.
+	CfgAnalysisServerAllowedNodes = "analysis.server.allowedNodes"
+
+	// CfgAnalysisServerHistoryRetention defines how long the history of the analysis server is kept.
+	CfgAnalysisServerHistoryRetention = "analysis.server.historyRetention"
+
 	// IdleTimeout defines the idle timeout of the read from the client's connection.
 	IdleTimeout = 1 * time.Minute
 )
@@ -39,11 +55,14 @@ type dependencies struct {
 	dig.In
 
 	Config *configuration.Configuration
+	Store  kvstore.KVStore
 }
 
 func init() {
 	Plugin = node.NewPlugin(PluginName, deps, node.Disabled, configure, run)
 	flag.String(CfgAnalysisServerBindAddress, "0.0.0.0:16178", "the bind address of the analysis server")
+	flag.StringSlice(CfgAnalysisServerAllowedNodes, nil, "the IDs of the nodes that are allowed to report to the analysis server (all nodes if empty)")
+	flag.Duration(CfgAnalysisServerHistoryRetention, 7*24*time.Hour, "how long the history of the analysis server is kept (forever if 0)")
 }
 
 var (
@@ -53,10 +72,27 @@ var (
 	server *tcp.TCPServer
 	prot   *protocol.Protocol
 	log    *logger.Logger
+
+	// allowedNodes contains the IDs of the nodes that are allowed to report to the analysis server (nil if all nodes are
+	// allowed).
+	allowedNodes map[identity.ID]types.Empty
+
+	history *NetworkHistory
+	// metricHeartbeatsRecorded maps node ID to the time its last metric heartbeat was recorded in the history.
+	metricHeartbeatsRecorded      = make(map[string]time.Time)
+	metricHeartbeatsRecordedMutex sync.Mutex
 )
 
+// History returns the history of the analysis server.
+func History() *NetworkHistory {
+	return history
+}
+
 func configure(plugin *node.Plugin) {
-	log = logger.NewLogger(PluginName)
+	log = logger_plugin.NewLogger(PluginName)
+	if err := configureAllowedNodes(deps.Config.Strings(CfgAnalysisServerAllowedNodes)); err != nil {
+		log.Fatalf("invalid %s: %s", CfgAnalysisServerAllowedNodes, err)
+	}
 	server = tcp.NewServer()
 
 	server.Events.Connect.Attach(events.NewClosure(HandleConnection))
@@ -66,6 +102,63 @@ func configure(plugin *node.Plugin) {
 	Events.Error.Attach(events.NewClosure(func(err error) {
 		log.Errorf("error in analysis server: %s", err.Error())
 	}))
+	configureHistory()
+}
+
+// configureHistory records the events of the analysis server in its history.
+func configureHistory() {
+	history = NewNetworkHistory(deps.Store.WithRealm([]byte{database.PrefixAnalysisHistory}))
+	// the nodes and links that were known before the restart are not tracked anymore
+	recordHistoryEvent(&HistoryEvent{Type: HistoryEventReset, Time: time.Now()})
+
+	Events.AddNode.Attach(events.NewClosure(func(event *AddNodeEvent) {
+		recordHistoryEvent(&HistoryEvent{Type: HistoryEventNodeAdded, Time: time.Now(), NetworkVersion: event.NetworkVersion, SourceID: event.NodeID})
+	}))
+	Events.RemoveNode.Attach(events.NewClosure(func(event *RemoveNodeEvent) {
+		recordHistoryEvent(&HistoryEvent{Type: HistoryEventNodeRemoved, Time: time.Now(), NetworkVersion: event.NetworkVersion, SourceID: event.NodeID})
+	}))
+	Events.ConnectNodes.Attach(events.NewClosure(func(event *ConnectNodesEvent) {
+		recordHistoryEvent(&HistoryEvent{Type: HistoryEventNodesConnected, Time: time.Now(), NetworkVersion: event.NetworkVersion, SourceID: event.SourceID, TargetID: event.TargetID})
+	}))
+	Events.DisconnectNodes.Attach(events.NewClosure(func(event *DisconnectNodesEvent) {
+		recordHistoryEvent(&HistoryEvent{Type: HistoryEventNodesDisconnected, Time: time.Now(), NetworkVersion: event.NetworkVersion, SourceID: event.SourceID, TargetID: event.TargetID})
+	}))
+	Events.MetricHeartbeat.Attach(events.NewClosure(recordMetricHeartbeat))
+}
+
+// recordMetricHeartbeat records the metric heartbeat in the history, if the last recorded metric heartbeat of the node
+// is older than metricHeartbeatHistoryInterval.
+func recordMetricHeartbeat(hb *packet.MetricHeartbeat) {
+	nodeID := ShortNodeIDString(hb.OwnID)
+	now := time.Now()
+
+	metricHeartbeatsRecordedMutex.Lock()
+	if now.Sub(metricHeartbeatsRecorded[nodeID]) < metricHeartbeatHistoryInterval {
+		metricHeartbeatsRecordedMutex.Unlock()
+		return
+	}
+	metricHeartbeatsRecorded[nodeID] = now
+	metricHeartbeatsRecordedMutex.Unlock()
+
+	recordHistoryEvent(&HistoryEvent{
+		Type:     HistoryEventMetricHeartbeat,
+		Time:     now,
+		SourceID: nodeID,
+		Metrics: &NodeMetrics{
+			Version:     hb.Version,
+			OS:          hb.OS,
+			Arch:        hb.Arch,
+			NumCPU:      hb.NumCPU,
+			CPUUsage:    hb.CPUUsage,
+			MemoryUsage: hb.MemoryUsage,
+		},
+	})
+}
+
+func recordHistoryEvent(event *HistoryEvent) {
+	if err := history.Record(event); err != nil {
+		log.Errorf("failed to record analysis history event: %s", err)
+	}
 }
 
 func run(_ *node.Plugin) {
@@ -134,28 +227,91 @@ func wireUp(p *protocol.Protocol) {
 	}))
 }
 
-// processHeartbeatPacket parses the serialized data into a Heartbeat packet and triggers its event.
+// configureAllowedNodes parses the base58 encoded IDs of the nodes that are allowed to report to the analysis server.
+func configureAllowedNodes(encodedNodeIDs []string) error {
+	if len(encodedNodeIDs) == 0 {
+		allowedNodes = nil
+		return nil
+	}
+
+	allowedNodes = make(map[identity.ID]types.Empty, len(encodedNodeIDs))
+	for _, encodedNodeID := range encodedNodeIDs {
+		nodeIDBytes, err := base58.Decode(encodedNodeID)
+		if err != nil {
+			return errors.Errorf("failed to decode node ID %s: %w", encodedNodeID, err)
+		}
+		if len(nodeIDBytes) != identity.IDLength {
+			return errors.Errorf("node ID %s needs to be %d bytes long", encodedNodeID, identity.IDLength)
+		}
+
+		var nodeID identity.ID
+		copy(nodeID[:], nodeIDBytes)
+		allowedNodes[nodeID] = types.Void
+	}
+
+	return nil
+}
+
+// verifyPacket verifies the signature of the packet and checks that its issuer is allowed to report to the analysis
+// server. It returns the packet without its signature block and the ID of its issuer.
+func verifyPacket(messageType message.Type, data []byte) (packetBytes []byte, issuerID identity.ID, err error) {
+	if packetBytes, issuerID, err = packet.VerifyPacket(messageType, data, maxClockSkew); err != nil {
+		return nil, identity.ID{}, err
+	}
+
+	if allowedNodes != nil {
+		if _, allowed := allowedNodes[issuerID]; !allowed {
+			return nil, identity.ID{}, errors.Errorf("%w: %s", ErrNodeNotAllowed, issuerID)
+		}
+	}
+
+	return packetBytes, issuerID, nil
+}
+
+// processHeartbeatPacket verifies and parses the serialized data into a Heartbeat packet and triggers its event.
 func processHeartbeatPacket(data []byte) {
-	heartbeatPacket, err := packet.ParseHeartbeat(data)
+	packetBytes, issuerID, err := verifyPacket(packet.MessageTypeHeartbeat, data)
+	if err != nil {
+		rejectPacket(err)
+		return
+	}
+
+	heartbeatPacket, err := packet.ParseHeartbeat(packetBytes)
 	if err != nil {
 		if !errors.Is(err, packet.ErrInvalidHeartbeatNetworkVersion) {
 			Events.Error.Trigger(err)
 		}
 		return
 	}
+	if !bytes.Equal(heartbeatPacket.OwnID, issuerID.Bytes()) {
+		rejectPacket(errors.Errorf("%w: heartbeat of %s signed by %s", ErrIssuerMismatch, base58.Encode(heartbeatPacket.OwnID), issuerID))
+		return
+	}
+
 	updateAutopeeringMap(heartbeatPacket)
 }
 
-// processMetricHeartbeatPacket parses the serialized data into a Metric Heartbeat packet and triggers its event.
+// processMetricHeartbeatPacket verifies and parses the serialized data into a Metric Heartbeat packet and triggers its event.
 // Note that the ParseMetricHeartbeat function will return an error if the hb version field is different than banner.SimplifiedAppVersion,
 // thus the hb will be discarded.
 func processMetricHeartbeatPacket(data []byte) {
-	hb, err := packet.ParseMetricHeartbeat(data)
+	packetBytes, issuerID, err := verifyPacket(packet.MessageTypeMetricHeartbeat, data)
+	if err != nil {
+		rejectPacket(err)
+		return
+	}
+
+	hb, err := packet.ParseMetricHeartbeat(packetBytes)
 	if err != nil {
 		if !errors.Is(err, packet.ErrInvalidMetricHeartbeatVersion) {
 			Events.Error.Trigger(err)
 		}
 		return
 	}
+	if !bytes.Equal(hb.OwnID, issuerID.Bytes()) {
+		rejectPacket(errors.Errorf("%w: metric heartbeat of %s signed by %s", ErrIssuerMismatch, base58.Encode(hb.OwnID), issuerID))
+		return
+	}
+
 	Events.MetricHeartbeat.Trigger(hb)
 }
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"sync"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/iotaledger/goshimmer/plugins/analysis/packet"
+)
+
+var (
+	// ErrNodeNotAllowed is returned for packets of nodes that are not contained in the allow-list of the server.
+	ErrNodeNotAllowed = errors.New("node is not allowed to report to the analysis server")
+	// ErrIssuerMismatch is returned for packets that were signed by a different node than the one they report about.
+	ErrIssuerMismatch = errors.New("packet was not signed by the node it reports about")
+)
+
+const (
+	// RejectionReasonUnsigned is the reason of packets that were rejected because they were not signed.
+	RejectionReasonUnsigned = "unsigned"
+	// RejectionReasonInvalidSignature is the reason of packets that were rejected because of an invalid, outdated or
+	// foreign signature.
+	RejectionReasonInvalidSignature = "invalidSignature"
+	// RejectionReasonNotAllowed is the reason of packets that were rejected because their node is not allowed to
+	// report to the analysis server.
+	RejectionReasonNotAllowed = "notAllowed"
+)
+
+var (
+	rejectedPackets      = make(map[string]uint64)
+	rejectedPacketsMutex sync.RWMutex
+)
+
+// RejectedPackets returns the amount of packets that were rejected since the start of the server by the reason of
+// their rejection.
+func RejectedPackets() map[string]uint64 {
+	rejectedPacketsMutex.RLock()
+	defer rejectedPacketsMutex.RUnlock()
+
+	result := make(map[string]uint64, len(rejectedPackets))
+	for reason, count := range rejectedPackets {
+		result[reason] = count
+	}
+
+	return result
+}
+
+// rejectPacket counts the packet that was rejected because of the given error.
+func rejectPacket(err error) {
+	log.Debugw("Rejected packet", "err", err)
+
+	reason := RejectionReasonInvalidSignature
+	switch {
+	case errors.Is(err, packet.ErrMissingSignature):
+		reason = RejectionReasonUnsigned
+	case errors.Is(err, ErrNodeNotAllowed):
+		reason = RejectionReasonNotAllowed
+	}
+
+	rejectedPacketsMutex.Lock()
+	defer rejectedPacketsMutex.Unlock()
+
+	rejectedPackets[reason]++
+}
@@ -0,0 +1,98 @@
+package assetregistry
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/labstack/echo"
+	"go.uber.org/dig"
+
+	"github.com/iotaledger/goshimmer/packages/assetregistry"
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+// PluginName is the name of the asset registry plugin.
+const PluginName = "AssetRegistry"
+
+var (
+	// Plugin is the plugin instance of the asset registry plugin.
+	Plugin *node.Plugin
+	deps   = new(dependencies)
+)
+
+type dependencies struct {
+	dig.In
+
+	Tangle   *tangle.Tangle
+	Server   *echo.Echo
+	Registry *assetregistry.Registry
+}
+
+func init() {
+	Plugin = node.NewPlugin(PluginName, deps, node.Enabled, configure)
+	Plugin.Events.Init.Attach(events.NewClosure(func(_ *node.Plugin, container *dig.Container) {
+		if err := container.Provide(newRegistry); err != nil {
+			Plugin.Panic(err)
+		}
+	}))
+}
+
+func newRegistry(t *tangle.Tangle, store kvstore.KVStore) *assetregistry.Registry {
+	return assetregistry.NewRegistry(store.WithRealm([]byte{database.PrefixAssetRegistry}), t.LedgerState.UTXODAG)
+}
+
+func configure(_ *node.Plugin) {
+	deps.Tangle.Booker.Events.MessageBooked.Attach(events.NewClosure(onMessageBooked))
+	configureWebAPI()
+}
+
+// onMessageBooked registers the asset metadata that is contained in a booked message and the metadata that waited for
+// the outputs of a booked transaction.
+func onMessageBooked(messageID tangle.MessageID) {
+	deps.Tangle.Storage.Message(messageID).Consume(func(message *tangle.Message) {
+		switch message.Payload().Type() {
+		case ledgerstate.TransactionType:
+			registerParked(message.Payload().(*ledgerstate.Transaction))
+		case assetregistry.Type:
+			register(message)
+		}
+	})
+}
+
+// register registers the asset metadata of the given message.
+func register(message *tangle.Message) {
+	metadataPayload, _, err := assetregistry.FromBytes(message.Payload().Bytes())
+	if err != nil {
+		Plugin.LogDebugf("failed to parse asset metadata of message %s: %s", message.ID().Base58(), err)
+		return
+	}
+
+	updated, err := deps.Registry.Register(metadataPayload, message.IssuingTime(), message.ID())
+	if errors.Is(err, assetregistry.ErrUnknownMintingOutput) {
+		Plugin.LogDebugf("parked asset metadata of message %s until its minting output is booked", message.ID().Base58())
+		return
+	}
+	if err != nil {
+		Plugin.LogDebugf("failed to register asset metadata of message %s: %s", message.ID().Base58(), err)
+		return
+	}
+	if updated {
+		Plugin.LogInfof("registered metadata of asset %s (%s)", metadataPayload.Color().Base58(), metadataPayload.Name())
+	}
+}
+
+// registerParked registers the asset metadata that waited for one of the outputs of the given transaction.
+func registerParked(transaction *ledgerstate.Transaction) {
+	for _, output := range transaction.Essence().Outputs() {
+		updatedAssets, err := deps.Registry.RegisterParked(output.ID())
+		if err != nil {
+			Plugin.LogDebugf("failed to register parked asset metadata of output %s: %s", output.ID().Base58(), err)
+		}
+		for _, asset := range updatedAssets {
+			Plugin.LogInfof("registered metadata of asset %s (%s)", asset.Color.Base58(), asset.Name)
+		}
+	}
+}
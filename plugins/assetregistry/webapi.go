@@ -0,0 +1,34 @@
+package assetregistry
+
+import (
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+	"github.com/labstack/echo"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+func configureWebAPI() {
+	deps.Server.GET("ledgerstate/assets/:color", GetAsset)
+}
+
+// GetAsset is the handler for the /ledgerstate/assets/:color endpoint. It returns the metadata that the minter of the
+// color issued to the Tangle.
+func GetAsset(c echo.Context) error {
+	color, err := ledgerstate.ColorFromBase58EncodedString(c.Param("color"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
+	}
+
+	asset, exists, err := deps.Registry.Asset(color)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, jsonmodels.NewErrorResponse(err))
+	}
+	if !exists {
+		return c.JSON(http.StatusNotFound, jsonmodels.NewErrorResponse(errors.Errorf("no metadata registered for asset %s", color.Base58())))
+	}
+
+	return c.JSON(http.StatusOK, jsonmodels.NewGetAssetResponse(asset))
+}
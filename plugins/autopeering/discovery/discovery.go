@@ -11,8 +11,9 @@ import (
 	"github.com/iotaledger/hive.go/autopeering/peer/service"
 	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/identity"
-	"github.com/iotaledger/hive.go/logger"
 	"github.com/mr-tron/base58"
+
+	logger_plugin "github.com/iotaledger/goshimmer/plugins/logger"
 )
 
 // autopeering constants.
@@ -29,7 +30,7 @@ var (
 
 // CreatePeerDisc creates a discover protocol instance.
 func CreatePeerDisc(localID *peer.Local) *discover.Protocol {
-	log := logger.NewLogger("Autopeering").Named("disc")
+	log := logger_plugin.NewLogger("Autopeering").Named("disc")
 
 	entryNodes, err := parseEntryNodes()
 	if err != nil {
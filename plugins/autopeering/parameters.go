@@ -1,6 +1,10 @@
 package autopeering
 
-import "github.com/iotaledger/hive.go/configuration"
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/configuration"
+)
 
 // ParametersDefinition contains the definition of configuration parameters used by the autopeering plugin.
 type ParametersDefinition struct {
@@ -15,6 +19,18 @@ type ParametersDefinition struct {
 
 	// Ro defines the config flag of Ro.
 	Ro float64 `default:"2.0" usage:"Ro parameter"`
+
+	// NeighborQualityThreshold defines the quality score below which a neighbor is considered poor.
+	NeighborQualityThreshold float64 `default:"0.2" usage:"the quality score below which a neighbor is considered poor (0 = never drop neighbors because of their quality)"`
+
+	// NeighborQualityCheckInterval defines the interval at which the quality of the neighbors is checked.
+	NeighborQualityCheckInterval time.Duration `default:"1m" usage:"the interval at which the quality of the neighbors is checked"`
+
+	// MaxPoorNeighborChecks defines the number of consecutive checks a neighbor can be poor before it is dropped.
+	MaxPoorNeighborChecks int `default:"5" usage:"the number of consecutive quality checks a neighbor can be poor before it is dropped"`
+
+	// PoorNeighborBlockTime defines how long a dropped poor neighbor is not selected again.
+	PoorNeighborBlockTime time.Duration `default:"30m" usage:"how long a neighbor that was dropped because of its quality is not selected again"`
 }
 
 // Parameters contains the configuration parameters of the autopeering plugin.
@@ -17,6 +17,7 @@ import (
 
 	"github.com/iotaledger/goshimmer/packages/gossip"
 	"github.com/iotaledger/goshimmer/packages/mana"
+	"github.com/iotaledger/goshimmer/packages/manualpeering"
 	net2 "github.com/iotaledger/goshimmer/packages/net"
 	"github.com/iotaledger/goshimmer/packages/shutdown"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/discovery"
@@ -40,6 +41,7 @@ type dependencies struct {
 	Selection             *selection.Protocol
 	Local                 *peer.Local
 	GossipMgr             *gossip.Manager        `optional:"true"`
+	ManualPeeringMgr      *manualpeering.Manager `optional:"true"`
 	ManaFunc              mana.ManaRetrievalFunc `optional:"true" name:"manaFunc"`
 	AutoPeeringConnMetric *net2.ConnMetric
 }
@@ -94,6 +96,11 @@ func run(*node.Plugin) {
 	if err := daemon.BackgroundWorker(PluginName, start, shutdown.PriorityAutopeering); err != nil {
 		Plugin.Panicf("Failed to start as daemon: %s", err)
 	}
+	if deps.GossipMgr != nil && Parameters.NeighborQualityThreshold > 0 {
+		if err := daemon.BackgroundWorker(PluginName+" Neighbor Quality", runNeighborQualityCheck, shutdown.PriorityAutopeering); err != nil {
+			Plugin.Panicf("Failed to start as daemon: %s", err)
+		}
+	}
 }
 
 func configureGossipIntegration() {
@@ -0,0 +1,107 @@
+package autopeering
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+)
+
+// poorNeighbors keeps track of the neighbors whose quality is below the threshold and of the dropped ones, which are
+// not selected again for a while.
+type poorNeighbors struct {
+	mutex      sync.Mutex
+	poorChecks map[identity.ID]int
+	blocked    map[identity.ID]time.Time
+}
+
+var poor = &poorNeighbors{
+	poorChecks: make(map[identity.ID]int),
+	blocked:    make(map[identity.ID]time.Time),
+}
+
+// isBlocked returns whether the peer was dropped because of its quality within the PoorNeighborBlockTime.
+func (p *poorNeighbors) isBlocked(id identity.ID) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	blockedUntil, blocked := p.blocked[id]
+	if blocked && time.Now().After(blockedUntil) {
+		delete(p.blocked, id)
+		return false
+	}
+	return blocked
+}
+
+// check records the result of a quality check of the neighbor and returns true if it has been poor for too long.
+func (p *poorNeighbors) check(id identity.ID, isPoor bool) (drop bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if !isPoor {
+		delete(p.poorChecks, id)
+		return false
+	}
+	p.poorChecks[id]++
+	if p.poorChecks[id] < Parameters.MaxPoorNeighborChecks {
+		return false
+	}
+	delete(p.poorChecks, id)
+	p.blocked[id] = time.Now().Add(Parameters.PoorNeighborBlockTime)
+	return true
+}
+
+// retain forgets the poor checks of peers that are no longer neighbors.
+func (p *poorNeighbors) retain(neighbors map[identity.ID]bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for id := range p.poorChecks {
+		if !neighbors[id] {
+			delete(p.poorChecks, id)
+		}
+	}
+}
+
+func runNeighborQualityCheck(ctx context.Context) {
+	ticker := time.NewTicker(Parameters.NeighborQualityCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			checkNeighborQuality()
+		}
+	}
+}
+
+// checkNeighborQuality drops the neighbors that have been poor for too long, so that the selection looks for
+// replacements.
+func checkNeighborQuality() {
+	qualities := deps.GossipMgr.NeighborQualities()
+
+	neighbors := make(map[identity.ID]bool)
+	for _, p := range deps.Selection.GetNeighbors() {
+		neighbors[p.ID()] = true
+
+		quality, connected := qualities[p.ID()]
+		if !connected || !quality.Scored {
+			continue
+		}
+		if deps.ManualPeeringMgr != nil && deps.ManualPeeringMgr.IsTrusted(p.ID()) {
+			continue
+		}
+		if !poor.check(p.ID(), quality.Score < Parameters.NeighborQualityThreshold) {
+			continue
+		}
+
+		Plugin.Logger().Infow("Dropping poor neighbor", "id", p.ID(), "score", quality.Score,
+			"latency", quality.AverageLatency, "firstSeenShare", quality.FirstSeenShare,
+			"requests", quality.Requests, "answeredRequests", quality.AnsweredRequests)
+		deps.Selection.RemoveNeighbor(p.ID())
+	}
+	poor.retain(neighbors)
+}
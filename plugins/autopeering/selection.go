@@ -6,12 +6,13 @@ import (
 	"github.com/iotaledger/hive.go/autopeering/peer/service"
 	"github.com/iotaledger/hive.go/autopeering/selection"
 	"github.com/iotaledger/hive.go/identity"
-	"github.com/iotaledger/hive.go/logger"
+
+	logger_plugin "github.com/iotaledger/goshimmer/plugins/logger"
 )
 
 func createPeerSel(localID *peer.Local, nbrDiscover *discover.Protocol) *selection.Protocol {
 	// assure that the logger is available
-	log := logger.NewLogger(PluginName).Named("sel")
+	log := logger_plugin.NewLogger(PluginName).Named("sel")
 
 	return selection.New(localID, nbrDiscover,
 		selection.Logger(log),
@@ -34,7 +35,12 @@ func isValidNeighbor(p *peer.Peer) bool {
 	if gossipService.Network() != "tcp" || gossipService.Port() < 0 || gossipService.Port() > 65535 {
 		return false
 	}
-	return true
+	// trusted peers are connected by the manual peering layer only, so that autopeering never drops them
+	if deps.ManualPeeringMgr != nil && deps.ManualPeeringMgr.IsTrusted(p.ID()) {
+		return false
+	}
+	// peers that were dropped because of their poor quality are not selected again for a while
+	return !poor.isBlocked(p.ID())
 }
 
 func evalMana(nodeIdentity *identity.Identity) uint64 {
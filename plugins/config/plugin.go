@@ -1,14 +1,20 @@
 package config
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/iotaledger/hive.go/configuration"
+	"github.com/iotaledger/hive.go/daemon"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/node"
 	flag "github.com/spf13/pflag"
 	"go.uber.org/dig"
+
+	"github.com/iotaledger/goshimmer/packages/shutdown"
 )
 
 // PluginName is the name of the config plugin.
@@ -16,7 +22,7 @@ const PluginName = "Config"
 
 var (
 	// Plugin is the plugin instance of the config plugin.
-	Plugin = node.NewPlugin(PluginName, nil, node.Enabled)
+	Plugin *node.Plugin
 
 	// flags
 	defaultConfigName   = "config.json"
@@ -33,6 +39,8 @@ func Init(container *dig.Container) {
 }
 
 func init() {
+	Plugin = node.NewPlugin(PluginName, nil, node.Enabled, run)
+
 	Plugin.Events.Init.Attach(events.NewClosure(func(_ *node.Plugin, container *dig.Container) {
 		if err := fetch(false); err != nil {
 			if !*skipConfigAvailable {
@@ -54,6 +62,31 @@ func init() {
 	}))
 }
 
+func run(plugin *node.Plugin) {
+	if err := daemon.BackgroundWorker("Config Reload", reloadOnSignal, shutdown.PriorityConfig); err != nil {
+		plugin.Panicf("Failed to start as daemon: %s", err)
+	}
+}
+
+// reloadOnSignal reloads the configuration whenever the node receives a SIGHUP.
+func reloadOnSignal(ctx context.Context) {
+	reloadSignal := make(chan os.Signal, 1)
+	signal.Notify(reloadSignal, syscall.SIGHUP)
+	defer signal.Stop(reloadSignal)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-reloadSignal:
+			Plugin.LogInfo("Received SIGHUP - reloading the configuration ...")
+			if _, err := Reload(); err != nil {
+				Plugin.LogErrorf("Failed to reload the configuration: %s", err)
+			}
+		}
+	}
+}
+
 // fetch fetches config values from a configFilePath (or the current working dir if not set).
 //
 // It automatically reads in a single config file starting with "config" (can be changed via the --config CLI flag)
@@ -61,27 +94,7 @@ func init() {
 func fetch(printConfig bool, ignoreSettingsAtPrint ...[]string) error {
 	flag.Parse()
 
-	if err := _node.LoadFile(*configFilePath); err != nil {
-		if hasFlag(defaultConfigName) {
-			// if a file was explicitly specified, raise the error
-			fmt.Println("config error")
-			return err
-		}
-		fmt.Printf("No config file found via '%s'. Loading default settings.", *configFilePath)
-	}
-
-	if err := _node.LoadFlagSet(flag.CommandLine); err != nil {
-		return err
-	}
-
-	// read in ENV variables
-	// load the env vars after default values from flags were set (otherwise the env vars are not added because the keys don't exist)
-	if err := _node.LoadEnvironmentVars(""); err != nil {
-		return err
-	}
-
-	// load the flags again to overwrite env vars that were also set via command line
-	if err := _node.LoadFlagSet(flag.CommandLine); err != nil {
+	if err := load(_node); err != nil {
 		return err
 	}
 
@@ -0,0 +1,180 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/configuration"
+	flag "github.com/spf13/pflag"
+)
+
+// region Reloadable ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Reloadable declares configuration parameters of a plugin that can be changed while the node is running.
+type Reloadable struct {
+	// Parameters contains the keys of the reloadable parameters (e.g. "webAPI.basicAuth.enabled"). A key also covers
+	// all the parameters below it, so "webAPI.basicAuth" covers the username and the password as well.
+	Parameters []string
+	// Validate checks the values of the given configuration before they are applied. It is optional.
+	Validate func(conf *configuration.Configuration) error
+	// Apply is called with the reloaded configuration once all changes are valid. The bound parameters are not
+	// updated, so Apply needs to read the new values from conf and swap them in under its own lock.
+	Apply func(conf *configuration.Configuration)
+}
+
+// covers returns true if the given (lower case) key belongs to the reloadable parameters.
+func (r *Reloadable) covers(key string) bool {
+	for _, parameter := range r.Parameters {
+		parameter = strings.ToLower(parameter)
+		if key == parameter || strings.HasPrefix(key, parameter+".") {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	reloadables      []*Reloadable
+	reloadablesMutex sync.Mutex
+
+	// reloadedValues contains the values of the reloaded parameters that differ from the startup configuration
+	reloadedValues = make(map[string]interface{})
+)
+
+// RegisterReloadable registers parameters that are updated when the configuration is reloaded.
+func RegisterReloadable(reloadable *Reloadable) {
+	reloadablesMutex.Lock()
+	defer reloadablesMutex.Unlock()
+
+	reloadables = append(reloadables, reloadable)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Reload ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ReloadResult contains the changed parameters of a reload.
+type ReloadResult struct {
+	// Reloaded contains the keys of the changed parameters that were applied.
+	Reloaded []string
+	// Ignored contains the keys of the changed parameters that can't be reloaded and keep their previous value.
+	Ignored []string
+}
+
+// Reload reads the configuration again from the config file, the flags and the environment variables. Changed
+// parameters that were registered as reloadable are validated and applied, all other changes are ignored and only
+// take effect after a restart and are logged as warnings. If a validation fails, none of the changes is applied. The
+// startup configuration and the bound parameters of the plugins are never modified.
+func Reload() (*ReloadResult, error) {
+	reloadablesMutex.Lock()
+	defer reloadablesMutex.Unlock()
+
+	conf := configuration.New()
+	if err := load(conf); err != nil {
+		return nil, errors.Wrap(err, "failed to read the configuration")
+	}
+
+	result := &ReloadResult{}
+	affected := make(map[*Reloadable]struct{})
+	for _, key := range changedKeys(currentValues(), conf.All()) {
+		reloadable := findReloadable(key)
+		if reloadable == nil {
+			result.Ignored = append(result.Ignored, key)
+			continue
+		}
+		result.Reloaded = append(result.Reloaded, key)
+		affected[reloadable] = struct{}{}
+	}
+
+	for _, reloadable := range reloadables {
+		if _, ok := affected[reloadable]; !ok || reloadable.Validate == nil {
+			continue
+		}
+		if err := reloadable.Validate(conf); err != nil {
+			return nil, errors.Wrap(err, "invalid configuration")
+		}
+	}
+
+	for _, key := range result.Reloaded {
+		reloadedValues[key] = conf.Get(key)
+	}
+
+	for _, reloadable := range reloadables {
+		if _, ok := affected[reloadable]; ok && reloadable.Apply != nil {
+			reloadable.Apply(conf)
+		}
+	}
+
+	for _, key := range result.Ignored {
+		Plugin.LogWarnf("Parameter %s changed but can't be reloaded, restart the node to apply it", key)
+	}
+	Plugin.LogInfof("Configuration reloaded, changed parameters: %v", result.Reloaded)
+
+	return result, nil
+}
+
+// findReloadable returns the Reloadable that covers the given key or nil if the key can't be reloaded.
+func findReloadable(key string) *Reloadable {
+	for _, reloadable := range reloadables {
+		if reloadable.covers(key) {
+			return reloadable
+		}
+	}
+	return nil
+}
+
+// currentValues returns the values of the running node, i.e. the startup configuration with the reloaded values.
+func currentValues() map[string]interface{} {
+	values := make(map[string]interface{})
+	for key, value := range _node.All() {
+		values[key] = value
+	}
+	for key, value := range reloadedValues {
+		values[key] = value
+	}
+
+	return values
+}
+
+// changedKeys returns the sorted keys whose values differ between the two sets of values. Keys can't disappear, as
+// the flags provide a value for all the bound parameters.
+func changedKeys(currentValues, updatedValues map[string]interface{}) (keys []string) {
+	for key, value := range updatedValues {
+		if currentValue, exists := currentValues[key]; !exists || fmt.Sprint(currentValue) != fmt.Sprint(value) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// load reads the config file, the flags and the environment variables into the given configuration.
+func load(conf *configuration.Configuration) error {
+	if err := conf.LoadFile(*configFilePath); err != nil {
+		if hasFlag(defaultConfigName) {
+			// if a file was explicitly specified, raise the error
+			fmt.Println("config error")
+			return err
+		}
+		fmt.Printf("No config file found via '%s'. Loading default settings.", *configFilePath)
+	}
+
+	if err := conf.LoadFlagSet(flag.CommandLine); err != nil {
+		return err
+	}
+
+	// read in ENV variables
+	// load the env vars after default values from flags were set (otherwise the env vars are not added because the keys don't exist)
+	if err := conf.LoadEnvironmentVars(""); err != nil {
+		return err
+	}
+
+	// load the flags again to overwrite env vars that were also set via command line
+	return conf.LoadFlagSet(flag.CommandLine)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/configuration"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testParameters = &struct {
+	Reloadable struct {
+		Name  string `default:"a" usage:"reloadable name"`
+		Count int    `default:"1" usage:"reloadable count"`
+	}
+	Static string `default:"b" usage:"static parameter"`
+}{}
+
+func init() {
+	configuration.BindParameters(testParameters, "test")
+
+	if err := logger.InitGlobalLogger(configuration.New()); err != nil {
+		panic(err)
+	}
+}
+
+func TestReload(t *testing.T) {
+	_node = configuration.New()
+	reloadables = nil
+	reloadedValues = make(map[string]interface{})
+
+	*configFilePath = filepath.Join(t.TempDir(), "config.json")
+	writeConfig := func(content string) {
+		require.NoError(t, os.WriteFile(*configFilePath, []byte(content), 0o600))
+	}
+	writeConfig(`{"test": {"reloadable": {"name": "a", "count": 1}, "static": "b"}}`)
+	require.NoError(t, fetch(false))
+
+	applied := 0
+	var appliedName string
+	var appliedCount int
+	RegisterReloadable(&Reloadable{
+		Parameters: []string{"test.Reloadable"},
+		Validate: func(conf *configuration.Configuration) error {
+			if conf.Int("test.reloadable.count") < 0 {
+				return errors.New("negative count")
+			}
+			return nil
+		},
+		Apply: func(conf *configuration.Configuration) {
+			applied++
+			appliedName = conf.String("test.reloadable.name")
+			appliedCount = conf.Int("test.reloadable.count")
+		},
+	})
+
+	// nothing changed
+	result, err := Reload()
+	require.NoError(t, err)
+	assert.Empty(t, result.Reloaded)
+	assert.Empty(t, result.Ignored)
+	assert.Equal(t, 0, applied)
+
+	writeConfig(`{"test": {"reloadable": {"name": "c", "count": 2}, "static": "d"}}`)
+	result, err = Reload()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test.reloadable.count", "test.reloadable.name"}, result.Reloaded)
+	assert.Equal(t, []string{"test.static"}, result.Ignored)
+	assert.Equal(t, 1, applied)
+	assert.Equal(t, "c", appliedName)
+	assert.Equal(t, 2, appliedCount)
+
+	// the bound parameters keep their startup values
+	assert.Equal(t, "a", testParameters.Reloadable.Name)
+	assert.Equal(t, 1, testParameters.Reloadable.Count)
+	assert.Equal(t, "b", testParameters.Static)
+
+	// the reloaded values are not applied again, but the ignored changes are still reported
+	result, err = Reload()
+	require.NoError(t, err)
+	assert.Empty(t, result.Reloaded)
+	assert.Equal(t, []string{"test.static"}, result.Ignored)
+	assert.Equal(t, 1, applied)
+
+	// invalid values are not applied at all
+	writeConfig(`{"test": {"reloadable": {"name": "e", "count": -1}, "static": "d"}}`)
+	_, err = Reload()
+	assert.Error(t, err)
+	assert.Equal(t, 1, applied)
+	assert.Equal(t, "c", appliedName)
+	assert.Equal(t, 2, appliedCount)
+
+	// changing a value back to the startup value is a change as well
+	writeConfig(`{"test": {"reloadable": {"name": "a", "count": 2}, "static": "b"}}`)
+	result, err = Reload()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test.reloadable.name"}, result.Reloaded)
+	assert.Empty(t, result.Ignored)
+	assert.Equal(t, "a", appliedName)
+}
@@ -3,6 +3,7 @@ package plugins
 import (
 	"github.com/iotaledger/hive.go/node"
 
+	"github.com/iotaledger/goshimmer/plugins/assetregistry"
 	"github.com/iotaledger/goshimmer/plugins/autopeering"
 	"github.com/iotaledger/goshimmer/plugins/banner"
 	"github.com/iotaledger/goshimmer/plugins/cli"
@@ -47,6 +48,7 @@ var Core = node.Plugins(
 	manarefresher.Plugin,
 	drng.Plugin,
 	faucet.Plugin,
+	assetregistry.Plugin,
 	metrics.Plugin,
 	spammer.Plugin,
 	manaeventlogger.Plugin,
@@ -115,6 +115,10 @@ class NeighborMetric {
     connection_origin: number;
     packets_read: number;
     packets_written: number;
+    bytes_read: number;
+    bytes_written: number;
+    read_throttling: number;
+    write_throttling: number;
     ts: number;
 }
 
@@ -27,6 +27,7 @@ import (
 	"github.com/iotaledger/goshimmer/packages/shutdown"
 	"github.com/iotaledger/goshimmer/packages/tangle"
 	"github.com/iotaledger/goshimmer/plugins/banner"
+	logger_plugin "github.com/iotaledger/goshimmer/plugins/logger"
 	"github.com/iotaledger/goshimmer/plugins/metrics"
 )
 
@@ -63,7 +64,7 @@ func init() {
 }
 
 func configure(plugin *node.Plugin) {
-	log = logger.NewLogger(plugin.Name)
+	log = logger_plugin.NewLogger(plugin.Name)
 	configureWebSocketWorkerPool()
 	configureLiveFeed()
 	configureDrngLiveFeed()
@@ -246,6 +247,10 @@ type neighbormetric struct {
 	ConnectionOrigin string `json:"connection_origin"`
 	PacketsRead      uint64 `json:"packets_read"`
 	PacketsWritten   uint64 `json:"packets_written"`
+	BytesRead        uint64 `json:"bytes_read"`
+	BytesWritten     uint64 `json:"bytes_written"`
+	ReadThrottling   int64  `json:"read_throttling"`
+	WriteThrottling  int64  `json:"write_throttling"`
 }
 
 type tipsInfo struct {
@@ -291,6 +296,10 @@ func neighborMetrics() []neighbormetric {
 			Address:          net.JoinHostPort(host, strconv.Itoa(port)),
 			PacketsRead:      neighbor.PacketsRead(),
 			PacketsWritten:   neighbor.PacketsWritten(),
+			BytesRead:        neighbor.BytesRead(),
+			BytesWritten:     neighbor.BytesWritten(),
+			ReadThrottling:   neighbor.ReadThrottlingTime().Milliseconds(),
+			WriteThrottling:  neighbor.WriteThrottlingTime().Milliseconds(),
 			ConnectionOrigin: origin,
 		})
 	}
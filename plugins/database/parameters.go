@@ -17,6 +17,9 @@ type ParametersDefinition struct {
 	// Dirty defines whether to override the database dirty flag.
 	Dirty string `default:"false" usage:"set the dirty flag of the database"`
 
+	// ConsistencyCheckOnDirty defines whether to check and repair the database on startup if it was not shutdown properly.
+	ConsistencyCheckOnDirty bool `default:"true" usage:"run a fast consistency check and repair of the database on startup if it was not shutdown properly"`
+
 	// ForceCacheTime is a new global cache time in seconds for object storage.
 	ForceCacheTime time.Duration `default:"-1s" usage:"interval of time for which objects should remain in memory. Zero time means no caching, negative value means use defaults"`
 }
@@ -17,6 +17,7 @@ import (
 
 	"github.com/iotaledger/goshimmer/packages/database"
 	"github.com/iotaledger/goshimmer/packages/shutdown"
+	logger_plugin "github.com/iotaledger/goshimmer/plugins/logger"
 )
 
 // PluginName is the name of the database plugin.
@@ -29,6 +30,7 @@ var (
 	log    *logger.Logger
 
 	db                database.DB
+	consistencyCheck  bool
 	cacheTimeProvider *database.CacheTimeProvider
 	cacheProviderOnce sync.Once
 )
@@ -54,12 +56,18 @@ func CacheTimeProvider() *database.CacheTimeProvider {
 	return cacheTimeProvider
 }
 
+// ConsistencyCheckRequired returns true if the database was not shutdown properly and needs to be checked by the
+// plugins that own the stored data before they use it.
+func ConsistencyCheckRequired() bool {
+	return consistencyCheck
+}
+
 func createCacheTimeProvider() {
 	cacheTimeProvider = database.NewCacheTimeProvider(Parameters.ForceCacheTime)
 }
 
 func createStore() kvstore.KVStore {
-	log = logger.NewLogger(PluginName)
+	log = logger_plugin.NewLogger(PluginName)
 
 	var err error
 	if Parameters.InMemory {
@@ -84,6 +92,9 @@ func configure(_ *node.Plugin) {
 		log.Fatalf("Failed to check database version: %s", err)
 	}
 
+	// the dirty flag is overridden below, so we need to remember whether the last shutdown was unclean
+	consistencyCheck = Parameters.ConsistencyCheckOnDirty && !Parameters.InMemory && IsDatabaseUnhealthy()
+
 	if Parameters.Directory != "" {
 		val, err := strconv.ParseBool(Parameters.Dirty)
 		if err != nil {
@@ -95,7 +106,7 @@ func configure(_ *node.Plugin) {
 		}
 	}
 
-	if IsDatabaseUnhealthy() {
+	if IsDatabaseUnhealthy() && !consistencyCheck {
 		log.Fatal("The database is marked as not properly shutdown/corrupted, please delete the database folder and restart.")
 	}
 
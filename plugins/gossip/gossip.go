@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/autopeering/peer"
@@ -14,11 +15,15 @@ import (
 	"github.com/iotaledger/goshimmer/packages/gossip"
 	"github.com/iotaledger/goshimmer/packages/libp2putil"
 	"github.com/iotaledger/goshimmer/packages/tangle"
+	logger_plugin "github.com/iotaledger/goshimmer/plugins/logger"
 )
 
 // ErrMessageNotFound is returned when a message could not be found in the Tangle.
 var ErrMessageNotFound = errors.New("message not found")
 
+// bulkSyncCheckInterval defines the interval in which the node checks whether it needs to start a bulk sync.
+const bulkSyncCheckInterval = 30 * time.Second
+
 var localAddr *net.TCPAddr
 
 func createManager(lPeer *peer.Local, t *tangle.Tangle) *gossip.Manager {
@@ -63,21 +68,71 @@ func createManager(lPeer *peer.Local, t *tangle.Tangle) *gossip.Manager {
 		Plugin.LogFatalf("Could create libp2p host: %s", err)
 	}
 
-	return gossip.NewManager(libp2pHost, lPeer, loadMessage, Plugin.Logger())
+	// checks whether the given message is already stored in the message layer.
+	hasMessage := func(msgID tangle.MessageID) bool {
+		cachedMessage := t.Storage.Message(msgID)
+		defer cachedMessage.Release()
+		return cachedMessage.Exists()
+	}
+
+	// the bulk sync is always served, even if the node does not use it itself
+	opts := []gossip.ManagerOption{gossip.WithBulkSync(hasMessage, t.TipManager.AllTips, t.TimeManager.Time)}
+	if Parameters.MessageAnnouncements {
+		opts = append(opts, gossip.WithMessageAnnouncements(hasMessage, Parameters.AnnouncementMinMessageSize))
+	}
+	if Parameters.Compression {
+		opts = append(opts, gossip.WithCompression())
+	}
+	opts = append(opts, gossip.WithBandwidthLimits(gossip.BandwidthLimits{
+		NeighborInbound:  Parameters.NeighborInboundLimit,
+		NeighborOutbound: Parameters.NeighborOutboundLimit,
+		GlobalInbound:    Parameters.GlobalInboundLimit,
+		GlobalOutbound:   Parameters.GlobalOutboundLimit,
+	}))
+
+	return gossip.NewManager(libp2pHost, lPeer, loadMessage, logger_plugin.NewLogger(PluginName), opts...)
 }
 
 func start(ctx context.Context) {
-	defer Plugin.LogInfo("Stopping " + PluginName + " ... done")
+	defer log.Info("Stopping " + PluginName + " ... done")
 
 	defer deps.GossipMgr.Stop()
 	defer func() {
 		if err := deps.GossipMgr.Libp2pHost.Close(); err != nil {
-			Plugin.LogWarn("Failed to close libp2p host: %+v", err)
+			log.Warnf("Failed to close libp2p host: %+v", err)
 		}
 	}()
 
-	Plugin.LogInfof("%s started: bind-address=%s", PluginName, localAddr.String())
+	log.Infof("%s started: bind-address=%s", PluginName, localAddr.String())
 
 	<-ctx.Done()
-	Plugin.LogInfo("Stopping " + PluginName + " ...")
+	log.Info("Stopping " + PluginName + " ...")
+}
+
+// runBulkSync regularly starts a bulk sync while the node is not in sync and has neighbors to sync from.
+func runBulkSync(ctx context.Context) {
+	ticker := time.NewTicker(bulkSyncCheckInterval)
+	defer ticker.Stop()
+
+	var lastReportedSync time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if status := deps.GossipMgr.BulkSyncStatus(); !status.Running && status.EndTime.After(lastReportedSync) {
+			lastReportedSync = status.EndTime
+			log.Infof("Bulk sync finished: peers=%d, received=%d, delivered=%d, failed=%d, duration=%s",
+				status.Peers, status.ReceivedMessages, status.DeliveredMessages, status.FailedMessages, status.EndTime.Sub(status.StartTime))
+		}
+
+		if deps.Tangle.TimeManager.Synced() || len(deps.GossipMgr.AllNeighbors()) == 0 {
+			continue
+		}
+		if err := deps.GossipMgr.StartBulkSync(ctx); err == nil {
+			log.Info("Bulk sync started")
+		}
+	}
 }
@@ -11,6 +11,30 @@ type ParametersDefinition struct {
 
 	// MissingMessageRequestRelayProbability defines the probability of missing message requests being relayed to other neighbors.
 	MissingMessageRequestRelayProbability float64 `default:"0.01" usage:"the probability of missing message requests being relayed to other neighbors"`
+
+	// MessageAnnouncements defines whether new messages are announced to the neighbors that support it instead of being sent in full.
+	MessageAnnouncements bool `default:"false" usage:"whether to announce new messages to neighbors that support it, which then request the missing ones"`
+
+	// AnnouncementMinMessageSize defines the minimum size of a message in bytes to be announced instead of being sent in full.
+	AnnouncementMinMessageSize int `default:"256" usage:"the minimum size of a message in bytes to be announced instead of being sent in full"`
+
+	// BulkSync defines whether the node fetches the missing history in batches from its neighbors while it is not in sync.
+	BulkSync bool `default:"true" usage:"whether to fetch the missing history in batches from the neighbors while the node is not in sync"`
+
+	// Compression defines whether the gossip streams to the neighbors that support it are compressed.
+	Compression bool `default:"false" usage:"whether to compress the gossip streams to neighbors that support it"`
+
+	// NeighborInboundLimit defines the maximum inbound bandwidth of a single neighbor in bytes per second.
+	NeighborInboundLimit int `default:"0" usage:"the maximum inbound bandwidth of a single neighbor in bytes per second (0 = unlimited)"`
+
+	// NeighborOutboundLimit defines the maximum outbound bandwidth of a single neighbor in bytes per second.
+	NeighborOutboundLimit int `default:"0" usage:"the maximum outbound bandwidth of a single neighbor in bytes per second (0 = unlimited)"`
+
+	// GlobalInboundLimit defines the maximum inbound bandwidth of all neighbors together in bytes per second.
+	GlobalInboundLimit int `default:"0" usage:"the maximum inbound bandwidth of all neighbors together in bytes per second (0 = unlimited)"`
+
+	// GlobalOutboundLimit defines the maximum outbound bandwidth of all neighbors together in bytes per second.
+	GlobalOutboundLimit int `default:"0" usage:"the maximum outbound bandwidth of all neighbors together in bytes per second (0 = unlimited)"`
 }
 
 // Parameters contains the configuration parameters of the gossip plugin.
@@ -5,12 +5,14 @@ import (
 	"github.com/iotaledger/hive.go/configuration"
 	"github.com/iotaledger/hive.go/daemon"
 	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
 	"go.uber.org/dig"
 
 	"github.com/iotaledger/goshimmer/packages/gossip"
 	"github.com/iotaledger/goshimmer/packages/shutdown"
 	"github.com/iotaledger/goshimmer/packages/tangle"
+	logger_plugin "github.com/iotaledger/goshimmer/plugins/logger"
 )
 
 // PluginName is the name of the gossip plugin.
@@ -21,6 +23,7 @@ var (
 	Plugin *node.Plugin
 
 	deps = new(dependencies)
+	log  *logger.Logger
 )
 
 type dependencies struct {
@@ -43,6 +46,7 @@ func init() {
 }
 
 func configure(_ *node.Plugin) {
+	log = logger_plugin.NewLogger(PluginName)
 	configureLogging()
 	configureMessageLayer()
 }
@@ -51,24 +55,29 @@ func run(plugin *node.Plugin) {
 	if err := daemon.BackgroundWorker(PluginName, start, shutdown.PriorityGossip); err != nil {
 		plugin.Logger().Panicf("Failed to start as daemon: %s", err)
 	}
+	if Parameters.BulkSync {
+		if err := daemon.BackgroundWorker(PluginName+" Bulk Sync", runBulkSync, shutdown.PriorityGossip); err != nil {
+			plugin.Logger().Panicf("Failed to start as daemon: %s", err)
+		}
+	}
 }
 
 func configureLogging() {
 	// log the gossip events
 	deps.GossipMgr.NeighborsEvents(gossip.NeighborsGroupAuto).NeighborAdded.Attach(events.NewClosure(func(n *gossip.Neighbor) {
-		Plugin.LogInfof("Neighbor added: %s / %s", gossip.GetAddress(n.Peer), n.ID())
+		log.Infow("Neighbor added", logger_plugin.KeyPeerID, n.ID().String(), "address", gossip.GetAddress(n.Peer))
 	}))
 	deps.GossipMgr.NeighborsEvents(gossip.NeighborsGroupAuto).NeighborRemoved.Attach(events.NewClosure(func(n *gossip.Neighbor) {
-		Plugin.LogInfof("Neighbor removed: %s / %s", gossip.GetAddress(n.Peer), n.ID())
+		log.Infow("Neighbor removed", logger_plugin.KeyPeerID, n.ID().String(), "address", gossip.GetAddress(n.Peer))
 	}))
 	deps.Tangle.Requester.Events.RequestStarted.Attach(events.NewClosure(func(messageID tangle.MessageID) {
-		Plugin.LogDebugf("started to request missing Message with %s", messageID)
+		log.Debugw("Started to request missing message", logger_plugin.KeyMessageID, messageID.Base58())
 	}))
 	deps.Tangle.Requester.Events.RequestStopped.Attach(events.NewClosure(func(messageID tangle.MessageID) {
-		Plugin.LogDebugf("stopped to request missing Message with %s", messageID)
+		log.Debugw("Stopped to request missing message", logger_plugin.KeyMessageID, messageID.Base58())
 	}))
 	deps.Tangle.Requester.Events.RequestFailed.Attach(events.NewClosure(func(messageID tangle.MessageID) {
-		Plugin.LogDebugf("failed to request missing Message with %s", messageID)
+		log.Debugw("Failed to request missing message", logger_plugin.KeyMessageID, messageID.Base58())
 	}))
 }
 
@@ -87,7 +96,7 @@ func configureMessageLayer() {
 
 	// request missing messages
 	deps.Tangle.Requester.Events.RequestIssued.Attach(events.NewClosure(func(sendRequest *tangle.SendRequestEvent) {
-		Plugin.LogDebugf("requesting missing Message with %s", sendRequest.ID)
+		log.Debugw("Requesting missing message", logger_plugin.KeyMessageID, sendRequest.ID.Base58())
 
 		deps.GossipMgr.RequestMessage(sendRequest.ID[:])
 	}))
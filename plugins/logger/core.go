@@ -0,0 +1,150 @@
+package logger
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/node"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Keys of the structured log fields, so that the same information is logged with the same key by all plugins.
+const (
+	// KeyPlugin is the key of the name of the plugin that logged the message.
+	KeyPlugin = "plugin"
+	// KeyMessageID is the key of a message ID.
+	KeyMessageID = "messageID"
+	// KeyBranchID is the key of a branch ID.
+	KeyBranchID = "branchID"
+	// KeyPeerID is the key of a peer ID.
+	KeyPeerID = "peerID"
+)
+
+// NewLogger returns a logger for the plugin with the given name. Its level can be changed independently of the other
+// plugins with SetPluginLevel and its messages are sampled if the plugin is configured in logger.sampling.plugins.
+// The levels of plugins that log through the logger of the node.Plugin can't be changed.
+func NewLogger(pluginName string) *logger.Logger {
+	level := registerPluginLevel(pluginName)
+
+	var pluginSampler *sampler
+	if Parameters.Sampling.Enabled && isSampled(pluginName) {
+		pluginSampler = newSampler(Parameters.Sampling.Tick, Parameters.Sampling.Initial, Parameters.Sampling.Thereafter)
+	}
+
+	options := []zap.Option{
+		zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return &pluginCore{Core: core, level: level, sampler: pluginSampler}
+		}),
+	}
+	// the console encoding contains the name of the logger already
+	if Parameters.Encoding == "json" {
+		options = append(options, zap.Fields(zap.String(KeyPlugin, pluginName)))
+	}
+
+	return logger.NewLogger(pluginName).Desugar().WithOptions(options...).Sugar()
+}
+
+func isSampled(pluginName string) bool {
+	for _, sampledPlugin := range Parameters.Sampling.Plugins {
+		if node.GetPluginIdentifier(sampledPlugin) == node.GetPluginIdentifier(pluginName) {
+			return true
+		}
+	}
+	return false
+}
+
+// region pluginCore ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// pluginCore wraps the core of the global logger. It replaces the global log level with the level of the plugin and
+// samples the log messages.
+type pluginCore struct {
+	zapcore.Core
+
+	level   *pluginLevel
+	sampler *sampler
+}
+
+// Enabled returns true if the plugin logs messages with the given level.
+func (c *pluginCore) Enabled(level zapcore.Level) bool {
+	return c.level.Enabled(level)
+}
+
+// With adds structured context to the core.
+func (c *pluginCore) With(fields []zapcore.Field) zapcore.Core {
+	return &pluginCore{
+		Core:    c.Core.With(fields),
+		level:   c.level,
+		sampler: c.sampler,
+	}
+}
+
+// Check adds the core to the checked entry if the entry is enabled and not dropped by the sampler. The level of the
+// wrapped core is skipped, as Write writes the entry regardless of it.
+func (c *pluginCore) Check(entry zapcore.Entry, checkedEntry *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(entry.Level) || (c.sampler != nil && !c.sampler.sample(entry)) {
+		return checkedEntry
+	}
+	return checkedEntry.AddCore(entry, c)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region sampler //////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// sampler limits the number of log messages per level. Within every tick, the first messages of a level are logged,
+// after that only every n-th message. Errors are never dropped.
+type sampler struct {
+	tick       time.Duration
+	initial    uint64
+	thereafter uint64
+
+	// counters contains a counter for the debug, info and warn level.
+	counters [3]samplerCounter
+}
+
+func newSampler(tick time.Duration, initial, thereafter int) *sampler {
+	if thereafter < 1 {
+		thereafter = 1
+	}
+	return &sampler{
+		tick:       tick,
+		initial:    uint64(initial),
+		thereafter: uint64(thereafter),
+	}
+}
+
+// sample returns true if the entry should be logged.
+func (s *sampler) sample(entry zapcore.Entry) bool {
+	if entry.Level < zapcore.DebugLevel || entry.Level > zapcore.WarnLevel {
+		return true
+	}
+
+	n := s.counters[entry.Level-zapcore.DebugLevel].inc(entry.Time, s.tick)
+	return n <= s.initial || (n-s.initial)%s.thereafter == 0
+}
+
+// samplerCounter counts the log messages of a single level within the current tick.
+type samplerCounter struct {
+	resetAt int64
+	count   uint64
+}
+
+// inc counts the message logged at the given time and returns the number of messages within the tick.
+func (c *samplerCounter) inc(t time.Time, tick time.Duration) uint64 {
+	now := t.UnixNano()
+	resetAt := atomic.LoadInt64(&c.resetAt)
+	if resetAt > now {
+		return atomic.AddUint64(&c.count, 1)
+	}
+
+	// start a new tick, if another goroutine started it already the message is counted in that tick
+	if !atomic.CompareAndSwapInt64(&c.resetAt, resetAt, now+tick.Nanoseconds()) {
+		return atomic.AddUint64(&c.count, 1)
+	}
+	atomic.StoreUint64(&c.count, 1)
+	return 1
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
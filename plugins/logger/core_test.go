@@ -0,0 +1,90 @@
+package logger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestPluginCore(t *testing.T) {
+	// the level of the wrapped core is ignored
+	observedCore, logs := observer.New(zapcore.ErrorLevel)
+	log := zap.New(&pluginCore{Core: observedCore, level: registerPluginLevel("TestPlugin")}).Sugar()
+
+	SetLevel(zapcore.InfoLevel)
+	log.Debug("debug")
+	log.Info("info")
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, "info", logs.TakeAll()[0].Message)
+
+	// the plugin names are case insensitive
+	require.NoError(t, SetPluginLevel("testplugin", zapcore.DebugLevel))
+	assert.Equal(t, map[string]zapcore.Level{"TestPlugin": zapcore.DebugLevel}, PluginLevels())
+	log.With("key", "value").Debug("debug")
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, map[string]interface{}{"key": "value"}, logs.TakeAll()[0].ContextMap())
+
+	require.NoError(t, SetPluginLevel("TestPlugin", zapcore.WarnLevel))
+	log.Info("info")
+	assert.Equal(t, 0, logs.Len())
+
+	require.NoError(t, ResetPluginLevel("TestPlugin"))
+	assert.Empty(t, PluginLevels())
+	log.Info("info")
+	assert.Equal(t, 1, logs.Len())
+}
+
+func TestSetPluginLevel_UnknownPlugin(t *testing.T) {
+	assert.True(t, errors.Is(SetPluginLevel("UnknownPlugin", zapcore.DebugLevel), ErrUnknownPlugin))
+	assert.True(t, errors.Is(ResetPluginLevel("UnknownPlugin"), ErrUnknownPlugin))
+
+	// the configured levels of plugins without a logger are kept, but not listed
+	applyLevels(zapcore.InfoLevel, map[string]zapcore.Level{"ConfiguredPlugin": zapcore.DebugLevel})
+	assert.Empty(t, PluginLevels())
+	registerPluginLevel("ConfiguredPlugin")
+	assert.Equal(t, map[string]zapcore.Level{"ConfiguredPlugin": zapcore.DebugLevel}, PluginLevels())
+
+	applyLevels(zapcore.InfoLevel, nil)
+	assert.Empty(t, PluginLevels())
+}
+
+func TestSampler(t *testing.T) {
+	sampler := newSampler(time.Second, 2, 3)
+	start := time.Now()
+
+	var sampled []int
+	for i := 1; i <= 8; i++ {
+		if sampler.sample(zapcore.Entry{Level: zapcore.InfoLevel, Time: start}) {
+			sampled = append(sampled, i)
+		}
+	}
+	assert.Equal(t, []int{1, 2, 5, 8}, sampled)
+
+	// the levels are counted separately and errors are never dropped
+	assert.True(t, sampler.sample(zapcore.Entry{Level: zapcore.DebugLevel, Time: start}))
+	for i := 0; i < 10; i++ {
+		assert.True(t, sampler.sample(zapcore.Entry{Level: zapcore.ErrorLevel, Time: start}))
+	}
+
+	// the counter is reset with the next tick
+	assert.True(t, sampler.sample(zapcore.Entry{Level: zapcore.InfoLevel, Time: start.Add(time.Second)}))
+	assert.True(t, sampler.sample(zapcore.Entry{Level: zapcore.InfoLevel, Time: start.Add(time.Second)}))
+	assert.False(t, sampler.sample(zapcore.Entry{Level: zapcore.InfoLevel, Time: start.Add(time.Second)}))
+}
+
+func TestParsePluginLevels(t *testing.T) {
+	levels, err := parsePluginLevels([]string{"MessageLayer=debug", "Gossip=warn"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]zapcore.Level{"MessageLayer": zapcore.DebugLevel, "Gossip": zapcore.WarnLevel}, levels)
+
+	_, err = parsePluginLevels([]string{"MessageLayer"})
+	assert.Error(t, err)
+	_, err = parsePluginLevels([]string{"MessageLayer=verbose"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,186 @@
+package logger
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/node"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// noOverride marks a plugin without its own log level.
+const noOverride = int32(-128)
+
+// ErrUnknownPlugin is returned if the log level of a plugin without a logger created by NewLogger is changed.
+var ErrUnknownPlugin = errors.New("no plugin logger with this name")
+
+var (
+	// defaultLevel is the log level of the plugins without their own level.
+	defaultLevel = zap.NewAtomicLevel()
+
+	pluginLevels      = make(map[string]*pluginLevel)
+	pluginLevelsMutex sync.Mutex
+)
+
+// SetLevel changes the log level of all plugins that don't have their own level.
+func SetLevel(level zapcore.Level) {
+	defaultLevel.SetLevel(level)
+	logger.SetLevel(level)
+}
+
+// Level returns the log level of the plugins that don't have their own level.
+func Level() zapcore.Level {
+	return defaultLevel.Level()
+}
+
+// SetPluginLevel overrides the log level of the given plugin. It returns ErrUnknownPlugin if the plugin does not use a
+// logger created by NewLogger, as the level would have no effect.
+func SetPluginLevel(pluginName string, level zapcore.Level) error {
+	l, err := registeredLevelOfPlugin(pluginName)
+	if err != nil {
+		return err
+	}
+	l.set(level)
+	return nil
+}
+
+// ResetPluginLevel removes the log level override of the given plugin. It returns ErrUnknownPlugin if the plugin does
+// not use a logger created by NewLogger.
+func ResetPluginLevel(pluginName string) error {
+	l, err := registeredLevelOfPlugin(pluginName)
+	if err != nil {
+		return err
+	}
+	l.reset()
+	return nil
+}
+
+// PluginLevels returns the log levels of the plugins with a logger created by NewLogger that override the default
+// level.
+func PluginLevels() map[string]zapcore.Level {
+	pluginLevelsMutex.Lock()
+	defer pluginLevelsMutex.Unlock()
+
+	levels := make(map[string]zapcore.Level)
+	for _, l := range pluginLevels {
+		if !l.registered {
+			continue
+		}
+		if level, ok := l.override(); ok {
+			levels[l.name] = level
+		}
+	}
+	return levels
+}
+
+// levelOfPlugin returns the log level of the plugin with the given name, which is shared by all its loggers. The
+// levels of the configuration are set before the plugins create their loggers, so the level is created if it does not
+// exist yet.
+func levelOfPlugin(pluginName string) *pluginLevel {
+	pluginLevelsMutex.Lock()
+	defer pluginLevelsMutex.Unlock()
+
+	return levelOfPluginWithoutLocking(pluginName)
+}
+
+// registerPluginLevel returns the log level of the plugin with the given name and marks that the plugin uses it.
+func registerPluginLevel(pluginName string) *pluginLevel {
+	pluginLevelsMutex.Lock()
+	defer pluginLevelsMutex.Unlock()
+
+	l := levelOfPluginWithoutLocking(pluginName)
+	l.registered = true
+	return l
+}
+
+// registeredLevelOfPlugin returns the log level of the plugin with the given name if the plugin uses it.
+func registeredLevelOfPlugin(pluginName string) (*pluginLevel, error) {
+	pluginLevelsMutex.Lock()
+	defer pluginLevelsMutex.Unlock()
+
+	l, exists := pluginLevels[node.GetPluginIdentifier(pluginName)]
+	if !exists || !l.registered {
+		return nil, errors.Wrapf(ErrUnknownPlugin, "failed to change the log level of plugin %s", pluginName)
+	}
+	return l, nil
+}
+
+func levelOfPluginWithoutLocking(pluginName string) *pluginLevel {
+	identifier := node.GetPluginIdentifier(pluginName)
+	l, exists := pluginLevels[identifier]
+	if !exists {
+		l = &pluginLevel{name: pluginName, level: noOverride}
+		pluginLevels[identifier] = l
+	}
+	return l
+}
+
+// parsePluginLevels parses plugin levels in the format "PluginName=level".
+func parsePluginLevels(pluginLevels []string) (map[string]zapcore.Level, error) {
+	levels := make(map[string]zapcore.Level, len(pluginLevels))
+	for _, pluginLevel := range pluginLevels {
+		parts := strings.Split(pluginLevel, "=")
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, errors.Errorf("invalid plugin log level %q, expected PluginName=level", pluginLevel)
+		}
+		level, err := parseLevel(parts[1])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid log level of plugin %s", parts[0])
+		}
+		levels[parts[0]] = level
+	}
+	return levels, nil
+}
+
+// applyLevels sets the default level and replaces all plugin levels with the given ones.
+func applyLevels(level zapcore.Level, levels map[string]zapcore.Level) {
+	SetLevel(level)
+
+	pluginLevelsMutex.Lock()
+	for _, l := range pluginLevels {
+		l.reset()
+	}
+	pluginLevelsMutex.Unlock()
+
+	for pluginName, pluginLevel := range levels {
+		levelOfPlugin(pluginName).set(pluginLevel)
+	}
+}
+
+func parseLevel(text string) (level zapcore.Level, err error) {
+	err = level.UnmarshalText([]byte(text))
+	return level, err
+}
+
+// pluginLevel is the log level of a plugin, which falls back to the default level if it is not overridden.
+type pluginLevel struct {
+	name  string
+	level int32
+	// registered is true if the plugin created a logger with NewLogger. It is guarded by the pluginLevelsMutex.
+	registered bool
+}
+
+// Enabled returns true if the plugin logs messages with the given level.
+func (p *pluginLevel) Enabled(level zapcore.Level) bool {
+	if override, ok := p.override(); ok {
+		return level >= override
+	}
+	return defaultLevel.Enabled(level)
+}
+
+func (p *pluginLevel) override() (zapcore.Level, bool) {
+	level := atomic.LoadInt32(&p.level)
+	return zapcore.Level(level), level != noOverride
+}
+
+func (p *pluginLevel) set(level zapcore.Level) {
+	atomic.StoreInt32(&p.level, int32(level))
+}
+
+func (p *pluginLevel) reset() {
+	atomic.StoreInt32(&p.level, noOverride)
+}
@@ -1,12 +1,19 @@
 package logger
 
-import "github.com/iotaledger/hive.go/configuration"
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/configuration"
+)
 
 // ParametersDefinition contains the definition of configuration parameters used by the logger plugin.
 type ParametersDefinition struct {
 	// Level defines the logger's level.
 	Level string `default:"info" usage:"log level"`
 
+	// PluginLevels defines the log levels of single plugins that override the Level.
+	PluginLevels []string `usage:"log levels of single plugins, e.g. MessageLayer=debug"`
+
 	// DisableCaller defines whether to disable caller info.
 	DisableCaller bool `default:"false" usage:"disable caller info in log"`
 
@@ -14,13 +21,27 @@ type ParametersDefinition struct {
 	DisableStacktrace bool `default:"false" usage:"disable stack trace in log"`
 
 	// Encoding defines the logger's encoding.
-	Encoding string `default:"console" usage:"log encoding"`
+	Encoding string `default:"console" usage:"log encoding, either console or json"`
 
 	// OutputPaths defines the logger's output paths.
 	OutputPaths []string `default:"stdout,goshimmer.log" usage:"log output paths"`
 
 	// DisableEvents defines whether to disable logger events.
 	DisableEvents bool `default:"true" usage:"disable logger events"`
+
+	// Sampling defines the sampling of the log messages of plugins on hot paths.
+	Sampling struct {
+		// Enabled defines whether the log messages of the Plugins are sampled.
+		Enabled bool `default:"true" usage:"whether to sample the log messages of the plugins on hot paths"`
+		// Plugins defines the plugins whose log messages are sampled.
+		Plugins []string `default:"MessageLayer,Gossip" usage:"the plugins whose log messages are sampled"`
+		// Initial defines the number of log messages per level and tick that are always logged.
+		Initial int `default:"100" usage:"the number of log messages per level and tick that are always logged"`
+		// Thereafter defines that only every n-th log message is logged after the Initial ones.
+		Thereafter int `default:"100" usage:"only every n-th log message is logged after the initial ones"`
+		// Tick defines the interval the Initial log messages refer to.
+		Tick time.Duration `default:"1s" usage:"the interval the initial log messages refer to"`
+	}
 }
 
 // Parameters contains the configuration parameters of the logger plugin.
@@ -8,6 +8,8 @@ import (
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
+
+	"github.com/iotaledger/goshimmer/plugins/config"
 )
 
 // PluginName is the name of the logger plugin.
@@ -33,5 +35,40 @@ func init() {
 
 		// enable logging for the daemon
 		daemon.DebugEnabled(true)
+
+		level, err := parseLevel(Parameters.Level)
+		if err != nil {
+			Plugin.Panic(err)
+		}
+		levels, err := parsePluginLevels(Parameters.PluginLevels)
+		if err != nil {
+			Plugin.Panic(err)
+		}
+		applyLevels(level, levels)
+
+		config.RegisterReloadable(&config.Reloadable{
+			Parameters: []string{"logger.level", "logger.pluginLevels"},
+			Validate: func(conf *configuration.Configuration) error {
+				if _, err := parseLevel(conf.String("logger.level")); err != nil {
+					return err
+				}
+				_, err := parsePluginLevels(conf.Strings("logger.pluginLevels"))
+				return err
+			},
+			Apply: func(conf *configuration.Configuration) {
+				level, err := parseLevel(conf.String("logger.level"))
+				if err != nil {
+					Plugin.LogErrorf("Failed to change the log level: %s", err)
+					return
+				}
+				levels, err := parsePluginLevels(conf.Strings("logger.pluginLevels"))
+				if err != nil {
+					Plugin.LogErrorf("Failed to change the log levels of the plugins: %s", err)
+					return
+				}
+				applyLevels(level, levels)
+				Plugin.LogInfof("Log level changed to %s, plugin levels: %v", level, levels)
+			},
+		})
 	}))
 }
@@ -0,0 +1,327 @@
+package manaeventlogger
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/mr-tron/base58"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/mana"
+)
+
+// subscriberBufferSize defines how many events a slow subscriber can lag behind before events are dropped for it.
+const subscriberBufferSize = 1024
+
+// region exportedEvent ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// exportedEvent is a mana event in the uniform export format together with the fields needed for filtering.
+type exportedEvent struct {
+	nodeID   identity.ID
+	manaType mana.Type
+	time     time.Time
+	json     *jsonmodels.ManaEvent
+	line     []byte
+}
+
+// newExportedEvent converts a mana event to its export format. Update events carry no timestamp of their own, so the
+// time they were received at is used instead.
+func newExportedEvent(ev mana.Event, receivedAt time.Time) (*exportedEvent, error) {
+	e := &exportedEvent{}
+	switch ev := ev.(type) {
+	case *mana.PledgedEvent:
+		e.nodeID, e.manaType, e.time = ev.NodeID, ev.ManaType, ev.Time
+		e.json = &jsonmodels.ManaEvent{
+			Type:          "pledge",
+			Amount:        ev.Amount,
+			TransactionID: ev.TransactionID.Base58(),
+		}
+	case *mana.RevokedEvent:
+		e.nodeID, e.manaType, e.time = ev.NodeID, ev.ManaType, ev.Time
+		e.json = &jsonmodels.ManaEvent{
+			Type:          "revoke",
+			Amount:        ev.Amount,
+			TransactionID: ev.TransactionID.Base58(),
+			InputID:       ev.InputID.Base58(),
+		}
+	case *mana.UpdatedEvent:
+		e.nodeID, e.manaType, e.time = ev.NodeID, ev.ManaType, receivedAt
+		e.json = &jsonmodels.ManaEvent{
+			Type:        "update",
+			OldBaseMana: ev.OldMana.BaseValue(),
+			NewBaseMana: ev.NewMana.BaseValue(),
+		}
+	default:
+		return nil, errors.Errorf("unsupported mana event type %T", ev)
+	}
+	e.json.ManaType = e.manaType.String()
+	e.json.NodeID = base58.Encode(e.nodeID.Bytes())
+	e.json.Time = e.time.Unix()
+
+	line, err := json.Marshal(e.json)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal mana event")
+	}
+	e.line = append(line, '\n')
+	return e, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region eventFilter //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// eventFilter selects exported events by node, mana type and time range. Zero values match everything.
+type eventFilter struct {
+	nodeIDs   map[identity.ID]struct{}
+	manaType  *mana.Type
+	startTime time.Time
+	endTime   time.Time
+}
+
+// newEventFilter creates an eventFilter from the filters of an API request.
+func newEventFilter(req *jsonmodels.GetManaEventsRequest) (*eventFilter, error) {
+	f := &eventFilter{}
+	if len(req.NodeIDs) > 0 {
+		f.nodeIDs = make(map[identity.ID]struct{}, len(req.NodeIDs))
+		for _, nodeIDStr := range req.NodeIDs {
+			nodeID, err := mana.IDFromStr(nodeIDStr)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid node ID %s", nodeIDStr)
+			}
+			f.nodeIDs[nodeID] = struct{}{}
+		}
+	}
+	if req.ManaType != "" {
+		manaType, err := mana.TypeFromString(req.ManaType)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid mana type %s", req.ManaType)
+		}
+		f.manaType = &manaType
+	}
+	if req.StartTime != 0 {
+		f.startTime = time.Unix(req.StartTime, 0)
+	}
+	if req.EndTime != 0 {
+		f.endTime = time.Unix(req.EndTime, 0)
+	}
+	if !f.startTime.IsZero() && !f.endTime.IsZero() && f.endTime.Before(f.startTime) {
+		return nil, errors.Errorf("endTime %d is before startTime %d", req.EndTime, req.StartTime)
+	}
+	return f, nil
+}
+
+func (f *eventFilter) matches(e *exportedEvent) bool {
+	if f == nil {
+		return true
+	}
+	if f.nodeIDs != nil {
+		if _, ok := f.nodeIDs[e.nodeID]; !ok {
+			return false
+		}
+	}
+	if f.manaType != nil && *f.manaType != e.manaType {
+		return false
+	}
+	if !f.startTime.IsZero() && e.time.Before(f.startTime) {
+		return false
+	}
+	if !f.endTime.IsZero() && e.time.After(f.endTime) {
+		return false
+	}
+	return true
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region eventWindow //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// eventWindow keeps the most recent exported events in memory. It is a ring buffer, the oldest event is overwritten
+// once the window is full.
+type eventWindow struct {
+	events []*exportedEvent
+	// head is the index of the oldest event as soon as the window is full.
+	head  int
+	mutex sync.RWMutex
+}
+
+func newEventWindow(size int) *eventWindow {
+	if size < 0 {
+		size = 0
+	}
+	return &eventWindow{events: make([]*exportedEvent, 0, size)}
+}
+
+func (w *eventWindow) add(e *exportedEvent) {
+	if cap(w.events) == 0 {
+		return
+	}
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if len(w.events) < cap(w.events) {
+		w.events = append(w.events, e)
+		return
+	}
+	w.events[w.head] = e
+	w.head = (w.head + 1) % len(w.events)
+}
+
+// query returns the kept events that match the given filter in the order they were received.
+func (w *eventWindow) query(f *eventFilter) []*jsonmodels.ManaEvent {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+	result := make([]*jsonmodels.ManaEvent, 0)
+	for i := range w.events {
+		if e := w.events[(w.head+i)%len(w.events)]; f.matches(e) {
+			result = append(result, e.json)
+		}
+	}
+	return result
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region eventBroker //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// subscriber receives the exported events that match its filter.
+type subscriber struct {
+	filter *eventFilter
+	events chan *exportedEvent
+}
+
+// eventBroker distributes exported events to its subscribers. Events are dropped for subscribers that can not keep up.
+type eventBroker struct {
+	subscribers map[*subscriber]struct{}
+	mutex       sync.RWMutex
+}
+
+func newEventBroker() *eventBroker {
+	return &eventBroker{subscribers: make(map[*subscriber]struct{})}
+}
+
+func (b *eventBroker) subscribe(f *eventFilter) *subscriber {
+	s := &subscriber{filter: f, events: make(chan *exportedEvent, subscriberBufferSize)}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.subscribers[s] = struct{}{}
+	return s
+}
+
+// unsubscribe removes the subscriber and closes its channel.
+func (b *eventBroker) unsubscribe(s *subscriber) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if _, ok := b.subscribers[s]; !ok {
+		return
+	}
+	delete(b.subscribers, s)
+	close(s.events)
+}
+
+func (b *eventBroker) publish(e *exportedEvent) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	for s := range b.subscribers {
+		if !s.filter.matches(e) {
+			continue
+		}
+		select {
+		case s.events <- e:
+		default:
+			log.Warnf("dropping mana event for slow subscriber")
+		}
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region sinks ////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// exportToFile appends all events as newline-delimited JSON to the file at the given path until the context is done.
+func exportToFile(ctx context.Context, path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0660)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open %s", path)
+	}
+	defer f.Close()
+
+	s := broker.subscribe(nil)
+	defer broker.unsubscribe(s)
+
+	w := bufio.NewWriter(f)
+	defer w.Flush()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e := <-s.events:
+			if _, err := w.Write(e.line); err != nil {
+				return errors.Wrapf(err, "failed to write to %s", path)
+			}
+			if len(s.events) > 0 {
+				continue
+			}
+			if err := w.Flush(); err != nil {
+				return errors.Wrapf(err, "failed to write to %s", path)
+			}
+		}
+	}
+}
+
+// exportToSocket listens on the unix socket at the given path and streams all events as newline-delimited JSON to
+// every connected client until the context is done.
+func exportToSocket(ctx context.Context, path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to remove stale socket %s", path)
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to listen on %s", path)
+	}
+	go func() {
+		<-ctx.Done()
+		_ = listener.Close()
+	}()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return errors.Wrapf(err, "failed to accept connection on %s", path)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			streamToConn(ctx, conn)
+		}()
+	}
+}
+
+func streamToConn(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+	s := broker.subscribe(nil)
+	defer broker.unsubscribe(s)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-s.events:
+			if _, err := conn.Write(e.line); err != nil {
+				log.Debugf("closing mana event socket connection: %s", err)
+				return
+			}
+		}
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
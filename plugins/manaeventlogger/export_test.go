@@ -0,0 +1,52 @@
+package manaeventlogger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/mr-tron/base58"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/mana"
+)
+
+func TestEventWindow_Query(t *testing.T) {
+	nodeA, nodeB := identity.GenerateIdentity().ID(), identity.GenerateIdentity().ID()
+	now := time.Unix(1600000000, 0)
+
+	w := newEventWindow(3)
+	for _, ev := range []mana.Event{
+		&mana.PledgedEvent{NodeID: nodeA, Amount: 1, Time: now, ManaType: mana.AccessMana},
+		&mana.PledgedEvent{NodeID: nodeA, Amount: 2, Time: now, ManaType: mana.ConsensusMana},
+		&mana.RevokedEvent{NodeID: nodeB, Amount: 3, Time: now.Add(time.Minute), ManaType: mana.ConsensusMana},
+		&mana.UpdatedEvent{NodeID: nodeB, OldMana: &mana.ConsensusBaseMana{BaseMana1: 3}, NewMana: &mana.ConsensusBaseMana{}, ManaType: mana.ConsensusMana},
+	} {
+		e, err := newExportedEvent(ev, now.Add(time.Hour))
+		require.NoError(t, err)
+		w.add(e)
+	}
+
+	// the oldest event was pushed out of the window and the others are returned in the order they were received
+	all := w.query(nil)
+	require.Len(t, all, 3)
+	assert.Equal(t, 2.0, all[0].Amount)
+	assert.Equal(t, "revoke", all[1].Type)
+	assert.Equal(t, "update", all[2].Type)
+
+	filter, err := newEventFilter(&jsonmodels.GetManaEventsRequest{
+		NodeIDs:  []string{base58.Encode(nodeB.Bytes())},
+		ManaType: mana.ConsensusMana.String(),
+		EndTime:  now.Add(time.Minute).Unix(),
+	})
+	require.NoError(t, err)
+	events := w.query(filter)
+	require.Len(t, events, 1)
+	assert.Equal(t, "revoke", events[0].Type)
+	assert.Equal(t, 3.0, events[0].Amount)
+
+	_, err = newEventFilter(&jsonmodels.GetManaEventsRequest{ManaType: "Foo"})
+	assert.Error(t, err)
+}
@@ -14,6 +14,12 @@ type ParametersDefinition struct {
 	BufferSize int `default:"100" usage:"event logs buffer size"`
 	// CheckBufferInterval defines interval between buffer checks.
 	CheckBufferInterval time.Duration `default:"5s" usage:"check buffer interval"`
+	// NDJSON defines the file path to export mana events to as newline-delimited JSON.
+	NDJSON string `usage:"file to export mana events to as newline-delimited JSON (disabled if empty)"`
+	// Socket defines the path of the unix socket to export mana events to as newline-delimited JSON.
+	Socket string `usage:"unix socket to export mana events to as newline-delimited JSON (disabled if empty)"`
+	// HistorySize defines the number of recent mana events that are kept to answer API queries.
+	HistorySize int `default:"10000" usage:"number of recent mana events kept in memory for API queries"`
 }
 
 // Parameters contains the configuration used by the mana event logger plugin.
@@ -11,9 +11,12 @@ import (
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
+	"github.com/labstack/echo"
+	"go.uber.org/dig"
 
 	"github.com/iotaledger/goshimmer/packages/mana"
 	"github.com/iotaledger/goshimmer/packages/shutdown"
+	logger_plugin "github.com/iotaledger/goshimmer/plugins/logger"
 )
 
 const (
@@ -24,9 +27,13 @@ const (
 var (
 	// Plugin is the plugin instance of the manaeventlogger plugin.
 	Plugin               *node.Plugin
+	deps                 = new(dependencies)
 	log                  *logger.Logger
 	onPledgeEventClosure *events.Closure
 	onRevokeEventClosure *events.Closure
+	onUpdateEventClosure *events.Closure
+	window               *eventWindow
+	broker               *eventBroker
 	eventsBuffer         []mana.Event
 	eventsBufferSize     int
 	csvPath              string
@@ -35,33 +42,68 @@ var (
 	checkBufferInterval  time.Duration
 )
 
+type dependencies struct {
+	dig.In
+
+	Server *echo.Echo
+}
+
 func init() {
-	Plugin = node.NewPlugin(PluginName, nil, node.Disabled, configure, run)
+	Plugin = node.NewPlugin(PluginName, deps, node.Disabled, configure, run)
 }
 
 func configure(*node.Plugin) {
-	log = logger.NewLogger(PluginName)
+	log = logger_plugin.NewLogger(PluginName)
 	eventsBufferSize = Parameters.BufferSize
 	csvPath = Parameters.CSV
 	checkBufferInterval = Parameters.CheckBufferInterval
+	window = newEventWindow(Parameters.HistorySize)
+	broker = newEventBroker()
 	onPledgeEventClosure = events.NewClosure(logPledge)
 	onRevokeEventClosure = events.NewClosure(logRevoke)
+	onUpdateEventClosure = events.NewClosure(logUpdate)
 	configureEvents()
+	configureWebAPI()
 }
 
 func configureEvents() {
 	mana.Events().Pledged.Attach(onPledgeEventClosure)
 	mana.Events().Revoked.Attach(onRevokeEventClosure)
+	mana.Events().Updated.Attach(onUpdateEventClosure)
 }
 
 func logPledge(ev *mana.PledgedEvent) {
-	eventsBuffer = append(eventsBuffer, ev)
+	bufferEvent(ev)
+	exportEvent(ev)
 }
 
 func logRevoke(ev *mana.RevokedEvent) {
+	bufferEvent(ev)
+	exportEvent(ev)
+}
+
+func logUpdate(ev *mana.UpdatedEvent) {
+	exportEvent(ev)
+}
+
+// bufferEvent adds the event to the buffer that is written to the CSV file.
+func bufferEvent(ev mana.Event) {
+	mu.Lock()
+	defer mu.Unlock()
 	eventsBuffer = append(eventsBuffer, ev)
 }
 
+// exportEvent makes the event available to API queries and to all the export subscribers.
+func exportEvent(ev mana.Event) {
+	e, err := newExportedEvent(ev, time.Now())
+	if err != nil {
+		log.Errorf("failed to export mana event: %s", err)
+		return
+	}
+	window.add(e)
+	broker.publish(e)
+}
+
 func checkBuffer() {
 	mu.Lock()
 	defer mu.Unlock()
@@ -114,6 +156,25 @@ func writeEventsToCSV(evs []mana.Event) error {
 }
 
 func run(_ *node.Plugin) {
+	if Parameters.NDJSON != "" {
+		if err := daemon.BackgroundWorker(PluginName+"[NDJSON]", func(ctx context.Context) {
+			if err := exportToFile(ctx, Parameters.NDJSON); err != nil {
+				log.Errorf("mana event export to file stopped: %s", err)
+			}
+		}, shutdown.PriorityMana); err != nil {
+			log.Panicf("Failed to start as daemon: %s", err)
+		}
+	}
+	if Parameters.Socket != "" {
+		if err := daemon.BackgroundWorker(PluginName+"[Socket]", func(ctx context.Context) {
+			if err := exportToSocket(ctx, Parameters.Socket); err != nil {
+				log.Errorf("mana event export to socket stopped: %s", err)
+			}
+		}, shutdown.PriorityMana); err != nil {
+			log.Panicf("Failed to start as daemon: %s", err)
+		}
+	}
+
 	if err := daemon.BackgroundWorker(PluginName, func(ctx context.Context) {
 		defer log.Infof("Stopping %s ... done", PluginName)
 		ticker := time.NewTicker(checkBufferInterval)
@@ -129,7 +190,10 @@ func run(_ *node.Plugin) {
 		}
 		log.Infof("stopping %s", PluginName)
 		mana.Events().Pledged.Detach(onPledgeEventClosure)
-		mana.Events().Pledged.Detach(onRevokeEventClosure)
+		mana.Events().Revoked.Detach(onRevokeEventClosure)
+		mana.Events().Updated.Detach(onUpdateEventClosure)
+		mu.Lock()
+		defer mu.Unlock()
 		if err := writeEventsToCSV(eventsBuffer); err != nil {
 			log.Infof("error writing events to csv: %w", err)
 		}
@@ -0,0 +1,71 @@
+package manaeventlogger
+
+import (
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+	"github.com/labstack/echo"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/plugins/webapi"
+)
+
+const (
+	// RouteManaEvents defines the HTTP path for querying the recent mana events.
+	RouteManaEvents = "mana/events"
+	// RouteManaEventsStream defines the HTTP path for streaming mana events as newline-delimited JSON.
+	RouteManaEventsStream = "mana/events/stream"
+)
+
+func configureWebAPI() {
+	deps.Server.GET(RouteManaEvents, getEventsHandler)
+	deps.Server.GET(RouteManaEventsStream, streamEventsHandler)
+}
+
+// getEventsHandler returns the recent mana events that match the filters of the request.
+func getEventsHandler(c echo.Context) error {
+	filter, err := parseEventFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetManaEventsResponse{Error: err.Error()})
+	}
+	return c.JSON(http.StatusOK, jsonmodels.GetManaEventsResponse{Events: window.query(filter)})
+}
+
+// streamEventsHandler streams the mana events that match the filters of the request as newline-delimited JSON until
+// the client disconnects.
+func streamEventsHandler(c echo.Context) error {
+	filter, err := parseEventFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetManaEventsResponse{Error: err.Error()})
+	}
+
+	s := broker.subscribe(filter)
+	defer broker.unsubscribe(s)
+
+	response := c.Response()
+	response.Header().Set(echo.HeaderContentType, "application/x-ndjson")
+	response.WriteHeader(http.StatusOK)
+	response.Flush()
+	for {
+		select {
+		case <-c.Request().Context().Done():
+			return nil
+		case e, ok := <-s.events:
+			if !ok {
+				return nil
+			}
+			if _, err := response.Write(e.line); err != nil {
+				return nil
+			}
+			response.Flush()
+		}
+	}
+}
+
+func parseEventFilter(c echo.Context) (*eventFilter, error) {
+	var request jsonmodels.GetManaEventsRequest
+	if err := webapi.ParseJSONRequest(c, &request); err != nil {
+		return nil, errors.Wrap(err, "invalid mana events request")
+	}
+	return newEventFilter(&request)
+}
@@ -3,19 +3,25 @@ package manualpeering
 import (
 	"context"
 	"encoding/json"
+	"sync"
 
 	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/configuration"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/daemon"
 	"github.com/iotaledger/hive.go/events"
-	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/kvstore"
 	"github.com/iotaledger/hive.go/node"
 	"github.com/labstack/echo"
 	"go.uber.org/dig"
 
+	"github.com/iotaledger/goshimmer/packages/database"
 	"github.com/iotaledger/goshimmer/packages/gossip"
 	"github.com/iotaledger/goshimmer/packages/manualpeering"
 	"github.com/iotaledger/goshimmer/packages/shutdown"
+	"github.com/iotaledger/goshimmer/plugins/config"
+	logger_plugin "github.com/iotaledger/goshimmer/plugins/logger"
 )
 
 // PluginName is the name of the manual peering plugin.
@@ -25,6 +31,10 @@ var (
 	// Plugin is the plugin instance of the manual peering plugin.
 	Plugin *node.Plugin
 	deps   = new(dependencies)
+
+	// configPeers contains the known peers that were passed from the config to the manager.
+	configPeers      []*manualpeering.KnownPeerToAdd
+	configPeersMutex sync.Mutex
 )
 
 type dependencies struct {
@@ -45,12 +55,23 @@ func init() {
 	}))
 }
 
-func newManager(lPeer *peer.Local, gossipMgr *gossip.Manager) *manualpeering.Manager {
-	return manualpeering.NewManager(gossipMgr, lPeer, logger.NewLogger(PluginName))
+func newManager(lPeer *peer.Local, gossipMgr *gossip.Manager, store kvstore.KVStore) *manualpeering.Manager {
+	return manualpeering.NewManager(gossipMgr, lPeer, logger_plugin.NewLogger(PluginName),
+		manualpeering.WithStore(store.WithRealm([]byte{database.PrefixManualPeering})))
 }
 
 func configure(_ *node.Plugin) {
 	configureWebAPI()
+	config.RegisterReloadable(&config.Reloadable{
+		Parameters: []string{"manualPeering.knownPeers"},
+		Validate: func(conf *configuration.Configuration) error {
+			_, err := parseKnownPeers(conf.String("manualPeering.knownPeers"))
+			return err
+		},
+		Apply: func(conf *configuration.Configuration) {
+			updatePeersFromConfig(deps.ManualPeeringMgr, conf.String("manualPeering.knownPeers"))
+		},
+	})
 }
 
 func run(*node.Plugin) {
@@ -72,24 +93,65 @@ func startManager(ctx context.Context) {
 }
 
 func addPeersFromConfigToManager(mgr *manualpeering.Manager) {
+	configPeersMutex.Lock()
+	defer configPeersMutex.Unlock()
+
 	peers, err := getKnownPeersFromConfig()
 	if err != nil {
 		Plugin.Logger().Errorw("Failed to get known peers from the config file, continuing without them...", "err", err)
 	} else if len(peers) != 0 {
 		Plugin.Logger().Infow("Pass known peers list from the config file to the manager", "peers", peers)
-		if err := mgr.AddPeer(peers...); err != nil {
+		if err := mgr.AddConfigPeer(peers...); err != nil {
 			Plugin.Logger().Infow("Failed to pass known peers list from the config file to the manager",
 				"peers", peers, "err", err)
 		}
 	}
+	configPeers = peers
+}
+
+// updatePeersFromConfig removes the config peers that are missing in the given known peers of the reloaded config and
+// adds or updates all others.
+func updatePeersFromConfig(mgr *manualpeering.Manager, knownPeers string) {
+	configPeersMutex.Lock()
+	defer configPeersMutex.Unlock()
+
+	peers, err := parseKnownPeers(knownPeers)
+	if err != nil {
+		Plugin.Logger().Errorw("Failed to get known peers from the config file", "err", err)
+		return
+	}
+
+	keep := make(map[ed25519.PublicKey]struct{}, len(peers))
+	for _, p := range peers {
+		keep[p.PublicKey] = struct{}{}
+	}
+	var removed []ed25519.PublicKey
+	for _, p := range configPeers {
+		if _, ok := keep[p.PublicKey]; !ok {
+			removed = append(removed, p.PublicKey)
+		}
+	}
+
+	Plugin.Logger().Infow("Update known peers list from the reloaded config", "peers", peers, "removed", removed)
+	if err := mgr.RemoveConfigPeer(removed...); err != nil {
+		Plugin.Logger().Errorw("Failed to remove known peers that were removed from the config", "err", err)
+	}
+	if err := mgr.AddConfigPeer(peers...); err != nil {
+		Plugin.Logger().Errorw("Failed to pass known peers list from the config file to the manager", "err", err)
+	}
+	configPeers = peers
 }
 
 func getKnownPeersFromConfig() ([]*manualpeering.KnownPeerToAdd, error) {
-	if Parameters.KnownPeers == "" {
+	return parseKnownPeers(Parameters.KnownPeers)
+}
+
+func parseKnownPeers(knownPeers string) ([]*manualpeering.KnownPeerToAdd, error) {
+	if knownPeers == "" {
 		return []*manualpeering.KnownPeerToAdd{}, nil
 	}
 	var peers []*manualpeering.KnownPeerToAdd
-	if err := json.Unmarshal([]byte(Parameters.KnownPeers), &peers); err != nil {
+	if err := json.Unmarshal([]byte(knownPeers), &peers); err != nil {
 		return nil, errors.Wrap(err, "can't parse peers from json")
 	}
 	return peers, nil
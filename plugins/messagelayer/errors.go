@@ -2,5 +2,9 @@ package messagelayer
 
 import "github.com/cockroachdb/errors"
 
-// ErrQueryNotAllowed is returned when the node is not synced and mana debug mode is disabled.
-var ErrQueryNotAllowed = errors.New("mana query not allowed, node is not synced, debug mode disabled")
+var (
+	// ErrQueryNotAllowed is returned when the node is not synced and mana debug mode is disabled.
+	ErrQueryNotAllowed = errors.New("mana query not allowed, node is not synced, debug mode disabled")
+	// ErrConsensusHistoryDisabled is returned when the past consensus mana is queried but the history is disabled.
+	ErrConsensusHistoryDisabled = errors.New("consensus mana history is disabled")
+)
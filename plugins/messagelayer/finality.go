@@ -6,6 +6,7 @@ import (
 
 	"github.com/iotaledger/goshimmer/packages/consensus/finality"
 	"github.com/iotaledger/goshimmer/packages/tangle"
+	logger_plugin "github.com/iotaledger/goshimmer/plugins/logger"
 )
 
 var finalityGadget finality.Gadget
@@ -18,12 +19,12 @@ func FinalityGadget() finality.Gadget {
 func configureFinality() {
 	deps.Tangle.ApprovalWeightManager.Events.MarkerWeightChanged.Attach(events.NewClosure(func(e *tangle.MarkerWeightChangedEvent) {
 		if err := finalityGadget.HandleMarker(e.Marker, e.Weight); err != nil {
-			Plugin.LogError(err)
+			log.Errorw("Failed to handle the marker weight", "marker", e.Marker.String(), "err", err)
 		}
 	}))
 	deps.Tangle.ApprovalWeightManager.Events.BranchWeightChanged.Attach(events.NewClosure(func(e *tangle.BranchWeightChangedEvent) {
 		if err := finalityGadget.HandleBranch(e.BranchID, e.Weight); err != nil {
-			Plugin.LogError(err)
+			log.Errorw("Failed to handle the branch weight", logger_plugin.KeyBranchID, e.BranchID.Base58(), "err", err)
 		}
 	}))
 
@@ -2,12 +2,13 @@ package messagelayer
 
 import (
 	"context"
-	"fmt"
 	"math"
 	"os"
 	"sort"
+	"sync"
 	"time"
 
+	"github.com/iotaledger/hive.go/configuration"
 	"github.com/iotaledger/hive.go/daemon"
 	"github.com/iotaledger/hive.go/datastructure/set"
 	"github.com/iotaledger/hive.go/events"
@@ -23,6 +24,8 @@ import (
 	"github.com/iotaledger/goshimmer/packages/mana"
 	"github.com/iotaledger/goshimmer/packages/shutdown"
 	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/plugins/config"
+	logger_plugin "github.com/iotaledger/goshimmer/plugins/logger"
 )
 
 const (
@@ -38,13 +41,12 @@ var (
 	osFactory          *objectstorage.Factory
 	storages           map[mana.Type]*objectstorage.ObjectStorage
 	allowedPledgeNodes map[mana.Type]AllowedPledge
-	// consensusBaseManaPastVectorStorage         *objectstorage.ObjectStorage
-	// consensusBaseManaPastVectorMetadataStorage *objectstorage.ObjectStorage
-	// consensusEventsLogStorage                  *objectstorage.ObjectStorage
-	// consensusEventsLogsStorageSize             atomic.Uint32.
+	allowedPledgeMutex sync.RWMutex
+	consensusHistory   *mana.ConsensusManaHistory
+
 	onTransactionConfirmedClosure *events.Closure
-	// onPledgeEventClosure          *events.Closure
-	// onRevokeEventClosure          *events.Closure
+	onPledgeEventClosure          *events.Closure
+	onRevokeEventClosure          *events.Closure
 	// debuggingEnabled              bool.
 )
 
@@ -59,11 +61,11 @@ func init() {
 }
 
 func configureManaPlugin(*node.Plugin) {
-	manaLogger = logger.NewLogger(PluginName)
+	manaLogger = logger_plugin.NewLogger(PluginName)
 
 	onTransactionConfirmedClosure = events.NewClosure(onTransactionConfirmed)
-	// onPledgeEventClosure = events.NewClosure(logPledgeEvent)
-	// onRevokeEventClosure = events.NewClosure(logRevokeEvent)
+	onPledgeEventClosure = events.NewClosure(logPledgeEvent)
+	onRevokeEventClosure = events.NewClosure(logRevokeEvent)
 
 	allowedPledgeNodes = make(map[mana.Type]AllowedPledge)
 	baseManaVectors = make(map[mana.Type]mana.BaseManaVector)
@@ -80,16 +82,42 @@ func configureManaPlugin(*node.Plugin) {
 		storages[mana.ResearchAccess] = osFactory.New(mana.PrefixAccessResearch, mana.FromObjectStorage)
 		storages[mana.ResearchConsensus] = osFactory.New(mana.PrefixConsensusResearch, mana.FromObjectStorage)
 	}
-	// consensusEventsLogStorage = osFactory.New(mana.PrefixEventStorage, mana.FromEventObjectStorage)
-	// consensusEventsLogsStorageSize.Store(getConsensusEventLogsStorageSize())
-	// manaLogger.Infof("read %d mana events from storage", consensusEventsLogsStorageSize.Load())
-	// consensusBaseManaPastVectorStorage = osFactory.New(mana.PrefixConsensusPastVector, mana.FromObjectStorage)
-	// consensusBaseManaPastVectorMetadataStorage = osFactory.New(mana.PrefixConsensusPastMetadata, mana.FromMetadataObjectStorage)
+	if ManaParameters.EnableConsensusHistory {
+		var err error
+		if consensusHistory, err = mana.NewConsensusManaHistory(store.WithRealm([]byte{db_pkg.PrefixMana})); err != nil {
+			manaLogger.Panic(err.Error())
+		}
+	}
 
 	err := verifyPledgeNodes()
 	if err != nil {
 		manaLogger.Panic(err.Error())
 	}
+	config.RegisterReloadable(&config.Reloadable{
+		Parameters: []string{
+			"mana.allowedAccessPledge",
+			"mana.allowedAccessFilterEnabled",
+			"mana.allowedConsensusPledge",
+			"mana.allowedConsensusFilterEnabled",
+		},
+		Validate: func(conf *configuration.Configuration) error {
+			if _, err := newAllowedPledge(conf.Bool("mana.allowedAccessFilterEnabled"), conf.Strings("mana.allowedAccessPledge")); err != nil {
+				return err
+			}
+			_, err := newAllowedPledge(conf.Bool("mana.allowedConsensusFilterEnabled"), conf.Strings("mana.allowedConsensusPledge"))
+			return err
+		},
+		Apply: func(conf *configuration.Configuration) {
+			if err := setAllowedPledgeNodes(
+				conf.Bool("mana.allowedAccessFilterEnabled"), conf.Strings("mana.allowedAccessPledge"),
+				conf.Bool("mana.allowedConsensusFilterEnabled"), conf.Strings("mana.allowedConsensusPledge"),
+			); err != nil {
+				manaLogger.Errorf("failed to update the allowed pledge nodes: %s", err)
+				return
+			}
+			manaLogger.Info("allowed pledge nodes updated")
+		},
+	})
 
 	// debuggingEnabled = ManaParameters.DebuggingEnabled
 
@@ -99,22 +127,23 @@ func configureManaPlugin(*node.Plugin) {
 func configureEvents() {
 	// until we have the proper event...
 	deps.Tangle.ConfirmationOracle.Events().TransactionConfirmed.Attach(onTransactionConfirmedClosure)
-	// mana.Events().Revoked.Attach(onRevokeEventClosure)
-}
-
-//func logPledgeEvent(ev *mana.PledgedEvent) {
-//	if ev.ManaType == mana.ConsensusMana {
-//		consensusEventsLogStorage.Store(ev.ToPersistable()).Release()
-//		consensusEventsLogsStorageSize.Inc()
-//	}
-//}
-//
-//func logRevokeEvent(ev *mana.RevokedEvent) {
-//	if ev.ManaType == mana.ConsensusMana {
-//		consensusEventsLogStorage.Store(ev.ToPersistable()).Release()
-//		consensusEventsLogsStorageSize.Inc()
-//	}
-//}
+	if consensusHistory != nil {
+		mana.Events().Pledged.Attach(onPledgeEventClosure)
+		mana.Events().Revoked.Attach(onRevokeEventClosure)
+	}
+}
+
+func logPledgeEvent(ev *mana.PledgedEvent) {
+	if err := consensusHistory.RecordEvent(ev); err != nil {
+		manaLogger.Errorf("failed to record pledge event in consensus mana history: %s", err)
+	}
+}
+
+func logRevokeEvent(ev *mana.RevokedEvent) {
+	if err := consensusHistory.RecordEvent(ev); err != nil {
+		manaLogger.Errorf("failed to record revoke event in consensus mana history: %s", err)
+	}
+}
 
 func onTransactionConfirmed(transactionID ledgerstate.TransactionID) {
 	deps.Tangle.LedgerState.Transaction(transactionID).Consume(func(transaction *ledgerstate.Transaction) {
@@ -183,12 +212,13 @@ func runManaPlugin(_ *node.Plugin) {
 	dec := ManaParameters.Decay
 	pruneInterval := ManaParameters.PruneConsensusEventLogsInterval
 	vectorsCleanUpInterval := ManaParameters.VectorsCleanupInterval
-	fmt.Printf("Prune interval: %v\n", pruneInterval)
 	mana.SetCoefficients(ema1, ema2, dec)
 	if err := daemon.BackgroundWorker("Mana", func(ctx context.Context) {
 		defer manaLogger.Infof("Stopping %s ... done", PluginName)
-		// ticker := time.NewTicker(pruneInterval)
-		// defer ticker.Stop()
+		ticker := time.NewTicker(pruneInterval)
+		defer ticker.Stop()
+		checkpointTicker := time.NewTicker(ManaParameters.ConsensusHistoryCheckpointInterval)
+		defer checkpointTicker.Stop()
 		cleanupTicker := time.NewTicker(vectorsCleanUpInterval)
 		defer cleanupTicker.Stop()
 		if !readStoredManaVectors() {
@@ -218,18 +248,25 @@ func runManaPlugin(_ *node.Plugin) {
 			}
 		}
 		pruneStorages()
+		if consensusHistory != nil {
+			if _, exists := consensusHistory.OldestAvailableTime(); !exists {
+				createConsensusHistoryCheckpoint()
+			}
+		}
 		for {
 			select {
 			case <-ctx.Done():
 				manaLogger.Infof("Stopping %s ...", PluginName)
-				// mana.Events().Pledged.Detach(onPledgeEventClosure)
-				// mana.Events().Pledged.Detach(onRevokeEventClosure)
+				mana.Events().Pledged.Detach(onPledgeEventClosure)
+				mana.Events().Revoked.Detach(onRevokeEventClosure)
 				deps.Tangle.ConfirmationOracle.Events().TransactionConfirmed.Detach(onTransactionConfirmedClosure)
 				storeManaVectors()
 				shutdownStorages()
 				return
-			// case <-ticker.C:
-			// pruneConsensusEventLogsStorage()
+			case <-checkpointTicker.C:
+				createConsensusHistoryCheckpoint()
+			case <-ticker.C:
+				pruneConsensusHistory()
 			case <-cleanupTicker.C:
 				cleanupManaVectors()
 			}
@@ -275,9 +312,29 @@ func shutdownStorages() {
 	for vectorType := range baseManaVectors {
 		storages[vectorType].Shutdown()
 	}
-	// consensusEventsLogStorage.Shutdown()
-	// consensusBaseManaPastVectorStorage.Shutdown()
-	// consensusBaseManaPastVectorMetadataStorage.Shutdown()
+}
+
+func createConsensusHistoryCheckpoint() {
+	if consensusHistory == nil {
+		return
+	}
+	manaMap, _, err := baseManaVectors[mana.ConsensusMana].GetManaMap()
+	if err != nil {
+		manaLogger.Errorf("failed to get consensus mana map: %s", err)
+		return
+	}
+	if err := consensusHistory.CreateCheckpoint(time.Now(), manaMap); err != nil {
+		manaLogger.Errorf("failed to create consensus mana history checkpoint: %s", err)
+	}
+}
+
+func pruneConsensusHistory() {
+	if consensusHistory == nil {
+		return
+	}
+	if err := consensusHistory.Prune(time.Now().Add(-ManaParameters.ConsensusHistoryRetention)); err != nil {
+		manaLogger.Errorf("failed to prune consensus mana history: %s", err)
+	}
 }
 
 // GetHighestManaNodes returns the n highest type mana nodes in descending order.
@@ -387,6 +444,9 @@ func OverrideMana(manaType mana.Type, nodeID identity.ID, bm *mana.AccessBaseMan
 
 // GetAllowedPledgeNodes returns the list of nodes that type mana is allowed to be pledged to.
 func GetAllowedPledgeNodes(manaType mana.Type) AllowedPledge {
+	allowedPledgeMutex.RLock()
+	defer allowedPledgeMutex.RUnlock()
+
 	return allowedPledgeNodes[manaType]
 }
 
@@ -422,42 +482,50 @@ func GetOnlineNodes(manaType mana.Type) (onlineNodesMana []mana.Node, t time.Tim
 }
 
 func verifyPledgeNodes() error {
-	access := AllowedPledge{
-		IsFilterEnabled: ManaParameters.AllowedAccessFilterEnabled,
+	return setAllowedPledgeNodes(
+		ManaParameters.AllowedAccessFilterEnabled, ManaParameters.AllowedAccessPledge,
+		ManaParameters.AllowedConsensusFilterEnabled, ManaParameters.AllowedConsensusPledge,
+	)
+}
+
+// setAllowedPledgeNodes replaces the nodes that access and consensus mana are allowed to be pledged to.
+func setAllowedPledgeNodes(accessFilterEnabled bool, accessPledge []string, consensusFilterEnabled bool, consensusPledge []string) error {
+	access, err := newAllowedPledge(accessFilterEnabled, accessPledge)
+	if err != nil {
+		return err
 	}
-	consensus := AllowedPledge{
-		IsFilterEnabled: ManaParameters.AllowedConsensusFilterEnabled,
+	consensus, err := newAllowedPledge(consensusFilterEnabled, consensusPledge)
+	if err != nil {
+		return err
 	}
 
-	access.Allowed = set.New(false)
-	// own ID is allowed by default
-	access.Allowed.Add(deps.Local.ID())
-	if access.IsFilterEnabled {
-		for _, pubKey := range ManaParameters.AllowedAccessPledge {
-			ID, err := mana.IDFromStr(pubKey)
-			if err != nil {
-				return err
-			}
-			access.Allowed.Add(ID)
-		}
+	allowedPledgeMutex.Lock()
+	defer allowedPledgeMutex.Unlock()
+
+	allowedPledgeNodes[mana.AccessMana] = access
+	allowedPledgeNodes[mana.ConsensusMana] = consensus
+	return nil
+}
+
+// newAllowedPledge creates the AllowedPledge from the given filter parameters.
+func newAllowedPledge(isFilterEnabled bool, pubKeys []string) (AllowedPledge, error) {
+	allowedPledge := AllowedPledge{
+		IsFilterEnabled: isFilterEnabled,
+		Allowed:         set.New(false),
 	}
 
-	consensus.Allowed = set.New(false)
 	// own ID is allowed by default
-	consensus.Allowed.Add(deps.Local.ID())
-	if consensus.IsFilterEnabled {
-		for _, pubKey := range ManaParameters.AllowedConsensusPledge {
+	allowedPledge.Allowed.Add(deps.Local.ID())
+	if allowedPledge.IsFilterEnabled {
+		for _, pubKey := range pubKeys {
 			ID, err := mana.IDFromStr(pubKey)
 			if err != nil {
-				return err
+				return AllowedPledge{}, err
 			}
-			consensus.Allowed.Add(ID)
+			allowedPledge.Allowed.Add(ID)
 		}
 	}
-
-	allowedPledgeNodes[mana.AccessMana] = access
-	allowedPledgeNodes[mana.ConsensusMana] = consensus
-	return nil
+	return allowedPledge, nil
 }
 
 // PendingManaOnOutput predicts how much mana (bm2) will be pledged to a node if the output specified is spent.
@@ -491,276 +559,69 @@ func GetPendingMana(value float64, n time.Duration) float64 {
 	return value * (1 - math.Pow(math.E, -mana.Decay*(n.Seconds())))
 }
 
-//// GetLoggedEvents gets the events logs for the node IDs and time frame specified. If none is specified, it returns the logs for all nodes.
-//func GetLoggedEvents(identityIDs []identity.ID, startTime time.Time, endTime time.Time) (map[identity.ID]*EventsLogs, error) {
-//	logs := make(map[identity.ID]*EventsLogs)
-//	lookup := make(map[identity.ID]bool)
-//	getAll := true
-//
-//	if len(identityIDs) > 0 {
-//		getAll = false
-//		for _, nodeID := range identityIDs {
-//			lookup[nodeID] = true
-//		}
-//	}
-//
-//	var err error
-//	consensusEventsLogStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
-//		cachedPe := &mana.CachedPersistableEvent{CachedObject: cachedObject}
-//		defer cachedPe.Release()
-//		pbm := cachedPe.Unwrap()
-//
-//		if !getAll {
-//			if !lookup[pbm.NodeID] {
-//				return true
-//			}
-//		}
-//
-//		if _, found := logs[pbm.NodeID]; !found {
-//			logs[pbm.NodeID] = &EventsLogs{}
-//		}
-//
-//		var ev mana.Event
-//		ev, err = mana.FromPersistableEvent(pbm)
-//		if err != nil {
-//			return false
-//		}
-//
-//		if ev.Timestamp().Before(startTime) || ev.Timestamp().After(endTime) {
-//			return true
-//		}
-//		switch ev.Type() {
-//		case mana.EventTypePledge:
-//			logs[pbm.NodeID].Pledge = append(logs[pbm.NodeID].Pledge, ev.(*mana.PledgedEvent))
-//		case mana.EventTypeRevoke:
-//			logs[pbm.NodeID].Revoke = append(logs[pbm.NodeID].Revoke, ev.(*mana.RevokedEvent))
-//		default:
-//			err = mana.ErrUnknownManaEvent
-//			return false
-//		}
-//		return true
-//	})
-//
-//	for ID := range logs {
-//		sort.Slice(logs[ID].Pledge, func(i, j int) bool {
-//			return logs[ID].Pledge[i].Time.Before(logs[ID].Pledge[j].Time)
-//		})
-//		sort.Slice(logs[ID].Revoke, func(i, j int) bool {
-//			return logs[ID].Revoke[i].Time.Before(logs[ID].Revoke[j].Time)
-//		})
-//	}
-//
-//	return logs, err
-//}
-//
-//// GetPastConsensusManaVectorMetadata gets the past consensus mana vector metadata.
-//func GetPastConsensusManaVectorMetadata() *mana.ConsensusBasePastManaVectorMetadata {
-//	cachedObj := consensusBaseManaPastVectorMetadataStorage.Load([]byte(mana.ConsensusBaseManaPastVectorMetadataStorageKey))
-//	cachedMetadata := &mana.CachedConsensusBasePastManaVectorMetadata{CachedObject: cachedObj}
-//	defer cachedMetadata.Release()
-//	return cachedMetadata.Unwrap()
-//}
-//
-//// GetPastConsensusManaVector builds a consensus base mana vector in the past.
-//func GetPastConsensusManaVector(t time.Time) (*mana.ConsensusBaseManaVector, []mana.Event, error) {
-//	baseManaVector, err := mana.NewBaseManaVector(mana.ConsensusMana)
-//	if err != nil {
-//		return nil, nil, err
-//	}
-//	cbmvPast := baseManaVector.(*mana.ConsensusBaseManaVector)
-//	cachedObj := consensusBaseManaPastVectorMetadataStorage.Load([]byte(mana.ConsensusBaseManaPastVectorMetadataStorageKey))
-//	cachedMetadata := &mana.CachedConsensusBasePastManaVectorMetadata{CachedObject: cachedObj}
-//	defer cachedMetadata.Release()
-//
-//	if cachedMetadata.Exists() {
-//		metadata := cachedMetadata.Unwrap()
-//		if t.After(metadata.Timestamp) {
-//			consensusBaseManaPastVectorStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
-//				cachedPbm := &mana.CachedPersistableBaseMana{CachedObject: cachedObject}
-//				defer cachedPbm.Release()
-//				p := cachedPbm.Unwrap()
-//				err = cbmvPast.FromPersistable(p)
-//				if err != nil {
-//					manaLogger.Errorf("error while restoring %s mana vector from storage: %w", mana.ConsensusMana.String(), err)
-//					baseManaVector, _ := mana.NewBaseManaVector(mana.ConsensusMana)
-//					cbmvPast = baseManaVector.(*mana.ConsensusBaseManaVector)
-//					return false
-//				}
-//				return true
-//			})
-//		}
-//	}
-//
-//	var eventLogs mana.EventSlice
-//	consensusEventsLogStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
-//		cachedPe := &mana.CachedPersistableEvent{CachedObject: cachedObject}
-//		defer cachedPe.Release()
-//		pe := cachedPe.Unwrap()
-//		if pe.Time.After(t) {
-//			return true
-//		}
-//
-//		// already consumed in stored base mana vector.
-//		if cachedMetadata.Exists() && cbmvPast.Size() > 0 {
-//			metadata := cachedMetadata.Unwrap()
-//			if pe.Time.Before(metadata.Timestamp) {
-//				return true
-//			}
-//		}
-//
-//		var ev mana.Event
-//		ev, err = mana.FromPersistableEvent(pe)
-//		if err != nil {
-//			return false
-//		}
-//		eventLogs = append(eventLogs, ev)
-//		return true
-//	})
-//	if err != nil {
-//		return nil, nil, err
-//	}
-//	eventLogs.Sort()
-//	err = cbmvPast.BuildPastBaseVector(eventLogs, t)
-//	if err != nil {
-//		return nil, nil, err
-//	}
-//
-//	err = cbmvPast.UpdateAll(t)
-//	if err != nil {
-//		return nil, nil, err
-//	}
-//
-//	return cbmvPast, eventLogs, nil
-//}
-//
-//func getConsensusEventLogsStorageSize() uint32 {
-//	var size uint32
-//	consensusEventsLogStorage.ForEachKeyOnly(func(key []byte) bool {
-//		size++
-//		return true
-//	}, objectstorage.WithIteratorSkipCache(true))
-//	return size
-//}
-//
-//func pruneConsensusEventLogsStorage() {
-//	if consensusEventsLogsStorageSize.Load() < maxConsensusEventsInStorage {
-//		return
-//	}
-//
-//	cachedObj := consensusBaseManaPastVectorMetadataStorage.Load([]byte(mana.ConsensusBaseManaPastVectorMetadataStorageKey))
-//	cachedMetadata := &mana.CachedConsensusBasePastManaVectorMetadata{CachedObject: cachedObj}
-//	defer cachedMetadata.Release()
-//
-//	bmv, err := mana.NewBaseManaVector(mana.ConsensusMana)
-//	if err != nil {
-//		manaLogger.Errorf("error creating consensus base mana vector: %v", err)
-//		return
-//	}
-//	cbmvPast := bmv.(*mana.ConsensusBaseManaVector)
-//	if cachedMetadata.Exists() {
-//		consensusBaseManaPastVectorStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
-//			cachedPbm := &mana.CachedPersistableBaseMana{CachedObject: cachedObject}
-//			pbm := cachedPbm.Unwrap()
-//			if pbm != nil {
-//				err = cbmvPast.FromPersistable(pbm)
-//				if err != nil {
-//					return false
-//				}
-//			}
-//			return true
-//		})
-//		if err != nil {
-//			manaLogger.Errorf("error reading stored consensus base mana vector: %v", err)
-//			return
-//		}
-//	}
-//
-//	var eventLogs mana.EventSlice
-//	consensusEventsLogStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
-//		cachedPe := &mana.CachedPersistableEvent{CachedObject: cachedObject}
-//		defer cachedPe.Release()
-//		pe := cachedPe.Unwrap()
-//		var ev mana.Event
-//		ev, err = mana.FromPersistableEvent(pe)
-//
-//		if cachedMetadata.Exists() {
-//			metadata := cachedMetadata.Unwrap()
-//			if ev.Timestamp().Before(metadata.Timestamp) {
-//				manaLogger.Errorf("consensus event storage contains event that is older, than the stored metadata timestamp %s: %s", metadata.Timestamp, ev.String())
-//				return true
-//			}
-//		}
-//
-//		if err != nil {
-//			return false
-//		}
-//		eventLogs = append(eventLogs, ev)
-//		return true
-//	})
-//	if err != nil {
-//		manaLogger.Infof("error reading persistable events: %v", err)
-//		return
-//	}
-//	eventLogs.Sort()
-//	// we always want (maxConsensusEventsInStorage - slidingEventsInterval) number of events left
-//	deleteWindow := len(eventLogs) - (maxConsensusEventsInStorage - slidingEventsInterval)
-//	storageSizeInt := int(consensusEventsLogsStorageSize.Load())
-//	if deleteWindow < 0 || deleteWindow > storageSizeInt {
-//		manaLogger.Errorf("invalid delete window %d for storage size %d, max storage size %d and sliding interval %d",
-//			deleteWindow, storageSizeInt, maxConsensusEventsInStorage, slidingEventsInterval)
-//		return
-//	}
-//	// Make sure to take related events. (we take deleteWindow oldest events)
-//	// Ensures that related events (same time) are not split between different intervals.
-//	prev := eventLogs[deleteWindow-1]
-//	var i int
-//	for i = deleteWindow; i < len(eventLogs); i++ {
-//		if !eventLogs[i].Timestamp().Equal(prev.Timestamp()) {
-//			break
-//		}
-//		prev = eventLogs[i]
-//	}
-//	toBePrunedEvents := eventLogs[:i]
-//	// TODO: later, when we have epochs, we have to make sure that `t` is before the epoch to be "finalized" next.
-//	// Otherwise, we won't be able to calculate the consensus mana for that epoch because we already pruned the events
-//	// leading up to it.
-//	t := toBePrunedEvents[len(toBePrunedEvents)-1].Timestamp()
-//
-//	err = cbmvPast.BuildPastBaseVector(toBePrunedEvents, t)
-//	if err != nil {
-//		manaLogger.Errorf("error building past consensus base mana vector: %w", err)
-//		return
-//	}
-//
-//	// store cbmv
-//	if err = consensusBaseManaPastVectorStorage.Prune(); err != nil {
-//		manaLogger.Errorf("error pruning consensus base mana vector storage: %w", err)
-//		return
-//	}
-//	for _, p := range cbmvPast.ToPersistables() {
-//		consensusBaseManaPastVectorStorage.Store(p).Release()
-//	}
-//
-//	// store the metadata
-//	metadata := &mana.ConsensusBasePastManaVectorMetadata{
-//		Timestamp: t,
-//	}
-//
-//	if err = consensusBaseManaPastVectorMetadataStorage.Prune(); err != nil {
-//		manaLogger.Errorf("error pruning consensus base mana vector metadata storage: %w", err)
-//		return
-//	}
-//	consensusBaseManaPastVectorMetadataStorage.Store(metadata).Release()
-//
-//	var entriesToDelete [][]byte
-//	for _, ev := range toBePrunedEvents {
-//		entriesToDelete = append(entriesToDelete, ev.ToPersistable().ObjectStorageKey())
-//	}
-//	manaLogger.Infof("deleting %d events from consensus event storage", len(entriesToDelete))
-//	consensusEventsLogStorage.DeleteEntriesFromStore(entriesToDelete)
-//	consensusEventsLogsStorageSize.Sub(uint32(len(entriesToDelete)))
-//	manaLogger.Infof("%d events remaining in consensus event storage", consensusEventsLogsStorageSize.Load())
-//}
+// GetLoggedEvents gets the events logs for the node IDs and time frame specified. If none is specified, it returns the logs for all nodes.
+func GetLoggedEvents(identityIDs []identity.ID, startTime time.Time, endTime time.Time) (map[identity.ID]*EventsLogs, error) {
+	if consensusHistory == nil {
+		return nil, ErrConsensusHistoryDisabled
+	}
+	loggedEvents, err := consensusHistory.Events(identityIDs, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := make(map[identity.ID]*EventsLogs)
+	for _, ev := range loggedEvents {
+		switch typedEvent := ev.(type) {
+		case *mana.PledgedEvent:
+			if _, found := logs[typedEvent.NodeID]; !found {
+				logs[typedEvent.NodeID] = &EventsLogs{}
+			}
+			logs[typedEvent.NodeID].Pledge = append(logs[typedEvent.NodeID].Pledge, typedEvent)
+		case *mana.RevokedEvent:
+			if _, found := logs[typedEvent.NodeID]; !found {
+				logs[typedEvent.NodeID] = &EventsLogs{}
+			}
+			logs[typedEvent.NodeID].Revoke = append(logs[typedEvent.NodeID].Revoke, typedEvent)
+		default:
+			return nil, mana.ErrUnknownManaEvent
+		}
+	}
+	return logs, nil
+}
+
+// GetPastConsensusManaVectorMetadata gets the past consensus mana vector metadata.
+// It returns nil if the consensus mana history is disabled or empty.
+func GetPastConsensusManaVectorMetadata() *mana.ConsensusBasePastManaVectorMetadata {
+	if consensusHistory == nil {
+		return nil
+	}
+	return consensusHistory.Metadata()
+}
+
+// GetPastConsensusManaVector returns the consensus mana of all nodes at time t.
+func GetPastConsensusManaVector(t time.Time) (mana.NodeMap, error) {
+	if consensusHistory == nil {
+		return nil, ErrConsensusHistoryDisabled
+	}
+	return consensusHistory.ManaMapAt(t)
+}
+
+// GetPastConsensusMana returns the consensus mana of the given node at time t.
+func GetPastConsensusMana(nodeID identity.ID, t time.Time) (float64, error) {
+	if consensusHistory == nil {
+		return 0, ErrConsensusHistoryDisabled
+	}
+	return consensusHistory.ManaAt(nodeID, t)
+}
+
+// GetPastHighestConsensusManaNodes returns the n highest consensus mana nodes at time t in descending order.
+// If n is zero, it returns all nodes.
+func GetPastHighestConsensusManaNodes(t time.Time, n uint) ([]mana.Node, error) {
+	if consensusHistory == nil {
+		return nil, ErrConsensusHistoryDisabled
+	}
+	return consensusHistory.HighestManaNodesAt(t, n)
+}
 
 func cleanupManaVectors() {
 	vectorTypes := []mana.Type{mana.AccessMana, mana.ConsensusMana}
@@ -779,11 +640,11 @@ type AllowedPledge struct {
 	Allowed         set.Set
 }
 
-//// EventsLogs represents the events logs.
-//type EventsLogs struct {
-//	Pledge []*mana.PledgedEvent `json:"pledge"`
-//	Revoke []*mana.RevokedEvent `json:"revoke"`
-//}
+// EventsLogs represents the events logs.
+type EventsLogs struct {
+	Pledge []*mana.PledgedEvent `json:"pledge"`
+	Revoke []*mana.RevokedEvent `json:"revoke"`
+}
 
 // QueryAllowed returns if the mana plugin answers queries or not.
 func QueryAllowed() (allowed bool) {
@@ -45,6 +45,13 @@ type ManaParametersDefinition struct {
 	// EnableResearchVectors determines if research mana vector should be used or not. To use the Mana Research
 	// Grafana Dashboard, this should be set to true.
 	EnableResearchVectors bool `default:"false" usage:"enable mana research vectors"`
+	// EnableConsensusHistory determines if the history of the consensus mana vector is stored, so that past consensus
+	// mana can be queried.
+	EnableConsensusHistory bool `default:"true" usage:"enable the consensus mana history"`
+	// ConsensusHistoryCheckpointInterval defines the interval to store a checkpoint of the consensus mana vector.
+	ConsensusHistoryCheckpointInterval time.Duration `default:"1h" usage:"interval to store a checkpoint of the consensus mana vector"`
+	// ConsensusHistoryRetention defines how long the consensus mana history is kept.
+	ConsensusHistoryRetention time.Duration `default:"168h" usage:"how long the consensus mana history is kept"`
 	// PruneConsensusEventLogsInterval defines the interval to check and prune consensus event logs storage.
 	PruneConsensusEventLogsInterval time.Duration `default:"5m" usage:"interval to check and prune consensus event storage"`
 	// VectorsCleanupInterval defines the interval to clean empty mana nodes from the base mana vectors.
@@ -59,6 +66,32 @@ type ManaParametersDefinition struct {
 type RateSetterParametersDefinition struct {
 	// Initial defines the initial rate of rate setting.
 	Initial float64 `default:"100000" usage:"the initial rate of rate setting"`
+	// DequeueStrategy defines how the next message is selected from the priority lanes.
+	DequeueStrategy string `default:"strict" usage:"how messages are selected from the priority lanes (strict or weighted)"`
+	// ValueLaneSize defines the maximum size of the value transaction lane (in bytes).
+	ValueLaneSize int `default:"1310720" usage:"maximum size of the value transaction lane (in bytes)"`
+	// ValueLaneWeight defines the weight of the value transaction lane when using the weighted strategy.
+	ValueLaneWeight int `default:"8" usage:"weight of the value transaction lane"`
+	// ValueLaneDropOldest defines if the oldest messages are dropped when the value transaction lane is full.
+	ValueLaneDropOldest bool `default:"false" usage:"drop the oldest instead of the newest message when the value transaction lane is full"`
+	// FaucetLaneSize defines the maximum size of the faucet request lane (in bytes).
+	FaucetLaneSize int `default:"655360" usage:"maximum size of the faucet request lane (in bytes)"`
+	// FaucetLaneWeight defines the weight of the faucet request lane when using the weighted strategy.
+	FaucetLaneWeight int `default:"4" usage:"weight of the faucet request lane"`
+	// FaucetLaneDropOldest defines if the oldest messages are dropped when the faucet request lane is full.
+	FaucetLaneDropOldest bool `default:"false" usage:"drop the oldest instead of the newest message when the faucet request lane is full"`
+	// DataLaneSize defines the maximum size of the data message lane (in bytes).
+	DataLaneSize int `default:"655360" usage:"maximum size of the data message lane (in bytes)"`
+	// DataLaneWeight defines the weight of the data message lane when using the weighted strategy.
+	DataLaneWeight int `default:"2" usage:"weight of the data message lane"`
+	// DataLaneDropOldest defines if the oldest messages are dropped when the data message lane is full.
+	DataLaneDropOldest bool `default:"false" usage:"drop the oldest instead of the newest message when the data message lane is full"`
+	// ActivityLaneSize defines the maximum size of the activity and spam message lane (in bytes).
+	ActivityLaneSize int `default:"131072" usage:"maximum size of the activity and spam message lane (in bytes)"`
+	// ActivityLaneWeight defines the weight of the activity and spam message lane when using the weighted strategy.
+	ActivityLaneWeight int `default:"1" usage:"weight of the activity and spam message lane"`
+	// ActivityLaneDropOldest defines if the oldest messages are dropped when the activity and spam message lane is full.
+	ActivityLaneDropOldest bool `default:"true" usage:"drop the oldest instead of the newest message when the activity and spam message lane is full"`
 }
 
 // SchedulerParametersDefinition contains the definition of the parameters used by the Scheduler.
@@ -13,16 +13,19 @@ import (
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
 	"go.uber.org/dig"
 
 	"github.com/iotaledger/goshimmer/packages/consensus/finality"
 	"github.com/iotaledger/goshimmer/packages/consensus/otv"
+	db_pkg "github.com/iotaledger/goshimmer/packages/database"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/mana"
 	"github.com/iotaledger/goshimmer/packages/shutdown"
 	"github.com/iotaledger/goshimmer/packages/tangle"
 	"github.com/iotaledger/goshimmer/plugins/database"
+	logger_plugin "github.com/iotaledger/goshimmer/plugins/logger"
 	"github.com/iotaledger/goshimmer/plugins/remotelog"
 )
 
@@ -42,6 +45,9 @@ var (
 	// Plugin is the plugin instance of the messagelayer plugin.
 	Plugin *node.Plugin
 	deps   = new(dependencies)
+
+	// log is the logger of the plugin, whose level can be changed at runtime.
+	log *logger.Logger
 )
 
 type dependencies struct {
@@ -78,15 +84,25 @@ func init() {
 }
 
 func configure(plugin *node.Plugin) {
+	log = logger_plugin.NewLogger(plugin.Name)
+
+	if database.ConsistencyCheckRequired() {
+		checkDatabaseConsistency()
+	}
+
 	deps.Tangle.Events.Error.Attach(events.NewClosure(func(err error) {
-		plugin.LogError(err)
+		log.Error(err)
 	}))
 
-	// Messages created by the node need to pass through the normal flow.
-	deps.Tangle.MessageFactory.Events.MessageConstructed.Attach(events.NewClosure(func(message *tangle.Message) {
+	// Messages created by the node are issued at the rate of the rate setter and need to pass through the normal flow.
+	deps.Tangle.RateSetter.Events.MessageIssued.Attach(events.NewClosure(func(message *tangle.Message) {
 		deps.Tangle.ProcessGossipMessage(message.Bytes(), deps.Local.Peer)
 	}))
 
+	deps.Tangle.RateSetter.Events.MessageDiscarded.Attach(events.NewClosure(func(messageID tangle.MessageID) {
+		log.Warnw("Message discarded by the rate setter", logger_plugin.KeyMessageID, messageID.Base58())
+	}))
+
 	deps.Tangle.Storage.Events.MessageStored.Attach(events.NewClosure(func(messageID tangle.MessageID) {
 		deps.Tangle.Storage.Message(messageID).Consume(func(message *tangle.Message) {
 			deps.Tangle.WeightProvider.Update(message.IssuingTime(), identity.NewID(message.IssuerPublicKey()))
@@ -94,7 +110,7 @@ func configure(plugin *node.Plugin) {
 	}))
 
 	deps.Tangle.Parser.Events.MessageRejected.Attach(events.NewClosure(func(ev *tangle.MessageRejectedEvent, err error) {
-		plugin.LogInfof("message with %s rejected in Parser: %v", ev.Message.ID().Base58(), err)
+		log.Infow("Message rejected in Parser", logger_plugin.KeyMessageID, ev.Message.ID().Base58(), "err", err)
 	}))
 
 	deps.Tangle.Parser.Events.BytesRejected.Attach(events.NewClosure(func(ev *tangle.BytesRejectedEvent, err error) {
@@ -102,30 +118,30 @@ func configure(plugin *node.Plugin) {
 			return
 		}
 
-		plugin.LogWarnf("bytes rejected from peer %s: %v", ev.Peer.ID(), err)
+		log.Warnw("Bytes rejected in Parser", logger_plugin.KeyPeerID, ev.Peer.ID().String(), "err", err)
 	}))
 
 	deps.Tangle.Scheduler.Events.MessageDiscarded.Attach(events.NewClosure(func(messageID tangle.MessageID) {
-		plugin.LogInfof("message rejected in Scheduler: %s", messageID.Base58())
+		log.Infow("Message rejected in Scheduler", logger_plugin.KeyMessageID, messageID.Base58())
 	}))
 
 	deps.Tangle.Scheduler.Events.NodeBlacklisted.Attach(events.NewClosure(func(nodeID identity.ID) {
-		plugin.LogInfof("node %s is blacklisted in Scheduler", nodeID.String())
+		log.Infow("Node blacklisted in Scheduler", logger_plugin.KeyPeerID, nodeID.String())
 	}))
 
 	deps.Tangle.TimeManager.Events.SyncChanged.Attach(events.NewClosure(func(ev *tangle.SyncChangedEvent) {
-		plugin.LogInfo("Sync changed: ", ev.Synced)
+		log.Info("Sync changed: ", ev.Synced)
 		if ev.Synced {
 			// make sure that we are using the configured rate when synced
 			rate := deps.Tangle.Options.SchedulerParams.Rate
 			deps.Tangle.Scheduler.SetRate(rate)
-			plugin.LogInfof("Scheduler rate: %v", rate)
+			log.Infof("Scheduler rate: %v", rate)
 		} else {
 			// increase scheduler rate
 			rate := deps.Tangle.Options.SchedulerParams.Rate
 			rate -= rate / 2 // 50% increase
 			deps.Tangle.Scheduler.SetRate(rate)
-			plugin.LogInfof("Scheduler rate: %v", rate)
+			log.Infof("Scheduler rate: %v", rate)
 		}
 	}))
 
@@ -136,19 +152,19 @@ func configure(plugin *node.Plugin) {
 		if err != nil {
 			plugin.Panic("can not open snapshot file:", err)
 		}
-		plugin.LogInfof("reading snapshot from %s ...", Parameters.Snapshot.File)
+		log.Infof("reading snapshot from %s ...", Parameters.Snapshot.File)
 		if _, err := snapshot.ReadFrom(f); err != nil {
 			plugin.Panic("could not read snapshot file in message layer plugin:", err)
 		}
 		if err = deps.Tangle.LedgerState.LoadSnapshot(snapshot); err != nil {
 			plugin.Panic("fail to load snapshot file in message layer plugin:", err)
 		}
-		plugin.LogInfof("reading snapshot from %s ... done", Parameters.Snapshot.File)
+		log.Infof("reading snapshot from %s ... done", Parameters.Snapshot.File)
 
 		// Set flag that we read the snapshot already, so we don't have to do it again after a restart.
 		err = deps.Storage.Set(snapshotLoadedKey, kvstore.Value{})
 		if err != nil {
-			plugin.LogErrorf("could not store snapshot_loaded flag: %v")
+			log.Errorf("could not store snapshot_loaded flag: %v", err)
 		}
 	}
 
@@ -164,6 +180,21 @@ func run(*node.Plugin) {
 	}
 }
 
+// checkDatabaseConsistency runs a fast consistency check of the Tangle after an unclean shutdown and repairs or rolls
+// back the inconsistent entries.
+func checkDatabaseConsistency() {
+	log.Info("The database was not shutdown properly, checking its consistency...")
+	start := time.Now()
+	report := deps.Tangle.CheckConsistency(db_pkg.FastConsistencyCheck, true)
+	for _, issue := range report.Issues {
+		log.Warnf("Database inconsistency: %s", issue)
+	}
+	if unrepaired := report.Unrepaired(); len(unrepaired) > 0 {
+		log.Fatalf("The database contains %d inconsistencies that can't be repaired, please delete the database folder and restart.", len(unrepaired))
+	}
+	log.Infof("Checking the consistency of the database... done, took %v: %s", time.Since(start), report)
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region Tangle ///////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -186,7 +217,15 @@ func newTangle(deps tangledeps) *tangle.Tangle {
 			TotalAccessManaRetrieveFunc:       totalAccessManaRetriever,
 		}),
 		tangle.RateSetterConfig(tangle.RateSetterParams{
-			Initial: &RateSetterParameters.Initial,
+			Initial:         &RateSetterParameters.Initial,
+			DequeueStrategy: parseDequeueStrategy(RateSetterParameters.DequeueStrategy),
+			Lanes: map[tangle.MessagePriority]tangle.LaneParams{
+				tangle.ValuePriority:    laneParams(RateSetterParameters.ValueLaneSize, RateSetterParameters.ValueLaneWeight, RateSetterParameters.ValueLaneDropOldest),
+				tangle.FaucetPriority:   laneParams(RateSetterParameters.FaucetLaneSize, RateSetterParameters.FaucetLaneWeight, RateSetterParameters.FaucetLaneDropOldest),
+				tangle.DataPriority:     laneParams(RateSetterParameters.DataLaneSize, RateSetterParameters.DataLaneWeight, RateSetterParameters.DataLaneDropOldest),
+				tangle.ActivityPriority: laneParams(RateSetterParameters.ActivityLaneSize, RateSetterParameters.ActivityLaneWeight, RateSetterParameters.ActivityLaneDropOldest),
+			},
+			PriorityFunc: messagePriority,
 		}),
 		tangle.SyncTimeWindow(Parameters.TangleTimeWindow),
 		tangle.StartSynced(Parameters.StartSynced),
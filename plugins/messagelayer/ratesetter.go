@@ -0,0 +1,47 @@
+package messagelayer
+
+import (
+	"bytes"
+
+	"github.com/iotaledger/goshimmer/packages/faucet"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+)
+
+// lowPriorityData contains the data payloads issued by the activity plugin and the spammer.
+var lowPriorityData = [][]byte{[]byte("activity"), []byte("SPAM")}
+
+// messagePriority assigns the locally issued messages to the priority lanes of the rate setter.
+func messagePriority(message *tangle.Message) tangle.MessagePriority {
+	switch message.Payload().Type() {
+	case ledgerstate.TransactionType:
+		return tangle.ValuePriority
+	case faucet.Type:
+		return tangle.FaucetPriority
+	case payload.GenericDataPayloadType:
+		data := message.Payload().(*payload.GenericDataPayload).Blob()
+		for _, lowPriority := range lowPriorityData {
+			if bytes.Equal(data, lowPriority) {
+				return tangle.ActivityPriority
+			}
+		}
+	}
+	return tangle.DataPriority
+}
+
+func parseDequeueStrategy(strategy string) tangle.DequeueStrategy {
+	// unknown strategies fall back to strict priority
+	if strategy == "weighted" {
+		return tangle.WeightedDequeue
+	}
+	return tangle.StrictDequeue
+}
+
+func laneParams(maxSize, weight int, dropOldest bool) tangle.LaneParams {
+	params := tangle.LaneParams{MaxSize: maxSize, Weight: weight, DropPolicy: tangle.DropNewest}
+	if dropOldest {
+		params.DropPolicy = tangle.DropOldest
+	}
+	return params
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/iotaledger/goshimmer/packages/shutdown"
 	"github.com/iotaledger/goshimmer/packages/tangle"
 	"github.com/iotaledger/goshimmer/plugins/analysis/server"
+	logger_plugin "github.com/iotaledger/goshimmer/plugins/logger"
 )
 
 // PluginName is the name of the metrics plugin.
@@ -48,7 +49,7 @@ func init() {
 }
 
 func configure(_ *node.Plugin) {
-	log = logger.NewLogger(PluginName)
+	log = logger_plugin.NewLogger(PluginName)
 }
 
 func run(_ *node.Plugin) {
@@ -31,6 +31,11 @@ var (
 	nodeAccessMana *schedulerutils.AccessManaCache
 	// nodeQueueSizesMutex protect map from concurrent read/write.
 	nodeQueueSizesMutex syncutils.RWMutex
+
+	// rateSetterLaneSizes current size of each priority lane of the rate setter.
+	rateSetterLaneSizes map[string]int
+	// rateSetterLaneSizesMutex protect map from concurrent read/write.
+	rateSetterLaneSizesMutex syncutils.RWMutex
 )
 
 func measureSchedulerMetrics() {
@@ -48,6 +53,13 @@ func measureSchedulerMetrics() {
 	schedulerRate = deps.Tangle.Scheduler.Rate()
 	readyMessagesCount = deps.Tangle.Scheduler.ReadyMessagesCount()
 	totalMessagesCount = deps.Tangle.Scheduler.TotalMessagesCount()
+
+	rateSetterLaneSizesMutex.Lock()
+	defer rateSetterLaneSizesMutex.Unlock()
+	rateSetterLaneSizes = make(map[string]int)
+	for priority, size := range deps.Tangle.RateSetter.LaneSizes() {
+		rateSetterLaneSizes[priority.String()] = size
+	}
 }
 
 // SchedulerNodeQueueSizes current size of each node's queue.
@@ -102,3 +114,17 @@ func SchedulerBufferSize() int {
 func SchedulerRate() int64 {
 	return schedulerRate.Milliseconds()
 }
+
+// RateSetterLaneSizes current size of each priority lane of the rate setter.
+func RateSetterLaneSizes() map[string]int {
+	rateSetterLaneSizesMutex.RLock()
+	defer rateSetterLaneSizesMutex.RUnlock()
+
+	// copy the original map
+	clone := make(map[string]int)
+	for key, element := range rateSetterLaneSizes {
+		clone[key] = element
+	}
+
+	return clone
+}
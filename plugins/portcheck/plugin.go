@@ -14,6 +14,7 @@ import (
 	"github.com/iotaledger/goshimmer/plugins/autopeering"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/discovery"
 	"github.com/iotaledger/goshimmer/plugins/banner"
+	logger_plugin "github.com/iotaledger/goshimmer/plugins/logger"
 )
 
 // PluginName is the name of the port check plugin.
@@ -39,7 +40,7 @@ func init() {
 }
 
 func configure(_ *node.Plugin) {
-	log = logger.NewLogger(PluginName)
+	log = logger_plugin.NewLogger(PluginName)
 }
 
 func run(*node.Plugin) {
@@ -10,6 +10,19 @@ import (
 type ParametersDefinition struct {
 	// Difficulty defines the PoW difficulty.
 	Difficulty int `default:"21" usage:"PoW difficulty"`
+	// Adaptive defines whether the required difficulty grows with the recent issuance rate of the issuer.
+	Adaptive bool `default:"false" usage:"whether the PoW difficulty grows with the recent issuance rate of the issuer"`
+	// AdaptiveWindow defines the time window in which the previous messages of an issuer in the past cone are counted.
+	// It is at least the maximum age of the parents of a message (30m).
+	AdaptiveWindow time.Duration `default:"30m" usage:"time window in which the previous messages of an issuer in the past cone are counted for the adaptive difficulty, at least the maximum parent age"`
+	// AdaptiveRate defines the number of messages an issuer can issue within the window before the difficulty increases.
+	AdaptiveRate int `default:"300" usage:"number of messages within the window after which the adaptive difficulty increases by one"`
+	// MaxDifficulty defines the maximum adaptive PoW difficulty.
+	MaxDifficulty int `default:"28" usage:"maximum adaptive PoW difficulty"`
+	// ManaThreshold defines the consensus mana from which issuers are only required to meet the base difficulty.
+	ManaThreshold float64 `default:"0" usage:"consensus mana from which issuers are only required to meet the base difficulty, 0 disables the fallback"`
+	// ManaEpoch defines the duration of the epochs at whose start the consensus mana of the mana fallback is taken.
+	ManaEpoch time.Duration `default:"1h" usage:"duration of the epochs, the consensus mana at the start of the previous epoch is used for the mana fallback"`
 	// NumThreads defines how many threaded workers are used to do PoW.
 	NumThreads int `default:"1" usage:"number of threads used to do the PoW"`
 	// Timeout defines the maximum allow time to perform PoW.
@@ -1,11 +1,13 @@
 package pow
 
 import (
-	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
 	"go.uber.org/dig"
 
+	"github.com/iotaledger/goshimmer/packages/pow"
 	"github.com/iotaledger/goshimmer/packages/tangle"
+	logger_plugin "github.com/iotaledger/goshimmer/plugins/logger"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
 )
 
 // PluginName is the name of the PoW plugin.
@@ -30,7 +32,7 @@ func init() {
 
 func configure(plugin *node.Plugin) {
 	// assure that the logger is available
-	log := logger.NewLogger(PluginName)
+	log := logger_plugin.NewLogger(PluginName)
 
 	if node.IsSkipped(deps.MessagelayerPlugin) {
 		log.Infof("%s is disabled; skipping %s\n", deps.MessagelayerPlugin.Name, PluginName)
@@ -43,6 +45,26 @@ func configure(plugin *node.Plugin) {
 	log.Infof("%s started: difficult=%d", PluginName, difficulty)
 
 	deps.Tangle.Parser.AddBytesFilter(tangle.NewPowFilter(worker, difficulty))
+	if Parameters.Adaptive {
+		configureAdaptiveDifficulty(worker)
+	}
 	deps.Tangle.MessageFactory.SetWorker(tangle.WorkerFunc(DoPOW))
 	deps.Tangle.MessageFactory.SetTimeout(timeout)
 }
+
+// configureAdaptiveDifficulty creates the adaptive difficulty, which the Tangle checks once the messages are solid. The
+// mana fallback uses the consensus mana history of the mana plugin.
+func configureAdaptiveDifficulty(worker *pow.Worker) {
+	adaptiveDifficulty = tangle.NewAdaptivePowDifficulty(deps.Tangle, worker, tangle.AdaptivePowParams{
+		BaseDifficulty:            difficulty,
+		MaxDifficulty:             Parameters.MaxDifficulty,
+		Window:                    Parameters.AdaptiveWindow,
+		Rate:                      Parameters.AdaptiveRate,
+		ManaThreshold:             Parameters.ManaThreshold,
+		ManaEpoch:                 Parameters.ManaEpoch,
+		ConsensusManaRetrieveFunc: messagelayer.GetPastConsensusMana,
+	})
+	deps.Tangle.AdaptivePowDifficulty = adaptiveDifficulty
+
+	log.Infof("adaptive difficulty enabled: window=%v, rate=%d, max difficulty=%d, mana threshold=%v", Parameters.AdaptiveWindow, Parameters.AdaptiveRate, Parameters.MaxDifficulty, Parameters.ManaThreshold)
+}
@@ -12,6 +12,8 @@ import (
 	_ "golang.org/x/crypto/blake2b" // required by crypto.BLAKE2b_512
 
 	"github.com/iotaledger/goshimmer/packages/pow"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	logger_plugin "github.com/iotaledger/goshimmer/plugins/logger"
 )
 
 // ErrMessageTooSmall is returned when the message is smaller than the 8-byte nonce.
@@ -33,12 +35,15 @@ var (
 
 	workerOnce sync.Once
 	worker     *pow.Worker
+
+	// adaptiveDifficulty is only set if the adaptive difficulty is enabled
+	adaptiveDifficulty *tangle.AdaptivePowDifficulty
 )
 
 // Worker returns the PoW worker instance of the PoW plugin.
 func Worker() *pow.Worker {
 	workerOnce.Do(func() {
-		log = logger.NewLogger(PluginName)
+		log = logger_plugin.NewLogger(PluginName)
 		// load the parameters
 		difficulty = Parameters.Difficulty
 		numWorkers = Parameters.NumThreads
@@ -64,13 +69,27 @@ func DoPOW(msg []byte) (uint64, error) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), parentsRefreshInterval)
 	defer cancel()
-	nonce, err := worker.Mine(ctx, content[:len(content)-pow.NonceBytes], difficulty)
+	nonce, err := worker.Mine(ctx, content[:len(content)-pow.NonceBytes], requiredDifficulty(msg))
 
 	// log.Debugw("PoW stopped", "nonce", nonce, "err", err)
 
 	return nonce, err
 }
 
+// requiredDifficulty returns the difficulty the given message needs to be accepted by the other nodes.
+func requiredDifficulty(msg []byte) int {
+	if adaptiveDifficulty == nil {
+		return difficulty
+	}
+
+	message, _, err := tangle.MessageFromBytes(msg)
+	if err != nil {
+		log.Warnf("failed to parse message to determine the adaptive difficulty: %s", err)
+		return difficulty
+	}
+	return adaptiveDifficulty.RequiredDifficulty(message)
+}
+
 // powData returns the bytes over which PoW should be computed.
 func powData(msgBytes []byte) ([]byte, error) {
 	contentLength := len(msgBytes) - ed25519.SignatureSize
@@ -8,6 +8,8 @@ import (
 
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
+
+	logger_plugin "github.com/iotaledger/goshimmer/plugins/logger"
 )
 
 // PluginName is the name of the profiling plugin.
@@ -24,7 +26,7 @@ func init() {
 }
 
 func configure(_ *node.Plugin) {
-	log = logger.NewLogger(PluginName)
+	log = logger_plugin.NewLogger(PluginName)
 }
 
 func run(_ *node.Plugin) {
@@ -19,6 +19,9 @@ var (
 	// Autopeering related metrics.
 	nodesNeighborCount *prometheus.GaugeVec
 	networkDiameter    prometheus.Gauge
+
+	// Analysis server related metrics.
+	rejectedAnalysisPackets *prometheus.GaugeVec
 )
 
 func registerClientsMetrics() {
@@ -64,10 +67,21 @@ func registerClientsMetrics() {
 		Help: "Autopeering network diameter",
 	})
 
+	rejectedAnalysisPackets = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "global_rejected_analysis_packets",
+			Help: "Number of packets rejected by the analysis server labeled with the reason of the rejection",
+		},
+		[]string{
+			"reason",
+		},
+	)
+
 	registry.MustRegister(nodesInfoCPU)
 	registry.MustRegister(nodesInfoMemory)
 	registry.MustRegister(nodesNeighborCount)
 	registry.MustRegister(networkDiameter)
+	registry.MustRegister(rejectedAnalysisPackets)
 
 	addCollect(collectNodesInfo)
 }
@@ -100,4 +114,8 @@ func collectNodesInfo() {
 	}
 
 	networkDiameter.Set(float64(metrics.NetworkDiameter()))
+
+	for reason, count := range analysisserver.RejectedPackets() {
+		rejectedAnalysisPackets.WithLabelValues(reason).Set(float64(count))
+	}
 }
@@ -12,6 +12,13 @@ var (
 	gossipOutboundPackets    prometheus.Gauge
 	autopeeringInboundBytes  prometheus.Gauge
 	autopeeringOutboundBytes prometheus.Gauge
+
+	gossipAnnouncedMessages      prometheus.Gauge
+	gossipAnnouncedBytes         prometheus.Gauge
+	gossipAnnouncementBytes      prometheus.Gauge
+	gossipRequestedAnnouncements prometheus.Gauge
+	gossipRequestedBytes         prometheus.Gauge
+	gossipSavedBytes             prometheus.Gauge
 )
 
 func registerNetworkMetrics() {
@@ -35,6 +42,30 @@ func registerNetworkMetrics() {
 		Name: "traffic_analysis_outbound_bytes",
 		Help: "traffic_Analysis client TX network traffic [bytes].",
 	})
+	gossipAnnouncedMessages = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "traffic_gossip_announced_messages",
+		Help: "traffic_gossip messages announced to neighbors instead of being sent [number].",
+	})
+	gossipAnnouncedBytes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "traffic_gossip_announced_bytes",
+		Help: "traffic_gossip TX network traffic that sending the announced messages would have caused [bytes].",
+	})
+	gossipAnnouncementBytes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "traffic_gossip_announcement_bytes",
+		Help: "traffic_gossip TX network traffic of the message announcements [bytes].",
+	})
+	gossipRequestedAnnouncements = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "traffic_gossip_requested_announcements",
+		Help: "traffic_gossip announced messages that were missing and requested from the neighbor [number].",
+	})
+	gossipRequestedBytes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "traffic_gossip_requested_bytes",
+		Help: "traffic_gossip TX network traffic of messages sent on request of a neighbor [bytes].",
+	})
+	gossipSavedBytes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "traffic_gossip_saved_bytes",
+		Help: "traffic_gossip TX network traffic saved by announcing messages instead of sending them [bytes].",
+	})
 
 	if deps.AutoPeeringConnMetric != nil {
 		registry.MustRegister(autopeeringInboundBytes)
@@ -43,6 +74,14 @@ func registerNetworkMetrics() {
 	registry.MustRegister(analysisOutboundBytes)
 	registry.MustRegister(gossipInboundPackets)
 	registry.MustRegister(gossipOutboundPackets)
+	if deps.GossipMgr != nil {
+		registry.MustRegister(gossipAnnouncedMessages)
+		registry.MustRegister(gossipAnnouncedBytes)
+		registry.MustRegister(gossipAnnouncementBytes)
+		registry.MustRegister(gossipRequestedAnnouncements)
+		registry.MustRegister(gossipRequestedBytes)
+		registry.MustRegister(gossipSavedBytes)
+	}
 
 	addCollect(collectNetworkMetrics)
 }
@@ -55,4 +94,13 @@ func collectNetworkMetrics() {
 	analysisOutboundBytes.Set(float64(metrics.AnalysisOutboundBytes()))
 	gossipInboundPackets.Set(float64(metrics.GossipInboundPackets()))
 	gossipOutboundPackets.Set(float64(metrics.GossipOutboundPackets()))
+	if deps.GossipMgr != nil {
+		announcementMetrics := deps.GossipMgr.AnnouncementMetrics()
+		gossipAnnouncedMessages.Set(float64(announcementMetrics.AnnouncedMessages))
+		gossipAnnouncedBytes.Set(float64(announcementMetrics.AnnouncedBytes))
+		gossipAnnouncementBytes.Set(float64(announcementMetrics.AnnouncementBytes))
+		gossipRequestedAnnouncements.Set(float64(announcementMetrics.RequestedAnnouncements))
+		gossipRequestedBytes.Set(float64(announcementMetrics.RequestedBytes))
+		gossipSavedBytes.Set(float64(announcementMetrics.BytesSaved()))
+	}
 }
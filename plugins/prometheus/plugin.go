@@ -18,6 +18,7 @@ import (
 	"github.com/iotaledger/goshimmer/packages/gossip"
 	"github.com/iotaledger/goshimmer/packages/net"
 	"github.com/iotaledger/goshimmer/packages/shutdown"
+	logger_plugin "github.com/iotaledger/goshimmer/plugins/logger"
 	"github.com/iotaledger/goshimmer/plugins/metrics"
 )
 
@@ -45,7 +46,7 @@ type dependencies struct {
 }
 
 func configure(plugin *node.Plugin) {
-	log = logger.NewLogger(plugin.Name)
+	log = logger_plugin.NewLogger(plugin.Name)
 
 	if Parameters.WorkerpoolMetrics {
 		registerWorkerpoolMetrics()
@@ -14,6 +14,7 @@ var (
 	totalMessagesCount prometheus.Gauge
 	bufferSize         prometheus.Gauge
 	maxBufferSize      prometheus.Gauge
+	rateSetterLaneSize *prometheus.GaugeVec
 )
 
 func registerSchedulerMetrics() {
@@ -58,6 +59,14 @@ func registerSchedulerMetrics() {
 		Help: "maximum number of bytes that can be stored in the buffer.",
 	})
 
+	rateSetterLaneSize = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ratesetter_lane_size",
+			Help: "current size of each priority lane of the rate setter (in bytes).",
+		}, []string{
+			"lane",
+		})
+
 	registry.MustRegister(queueSizePerNode)
 	registry.MustRegister(manaAmountPerNode)
 	registry.MustRegister(schedulerRate)
@@ -65,6 +74,7 @@ func registerSchedulerMetrics() {
 	registry.MustRegister(totalMessagesCount)
 	registry.MustRegister(bufferSize)
 	registry.MustRegister(maxBufferSize)
+	registry.MustRegister(rateSetterLaneSize)
 
 	addCollect(collectSchedulerMetrics)
 }
@@ -85,4 +95,7 @@ func collectSchedulerMetrics() {
 	totalMessagesCount.Set(float64(metrics.SchedulerTotalBufferMessagesCount()))
 	bufferSize.Set(float64(metrics.SchedulerBufferSize()))
 	maxBufferSize.Set(float64(metrics.SchedulerMaxBufferSize()))
+	for lane, size := range metrics.RateSetterLaneSizes() {
+		rateSetterLaneSize.WithLabelValues(lane).Set(float64(size))
+	}
 }
@@ -34,4 +34,14 @@ func collectWorkerpoolMetrics() {
 	workerpools.WithLabelValues(
 		name,
 	).Set(float64(load))
+
+	name, load = deps.GossipMgr.MessageAnnouncementWorkerPoolStatus()
+	workerpools.WithLabelValues(
+		name,
+	).Set(float64(load))
+
+	name, load = deps.GossipMgr.SyncWorkerPoolStatus()
+	workerpools.WithLabelValues(
+		name,
+	).Set(float64(load))
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/iotaledger/goshimmer/packages/shutdown"
 	"github.com/iotaledger/goshimmer/packages/spammer"
 	"github.com/iotaledger/goshimmer/packages/tangle"
+	logger_plugin "github.com/iotaledger/goshimmer/plugins/logger"
 )
 
 var messageSpammer *spammer.Spammer
@@ -38,7 +39,7 @@ func init() {
 }
 
 func configure(_ *node.Plugin) {
-	log = logger.NewLogger(PluginName)
+	log = logger_plugin.NewLogger(PluginName)
 
 	messageSpammer = spammer.New(deps.Tangle.IssuePayload, log)
 	deps.Server.GET("spammer", handleRequest)
@@ -12,6 +12,7 @@ import (
 	"github.com/iotaledger/goshimmer/packages/tangle"
 	"github.com/iotaledger/goshimmer/packages/txstream/server"
 	"github.com/iotaledger/goshimmer/packages/txstream/tangleledger"
+	logger_plugin "github.com/iotaledger/goshimmer/plugins/logger"
 )
 
 const (
@@ -35,7 +36,7 @@ func init() {
 }
 
 func configure(_ *node.Plugin) {
-	log = logger.NewLogger(pluginName)
+	log = logger_plugin.NewLogger(pluginName)
 }
 
 func run(_ *node.Plugin) {
@@ -4,6 +4,7 @@ import (
 	"github.com/iotaledger/hive.go/node"
 
 	"github.com/iotaledger/goshimmer/plugins/webapi"
+	"github.com/iotaledger/goshimmer/plugins/webapi/admin"
 	"github.com/iotaledger/goshimmer/plugins/webapi/autopeering"
 	"github.com/iotaledger/goshimmer/plugins/webapi/data"
 	"github.com/iotaledger/goshimmer/plugins/webapi/drng"
@@ -16,12 +17,14 @@ import (
 	"github.com/iotaledger/goshimmer/plugins/webapi/snapshot"
 	drngTools "github.com/iotaledger/goshimmer/plugins/webapi/tools/drng"
 	msgTools "github.com/iotaledger/goshimmer/plugins/webapi/tools/message"
+	"github.com/iotaledger/goshimmer/plugins/webapi/tools/remotepow"
 	"github.com/iotaledger/goshimmer/plugins/webapi/weightprovider"
 )
 
 // WebAPI contains the webapi endpoint plugins of a GoShimmer node.
 var WebAPI = node.Plugins(
 	webapi.Plugin,
+	admin.Plugin,
 	data.Plugin,
 	drng.Plugin,
 	faucet.Plugin,
@@ -31,6 +34,7 @@ var WebAPI = node.Plugins(
 	info.Plugin,
 	drngTools.Plugin,
 	msgTools.Plugin,
+	remotepow.Plugin,
 	mana.Plugin,
 	ledgerstate.Plugin,
 	snapshot.Plugin,
@@ -0,0 +1,100 @@
+// Package admin is a plugin that provides web API endpoints to administrate the node.
+package admin
+
+import (
+	"net/http"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/iotaledger/hive.go/node"
+	"github.com/labstack/echo"
+	"go.uber.org/dig"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/plugins/config"
+	"github.com/iotaledger/goshimmer/plugins/logger"
+)
+
+// PluginName is the name of the web API admin endpoint plugin.
+const PluginName = "WebAPIAdminEndpoint"
+
+var (
+	// Plugin is the plugin instance of the web API admin endpoint plugin.
+	Plugin *node.Plugin
+	deps   = new(dependencies)
+)
+
+type dependencies struct {
+	dig.In
+
+	Server *echo.Echo
+}
+
+func init() {
+	Plugin = node.NewPlugin(PluginName, deps, node.Enabled, configure)
+}
+
+func configure(_ *node.Plugin) {
+	deps.Server.POST("admin/config/reload", reloadConfig)
+	deps.Server.GET("admin/log/levels", getLogLevels)
+	deps.Server.POST("admin/log/levels", setLogLevel)
+}
+
+// reloadConfig reloads the configuration of the node.
+func reloadConfig(c echo.Context) error {
+	result, err := config.Reload()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.ConfigReloadResponse{Error: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, jsonmodels.ConfigReloadResponse{
+		Reloaded: result.Reloaded,
+		Ignored:  result.Ignored,
+	})
+}
+
+// getLogLevels returns the default log level and the log levels of the plugins that override it.
+func getLogLevels(c echo.Context) error {
+	return c.JSON(http.StatusOK, logLevelsResponse())
+}
+
+// setLogLevel changes the default log level or the log level of a single plugin. Only the levels of the plugins that
+// use a logger of the logger plugin can be changed.
+func setLogLevel(c echo.Context) error {
+	var request jsonmodels.LogLevelRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.LogLevelsResponse{Error: err.Error()})
+	}
+
+	if request.Level == "" {
+		if request.Plugin == "" {
+			return c.JSON(http.StatusBadRequest, jsonmodels.LogLevelsResponse{Error: "the default log level must not be empty"})
+		}
+		if err := logger.ResetPluginLevel(request.Plugin); err != nil {
+			return c.JSON(http.StatusBadRequest, jsonmodels.LogLevelsResponse{Error: err.Error()})
+		}
+		return c.JSON(http.StatusOK, logLevelsResponse())
+	}
+
+	var level zapcore.Level
+	if err := level.UnmarshalText([]byte(request.Level)); err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.LogLevelsResponse{Error: err.Error()})
+	}
+	if request.Plugin == "" {
+		logger.SetLevel(level)
+	} else if err := logger.SetPluginLevel(request.Plugin, level); err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.LogLevelsResponse{Error: err.Error()})
+	}
+	return c.JSON(http.StatusOK, logLevelsResponse())
+}
+
+func logLevelsResponse() jsonmodels.LogLevelsResponse {
+	pluginLevels := make(map[string]string)
+	for pluginName, level := range logger.PluginLevels() {
+		pluginLevels[pluginName] = level.String()
+	}
+	return jsonmodels.LogLevelsResponse{
+		Level:        logger.Level().String(),
+		PluginLevels: pluginLevels,
+	}
+}
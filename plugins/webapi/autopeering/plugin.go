@@ -9,10 +9,12 @@ import (
 	"github.com/iotaledger/hive.go/autopeering/peer"
 	"github.com/iotaledger/hive.go/autopeering/peer/service"
 	"github.com/iotaledger/hive.go/autopeering/selection"
+	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/node"
 	"github.com/labstack/echo"
 	"go.uber.org/dig"
 
+	"github.com/iotaledger/goshimmer/packages/gossip"
 	"github.com/iotaledger/goshimmer/packages/jsonmodels"
 )
 
@@ -31,6 +33,7 @@ type dependencies struct {
 	Server    *echo.Echo
 	Selection *selection.Protocol `optional:"true"`
 	Discover  *discover.Protocol  `optional:"true"`
+	GossipMgr *gossip.Manager     `optional:"true"`
 }
 
 func init() {
@@ -56,11 +59,15 @@ func getNeighbors(c echo.Context) error {
 	}
 
 	if deps.Selection != nil {
+		var qualities map[identity.ID]gossip.NeighborQuality
+		if deps.GossipMgr != nil {
+			qualities = deps.GossipMgr.NeighborQualities()
+		}
 		for _, p := range deps.Selection.GetOutgoingNeighbors() {
-			chosen = append(chosen, createNeighborFromPeer(p))
+			chosen = append(chosen, createNeighborWithQuality(p, qualities))
 		}
 		for _, p := range deps.Selection.GetIncomingNeighbors() {
-			accepted = append(accepted, createNeighborFromPeer(p))
+			accepted = append(accepted, createNeighborWithQuality(p, qualities))
 		}
 	}
 
@@ -77,6 +84,23 @@ func createNeighborFromPeer(p *peer.Peer) jsonmodels.Neighbor {
 	return n
 }
 
+func createNeighborWithQuality(p *peer.Peer, qualities map[identity.ID]gossip.NeighborQuality) jsonmodels.Neighbor {
+	n := createNeighborFromPeer(p)
+	if quality, ok := qualities[p.ID()]; ok {
+		n.Quality = &jsonmodels.NeighborQuality{
+			DeliveredMessages: quality.DeliveredMessages,
+			FirstSeenMessages: quality.FirstSeenMessages,
+			FirstSeenShare:    quality.FirstSeenShare,
+			AverageLatency:    quality.AverageLatency.Milliseconds(),
+			Requests:          quality.Requests,
+			AnsweredRequests:  quality.AnsweredRequests,
+			Scored:            quality.Scored,
+			Score:             quality.Score,
+		}
+	}
+	return n
+}
+
 func getServices(p *peer.Peer) []jsonmodels.PeerService {
 	var services []jsonmodels.PeerService
 
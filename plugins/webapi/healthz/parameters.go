@@ -0,0 +1,24 @@
+package healthz
+
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/configuration"
+)
+
+// ParametersDefinition contains the definition of the parameters used by the healthz plugin.
+type ParametersDefinition struct {
+	// MinNeighbors defines the number of gossip neighbors the node needs to be ready.
+	MinNeighbors int `default:"1" usage:"the number of gossip neighbors the node needs to be ready"`
+	// MaxSchedulerBufferFill defines the fill level of the scheduler buffer above which the node is not ready.
+	MaxSchedulerBufferFill float64 `default:"0.9" usage:"the fill level of the scheduler buffer (0-1) above which the node is not ready"`
+	// MaxTimeOffset defines the offset of the local clock to the network time above which the node is not ready.
+	MaxTimeOffset time.Duration `default:"5s" usage:"the offset of the local clock to the network time above which the node is not ready"`
+}
+
+// Parameters contains the configuration parameters of the healthz plugin.
+var Parameters = &ParametersDefinition{}
+
+func init() {
+	configuration.BindParameters(Parameters, "healthz")
+}
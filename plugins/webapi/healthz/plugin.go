@@ -2,15 +2,25 @@ package healthz
 
 import (
 	"context"
+	"math"
 	"net/http"
 
+	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/kvstore"
 	"github.com/iotaledger/hive.go/node"
 	"github.com/iotaledger/hive.go/typeutils"
 	"github.com/labstack/echo"
 	"go.uber.org/dig"
 
+	"github.com/iotaledger/goshimmer/packages/clock"
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/gossip"
+	"github.com/iotaledger/goshimmer/packages/health"
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
 	"github.com/iotaledger/goshimmer/packages/shutdown"
+	"github.com/iotaledger/goshimmer/packages/tangle"
 )
 
 // PluginName is the name of the web API healthz endpoint plugin.
@@ -19,7 +29,11 @@ const PluginName = "WebAPIHealthzEndpoint"
 type dependencies struct {
 	dig.In
 
-	Server *echo.Echo
+	Server       *echo.Echo
+	HealthChecks *health.Registry
+	Store        kvstore.KVStore `optional:"true"`
+	Tangle       *tangle.Tangle  `optional:"true"`
+	GossipMgr    *gossip.Manager `optional:"true"`
 }
 
 var (
@@ -28,14 +42,28 @@ var (
 	deps   = new(dependencies)
 
 	healthy typeutils.AtomicBool
+
+	// probeKey is the key that is written to check whether the database is writable.
+	probeKey = []byte("healthz_probe")
 )
 
 func init() {
 	Plugin = node.NewPlugin(PluginName, deps, node.Enabled, configure, run)
+
+	Plugin.Events.Init.Attach(events.NewClosure(func(_ *node.Plugin, container *dig.Container) {
+		if err := container.Provide(health.NewRegistry); err != nil {
+			Plugin.Panic(err)
+		}
+	}))
 }
 
 func configure(_ *node.Plugin) {
-	deps.Server.GET("healthz", getHealthz)
+	registerChecks()
+
+	// healthz is kept as an alias of the liveness check
+	deps.Server.GET("healthz", getLiveness)
+	deps.Server.GET("healthz/live", getLiveness)
+	deps.Server.GET("healthz/ready", getReadiness)
 }
 
 func run(plugin *node.Plugin) {
@@ -53,9 +81,91 @@ func worker(ctx context.Context) {
 	<-ctx.Done()
 }
 
-func getHealthz(c echo.Context) error {
+// registerChecks registers the readiness checks of the core components that are available.
+func registerChecks() {
+	deps.HealthChecks.Register("plugins", func() error {
+		if !healthy.IsSet() {
+			return errors.New("plugins are not running")
+		}
+		return nil
+	})
+
+	deps.HealthChecks.Register("clock", func() error {
+		if offset := clock.Offset(); math.Abs(float64(offset)) > float64(Parameters.MaxTimeOffset) {
+			return errors.Errorf("local clock is off by %v", offset)
+		}
+		return nil
+	})
+
+	if deps.Store != nil {
+		probeStore := deps.Store.WithRealm([]byte{database.PrefixHealth})
+		deps.HealthChecks.Register("database", func() error {
+			if err := probeStore.Set(probeKey, []byte{}); err != nil {
+				return errors.Errorf("database is not writable: %w", err)
+			}
+			if err := probeStore.Delete(probeKey); err != nil {
+				return errors.Errorf("database is not writable: %w", err)
+			}
+			return nil
+		})
+	}
+
+	if deps.Tangle != nil {
+		deps.HealthChecks.Register("tangle", func() error {
+			if !deps.Tangle.TimeManager.Synced() {
+				return errors.New("tangle is not synced")
+			}
+			return nil
+		})
+		deps.HealthChecks.Register("scheduler", func() error {
+			maxBufferSize := deps.Tangle.Scheduler.MaxBufferSize()
+			if maxBufferSize == 0 {
+				return nil
+			}
+			if fill := float64(deps.Tangle.Scheduler.BufferSize()) / float64(maxBufferSize); fill > Parameters.MaxSchedulerBufferFill {
+				return errors.Errorf("scheduler buffer is %.0f%% full", fill*100)
+			}
+			return nil
+		})
+	}
+
+	if deps.GossipMgr != nil {
+		deps.HealthChecks.Register("neighbors", func() error {
+			if neighbors := len(deps.GossipMgr.AllNeighbors()); neighbors < Parameters.MinNeighbors {
+				return errors.Errorf("%d of %d required neighbors connected", neighbors, Parameters.MinNeighbors)
+			}
+			return nil
+		})
+	}
+}
+
+// getLiveness returns whether the node is running.
+func getLiveness(c echo.Context) error {
 	if !healthy.IsSet() {
 		return c.NoContent(http.StatusServiceUnavailable)
 	}
 	return c.NoContent(http.StatusOK)
 }
+
+// getReadiness returns whether the node is ready to serve requests together with the results of the readiness checks
+// of its components.
+func getReadiness(c echo.Context) error {
+	ready, results := deps.HealthChecks.Evaluate()
+
+	response := jsonmodels.ReadinessResponse{
+		Ready:  ready,
+		Checks: make([]jsonmodels.ReadinessCheck, 0, len(results)),
+	}
+	for _, result := range results {
+		check := jsonmodels.ReadinessCheck{Name: result.Name, Ready: result.Ready()}
+		if !result.Ready() {
+			check.Error = result.Err.Error()
+		}
+		response.Checks = append(response.Checks, check)
+	}
+
+	if !ready {
+		return c.JSON(http.StatusServiceUnavailable, response)
+	}
+	return c.JSON(http.StatusOK, response)
+}
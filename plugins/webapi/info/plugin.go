@@ -11,6 +11,7 @@ import (
 	"github.com/mr-tron/base58/base58"
 	"go.uber.org/dig"
 
+	"github.com/iotaledger/goshimmer/packages/gossip"
 	"github.com/iotaledger/goshimmer/packages/jsonmodels"
 	"github.com/iotaledger/goshimmer/packages/mana"
 	"github.com/iotaledger/goshimmer/packages/tangle"
@@ -27,9 +28,10 @@ const PluginName = "WebAPIInfoEndpoint"
 type dependencies struct {
 	dig.In
 
-	Server *echo.Echo
-	Local  *peer.Local
-	Tangle *tangle.Tangle
+	Server    *echo.Echo
+	Local     *peer.Local
+	Tangle    *tangle.Tangle
+	GossipMgr *gossip.Manager `optional:"true"`
 }
 
 var (
@@ -54,7 +56,19 @@ func configure(_ *node.Plugin) {
 // 		"messageID":"24Uq4UFQ7p5oLyjuXX32jHhNreo5hY9eo8Awh36RhdTHCwFMtct3SE2rhe3ceYz6rjKDjBs3usoHS3ujFEabP5ri",
 // 		"time":1595528075204868900,
 // 		"synced":true
-// }
+// },
+//	"bulkSync":{
+// 		"running":false,
+// 		"startTime":1595527975,
+// 		"endTime":1595528015,
+// 		"peers":2,
+// 		"missingMessages":0,
+// 		"inFlightRequests":0,
+// 		"requestedMessages":1280,
+// 		"receivedMessages":48231,
+// 		"deliveredMessages":48231,
+// 		"failedMessages":0
+// },
 // 	"identityID":"5bf4aa1d6c47e4ce",
 // 	"publickey":"CjUsn86jpFHWnSCx3NhWfU4Lk16mDdy1Hr7ERSTv3xn9",
 // 	"enabledplugins":[
@@ -128,10 +142,16 @@ func getInfo(c echo.Context) error {
 		nodeQueueSizes[nodeID.String()] = size
 	}
 
+	laneSizes := make(map[string]int)
+	for priority, size := range deps.Tangle.RateSetter.LaneSizes() {
+		laneSizes[priority.String()] = size
+	}
+
 	return c.JSON(http.StatusOK, jsonmodels.InfoResponse{
 		Version:                 banner.AppVersion,
 		NetworkVersion:          discovery.Parameters.NetworkVersion,
 		TangleTime:              tangleTime,
+		BulkSync:                getBulkSync(),
 		IdentityID:              base58.Encode(deps.Local.Identity.ID().Bytes()),
 		IdentityIDShort:         deps.Local.Identity.ID().String(),
 		PublicKey:               deps.Local.PublicKey().String(),
@@ -152,5 +172,40 @@ func getInfo(c echo.Context) error {
 			CurrentBufferSize: deps.Tangle.Scheduler.BufferSize(),
 			NodeQueueSizes:    nodeQueueSizes,
 		},
+		RateSetter: jsonmodels.RateSetter{
+			Rate:      deps.Tangle.RateSetter.Rate(),
+			Size:      deps.Tangle.RateSetter.Size(),
+			LaneSizes: laneSizes,
+		},
 	})
 }
+
+// getBulkSync returns the progress of the running or the last bulk sync or nil if the node has not synced yet.
+func getBulkSync() *jsonmodels.BulkSync {
+	if deps.GossipMgr == nil {
+		return nil
+	}
+	status := deps.GossipMgr.BulkSyncStatus()
+	if status.StartTime.IsZero() {
+		return nil
+	}
+
+	bulkSync := &jsonmodels.BulkSync{
+		Running:           status.Running,
+		StartTime:         status.StartTime.Unix(),
+		Peers:             status.Peers,
+		MissingMessages:   status.MissingMessages,
+		InFlightRequests:  status.InFlightRequests,
+		RequestedMessages: status.RequestedMessages,
+		ReceivedMessages:  status.ReceivedMessages,
+		DeliveredMessages: status.DeliveredMessages,
+		FailedMessages:    status.FailedMessages,
+	}
+	if !status.EndTime.IsZero() {
+		bulkSync.EndTime = status.EndTime.Unix()
+	}
+	if status.Err != nil {
+		bulkSync.Error = status.Err.Error()
+	}
+	return bulkSync
+}
@@ -3,7 +3,9 @@ package ledgerstate
 import (
 	"context"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
@@ -13,14 +15,17 @@ import (
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
 	"github.com/labstack/echo"
+	"github.com/mr-tron/base58"
 	"go.uber.org/dig"
 
 	"github.com/iotaledger/goshimmer/packages/clock"
+	"github.com/iotaledger/goshimmer/packages/consensus/gof"
 	"github.com/iotaledger/goshimmer/packages/jsonmodels"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/mana"
 	"github.com/iotaledger/goshimmer/packages/shutdown"
 	"github.com/iotaledger/goshimmer/packages/tangle"
+	logger_plugin "github.com/iotaledger/goshimmer/plugins/logger"
 	"github.com/iotaledger/goshimmer/plugins/messagelayer"
 )
 
@@ -75,7 +80,7 @@ func configure(_ *node.Plugin) {
 		doubleSpendFilter.Remove(transactionID)
 	})
 	deps.Tangle.ConfirmationOracle.Events().TransactionConfirmed.Attach(onTransactionConfirmed)
-	log = logger.NewLogger(PluginName)
+	log = logger_plugin.NewLogger(PluginName)
 }
 
 func run(*node.Plugin) {
@@ -86,13 +91,16 @@ func run(*node.Plugin) {
 	// register endpoints
 	deps.Server.GET("ledgerstate/addresses/:address", GetAddress)
 	deps.Server.GET("ledgerstate/addresses/:address/unspentOutputs", GetAddressUnspentOutputs)
+	deps.Server.GET("ledgerstate/addresses/:address/balance", GetAddressBalance)
 	deps.Server.POST("ledgerstate/addresses/unspentOutputs", PostAddressUnspentOutputs)
+	deps.Server.GET("ledgerstate/aliases/:aliasID/history", GetAliasHistory)
 	deps.Server.GET("ledgerstate/branches/:branchID", GetBranch)
 	deps.Server.GET("ledgerstate/branches/:branchID/children", GetBranchChildren)
 	deps.Server.GET("ledgerstate/branches/:branchID/conflicts", GetBranchConflicts)
 	deps.Server.GET("ledgerstate/branches/:branchID/supporters", GetBranchSupporters)
 	deps.Server.GET("ledgerstate/outputs/:outputID/consumers", GetOutputConsumers)
 	deps.Server.GET("ledgerstate/outputs/:outputID/metadata", GetOutputMetadata)
+	deps.Server.GET("ledgerstate/outputs/:outputID/preimage", GetOutputPreimage)
 	deps.Server.GET("ledgerstate/transactions/:transactionID", GetTransaction)
 	deps.Server.GET("ledgerstate/transactions/:transactionID/metadata", GetTransactionMetadata)
 	deps.Server.POST("ledgerstate/transactions", PostTransaction)
@@ -120,40 +128,216 @@ func worker(ctx context.Context) {
 
 // region GetAddress ///////////////////////////////////////////////////////////////////////////////////////////////////
 
+const (
+	// defaultAddressOutputsLimit defines the amount of outputs returned in a single page if no limit is requested.
+	defaultAddressOutputsLimit = 100
+
+	// maxAddressOutputsLimit defines the maximum amount of outputs returned in a single page.
+	maxAddressOutputsLimit = 1000
+)
+
 // GetAddress is the handler for the /ledgerstate/addresses/:address endpoint.
 func GetAddress(c echo.Context) error {
+	return getAddressOutputs(c, false)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region GetAddressUnspentOutputs /////////////////////////////////////////////////////////////////////////////////////
+
+// GetAddressUnspentOutputs is the handler for the /ledgerstate/addresses/:address/unspentOutputs endpoint.
+func GetAddressUnspentOutputs(c echo.Context) error {
+	return getAddressOutputs(c, true)
+}
+
+// getAddressOutputs returns a page of the outputs of the address that match the filters of the request. The outputs
+// are ordered by their identifier and the nextCursor of the response needs to be passed as the cursor of the request
+// for the following page.
+func getAddressOutputs(c echo.Context, unspentOnly bool) error {
 	address, err := ledgerstate.AddressFromBase58EncodedString(c.Param("address"))
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
 	}
+	filter, err := addressOutputsFilterFromContext(c, unspentOnly)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
+	}
+	limit, err := uintQueryParam(c, "limit", defaultAddressOutputsLimit, maxAddressOutputsLimit)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
+	}
+	if limit == 0 {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(errors.New("limit must be greater than 0")))
+	}
 
-	cachedOutputs := deps.Tangle.LedgerState.CachedOutputsOnAddress(address)
-	defer cachedOutputs.Release()
+	var cursor *ledgerstate.OutputID
+	if cursorString := c.QueryParam("cursor"); cursorString != "" {
+		cursorOutputID, cursorErr := ledgerstate.OutputIDFromBase58(cursorString)
+		if cursorErr != nil {
+			return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(errors.Errorf("failed to parse cursor: %w", cursorErr)))
+		}
+		cursor = &cursorOutputID
+	}
+
+	// one more OutputID than the limit is loaded to know if there is a following page, and further ones are loaded
+	// as long as the filter doesn't match enough outputs
+	response := jsonmodels.NewGetAddressResponse(address, nil)
+	for {
+		outputIDs := deps.Tangle.LedgerState.UTXODAG.AddressOutputIDs(address, cursor, int(limit)+1)
+		for i := range outputIDs {
+			if len(response.Outputs) == int(limit) {
+				response.NextCursor = cursor.Base58()
+				return c.JSON(http.StatusOK, response)
+			}
 
-	return c.JSON(http.StatusOK, jsonmodels.NewGetAddressResponse(address, cachedOutputs.Unwrap()))
+			if output, matches := filter.match(outputIDs[i]); matches {
+				response.Outputs = append(response.Outputs, jsonmodels.NewOutput(output))
+			}
+			cursor = &outputIDs[i]
+		}
+
+		if len(outputIDs) <= int(limit) {
+			return c.JSON(http.StatusOK, response)
+		}
+	}
 }
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
-// region GetAddressUnspentOutputs /////////////////////////////////////////////////////////////////////////////////////
+// region GetAddressBalance ////////////////////////////////////////////////////////////////////////////////////////////
 
-// GetAddressUnspentOutputs is the handler for the /ledgerstate/addresses/:address/unspentOutputs endpoint.
-func GetAddressUnspentOutputs(c echo.Context) error {
+// GetAddressBalance is the handler for the /ledgerstate/addresses/:address/balance endpoint. It sums up the balances
+// of the unspent outputs of the address that match the filters of the request by their color. The OutputIDs of the
+// address are walked and their outputs are loaded one after another, so that addresses with many outputs can be queried
+// without collecting their OutputIDs or building a huge response.
+func GetAddressBalance(c echo.Context) error {
 	address, err := ledgerstate.AddressFromBase58EncodedString(c.Param("address"))
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
 	}
+	filter, err := addressOutputsFilterFromContext(c, true)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
+	}
 
-	cachedOutputs := deps.Tangle.LedgerState.CachedOutputsOnAddress(address)
-	defer cachedOutputs.Release()
+	balances := make(map[ledgerstate.Color]uint64)
+	unspentOutputs := 0
+	deps.Tangle.LedgerState.UTXODAG.ForEachAddressOutputID(address, func(outputID ledgerstate.OutputID) bool {
+		output, matches := filter.match(outputID)
+		if !matches {
+			return true
+		}
 
-	return c.JSON(http.StatusOK, jsonmodels.NewGetAddressResponse(address, cachedOutputs.Unwrap().Filter(func(output ledgerstate.Output) (isUnspent bool) {
-		deps.Tangle.LedgerState.CachedOutputMetadata(output.ID()).Consume(func(outputMetadata *ledgerstate.OutputMetadata) {
-			isUnspent = outputMetadata.ConsumerCount() == 0
+		unspentOutputs++
+		output.Balances().ForEach(func(color ledgerstate.Color, balance uint64) bool {
+			balances[color] += balance
+			return true
 		})
 
-		return
-	})))
+		return true
+	})
+
+	return c.JSON(http.StatusOK, jsonmodels.NewGetAddressBalanceResponse(address, balances, unspentOutputs))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region addressOutputsFilter /////////////////////////////////////////////////////////////////////////////////////////
+
+// addressOutputsFilter contains the conditions that the outputs of an address need to fulfill to be returned.
+type addressOutputsFilter struct {
+	unspentOnly        bool
+	outputType         *ledgerstate.OutputType
+	color              ledgerstate.Color
+	minBalance         uint64
+	minGradeOfFinality gof.GradeOfFinality
+	fromTimestamp      time.Time
+	toTimestamp        time.Time
+}
+
+// addressOutputsFilterFromContext parses the addressOutputsFilter from the query parameters type, color, minBalance,
+// minGoF, fromTimestamp and toTimestamp (unix seconds, inclusive) of the request. The minBalance applies to the
+// balance of the requested color and to the IOTA balance if no color is requested.
+func addressOutputsFilterFromContext(c echo.Context, unspentOnly bool) (filter *addressOutputsFilter, err error) {
+	filter = &addressOutputsFilter{
+		unspentOnly: unspentOnly,
+		color:       ledgerstate.ColorIOTA,
+	}
+
+	if outputTypeString := c.QueryParam("type"); outputTypeString != "" {
+		outputType, typeErr := ledgerstate.OutputTypeFromString(outputTypeString)
+		if typeErr != nil {
+			return nil, errors.Errorf("failed to parse type: %w", typeErr)
+		}
+		filter.outputType = &outputType
+	}
+	if colorString := c.QueryParam("color"); colorString != "" {
+		if filter.color, err = ledgerstate.ColorFromBase58EncodedString(colorString); err != nil {
+			return nil, errors.Errorf("failed to parse color: %w", err)
+		}
+	}
+	if filter.minBalance, err = uintQueryParam(c, "minBalance", 0, math.MaxUint64); err != nil {
+		return nil, err
+	}
+	minGradeOfFinality, err := uintQueryParam(c, "minGoF", uint64(gof.None), uint64(gof.High))
+	if err != nil {
+		return nil, err
+	}
+	filter.minGradeOfFinality = gof.GradeOfFinality(minGradeOfFinality)
+
+	fromTimestamp, err := uintQueryParam(c, "fromTimestamp", 0, math.MaxInt64)
+	if err != nil {
+		return nil, err
+	}
+	toTimestamp, err := uintQueryParam(c, "toTimestamp", 0, math.MaxInt64)
+	if err != nil {
+		return nil, err
+	}
+	if fromTimestamp != 0 {
+		filter.fromTimestamp = time.Unix(int64(fromTimestamp), 0)
+	}
+	if toTimestamp != 0 {
+		filter.toTimestamp = time.Unix(int64(toTimestamp), 0)
+	}
+	if fromTimestamp != 0 && toTimestamp != 0 && fromTimestamp > toTimestamp {
+		return nil, errors.Errorf("fromTimestamp %d is greater than toTimestamp %d", fromTimestamp, toTimestamp)
+	}
+
+	return filter, nil
+}
+
+// match loads the output with the given OutputID and returns it together with a flag that indicates if it fulfills
+// the conditions of the filter. The cheap checks are performed first, so that the Transaction is only loaded if a time
+// range was requested.
+func (a *addressOutputsFilter) match(outputID ledgerstate.OutputID) (output ledgerstate.Output, matches bool) {
+	deps.Tangle.LedgerState.CachedOutputMetadata(outputID).Consume(func(outputMetadata *ledgerstate.OutputMetadata) {
+		matches = (!a.unspentOnly || outputMetadata.ConsumerCount() == 0) && outputMetadata.GradeOfFinality() >= a.minGradeOfFinality
+	})
+	if !matches {
+		return nil, false
+	}
+
+	deps.Tangle.LedgerState.CachedOutput(outputID).Consume(func(loadedOutput ledgerstate.Output) {
+		output = loadedOutput
+	})
+	if output == nil || (a.outputType != nil && output.Type() != *a.outputType) {
+		return nil, false
+	}
+	if balance, exists := output.Balances().Get(a.color); (a.color != ledgerstate.ColorIOTA && !exists) || balance < a.minBalance {
+		return nil, false
+	}
+
+	if !a.fromTimestamp.IsZero() || !a.toTimestamp.IsZero() {
+		var timestamp time.Time
+		deps.Tangle.LedgerState.Transaction(outputID.TransactionID()).Consume(func(transaction *ledgerstate.Transaction) {
+			timestamp = transaction.Essence().Timestamp()
+		})
+		if timestamp.IsZero() || timestamp.Before(a.fromTimestamp) || (!a.toTimestamp.IsZero() && timestamp.After(a.toTimestamp)) {
+			return nil, false
+		}
+	}
+
+	return output, true
 }
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -191,6 +375,9 @@ func PostAddressUnspentOutputs(c echo.Context) error {
 			deps.Tangle.LedgerState.CachedOutputMetadata(output.ID()).Consume(func(outputMetadata *ledgerstate.OutputMetadata) {
 				isUnspent = outputMetadata.ConsumerCount() == 0
 			})
+			if !isUnspent {
+				res.UnspentOutputs[i].SpentOutputs++
+			}
 			return
 		}) {
 			cachedOutputMetadata := deps.Tangle.LedgerState.CachedOutputMetadata(output.ID())
@@ -217,6 +404,90 @@ func PostAddressUnspentOutputs(c echo.Context) error {
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region GetAliasHistory //////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// defaultAliasHistoryLimit defines the amount of history entries returned if no limit is requested.
+	defaultAliasHistoryLimit = 100
+
+	// maxAliasHistoryLimit defines the maximum amount of history entries returned in a single response.
+	maxAliasHistoryLimit = 1000
+)
+
+// GetAliasHistory is the handler for the /ledgerstate/aliases/:aliasID/history endpoint. It returns the outputs of the
+// chain of the alias ordered by their state index. The optional query parameters fromStateIndex and toStateIndex
+// restrict the (inclusive) range of state indices and limit the size of the page. The nextStateIndex of the response
+// needs to be passed as the fromStateIndex of the request for the next page.
+func GetAliasHistory(c echo.Context) (err error) {
+	aliasAddress, err := ledgerstate.AliasAddressFromBase58EncodedString(c.Param("aliasID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
+	}
+
+	fromStateIndex, err := uintQueryParam(c, "fromStateIndex", 0, math.MaxUint32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
+	}
+	toStateIndex, err := uintQueryParam(c, "toStateIndex", math.MaxUint32, math.MaxUint32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
+	}
+	if fromStateIndex > toStateIndex {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(errors.Errorf("fromStateIndex %d is greater than toStateIndex %d", fromStateIndex, toStateIndex)))
+	}
+	limit, err := uintQueryParam(c, "limit", defaultAliasHistoryLimit, maxAliasHistoryLimit)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
+	}
+
+	aliasHistory, nextStateIndex := deps.Tangle.LedgerState.UTXODAG.AliasHistory(aliasAddress, uint32(fromStateIndex), uint32(toStateIndex), int(limit))
+	if len(aliasHistory) == 0 && fromStateIndex == 0 && toStateIndex == math.MaxUint32 {
+		return c.JSON(http.StatusNotFound, jsonmodels.NewErrorResponse(errors.Errorf("failed to load history of alias %s", aliasAddress.Base58())))
+	}
+
+	response := &jsonmodels.GetAliasHistoryResponse{
+		AliasID:        aliasAddress.Base58(),
+		History:        make([]*jsonmodels.AliasHistoryEntry, 0),
+		Limit:          int(limit),
+		NextStateIndex: nextStateIndex,
+	}
+	for _, aliasHistoryEntry := range aliasHistory {
+		var aliasOutput *ledgerstate.AliasOutput
+		deps.Tangle.LedgerState.CachedOutput(aliasHistoryEntry.OutputID()).Consume(func(output ledgerstate.Output) {
+			aliasOutput, _ = output.(*ledgerstate.AliasOutput)
+		})
+		if aliasOutput == nil {
+			continue
+		}
+
+		deps.Tangle.LedgerState.CachedOutputMetadata(aliasHistoryEntry.OutputID()).Consume(func(outputMetadata *ledgerstate.OutputMetadata) {
+			response.History = append(response.History, jsonmodels.NewAliasHistoryEntry(aliasHistoryEntry, aliasOutput, outputMetadata))
+		})
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// uintQueryParam parses the query parameter with the given name as an unsigned integer that may not exceed the given
+// maximum. It returns the default value if the parameter is not set.
+func uintQueryParam(c echo.Context, name string, defaultValue, maxValue uint64) (value uint64, err error) {
+	valueString := c.QueryParam(name)
+	if valueString == "" {
+		return defaultValue, nil
+	}
+
+	if value, err = strconv.ParseUint(valueString, 10, 64); err != nil {
+		return 0, errors.Errorf("failed to parse %s: %w", name, err)
+	}
+	if value > maxValue {
+		return 0, errors.Errorf("%s must not be greater than %d", name, maxValue)
+	}
+
+	return value, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region GetBranch ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // GetBranch is the handler for the /ledgerstate/branch/:branchID endpoint.
@@ -365,6 +636,55 @@ func GetOutputMetadata(c echo.Context) (err error) {
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region GetOutputPreimage ////////////////////////////////////////////////////////////////////////////////////////////
+
+// GetOutputPreimage is the handler for the /ledgerstate/outputs/:outputID/preimage endpoint. It returns the preimage
+// that a consumer of the hash time-locked output revealed to claim it.
+func GetOutputPreimage(c echo.Context) (err error) {
+	outputID, err := ledgerstate.OutputIDFromBase58(c.Param("outputID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
+	}
+
+	var htlc *ledgerstate.ExtendedLockedOutput
+	if !deps.Tangle.LedgerState.CachedOutput(outputID).Consume(func(output ledgerstate.Output) {
+		htlc, _ = output.(*ledgerstate.ExtendedLockedOutput)
+	}) {
+		return c.JSON(http.StatusNotFound, jsonmodels.NewErrorResponse(errors.Errorf("failed to load Output with %s", outputID)))
+	}
+	if htlc == nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(errors.Errorf("output %s is not an ExtendedLockedOutput", outputID)))
+	}
+	hashLockType, hashLock := htlc.HashLock()
+	if hashLockType == 0 {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(errors.Errorf("output %s is not hash locked", outputID)))
+	}
+
+	cachedConsumers := deps.Tangle.LedgerState.Consumers(outputID)
+	defer cachedConsumers.Release()
+	for _, consumer := range cachedConsumers.Unwrap() {
+		var preimage []byte
+		deps.Tangle.LedgerState.Transaction(consumer.TransactionID()).Consume(func(transaction *ledgerstate.Transaction) {
+			if revealedPreimage, revealed := transaction.RevealedPreimage(outputID); revealed && htlc.PreimageValid(revealedPreimage) {
+				preimage = revealedPreimage
+			}
+		})
+		if preimage != nil {
+			return c.JSON(http.StatusOK, &jsonmodels.GetOutputPreimageResponse{
+				OutputID:      outputID.Base58(),
+				TransactionID: consumer.TransactionID().Base58(),
+				HashLockType:  hashLockType.String(),
+				HashLock:      base58.Encode(hashLock[:]),
+				Preimage:      base58.Encode(preimage),
+			})
+		}
+	}
+
+	return c.JSON(http.StatusNotFound, jsonmodels.NewErrorResponse(errors.Errorf("no preimage revealed for output %s", outputID)))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region GetTransaction ///////////////////////////////////////////////////////////////////////////////////////////////
 
 // GetTransaction is the handler for the /ledgerstate/transactions/:transactionID endpoint.
@@ -0,0 +1,120 @@
+package ledgerstate
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+func TestGetAddress_Pagination(t *testing.T) {
+	address, outputIDs := setupAddressOutputs(t, 5)
+
+	// without a limit the default page size applies
+	response := requestAddressOutputs(t, address, "")
+	assert.Equal(t, outputIDs, responseOutputIDs(response))
+	assert.Empty(t, response.NextCursor)
+
+	var pages []string
+	for cursor := ""; ; {
+		response = requestAddressOutputs(t, address, "limit=2&cursor="+cursor)
+		assert.LessOrEqual(t, len(response.Outputs), 2)
+		pages = append(pages, responseOutputIDs(response)...)
+		if cursor = response.NextCursor; cursor == "" {
+			break
+		}
+	}
+	assert.Equal(t, outputIDs, pages)
+
+	// the outputs that don't match the filter are skipped without ending the page early
+	response = requestAddressOutputs(t, address, "limit=2&minBalance=3")
+	require.Len(t, response.Outputs, 2)
+	assert.NotEmpty(t, response.NextCursor)
+	response = requestAddressOutputs(t, address, "limit=2&minBalance=3&cursor="+response.NextCursor)
+	assert.Len(t, response.Outputs, 1)
+	assert.Empty(t, response.NextCursor)
+}
+
+func TestGetAddress_InvalidParameters(t *testing.T) {
+	address, _ := setupAddressOutputs(t, 1)
+
+	for _, query := range []string{"limit=0", "limit=1001", "limit=-1", "cursor=invalid"} {
+		recorder := serveAddressOutputs(address, query)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code, query)
+	}
+}
+
+// setupAddressOutputs creates a tangle whose snapshot contains the given number of outputs on a new address. The
+// balance of the outputs is their position in the snapshot, starting at 1. It returns the address and the OutputIDs
+// in the order of their bytes.
+func setupAddressOutputs(t *testing.T, count int) (address ledgerstate.Address, outputIDs []string) {
+	testTangle := tangle.NewTestTangle()
+	t.Cleanup(testTangle.Shutdown)
+	deps.Tangle = testTangle
+
+	address = ledgerstate.NewED25519Address(ed25519.GenerateKeyPair().PublicKey)
+	outputs := make([]ledgerstate.Output, count)
+	unspentOutputs := make([]bool, count)
+	for i := range outputs {
+		outputs[i] = ledgerstate.NewSigLockedSingleOutput(uint64(i+1), address)
+		unspentOutputs[i] = true
+	}
+	essence := ledgerstate.NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{},
+		ledgerstate.NewInputs(ledgerstate.NewUTXOInput(ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, 0))),
+		ledgerstate.NewOutputs(outputs...),
+	)
+	unlockBlocks := ledgerstate.UnlockBlocks{ledgerstate.NewReferenceUnlockBlock(0)}
+	transaction := ledgerstate.NewTransaction(essence, unlockBlocks)
+	require.NoError(t, testTangle.LedgerState.LoadSnapshot(&ledgerstate.Snapshot{
+		Transactions: map[ledgerstate.TransactionID]ledgerstate.Record{
+			transaction.ID(): {Essence: essence, UnlockBlocks: unlockBlocks, UnspentOutputs: unspentOutputs},
+		},
+	}))
+
+	// the outputs of a transaction are sorted, so their OutputIDs are ordered by their index
+	for i := range outputs {
+		outputIDs = append(outputIDs, ledgerstate.NewOutputID(transaction.ID(), uint16(i)).Base58())
+	}
+
+	return address, outputIDs
+}
+
+func serveAddressOutputs(address ledgerstate.Address, query string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodGet, "/ledgerstate/addresses/"+address.Base58()+"?"+query, nil)
+	recorder := httptest.NewRecorder()
+	c := echo.New().NewContext(request, recorder)
+	c.SetParamNames("address")
+	c.SetParamValues(address.Base58())
+	_ = GetAddress(c)
+
+	return recorder
+}
+
+func requestAddressOutputs(t *testing.T, address ledgerstate.Address, query string) *jsonmodels.GetAddressResponse {
+	recorder := serveAddressOutputs(address, query)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	response := &jsonmodels.GetAddressResponse{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), response))
+
+	return response
+}
+
+func responseOutputIDs(response *jsonmodels.GetAddressResponse) (outputIDs []string) {
+	for _, output := range response.Outputs {
+		outputIDs = append(outputIDs, output.OutputID.Base58)
+	}
+
+	return outputIDs
+}
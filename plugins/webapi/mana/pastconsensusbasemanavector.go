@@ -1,33 +1,53 @@
 package mana
 
-//import (
-//	"net/http"
-//	"time"
-//
-//	"github.com/labstack/echo"
-//
-//	manaPlugin "github.com/iotaledger/goshimmer/plugins/messagelayer"
-//	"github.com/iotaledger/goshimmer/plugins/webapi/jsonmodels"
-//)
-//
-//// getPastConsensusManaVectorHandler handles the request.
-//func getPastConsensusManaVectorHandler(c echo.Context) error {
-//	var req jsonmodels.PastConsensusManaVectorRequest
-//	if err := c.Bind(&req); err != nil {
-//		return c.JSON(http.StatusBadRequest, jsonmodels.PendingResponse{Error: err.Error()})
-//	}
-//	timestamp := time.Unix(req.Timestamp, 0)
-//	consensus, _, err := manaPlugin.GetPastConsensusManaVector(timestamp.Add(1 * time.Second))
-//	if err != nil {
-//		return c.JSON(http.StatusBadRequest, jsonmodels.PastConsensusManaVectorResponse{Error: err.Error()})
-//	}
-//	manaMap, _, err := consensus.GetManaMap(timestamp)
-//	if err != nil {
-//		return c.JSON(http.StatusBadRequest, jsonmodels.PastConsensusManaVectorResponse{Error: err.Error()})
-//	}
-//
-//	return c.JSON(http.StatusOK, jsonmodels.PastConsensusManaVectorResponse{
-//		Consensus: manaMap.ToNodeStrList(),
-//		TimeStamp: timestamp.Unix(),
-//	})
-//}
+import (
+	"net/http"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/labstack/echo"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/mana"
+	manaPlugin "github.com/iotaledger/goshimmer/plugins/messagelayer"
+)
+
+// getPastConsensusManaVectorHandler handles the request.
+func getPastConsensusManaVectorHandler(c echo.Context) error {
+	var req jsonmodels.PastConsensusManaVectorRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.PastConsensusManaVectorResponse{Error: err.Error()})
+	}
+	timestamp := time.Unix(req.Timestamp, 0)
+
+	if req.NodeID != "" {
+		nodeID, err := mana.IDFromStr(req.NodeID)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, jsonmodels.PastConsensusManaVectorResponse{Error: err.Error()})
+		}
+		consensusMana, err := manaPlugin.GetPastConsensusMana(nodeID, timestamp)
+		if err != nil {
+			if errors.Is(err, mana.ErrNodeNotFoundInBaseManaVector) {
+				return c.JSON(http.StatusNotFound, jsonmodels.PastConsensusManaVectorResponse{Error: err.Error()})
+			}
+			return c.JSON(http.StatusBadRequest, jsonmodels.PastConsensusManaVectorResponse{Error: err.Error()})
+		}
+		return c.JSON(http.StatusOK, jsonmodels.PastConsensusManaVectorResponse{
+			Consensus: mana.NodeMap{nodeID: consensusMana}.ToNodeStrList(),
+			TimeStamp: timestamp.Unix(),
+		})
+	}
+
+	highestNodes, err := manaPlugin.GetPastHighestConsensusManaNodes(timestamp, req.Number)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.PastConsensusManaVectorResponse{Error: err.Error()})
+	}
+	consensus := make([]mana.NodeStr, 0, len(highestNodes))
+	for _, n := range highestNodes {
+		consensus = append(consensus, n.ToNodeStr())
+	}
+	return c.JSON(http.StatusOK, jsonmodels.PastConsensusManaVectorResponse{
+		Consensus: consensus,
+		TimeStamp: timestamp.Unix(),
+	})
+}
@@ -1,14 +1,23 @@
 package mana
 
-//// getPastConsensusVectorMetadataHandler handles the request.
-//func getPastConsensusVectorMetadataHandler(c echo.Context) error {
-//	metadata := manaPlugin.GetPastConsensusManaVectorMetadata()
-//	if metadata == nil {
-//		return c.JSON(http.StatusOK, jsonmodels.PastConsensusVectorMetadataResponse{
-//			Error: "Past consensus mana vector metadata not found",
-//		})
-//	}
-//	return c.JSON(http.StatusOK, jsonmodels.PastConsensusVectorMetadataResponse{
-//		Metadata: metadata,
-//	})
-//}
+import (
+	"net/http"
+
+	"github.com/labstack/echo"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	manaPlugin "github.com/iotaledger/goshimmer/plugins/messagelayer"
+)
+
+// getPastConsensusVectorMetadataHandler handles the request.
+func getPastConsensusVectorMetadataHandler(c echo.Context) error {
+	metadata := manaPlugin.GetPastConsensusManaVectorMetadata()
+	if metadata == nil {
+		return c.JSON(http.StatusOK, jsonmodels.PastConsensusVectorMetadataResponse{
+			Error: "Past consensus mana vector metadata not found",
+		})
+	}
+	return c.JSON(http.StatusOK, jsonmodels.PastConsensusVectorMetadataResponse{
+		Metadata: metadata,
+	})
+}
@@ -39,7 +39,7 @@ func configure(_ *node.Plugin) {
 	deps.Server.GET("mana/allowedManaPledge", allowedManaPledgeHandler)
 	deps.Server.GET("mana/delegated", GetDelegatedMana)
 	deps.Server.GET("mana/delegated/outputs", GetDelegatedOutputs)
-	// deps.Server.GET("/mana/consensus/past", getPastConsensusManaVectorHandler)
-	// deps.Server.GET("/mana/consensus/logs", getEventLogsHandler)
-	// deps.Server.GET("/mana/consensus/metadata", getPastConsensusVectorMetadataHandler)
+	deps.Server.GET("/mana/consensus/past", getPastConsensusManaVectorHandler)
+	deps.Server.GET("/mana/consensus/logs", getEventLogsHandler)
+	deps.Server.GET("/mana/consensus/metadata", getPastConsensusVectorMetadataHandler)
 }
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/configuration"
 	"github.com/iotaledger/hive.go/daemon"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/logger"
@@ -16,6 +18,8 @@ import (
 	"go.uber.org/dig"
 
 	"github.com/iotaledger/goshimmer/packages/shutdown"
+	"github.com/iotaledger/goshimmer/plugins/config"
+	logger_plugin "github.com/iotaledger/goshimmer/plugins/logger"
 )
 
 // PluginName is the name of the web API plugin.
@@ -27,8 +31,18 @@ var (
 	deps   = new(dependencies)
 
 	log *logger.Logger
+
+	// basicAuth contains the credentials of the basic auth, which can be changed by reloading the config.
+	basicAuth      basicAuthCredentials
+	basicAuthMutex sync.RWMutex
 )
 
+type basicAuthCredentials struct {
+	enabled  bool
+	username string
+	password string
+}
+
 type dependencies struct {
 	dig.In
 
@@ -57,16 +71,28 @@ func newServer() *echo.Echo {
 		AllowMethods: []string{http.MethodGet, http.MethodHead, http.MethodPut, http.MethodPatch, http.MethodPost, http.MethodDelete},
 	}))
 
-	// if enabled, configure basic-auth
-	if Parameters.BasicAuth.Enabled {
-		server.Use(middleware.BasicAuth(func(username, password string, c echo.Context) (bool, error) {
-			if username == Parameters.BasicAuth.Username &&
-				password == Parameters.BasicAuth.Password {
+	// the basic-auth is always installed, so that it can be enabled by reloading the config
+	setBasicAuth(basicAuthCredentials{
+		enabled:  Parameters.BasicAuth.Enabled,
+		username: Parameters.BasicAuth.Username,
+		password: Parameters.BasicAuth.Password,
+	})
+	server.Use(middleware.BasicAuthWithConfig(middleware.BasicAuthConfig{
+		Skipper: func(echo.Context) bool {
+			basicAuthMutex.RLock()
+			defer basicAuthMutex.RUnlock()
+			return !basicAuth.enabled
+		},
+		Validator: func(username, password string, c echo.Context) (bool, error) {
+			basicAuthMutex.RLock()
+			defer basicAuthMutex.RUnlock()
+			if username == basicAuth.username &&
+				password == basicAuth.password {
 				return true, nil
 			}
 			return false, nil
-		}))
-	}
+		},
+	}))
 
 	server.HTTPErrorHandler = func(err error, c echo.Context) {
 		log.Warnf("Request failed: %s", err)
@@ -109,8 +135,33 @@ func newServer() *echo.Echo {
 	return server
 }
 
+// setBasicAuth replaces the basic-auth credentials.
+func setBasicAuth(credentials basicAuthCredentials) {
+	basicAuthMutex.Lock()
+	defer basicAuthMutex.Unlock()
+
+	basicAuth = credentials
+}
+
 func configure(*node.Plugin) {
-	log = logger.NewLogger(PluginName)
+	log = logger_plugin.NewLogger(PluginName)
+	config.RegisterReloadable(&config.Reloadable{
+		Parameters: []string{"webAPI.basicAuth"},
+		Validate: func(conf *configuration.Configuration) error {
+			if conf.Bool("webAPI.basicAuth.enabled") && conf.String("webAPI.basicAuth.username") == "" {
+				return errors.New("the basic auth username must not be empty")
+			}
+			return nil
+		},
+		Apply: func(conf *configuration.Configuration) {
+			setBasicAuth(basicAuthCredentials{
+				enabled:  conf.Bool("webAPI.basicAuth.enabled"),
+				username: conf.String("webAPI.basicAuth.username"),
+				password: conf.String("webAPI.basicAuth.password"),
+			})
+			log.Infof("Basic auth updated, basic-auth=%v", conf.Bool("webAPI.basicAuth.enabled"))
+		},
+	})
 	// configure the server
 	deps.Server.HideBanner = true
 	deps.Server.HidePort = true
@@ -45,6 +45,7 @@ func MissingAvailableHandler(c echo.Context) error {
 		}
 	}
 
+	requestContext := c.Request().Context()
 	msgAvailability := make(map[string][]string)
 	var msgAvailabilityLock sync.Mutex
 	var wg sync.WaitGroup
@@ -55,7 +56,7 @@ func MissingAvailableHandler(c echo.Context) error {
 			wg.Add(1)
 			go func(peerId string, c *client.GoShimmerAPI) {
 				fmt.Println("Querying", peerId, "about", missingIDBase58)
-				_, err := c.GetMessageMetadata(missingIDBase58)
+				_, err := c.GetMessageMetadata(requestContext, missingIDBase58)
 				if err == nil {
 					msgAvailabilityLock.Lock()
 					msgAvailability[missingIDBase58] = append(msgAvailability[missingIDBase58], peerId)
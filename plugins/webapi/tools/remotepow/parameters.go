@@ -0,0 +1,34 @@
+package remotepow
+
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/configuration"
+)
+
+// ParametersDefinition contains the definition of the parameters used by the remote PoW endpoint plugin.
+type ParametersDefinition struct {
+	// MaxDifficulty defines the maximum difficulty the node performs the PoW for.
+	MaxDifficulty int `default:"25" usage:"maximum difficulty the node performs the PoW for"`
+	// NumThreads defines how many threads are used to perform the PoW of the requests.
+	NumThreads int `default:"1" usage:"number of threads used to perform the PoW of the requests"`
+	// QueueSize defines how many requests can wait for the PoW.
+	QueueSize int `default:"10" usage:"number of requests that can wait for the PoW"`
+	// Timeout defines the maximum time a request can wait and perform the PoW.
+	Timeout time.Duration `default:"1m" usage:"maximum time a request can wait and perform the PoW"`
+	// MaxPendingPerClient defines how many requests of a single client can be queued or processed at once.
+	MaxPendingPerClient int `default:"1" usage:"number of requests of a single client that can be queued or processed at once"`
+	// MaxRequestsPerClient defines how many requests a single client can send within the ClientInterval.
+	MaxRequestsPerClient int `default:"10" usage:"number of requests a single client can send within the client interval"`
+	// ClientInterval defines the interval the MaxRequestsPerClient refers to.
+	ClientInterval time.Duration `default:"1m" usage:"interval the maximum number of requests per client refers to"`
+	// TrustedProxies defines the reverse proxies whose forwarded client addresses are used to apply the client limits.
+	TrustedProxies []string `usage:"IP addresses or CIDR ranges of the reverse proxies whose X-Forwarded-For and X-Real-IP headers identify the clients"`
+}
+
+// Parameters contains the configuration parameters of the remote PoW endpoint plugin.
+var Parameters = &ParametersDefinition{}
+
+func init() {
+	configuration.BindParameters(Parameters, "remotePoW")
+}
@@ -0,0 +1,170 @@
+// Package remotepow is a plugin that performs the PoW for the message bytes of remote clients.
+package remotepow
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/labstack/echo"
+	"github.com/labstack/echo/middleware"
+	"go.uber.org/dig"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/pow"
+	"github.com/iotaledger/goshimmer/packages/shutdown"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+// PluginName is the name of the web API tools remote PoW endpoint plugin.
+const PluginName = "WebAPIToolsRemotePoWEndpoint"
+
+// RoutePoW is the API route of the remote PoW.
+const RoutePoW = "tools/pow"
+
+const (
+	// maxDataSize is the maximum size of the data of a request, i.e. the size of a message without its nonce.
+	maxDataSize = tangle.MaxMessageSize - pow.NonceBytes
+	// maxBodySize is the maximum size of the body of a request, which contains the base64 encoded data.
+	maxBodySize = 2 * tangle.MaxMessageSize
+)
+
+var (
+	// Plugin is the plugin instance of the web API tools remote PoW endpoint plugin.
+	Plugin = node.NewPlugin(PluginName, deps, node.Disabled, configure, run)
+	deps   = new(dependencies)
+
+	service        *pow.Service
+	trustedProxies []*net.IPNet
+)
+
+type dependencies struct {
+	dig.In
+
+	Server *echo.Echo
+}
+
+func configure(plugin *node.Plugin) {
+	var err error
+	if trustedProxies, err = parseTrustedProxies(Parameters.TrustedProxies); err != nil {
+		plugin.LogFatalf("Invalid trusted proxies: %s", err)
+	}
+
+	service = pow.NewService(pow.New(Parameters.NumThreads), pow.ServiceParams{
+		MaxDifficulty:        Parameters.MaxDifficulty,
+		QueueSize:            Parameters.QueueSize,
+		MaxPendingPerClient:  Parameters.MaxPendingPerClient,
+		MaxRequestsPerClient: Parameters.MaxRequestsPerClient,
+		ClientInterval:       Parameters.ClientInterval,
+	})
+	deps.Server.POST(RoutePoW, powHandler, bodyLimit())
+
+	plugin.LogInfof("Remote PoW enabled: max difficulty=%d, threads=%d", Parameters.MaxDifficulty, Parameters.NumThreads)
+}
+
+func run(plugin *node.Plugin) {
+	if err := daemon.BackgroundWorker(PluginName, service.Run, shutdown.PriorityWebAPI); err != nil {
+		plugin.Panicf("Failed to start as daemon: %s", err)
+	}
+}
+
+// bodyLimit returns the middleware that rejects requests with a body larger than maxBodySize before it is read.
+func bodyLimit() echo.MiddlewareFunc {
+	return middleware.BodyLimit(strconv.Itoa(maxBodySize))
+}
+
+// powHandler performs the PoW for the bytes of the request.
+func powHandler(c echo.Context) error {
+	var request jsonmodels.PowRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.PowResponse{Error: err.Error()})
+	}
+	if len(request.Data) > maxDataSize {
+		return c.JSON(http.StatusBadRequest, jsonmodels.PowResponse{Error: errors.Errorf("data must not be longer than %d bytes", maxDataSize).Error()})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), Parameters.Timeout)
+	defer cancel()
+
+	nonce, err := service.Mine(ctx, clientKey(c.Request(), trustedProxies), request.Data, request.Difficulty)
+	if err != nil {
+		return c.JSON(statusCode(err), jsonmodels.PowResponse{Error: err.Error()})
+	}
+	return c.JSON(http.StatusOK, jsonmodels.PowResponse{Nonce: nonce})
+}
+
+// clientKey returns the key the client limits of the given request are tracked by. It is the address of the connection,
+// unless the connection comes from a trusted proxy, in which case the client address forwarded by the proxy is used.
+// The forwarding headers of other connections are ignored, as the clients could otherwise evade their limits.
+func clientKey(request *http.Request, trustedProxies []*net.IPNet) string {
+	host, _, err := net.SplitHostPort(request.RemoteAddr)
+	if err != nil {
+		host = request.RemoteAddr
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		for _, trustedProxy := range trustedProxies {
+			if trustedProxy.Contains(ip) {
+				return forwardedClient(request, host)
+			}
+		}
+	}
+
+	return host
+}
+
+// forwardedClient returns the client address that a trusted proxy forwarded in the request or the given default.
+func forwardedClient(request *http.Request, defaultClient string) string {
+	if forwardedFor := request.Header.Get(echo.HeaderXForwardedFor); forwardedFor != "" {
+		return strings.TrimSpace(strings.Split(forwardedFor, ",")[0])
+	}
+	if realIP := request.Header.Get(echo.HeaderXRealIP); realIP != "" {
+		return realIP
+	}
+
+	return defaultClient
+}
+
+// parseTrustedProxies parses the given IP addresses and CIDR ranges.
+func parseTrustedProxies(proxies []string) (trustedProxies []*net.IPNet, err error) {
+	for _, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, errors.Errorf("invalid IP address %s", proxy)
+			}
+			if ip4 := ip.To4(); ip4 != nil {
+				ip = ip4
+			}
+			trustedProxies = append(trustedProxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(8*len(ip), 8*len(ip))})
+			continue
+		}
+
+		_, ipNet, parseErr := net.ParseCIDR(proxy)
+		if parseErr != nil {
+			return nil, errors.Errorf("invalid CIDR range %s: %w", proxy, parseErr)
+		}
+		trustedProxies = append(trustedProxies, ipNet)
+	}
+
+	return trustedProxies, nil
+}
+
+// statusCode returns the HTTP status code of the given error of the PoW service.
+func statusCode(err error) int {
+	switch {
+	case errors.Is(err, pow.ErrInvalidRequest), errors.Is(err, pow.ErrDifficultyTooHigh):
+		return http.StatusBadRequest
+	case errors.Is(err, pow.ErrClientLimitExceeded):
+		return http.StatusTooManyRequests
+	case errors.Is(err, pow.ErrQueueFull), errors.Is(err, pow.ErrServiceStopped):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,68 @@
+package remotepow
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+)
+
+func TestClientKey(t *testing.T) {
+	trustedProxies, err := parseTrustedProxies([]string{"10.0.0.1", "192.168.0.0/16", "::1"})
+	require.NoError(t, err)
+
+	newRequest := func(remoteAddr string, header ...string) *http.Request {
+		request := httptest.NewRequest(http.MethodPost, "/"+RoutePoW, nil)
+		request.RemoteAddr = remoteAddr
+		for i := 0; i+1 < len(header); i += 2 {
+			request.Header.Set(header[i], header[i+1])
+		}
+		return request
+	}
+
+	// the forwarding headers of untrusted connections are ignored
+	assert.Equal(t, "1.2.3.4", clientKey(newRequest("1.2.3.4:1234", echo.HeaderXForwardedFor, "5.6.7.8"), trustedProxies))
+	assert.Equal(t, "1.2.3.4", clientKey(newRequest("1.2.3.4:1234", echo.HeaderXRealIP, "5.6.7.8"), nil))
+
+	// trusted proxies forward the address of the client
+	assert.Equal(t, "5.6.7.8", clientKey(newRequest("10.0.0.1:1234", echo.HeaderXForwardedFor, "5.6.7.8, 10.0.0.2"), trustedProxies))
+	assert.Equal(t, "5.6.7.8", clientKey(newRequest("192.168.1.1:1234", echo.HeaderXRealIP, "5.6.7.8"), trustedProxies))
+	assert.Equal(t, "5.6.7.8", clientKey(newRequest("[::1]:1234", echo.HeaderXRealIP, "5.6.7.8"), trustedProxies))
+	assert.Equal(t, "10.0.0.1", clientKey(newRequest("10.0.0.1:1234"), trustedProxies))
+}
+
+func TestPowHandler_Size(t *testing.T) {
+	server := echo.New()
+	server.POST("/"+RoutePoW, powHandler, bodyLimit())
+
+	post := func(body []byte) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "/"+RoutePoW, bytes.NewReader(body))
+		request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		recorder := httptest.NewRecorder()
+		server.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	// data that doesn't fit into a message is rejected before the PoW is queued
+	body, err := json.Marshal(jsonmodels.PowRequest{Data: make([]byte, maxDataSize+1), Difficulty: 1})
+	require.NoError(t, err)
+	require.LessOrEqual(t, len(body), maxBodySize)
+	assert.Equal(t, http.StatusBadRequest, post(body).Code)
+
+	// larger bodies are not read at all
+	assert.Equal(t, http.StatusRequestEntityTooLarge, post(make([]byte, maxBodySize+1)).Code)
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	for _, proxies := range [][]string{{"invalid"}, {"10.0.0.0/33"}} {
+		_, err := parseTrustedProxies(proxies)
+		assert.Error(t, err, proxies)
+	}
+}
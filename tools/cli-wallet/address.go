@@ -4,9 +4,11 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strconv"
 	"text/tabwriter"
 
 	"github.com/iotaledger/goshimmer/client/wallet"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
 )
 
 func execAddressCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
@@ -76,7 +78,7 @@ func execAddressCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 
 		addressPrinted := false
 		for _, addr := range cliWallet.AddressManager().Addresses() {
-			_, _ = fmt.Fprintf(w, "%d\t%s\t%t\n", addr.Index, addr.Base58(), cliWallet.AddressManager().IsAddressSpent(addr.Index))
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%t\n", addressIndex(addr), addr.Base58(), cliWallet.AddressManager().IsSpent(addr))
 
 			addressPrinted = true
 		}
@@ -99,7 +101,7 @@ func execAddressCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 
 		addressPrinted := false
 		for _, addr := range cliWallet.AddressManager().UnspentAddresses() {
-			_, _ = fmt.Fprintf(w, "%d\t%s\t%t\n", addr.Index, addr.String(), cliWallet.AddressManager().IsAddressSpent(addr.Index))
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%t\n", addressIndex(addr), addr.String(), cliWallet.AddressManager().IsSpent(addr))
 
 			addressPrinted = true
 		}
@@ -122,7 +124,7 @@ func execAddressCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 
 		addressPrinted := false
 		for _, addr := range cliWallet.AddressManager().SpentAddresses() {
-			_, _ = fmt.Fprintf(w, "%d\t%s\t%t\n", addr.Index, addr.Base58(), cliWallet.AddressManager().IsAddressSpent(addr.Index))
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%t\n", addressIndex(addr), addr.Base58(), cliWallet.AddressManager().IsSpent(addr))
 
 			addressPrinted = true
 		}
@@ -132,3 +134,12 @@ func execAddressCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 		}
 	}
 }
+
+// addressIndex returns the index of the given address, remainder addresses on the change chain are marked as such.
+func addressIndex(addr address.Address) string {
+	if addr.Change {
+		return fmt.Sprintf("change/%d", addr.Index)
+	}
+
+	return strconv.FormatUint(addr.Index, 10)
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"os"
 	"text/tabwriter"
+	"time"
 
 	"github.com/iotaledger/goshimmer/client/wallet"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/refreshoptions"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 )
 
@@ -18,7 +20,7 @@ func execBalanceCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 	fmt.Println("Fetching balance...")
 
 	// refresh wallet once
-	err = cliWallet.Refresh(true)
+	err = cliWallet.Refresh(refreshoptions.RescanSpentAddresses(true))
 	if err != nil {
 		printUsage(nil, err.Error())
 	}
@@ -76,6 +78,16 @@ func execBalanceCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 		printTimedBalance(header, timeTitle, cliWallet, confirmedConditional, pendingConditional)
 	}
 
+	// fetch hash time-locked outputs
+	htlcs, err := cliWallet.HashTimeLockedOutputs(false)
+	if err != nil {
+		printUsage(nil, err.Error())
+	}
+
+	if len(htlcs) > 0 {
+		printHashTimeLockedOutputs(cliWallet, htlcs)
+	}
+
 	// fetch balances from wallet
 	confirmedGovAliasBalance, confirmedStateAliasBalance, pendingGovAliasBalance, pendingStateAliasBalance, err := cliWallet.AliasBalance(false)
 	if err != nil {
@@ -86,6 +98,9 @@ func execBalanceCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 		printUsage(nil, err.Error())
 	}
 
+	// collect the aliases that control the supply of their tokens before the state controlled ones are filtered
+	confirmedSupplies, pendingSupplies := supplyControlledAliases(confirmedStateAliasBalance), supplyControlledAliases(pendingStateAliasBalance)
+
 	// process returned data, prepare for printing
 
 	// remove alias outputs from confirmedStateAliasBalance that are state & governance controlled
@@ -184,6 +199,11 @@ func execBalanceCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 	if len(confirmedDel) != 0 || len(pendingDel) != 0 {
 		printAliasBalance("Delegated Funds", "DELEGATION ID (ALIAS ID)", cliWallet, confirmedDel, pendingDel)
 	}
+
+	// print the token supplies that are controlled by the wallet
+	if len(confirmedSupplies) != 0 || len(pendingSupplies) != 0 {
+		printTokenSupplies(cliWallet, confirmedSupplies, pendingSupplies)
+	}
 }
 
 func printTimedBalance(header, timeTitle string, cliWallet *wallet.Wallet, confirmed, pending wallet.TimedBalanceSlice) {
@@ -275,3 +295,85 @@ func printAliasBalance(header, idName string, cliWallet *wallet.Wallet, confirme
 
 	_ = w.Flush()
 }
+
+func printHashTimeLockedOutputs(cliWallet *wallet.Wallet, htlcs wallet.OutputsByID) {
+	// initialize tab writer
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 0, 8, 2, '\t', 0)
+	// print header
+	fmt.Println()
+	fmt.Println("Hash Time-Locked Outputs - execute `claim-htlc` or `refund-htlc` command to sweep these funds into wallet")
+	fmt.Println()
+	_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", "STATUS", "OUTPUT ID", "ACTION", "DEADLINE", "BALANCE", "COLOR")
+	_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", "------", "--------------------------------------------", "----------------", "------------------------------", "---------------", "--------------------------------------------")
+
+	now := time.Now()
+	for outputID, output := range htlcs {
+		casted := output.Object.(*ledgerstate.ExtendedLockedOutput)
+		_, fallbackDeadline := casted.FallbackOptions()
+		status := "[PEND]"
+		if output.GradeOfFinalityReached {
+			status = "[ OK ]"
+		}
+		var action string
+		switch {
+		case casted.HashLockedNow(now) && output.Address.Address().Equals(casted.Address()):
+			action = "claim until"
+		case casted.HashLockedNow(now):
+			action = "refund after"
+		case output.Address.Address().Equals(casted.UnlockAddressNow(now)):
+			action = "refund since"
+		default:
+			action = "expired since"
+		}
+		i := 0
+		casted.Balances().ForEach(func(color ledgerstate.Color, balance uint64) bool {
+			if i == 0 {
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d %s\t%s\n", status, outputID.Base58(), action, fallbackDeadline.String(),
+					balance, cliWallet.AssetRegistry().Symbol(color), color.String())
+			} else {
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d %s\t%s\n", "", "", "", "",
+					balance, cliWallet.AssetRegistry().Symbol(color), color.String())
+			}
+			i++
+			return true
+		})
+	}
+	_ = w.Flush()
+}
+
+func supplyControlledAliases(aliases map[ledgerstate.AliasAddress]*ledgerstate.AliasOutput) (supplyControlled []*ledgerstate.AliasOutput) {
+	for _, alias := range aliases {
+		if alias.IsSupplyControlled() {
+			supplyControlled = append(supplyControlled, alias)
+		}
+	}
+	return supplyControlled
+}
+
+func printTokenSupplies(cliWallet *wallet.Wallet, confirmed, pending []*ledgerstate.AliasOutput) {
+	// initialize tab writer
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 0, 8, 2, '\t', 0)
+	// print header
+	fmt.Println()
+	fmt.Println("Supply-Controlled Tokens - execute `mint-tokens` or `burn-tokens` command to change their supply")
+	fmt.Println()
+	_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", "STATUS", "ALIAS ID", "SUPPLY", "COLOR", "TOKEN NAME")
+	_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", "------", "--------------------------------------------", "---------------", "--------------------------------------------", "-------------------------")
+
+	printSupply := func(status string, alias *ledgerstate.AliasOutput) {
+		color := alias.TokenColor()
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%d %s\t%s\t%s\n", status, alias.GetAliasAddress().Base58(), alias.TokenSupply(),
+			cliWallet.AssetRegistry().Symbol(color),
+			color.String(),
+			cliWallet.AssetRegistry().Name(color))
+	}
+	for _, alias := range confirmed {
+		printSupply("[ OK ]", alias)
+	}
+	for _, alias := range pending {
+		printSupply("[PEND]", alias)
+	}
+	_ = w.Flush()
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mr-tron/base58"
+
+	"github.com/iotaledger/goshimmer/client/wallet"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/claimhtlcoptions"
+)
+
+func execClaimHTLCCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	helpPtr := command.Bool("help", false, "show this help screen")
+	outputIDPtr := command.String("output-id", "", "ID of the hash time-locked output to claim")
+	preimagePtr := command.String("preimage", "", "base58 encoded preimage of the hash lock")
+	addressPtr := command.String("dest-addr", "", "(optional) address to send the claimed funds to")
+	accessManaPledgeIDPtr := command.String("access-mana-id", "", "node ID to pledge access mana to")
+	consensusManaPledgeIDPtr := command.String("consensus-mana-id", "", "node ID to pledge consensus mana to")
+
+	err := command.Parse(os.Args[2:])
+	if err != nil {
+		panic(err)
+	}
+
+	if *helpPtr {
+		printUsage(command)
+	}
+
+	if *outputIDPtr == "" {
+		printUsage(command, "output-id has to be set")
+	}
+	if *preimagePtr == "" {
+		printUsage(command, "preimage has to be set")
+	}
+	preimage, err := base58.Decode(*preimagePtr)
+	if err != nil {
+		printUsage(command, fmt.Sprintf("wrong preimage: %s", err.Error()))
+	}
+
+	options := []claimhtlcoptions.ClaimHTLCOption{
+		claimhtlcoptions.OutputID(*outputIDPtr),
+		claimhtlcoptions.Preimage(preimage),
+		claimhtlcoptions.AccessManaPledgeID(*accessManaPledgeIDPtr),
+		claimhtlcoptions.ConsensusManaPledgeID(*consensusManaPledgeIDPtr),
+	}
+	if *addressPtr != "" {
+		options = append(options, claimhtlcoptions.ToAddress(*addressPtr))
+	}
+
+	fmt.Println("Claiming hash time-locked output...")
+	_, err = cliWallet.ClaimHTLC(options...)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	fmt.Println()
+	fmt.Println("Claiming hash time-locked output... [DONE]")
+}
@@ -13,6 +13,7 @@ type configuration struct {
 	BasicAuth            client.BasicAuth `json:"basicAuth,omitempty"`
 	ReuseAddresses       bool             `json:"reuse_addresses"`
 	FaucetPowDifficulty  int              `json:"faucetPowDifficulty"`
+	RemotePoW            bool             `json:"remotePoW"`
 	AssetRegistryNetwork string           `json:"assetRegistryNetwork"`
 }
 
@@ -28,6 +29,7 @@ var configJSON = `{
 	},
 	"reuse_addresses": false,
 	"faucetPowDifficulty": 25,
+	"remotePoW": false,
 	"assetRegistryNetwork": "nectar"
 }`
 
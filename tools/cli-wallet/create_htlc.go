@@ -0,0 +1,121 @@
+package main
+
+import (
+	"crypto/rand"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mr-tron/base58"
+
+	"github.com/iotaledger/goshimmer/client/wallet"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/sendoptions"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+func execCreateHTLCCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	helpPtr := command.Bool("help", false, "show this help screen")
+	addressPtr := command.String("dest-addr", "", "address of the counterparty that can claim the funds with the preimage")
+	amountPtr := command.Int64("amount", 0, "the amount of tokens that are supposed to be locked")
+	colorPtr := command.String("color", "IOTA", "(optional) color of the tokens to lock")
+	hashTypePtr := command.String("hash-type", "sha256", "(optional) hash function of the hash lock: sha256 or blake2b")
+	hashLockPtr := command.String("hash-lock", "", "(optional) base58 encoded hash the counterparty has to reveal the preimage of. If empty, a random preimage is generated")
+	fallbackDeadlinePtr := command.Int64("fallb-deadline", 0, "unix timestamp after which the funds can be refunded to the wallet")
+	accessManaPledgeIDPtr := command.String("access-mana-id", "", "node ID to pledge access mana to")
+	consensusManaPledgeIDPtr := command.String("consensus-mana-id", "", "node ID to pledge consensus mana to")
+
+	err := command.Parse(os.Args[2:])
+	if err != nil {
+		panic(err)
+	}
+
+	if *helpPtr {
+		printUsage(command)
+	}
+
+	if *addressPtr == "" {
+		printUsage(command, "dest-addr has to be set")
+	}
+	if *amountPtr <= 0 {
+		printUsage(command, "amount has to be set and be bigger than 0")
+	}
+	if *fallbackDeadlinePtr <= 0 {
+		printUsage(command, "fallb-deadline has to be set")
+	}
+	fallbackDeadline := time.Unix(*fallbackDeadlinePtr, 0)
+	if fallbackDeadline.Before(time.Now()) {
+		printUsage(command, fmt.Sprintf("fallback deadline %s is in the past", fallbackDeadline.String()))
+	}
+
+	destinationAddress, err := ledgerstate.AddressFromBase58EncodedString(*addressPtr)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	var color ledgerstate.Color
+	switch *colorPtr {
+	case "IOTA":
+		color = ledgerstate.ColorIOTA
+	default:
+		colorBytes, parseErr := base58.Decode(*colorPtr)
+		if parseErr != nil {
+			printUsage(command, parseErr.Error())
+		}
+
+		color, _, parseErr = ledgerstate.ColorFromBytes(colorBytes)
+		if parseErr != nil {
+			printUsage(command, parseErr.Error())
+		}
+	}
+
+	hashLockType, err := ledgerstate.HashLockTypeFromString(*hashTypePtr)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	var preimage []byte
+	var hashLock [ledgerstate.HashLockSize]byte
+	if *hashLockPtr == "" {
+		// we are the initiator of the swap and choose the secret
+		preimage = make([]byte, 32)
+		if _, err = rand.Read(preimage); err != nil {
+			printUsage(command, err.Error())
+		}
+		hashLock = hashLockType.Hash(preimage)
+	} else {
+		hashLockBytes, decodeErr := base58.Decode(*hashLockPtr)
+		if decodeErr != nil || len(hashLockBytes) != ledgerstate.HashLockSize {
+			printUsage(command, fmt.Sprintf("hash-lock must be a base58 encoded %d byte hash", ledgerstate.HashLockSize))
+		}
+		copy(hashLock[:], hashLockBytes)
+	}
+
+	fmt.Println("Creating hash time-locked output...")
+	tx, err := cliWallet.SendFunds(
+		sendoptions.Destination(address.Address{AddressBytes: destinationAddress.Array()}, uint64(*amountPtr), color),
+		sendoptions.Fallback(cliWallet.ReceiveAddress().Address(), fallbackDeadline),
+		sendoptions.HashLock(hashLockType, hashLock),
+		sendoptions.AccessManaPledgeID(*accessManaPledgeIDPtr),
+		sendoptions.ConsensusManaPledgeID(*consensusManaPledgeIDPtr),
+		sendoptions.UsePendingOutputs(false),
+	)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	fmt.Println()
+	for _, output := range tx.Essence().Outputs() {
+		if output.Type() == ledgerstate.ExtendedLockedOutputType && output.Address().Equals(destinationAddress) {
+			fmt.Println("HTLC output ID: ", output.ID().Base58())
+		}
+	}
+	fmt.Println("Hash lock:      ", hashLockType.String(), base58.Encode(hashLock[:]))
+	if preimage != nil {
+		fmt.Println("Preimage:       ", base58.Encode(preimage), "(keep it secret until the counterparty locked its funds)")
+	}
+	fmt.Println("Refundable after:", fallbackDeadline.String())
+	fmt.Println()
+	fmt.Println("Creating hash time-locked output... [DONE]")
+}
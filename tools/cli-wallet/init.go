@@ -0,0 +1,177 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/mr-tron/base58"
+
+	"github.com/iotaledger/goshimmer/client/wallet"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/refreshoptions"
+	walletseed "github.com/iotaledger/goshimmer/client/wallet/packages/seed"
+)
+
+// recoveryGapLimit is the gap limit of the recovery scan that is performed after the wallet was restored.
+var recoveryGapLimit = refreshoptions.DefaultGapLimit
+
+// createSeed creates the seed of a new wallet according to the flags of the init or restore command.
+func createSeed() *walletseed.Seed {
+	switch os.Args[1] {
+	case "init":
+		return execInitCommand(flag.NewFlagSet("init", flag.ExitOnError))
+	case "restore":
+		return execRestoreCommand(flag.NewFlagSet("restore", flag.ExitOnError))
+	default:
+		panic("seeds can only be created by the init or restore command")
+	}
+}
+
+func execInitCommand(command *flag.FlagSet) *walletseed.Seed {
+	command.Usage = func() {
+		printUsage(command)
+	}
+
+	mnemonicPtr := command.Bool("mnemonic", false, "generate a BIP-39 mnemonic phrase instead of a base58 seed")
+	passphrasePtr := command.String("passphrase", "", "optional passphrase that protects the mnemonic (requires -mnemonic)")
+	accountPtr := command.Uint("account", 0, "account of the hierarchical derivation path (requires -mnemonic)")
+	helpPtr := command.Bool("help", false, "display this help screen")
+
+	err := command.Parse(os.Args[2:])
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+	if *helpPtr {
+		printUsage(command)
+	}
+
+	if !*mnemonicPtr {
+		if *passphrasePtr != "" || *accountPtr != 0 {
+			printUsage(command, "-passphrase and -account can only be used together with -mnemonic")
+		}
+
+		seed := walletseed.NewSeed()
+		printSeedBackup(base58.Encode(seed.Bytes()))
+
+		return seed
+	}
+
+	mnemonic, err := walletseed.NewMnemonic()
+	if err != nil {
+		panic(err)
+	}
+	seed := seedFromMnemonic(command, mnemonic, *passphrasePtr, *accountPtr)
+	printMnemonicBackup(mnemonic, seed)
+
+	return seed
+}
+
+func execRestoreCommand(command *flag.FlagSet) *walletseed.Seed {
+	command.Usage = func() {
+		printUsage(command)
+	}
+
+	mnemonicPtr := command.String("mnemonic", "", "BIP-39 mnemonic phrase of the wallet (in quotes)")
+	seedPtr := command.String("seed", "", "base58 encoded seed of the wallet")
+	passphrasePtr := command.String("passphrase", "", "optional passphrase that protects the mnemonic (requires -mnemonic)")
+	accountPtr := command.Uint("account", 0, "account of the hierarchical derivation path (requires -mnemonic)")
+	gapLimitPtr := command.Int("gap-limit", refreshoptions.DefaultGapLimit, "number of consecutive unused addresses that end the scan for funds")
+	helpPtr := command.Bool("help", false, "display this help screen")
+
+	err := command.Parse(os.Args[2:])
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+	if *helpPtr {
+		printUsage(command)
+	}
+	if *gapLimitPtr <= 0 {
+		printUsage(command, "gap-limit needs to be positive")
+	}
+	recoveryGapLimit = *gapLimitPtr
+
+	switch {
+	case *mnemonicPtr != "" && *seedPtr != "":
+		printUsage(command, "please provide either -mnemonic or -seed")
+	case *mnemonicPtr != "":
+		seed := seedFromMnemonic(command, *mnemonicPtr, *passphrasePtr, *accountPtr)
+		fmt.Println("RESTORING WALLET FROM MNEMONIC ...                        [DONE]")
+		fmt.Println("Derivation path of the first address: " + seed.DerivationPath(0))
+
+		return seed
+	case *seedPtr != "":
+		if *passphrasePtr != "" || *accountPtr != 0 {
+			printUsage(command, "-passphrase and -account can only be used together with -mnemonic")
+		}
+		seedBytes, decodeErr := base58.Decode(*seedPtr)
+		if decodeErr != nil || len(seedBytes) != ed25519.SeedSize {
+			printUsage(command, "invalid base58 seed")
+		}
+		fmt.Println("RESTORING WALLET FROM SEED ...                            [DONE]")
+
+		return walletseed.NewSeed(seedBytes)
+	}
+
+	printUsage(command, "please provide the -mnemonic or the -seed of the wallet")
+
+	return nil
+}
+
+func execRecoverCommand(cliWallet *wallet.Wallet) {
+	fmt.Println()
+	fmt.Println("Scanning addresses for funds ...                          (this can take a while)")
+
+	if err := cliWallet.Refresh(refreshoptions.Recover(recoveryGapLimit)); err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("Scanning addresses for funds ... [DONE]                    (%d addresses)\n", len(cliWallet.AddressManager().Addresses()))
+}
+
+func seedFromMnemonic(command *flag.FlagSet, mnemonic, passphrase string, account uint) *walletseed.Seed {
+	if account > walletseed.MaxIndex {
+		printUsage(command, fmt.Sprintf("account needs to be smaller than %d", walletseed.MaxIndex+1))
+	}
+
+	seed, err := walletseed.FromMnemonic(mnemonic, passphrase, uint32(account))
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	return seed
+}
+
+func printSeedBackup(encodedSeed string) {
+	fmt.Println("GENERATING NEW WALLET ...                                 [DONE]")
+	fmt.Println()
+	fmt.Println("================================================================")
+	fmt.Println("!!!            PLEASE CREATE A BACKUP OF YOUR SEED           !!!")
+	fmt.Println("!!!                                                          !!!")
+	fmt.Println("!!!       " + encodedSeed + "       !!!")
+	fmt.Println("!!!                                                          !!!")
+	fmt.Println("!!!            PLEASE CREATE A BACKUP OF YOUR SEED           !!!")
+	fmt.Println("================================================================")
+}
+
+func printMnemonicBackup(mnemonic string, seed *walletseed.Seed) {
+	fmt.Println("GENERATING NEW WALLET ...                                 [DONE]")
+	fmt.Println()
+	fmt.Println("================================================================")
+	fmt.Println("!!!          PLEASE CREATE A BACKUP OF YOUR MNEMONIC         !!!")
+	fmt.Println("!!!                                                          !!!")
+	words := strings.Fields(mnemonic)
+	for i := 0; i < len(words); i += 4 {
+		line := ""
+		for j := i; j < i+4 && j < len(words); j++ {
+			line += fmt.Sprintf("%2d. %-8s ", j+1, words[j])
+		}
+		fmt.Printf("!!!   %-52s   !!!\n", line)
+	}
+	fmt.Println("!!!                                                          !!!")
+	fmt.Println("!!!  the passphrase (if any) is required to restore as well  !!!")
+	fmt.Println("!!!          PLEASE CREATE A BACKUP OF YOUR MNEMONIC         !!!")
+	fmt.Println("================================================================")
+	fmt.Println("Derivation path of the first address: " + seed.DerivationPath(0))
+}
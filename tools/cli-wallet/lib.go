@@ -9,9 +9,7 @@ import (
 
 	"github.com/capossele/asset-registry/pkg/registryservice"
 	"github.com/iotaledger/hive.go/bitmask"
-	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/marshalutil"
-	"github.com/mr-tron/base58"
 
 	"github.com/iotaledger/goshimmer/client"
 	"github.com/iotaledger/goshimmer/client/wallet"
@@ -26,7 +24,7 @@ func printBanner() {
 }
 
 func loadWallet() *wallet.Wallet {
-	seed, lastAddressIndex, spentAddresses, assetRegistry, err := importWalletStateFile("wallet.dat")
+	seed, lastAddressIndex, spentAddresses, changeAddressCount, spentChangeAddresses, assetRegistry, err := importWalletStateFile("wallet.dat")
 	if err != nil {
 		panic(err)
 	}
@@ -36,6 +34,9 @@ func loadWallet() *wallet.Wallet {
 	if config.BasicAuth.IsEnabled() {
 		options = append(options, client.WithBasicAuth(config.BasicAuth.Credentials()))
 	}
+	if config.RemotePoW {
+		options = append(options, client.WithRemotePoW())
+	}
 
 	if assetRegistry != nil {
 		// we do have an asset registry parsed
@@ -52,6 +53,7 @@ func loadWallet() *wallet.Wallet {
 	walletOptions := []wallet.Option{
 		wallet.WebAPI(config.WebAPI, options...),
 		wallet.Import(seed, lastAddressIndex, spentAddresses, assetRegistry),
+		wallet.ImportChangeAddresses(changeAddressCount, spentChangeAddresses),
 	}
 	if config.ReuseAddresses {
 		walletOptions = append(walletOptions, wallet.ReusableAddress(true))
@@ -62,43 +64,32 @@ func loadWallet() *wallet.Wallet {
 	return wallet.New(walletOptions...)
 }
 
-func importWalletStateFile(filename string) (seed *walletseed.Seed, lastAddressIndex uint64, spentAddresses []bitmask.BitMask, assetRegistry *wallet.AssetRegistry, err error) {
+func importWalletStateFile(filename string) (seed *walletseed.Seed, lastAddressIndex uint64, spentAddresses []bitmask.BitMask, changeAddressCount uint64, spentChangeAddresses []bitmask.BitMask, assetRegistry *wallet.AssetRegistry, err error) {
 	walletStateBytes, err := os.ReadFile(filename)
 	if err != nil {
 		if !os.IsNotExist(err) {
 			return
 		}
 
-		if len(os.Args) < 2 || os.Args[1] != "init" {
-			printUsage(nil, "no wallet file (wallet.dat) found: please call \""+filepath.Base(os.Args[0])+" init\"")
+		if len(os.Args) < 2 || (os.Args[1] != "init" && os.Args[1] != "restore") {
+			printUsage(nil, "no wallet file (wallet.dat) found: please call \""+filepath.Base(os.Args[0])+" init\" or \""+filepath.Base(os.Args[0])+" restore\"")
 		}
 
-		seed = walletseed.NewSeed()
+		seed = createSeed()
 		lastAddressIndex = 0
 		spentAddresses = []bitmask.BitMask{}
 		err = nil
 
-		fmt.Println("GENERATING NEW WALLET ...                                 [DONE]")
-		fmt.Println()
-		fmt.Println("================================================================")
-		fmt.Println("!!!            PLEASE CREATE A BACKUP OF YOUR SEED           !!!")
-		fmt.Println("!!!                                                          !!!")
-		fmt.Println("!!!       " + base58.Encode(seed.Bytes()) + "       !!!")
-		fmt.Println("!!!                                                          !!!")
-		fmt.Println("!!!            PLEASE CREATE A BACKUP OF YOUR SEED           !!!")
-		fmt.Println("================================================================")
-
 		return
 	}
 
-	if len(os.Args) >= 2 && os.Args[1] == "init" {
+	if len(os.Args) >= 2 && (os.Args[1] == "init" || os.Args[1] == "restore") {
 		printUsage(nil, "please remove the wallet.dat before trying to create a new wallet")
 	}
 
 	marshalUtil := marshalutil.New(walletStateBytes)
 
-	seedBytes, err := marshalUtil.ReadBytes(ed25519.SeedSize)
-	seed = walletseed.NewSeed(seedBytes)
+	seed, err = walletseed.FromMarshalUtil(marshalUtil)
 	if err != nil {
 		return
 	}
@@ -109,6 +100,27 @@ func importWalletStateFile(filename string) (seed *walletseed.Seed, lastAddressI
 	}
 
 	assetRegistry, _, err = wallet.ParseAssetRegistry(marshalUtil)
+	if err != nil {
+		return
+	}
+
+	// the state of hierarchical seeds contains the remainder addresses on the change chain
+	if seed.IsHierarchical() {
+		if changeAddressCount, err = marshalUtil.ReadUint64(); err != nil {
+			return
+		}
+		spentChangeAddressesLength, lengthErr := marshalUtil.ReadUint32()
+		if lengthErr != nil {
+			err = lengthErr
+			return
+		}
+		spentChangeAddressesBytes, readErr := marshalUtil.ReadBytes(int(spentChangeAddressesLength))
+		if readErr != nil {
+			err = readErr
+			return
+		}
+		spentChangeAddresses = *(*[]bitmask.BitMask)(unsafe.Pointer(&spentChangeAddressesBytes))
+	}
 
 	spentAddressesBytes := marshalUtil.ReadRemainingBytes()
 	spentAddresses = *(*[]bitmask.BitMask)(unsafe.Pointer(&spentAddressesBytes))
@@ -163,6 +175,12 @@ func printUsage(command *flag.FlagSet, optionalErrorMessage ...string) {
 		fmt.Println("        consolidate available funds under one wallet address")
 		fmt.Println("  claim-conditional")
 		fmt.Println("        claim (move) conditionally owned funds into the wallet")
+		fmt.Println("  create-htlc")
+		fmt.Println("        lock funds with a hash time-locked output for an atomic swap")
+		fmt.Println("  claim-htlc")
+		fmt.Println("        claim a hash time-locked output by revealing its preimage")
+		fmt.Println("  refund-htlc")
+		fmt.Println("        refund a hash time-locked output after its fallback deadline")
 		fmt.Println("  request-funds")
 		fmt.Println("        request funds from the testnet-faucet")
 		fmt.Println("  create-asset")
@@ -183,6 +201,10 @@ func printUsage(command *flag.FlagSet, optionalErrorMessage ...string) {
 		fmt.Println("        deposit funds into an nft")
 		fmt.Println("  withdraw-from-nft")
 		fmt.Println("        withdraw funds from an nft")
+		fmt.Println("  mint-tokens")
+		fmt.Println("        mint tokens whose supply is controlled by an alias")
+		fmt.Println("  burn-tokens")
+		fmt.Println("        burn tokens whose supply is controlled by an alias")
 		fmt.Println("  sweep-nft-owned-funds")
 		fmt.Println("        sweep all available funds owned by nft into the wallet")
 		fmt.Println("  sweep-nft-owned-nfts")
@@ -190,7 +212,9 @@ func printUsage(command *flag.FlagSet, optionalErrorMessage ...string) {
 		fmt.Println("  address")
 		fmt.Println("        start the address manager of this wallet")
 		fmt.Println("  init")
-		fmt.Println("        generate a new wallet using a random seed")
+		fmt.Println("        generate a new wallet using a random seed (or a BIP-39 mnemonic with -mnemonic)")
+		fmt.Println("  restore")
+		fmt.Println("        restore a wallet from its mnemonic or base58 seed")
 		fmt.Println("  server-status")
 		fmt.Println("        display the server status")
 		fmt.Println("  pledge-id")
@@ -62,6 +62,9 @@ func main() {
 	sendFundsCommand := flag.NewFlagSet("send-funds", flag.ExitOnError)
 	consolidateFundsCommand := flag.NewFlagSet("consolidate-funds", flag.ExitOnError)
 	claimConditionalFundsCommand := flag.NewFlagSet("claim-conditional", flag.ExitOnError)
+	createHTLCCommand := flag.NewFlagSet("create-htlc", flag.ExitOnError)
+	claimHTLCCommand := flag.NewFlagSet("claim-htlc", flag.ExitOnError)
+	refundHTLCCommand := flag.NewFlagSet("refund-htlc", flag.ExitOnError)
 	createAssetCommand := flag.NewFlagSet("create-asset", flag.ExitOnError)
 	assetInfoCommand := flag.NewFlagSet("asset-info", flag.ExitOnError)
 	delegateFundsCommand := flag.NewFlagSet("delegate-funds", flag.ExitOnError)
@@ -71,6 +74,8 @@ func main() {
 	destroyNFTCommand := flag.NewFlagSet("destroy-nft", flag.ExitOnError)
 	depositToNFTCommand := flag.NewFlagSet("deposit-to-nft", flag.ExitOnError)
 	withdrawFromNFTCommand := flag.NewFlagSet("withdraw-from-nft", flag.ExitOnError)
+	mintTokensCommand := flag.NewFlagSet("mint-tokens", flag.ExitOnError)
+	burnTokensCommand := flag.NewFlagSet("burn-tokens", flag.ExitOnError)
 	sweepNFTOwnedFundsCommand := flag.NewFlagSet("sweep-nft-owned-funds", flag.ExitOnError)
 	sweepNFTOwnedNFTsCommand := flag.NewFlagSet("sweep-nft-owned-nfts", flag.ExitOnError)
 	addressCommand := flag.NewFlagSet("address", flag.ExitOnError)
@@ -91,6 +96,12 @@ func main() {
 		execConsolidateFundsCommand(consolidateFundsCommand, wallet)
 	case "claim-conditional":
 		execClaimConditionalCommand(claimConditionalFundsCommand, wallet)
+	case "create-htlc":
+		execCreateHTLCCommand(createHTLCCommand, wallet)
+	case "claim-htlc":
+		execClaimHTLCCommand(claimHTLCCommand, wallet)
+	case "refund-htlc":
+		execRefundHTLCCommand(refundHTLCCommand, wallet)
 	case "create-asset":
 		execCreateAssetCommand(createAssetCommand, wallet)
 	case "asset-info":
@@ -109,6 +120,10 @@ func main() {
 		execDepositToNFTCommand(depositToNFTCommand, wallet)
 	case "withdraw-from-nft":
 		execWithdrawFromFTCommand(withdrawFromNFTCommand, wallet)
+	case "mint-tokens":
+		execMintTokensCommand(mintTokensCommand, wallet)
+	case "burn-tokens":
+		execBurnTokensCommand(burnTokensCommand, wallet)
 	case "sweep-nft-owned-funds":
 		execSweepNFTOwnedFundsCommand(sweepNFTOwnedFundsCommand, wallet)
 	case "sweep-nft-owned-nfts":
@@ -122,6 +137,10 @@ func main() {
 	case "init":
 		fmt.Println()
 		fmt.Println("CREATING WALLET STATE FILE (wallet.dat) ...               [DONE]")
+	case "restore":
+		execRecoverCommand(wallet)
+		fmt.Println()
+		fmt.Println("CREATING WALLET STATE FILE (wallet.dat) ...               [DONE]")
 	case "server-status":
 		execServerStatusCommand(serverStatusCommand, wallet)
 	case "help":
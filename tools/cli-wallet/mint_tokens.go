@@ -0,0 +1,62 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/iotaledger/goshimmer/client/wallet"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/minttokensoptions"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+func execMintTokensCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	command.Usage = func() {
+		printUsage(command)
+	}
+
+	helpPtr := command.Bool("help", false, "show this help screen")
+	aliasIDPtr := command.String("id", "", "unique identifier of the alias that controls the token supply")
+	amountPtr := command.Int64("amount", 0, "the amount of tokens that are supposed to be minted")
+	addressPtr := command.String("dest-addr", "", "(optional) address to send the minted tokens to")
+	accessManaPledgeIDPtr := command.String("access-mana-id", "", "node ID to pledge access mana to")
+	consensusManaPledgeIDPtr := command.String("consensus-mana-id", "", "node ID to pledge consensus mana to")
+
+	err := command.Parse(os.Args[2:])
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+	if *helpPtr {
+		printUsage(command)
+	}
+
+	if *aliasIDPtr == "" {
+		printUsage(command, "an alias ID must be given for minting")
+	}
+	if *amountPtr <= 0 {
+		printUsage(command, "amount has to be set and be bigger than 0")
+	}
+
+	aliasID, err := ledgerstate.AliasAddressFromBase58EncodedString(*aliasIDPtr)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	options := []minttokensoptions.MintTokensOption{
+		minttokensoptions.Alias(aliasID.Base58()),
+		minttokensoptions.Amount(uint64(*amountPtr)),
+		minttokensoptions.AccessManaPledgeID(*accessManaPledgeIDPtr),
+		minttokensoptions.ConsensusManaPledgeID(*consensusManaPledgeIDPtr),
+	}
+	if *addressPtr != "" {
+		options = append(options, minttokensoptions.ToAddress(*addressPtr))
+	}
+
+	fmt.Println("Minting tokens...")
+	_, tokenColor, err := cliWallet.MintTokens(options...)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+	fmt.Println("Minting tokens... [DONE]")
+	fmt.Println("Token Color:", tokenColor.Base58())
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/iotaledger/goshimmer/client/wallet"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/refundhtlcoptions"
+)
+
+func execRefundHTLCCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	helpPtr := command.Bool("help", false, "show this help screen")
+	outputIDPtr := command.String("output-id", "", "ID of the hash time-locked output to refund")
+	addressPtr := command.String("dest-addr", "", "(optional) address to send the refunded funds to")
+	accessManaPledgeIDPtr := command.String("access-mana-id", "", "node ID to pledge access mana to")
+	consensusManaPledgeIDPtr := command.String("consensus-mana-id", "", "node ID to pledge consensus mana to")
+
+	err := command.Parse(os.Args[2:])
+	if err != nil {
+		panic(err)
+	}
+
+	if *helpPtr {
+		printUsage(command)
+	}
+
+	if *outputIDPtr == "" {
+		printUsage(command, "output-id has to be set")
+	}
+
+	options := []refundhtlcoptions.RefundHTLCOption{
+		refundhtlcoptions.OutputID(*outputIDPtr),
+		refundhtlcoptions.AccessManaPledgeID(*accessManaPledgeIDPtr),
+		refundhtlcoptions.ConsensusManaPledgeID(*consensusManaPledgeIDPtr),
+	}
+	if *addressPtr != "" {
+		options = append(options, refundhtlcoptions.ToAddress(*addressPtr))
+	}
+
+	fmt.Println("Refunding hash time-locked output...")
+	_, err = cliWallet.RefundHTLC(options...)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	fmt.Println()
+	fmt.Println("Refunding hash time-locked output... [DONE]")
+}
@@ -0,0 +1,74 @@
+// Package main implements an offline tool that checks the database of a stopped node for broken invariants of the
+// tangle, the markers, the branch DAG and the UTXO storages, and optionally repairs or rolls back the inconsistent
+// entries.
+package main
+
+import (
+	"log"
+	"os"
+	"time"
+
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+const (
+	cfgDatabaseDirectory = "db"
+	cfgRepair            = "repair"
+	cfgFast              = "fast"
+)
+
+func init() {
+	flag.String(cfgDatabaseDirectory, "mainnetdb", "path to the database directory of the stopped node")
+	flag.Bool(cfgRepair, false, "delete the inconsistent entries and roll back the messages that can't be trusted anymore")
+	flag.Bool(cfgFast, false, "only run the checks that are also run on startup after an unclean shutdown")
+}
+
+func main() {
+	flag.Parse()
+	if err := viper.BindPFlags(flag.CommandLine); err != nil {
+		panic(err)
+	}
+
+	directory := viper.GetString(cfgDatabaseDirectory)
+	if _, err := os.Stat(directory); err != nil {
+		log.Fatalf("unable to access the database directory %s: %s", directory, err)
+	}
+
+	db, err := database.NewDB(directory)
+	if err != nil {
+		log.Fatalf("unable to open the database %s: %s", directory, err)
+	}
+
+	mode := database.FullConsistencyCheck
+	if viper.GetBool(cfgFast) {
+		mode = database.FastConsistencyCheck
+	}
+	repair := viper.GetBool(cfgRepair)
+
+	log.Printf("checking the consistency of %s (mode=%s, repair=%t)...", directory, mode, repair)
+	start := time.Now()
+
+	t := tangle.New(tangle.Store(db.NewStore()), tangle.CacheTimeProvider(database.NewCacheTimeProvider(0)))
+	report := t.CheckConsistency(mode, repair)
+	t.Shutdown()
+
+	if err := db.Close(); err != nil {
+		log.Fatalf("unable to flush the database: %s", err)
+	}
+
+	for _, issue := range report.Issues {
+		log.Print(issue)
+	}
+	log.Printf("done, took %v: %s", time.Since(start), report)
+
+	if unrepaired := report.Unrepaired(); len(unrepaired) > 0 {
+		if !repair {
+			log.Printf("run again with --%s to repair the database", cfgRepair)
+		}
+		os.Exit(1)
+	}
+}
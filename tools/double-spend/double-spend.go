@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"sync"
@@ -32,7 +33,7 @@ func main() {
 	mySeed := walletseed.NewSeed()
 	myAddr := mySeed.Address(0)
 
-	if _, err := clients[0].SendFaucetRequest(myAddr.Address().Base58(), -1); err != nil {
+	if _, err := clients[0].SendFaucetRequest(context.Background(), myAddr.Address().Base58(), -1); err != nil {
 		fmt.Println(err)
 		return
 	}
@@ -42,7 +43,7 @@ func main() {
 	// wait for the funds
 	for i := 0; i < 10; i++ {
 		time.Sleep(5 * time.Second)
-		resp, err := clients[0].PostAddressUnspentOutputs([]string{myAddr.Address().Base58()})
+		resp, err := clients[0].PostAddressUnspentOutputs(context.Background(), []string{myAddr.Address().Base58()})
 		if err != nil {
 			fmt.Println(err)
 			return
@@ -102,7 +103,7 @@ func main() {
 			conflictingTxs[i] = tx
 
 			// issue the tx
-			resp, err2 := clients[i].PostTransaction(tx.Bytes())
+			resp, err2 := clients[i].PostTransaction(context.Background(), tx.Bytes())
 			if err2 != nil {
 				fmt.Println(err)
 				return
@@ -16,6 +16,7 @@ import (
 	"github.com/iotaledger/goshimmer/client/wallet"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/seed"
+	"github.com/iotaledger/goshimmer/packages/assetregistry"
 	"github.com/iotaledger/goshimmer/packages/consensus/gof"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/mana"
@@ -333,6 +334,12 @@ func (connector *mockConnector) UnspentOutputs(addresses ...address.Address) (ou
 	return
 }
 
+func (connector *mockConnector) ScanAddresses(addresses ...address.Address) (outputs wallet.OutputsByAddressAndOutputID, spentAddresses map[address.Address]bool, err error) {
+	outputs, err = connector.UnspentOutputs(addresses...)
+
+	return
+}
+
 func newMockConnector(outputs ...*wallet.Output) (connector *mockConnector) {
 	connector = &mockConnector{
 		outputs: make(map[address.Address]map[ledgerstate.OutputID]*wallet.Output),
@@ -367,6 +374,14 @@ func (connector *mockConnector) GetUnspentAliasOutput(addr *ledgerstate.AliasAdd
 	return
 }
 
+func (connector *mockConnector) IssueAssetMetadata(metadataPayload *assetregistry.Payload) (err error) {
+	return
+}
+
+func (connector *mockConnector) GetAsset(color ledgerstate.Color) (asset *wallet.Asset, err error) {
+	return nil, fmt.Errorf("no metadata of asset %s available", color.Base58())
+}
+
 func (connector *mockConnector) GetTransactionGoF(txID ledgerstate.TransactionID) (gradeOfFinality gof.GradeOfFinality, err error) {
 	return
 }
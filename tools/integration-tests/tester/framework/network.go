@@ -138,7 +138,7 @@ func (n *Network) WaitForAutopeering(ctx context.Context) error {
 		connections := make(map[string]map[string]struct{}, len(n.peers))
 		// query all nodes and add their neighbors to the connection graph
 		for _, peer := range n.peers {
-			resp, err := peer.GetAutopeeringNeighbors(false)
+			resp, err := peer.GetAutopeeringNeighbors(ctx, false)
 			if err != nil {
 				return false, errors.Wrap(err, "client failed to return autopeering connections")
 			}
@@ -160,7 +160,7 @@ func (n *Network) WaitForAutopeering(ctx context.Context) error {
 func (n *Network) WaitForPeerDiscovery(ctx context.Context) error {
 	condition := func() (bool, error) {
 		for _, peer := range n.peers {
-			resp, err := peer.GetAutopeeringNeighbors(true)
+			resp, err := peer.GetAutopeeringNeighbors(ctx, true)
 			if err != nil {
 				return false, errors.Wrap(err, "client failed to return known peers")
 			}
@@ -375,7 +375,7 @@ func (n *Network) addManualPeers(nodes []*Node) error {
 			peers = append(peers, p)
 		}
 
-		if err := nodes[i].AddManualPeers(peers); err != nil {
+		if err := nodes[i].AddManualPeers(context.Background(), peers); err != nil {
 			return errors.Wrap(err, "failed to add manual nodes via API")
 		}
 	}
@@ -386,7 +386,7 @@ func (n *Network) addManualPeers(nodes []*Node) error {
 func (n *Network) waitForManualPeering(ctx context.Context, nodes []*Node) error {
 	condition := func() (bool, error) {
 		for _, node := range nodes {
-			peers, err := node.GetManualPeers()
+			peers, err := node.GetManualPeers(ctx)
 			if err != nil {
 				return false, errors.Wrap(err, "client failed to return manually connected peers")
 			}
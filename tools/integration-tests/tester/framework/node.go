@@ -95,6 +95,6 @@ func (n *Node) Restart(ctx context.Context) error {
 
 // IsRunning returns true is the node is running.
 func (n *Node) IsRunning() (bool, error) {
-	err := n.HealthCheck()
+	err := n.HealthCheck(context.Background())
 	return err == nil, nil
 }
@@ -29,7 +29,7 @@ func TestAutopeeringNetworkSplit(t *testing.T) {
 	// test that nodes only have neighbors from same partition
 	for _, partition := range n.Partitions() {
 		for _, peer := range partition.Peers() {
-			resp, err := peer.GetAutopeeringNeighbors(false)
+			resp, err := peer.GetAutopeeringNeighbors(ctx, false)
 			require.NoError(t, err)
 
 			// check that all neighbors are indeed in the same partition
@@ -104,9 +104,9 @@ func TestSimpleDoubleSpend(t *testing.T) {
 
 	// conflicting txs should have spawned branches
 	require.Eventually(t, func() bool {
-		res1, err := node1.GetTransactionMetadata(txs1[0].ID().Base58())
+		res1, err := node1.GetTransactionMetadata(ctx, txs1[0].ID().Base58())
 		require.NoError(t, err)
-		res2, err := node2.GetTransactionMetadata(txs2[0].ID().Base58())
+		res2, err := node2.GetTransactionMetadata(ctx, txs2[0].ID().Base58())
 		require.NoError(t, err)
 		return res1.BranchID != ledgerstate.MasterBranchID.String() &&
 			res2.BranchID != ledgerstate.MasterBranchID.String()
@@ -55,56 +55,56 @@ func TestDiagnosticApis(t *testing.T) {
 	fmt.Println("run /diagnostic/messages")
 	api := peers[0].GoShimmerAPI
 	fmt.Println("get api")
-	resp, err := api.GetDiagnosticsMessages()
+	resp, err := api.GetDiagnosticsMessages(ctx)
 	require.NoError(t, err, "error while performing /diagnostic/messages api call")
 	records, err := resp.ReadAll()
 	require.NoError(t, err, "error while reading  /diagnostic/messages csv")
 	require.Equal(t, records[0], messageHeader, "unexpected message header")
 
 	fmt.Println("run tools/diagnostic/messages/firstweakreferences")
-	resp, err = peers[0].GoShimmerAPI.GetDiagnosticsFirstWeakMessageReferences()
+	resp, err = peers[0].GoShimmerAPI.GetDiagnosticsFirstWeakMessageReferences(ctx)
 	require.NoError(t, err, "error while performing tools/diagnostic/messages/firstweakreferences api call")
 	records, err = resp.ReadAll()
 	require.NoError(t, err, "error while reading  /diagnostic/messages/firstweakreferences csv")
 	require.Equal(t, messageHeader, records[0], "unexpected message header")
 
 	fmt.Println("run tools/diagnostic/tips")
-	tips, err := peers[0].GoShimmerAPI.GetDiagnosticsTips()
+	tips, err := peers[0].GoShimmerAPI.GetDiagnosticsTips(ctx)
 	require.NoError(t, err, "error while performing tools/diagnostic/tips api call")
 	records, err = tips.ReadAll()
 	require.NoError(t, err, "error while reading tools/diagnostic/tips api csv")
 	require.Equal(t, tipsHeader, records[0], "unexpected tips header")
 
 	fmt.Println("run tools/diagnostic/branches")
-	branches, err := peers[0].GoShimmerAPI.GetDiagnosticsBranches()
+	branches, err := peers[0].GoShimmerAPI.GetDiagnosticsBranches(ctx)
 	require.NoError(t, err, "error while running tools/diagnostic/branches")
 	records, err = branches.ReadAll()
 	require.NoError(t, err, "error while reading tools/diagnostic/branches csv")
 	require.Equal(t, branchesHeader, records[0], "unexpected branches header")
 
 	fmt.Println("run tools/diagnostic/branches/lazybooked")
-	lazyBookedBranches, err := peers[0].GoShimmerAPI.GetDiagnosticsLazyBookedBranches()
+	lazyBookedBranches, err := peers[0].GoShimmerAPI.GetDiagnosticsLazyBookedBranches(ctx)
 	require.NoError(t, err, "error while running tools/diagnostic/branches/lazybooked api call")
 	records, err = lazyBookedBranches.ReadAll()
 	require.NoError(t, err, "error while reading tools/diagnostic/branches/lazybooked csv")
 	require.Equal(t, branchesHeader, records[0], "unexpected tips header")
 
 	fmt.Println("run tools/diagnostic/branches/invalid")
-	invalidBranches, err := peers[0].GoShimmerAPI.GetDiagnosticsInvalidBranches()
+	invalidBranches, err := peers[0].GoShimmerAPI.GetDiagnosticsInvalidBranches(ctx)
 	require.NoError(t, err, "error while running tools/diagnostic/branches/invalid api call")
 	records, err = invalidBranches.ReadAll()
 	require.NoError(t, err, "error while reading tools/diagnostic/branches/invalid csv")
 	require.Equal(t, branchesHeader, records[0], "unexpected tips header")
 
 	fmt.Println("run tools/diagnostic/utxodag")
-	dag, err := peers[0].GoShimmerAPI.GetDiagnosticsUtxoDag()
+	dag, err := peers[0].GoShimmerAPI.GetDiagnosticsUtxoDag(ctx)
 	require.NoError(t, err, "error while running tools/diagnostic/utxodag api call")
 	records, err = dag.ReadAll()
 	require.NoError(t, err, "error while reading tools/diagnostic/utxodag csv")
 	require.Equal(t, utxoDagHeader, records[0], "unexpected utxoDagHeader header")
 
 	fmt.Println("run tools/diagnostic/drng")
-	drng, err := peers[0].GoShimmerAPI.GetDiagnosticsDRNG()
+	drng, err := peers[0].GoShimmerAPI.GetDiagnosticsDRNG(ctx)
 	require.NoError(t, err, "error while running tools/diagnostic/drng api call")
 	records, err = drng.ReadAll()
 	require.NoError(t, err, "error while reading tools/diagnostic/drng csv")
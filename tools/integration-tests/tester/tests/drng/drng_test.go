@@ -50,7 +50,7 @@ func TestDRNG(t *testing.T) {
 }
 
 func getRandomness(t *testing.T, node *framework.Node) jsonmodels.Randomness {
-	resp, err := node.GetRandomness()
+	resp, err := node.GetRandomness(context.Background())
 	require.NoError(t, err)
 
 	id := uint32(node.Config().DRNG.Custom.InstanceID)
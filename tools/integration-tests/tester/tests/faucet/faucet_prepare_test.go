@@ -79,7 +79,7 @@ func TestFaucetPrepare(t *testing.T) {
 	// check that more funds preparation has been triggered
 	// wait for the faucet to finish preparing new outputs
 	require.Eventually(t, func() bool {
-		resp, err := faucet.PostAddressUnspentOutputs([]string{faucet.Address(lastFundingOutputAddr + splittingMultiplier*supplyOutputsCount - 1).Base58()})
+		resp, err := faucet.PostAddressUnspentOutputs(ctx, []string{faucet.Address(lastFundingOutputAddr + splittingMultiplier*supplyOutputsCount - 1).Base58()})
 		require.NoError(t, err)
 		return len(resp.UnspentOutputs[0].Outputs) > 0
 	}, tests.Timeout, tests.Tick)
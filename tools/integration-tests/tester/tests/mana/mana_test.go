@@ -177,7 +177,7 @@ func TestManaApis(t *testing.T) {
 	// Test /mana
 	t.Run("mana", func(t *testing.T) {
 		// the faucet should have consensus mana and access mana = 1
-		resp, err := faucet.GetManaFullNodeID(fullID(faucet.ID()))
+		resp, err := faucet.GetManaFullNodeID(ctx, fullID(faucet.ID()))
 		require.NoError(t, err)
 		t.Logf("/mana %+v", resp)
 		require.Equal(t, fullID(faucet.ID()), resp.NodeID)
@@ -185,7 +185,7 @@ func TestManaApis(t *testing.T) {
 
 		// on startup, the faucet pledges consensus mana to the emptyNodeID
 		require.Equal(t, resp.Consensus, minConsensusMana)
-		resp, err = faucet.GetManaFullNodeID(fullID(emptyNodeID))
+		resp, err = faucet.GetManaFullNodeID(ctx, fullID(emptyNodeID))
 		require.NoError(t, err)
 		t.Logf("/mana %+v", resp)
 		require.Equal(t, fullID(emptyNodeID), resp.NodeID)
@@ -195,7 +195,7 @@ func TestManaApis(t *testing.T) {
 
 	// Test /mana/all
 	t.Run("mana/all", func(t *testing.T) {
-		resp, err := faucet.GetAllMana()
+		resp, err := faucet.GetAllMana(ctx)
 		require.NoError(t, err)
 		t.Logf("/mana/all %+v", resp)
 		require.NotEmpty(t, resp.Access)
@@ -206,7 +206,7 @@ func TestManaApis(t *testing.T) {
 
 	// Test /mana/access/nhighest and /mana/consensus/nhighest
 	t.Run("mana/*/nhighest", func(t *testing.T) {
-		aResp, err := faucet.GetNHighestAccessMana(3)
+		aResp, err := faucet.GetNHighestAccessMana(ctx, 3)
 		require.NoError(t, err)
 		t.Logf("/mana/access/nhighest %+v", aResp)
 		require.Len(t, aResp.Nodes, 3)
@@ -217,7 +217,7 @@ func TestManaApis(t *testing.T) {
 		}
 
 		expectedConsensusOrder := []identity.ID{peers[1].ID(), peers[2].ID(), peers[3].ID(), emptyNodeID}
-		cResp, err := faucet.GetNHighestConsensusMana(len(expectedConsensusOrder))
+		cResp, err := faucet.GetNHighestConsensusMana(ctx, len(expectedConsensusOrder))
 		require.NoError(t, err)
 		t.Logf("/mana/consensus/nhighest %+v", cResp)
 		require.Len(t, cResp.Nodes, len(expectedConsensusOrder))
@@ -228,13 +228,13 @@ func TestManaApis(t *testing.T) {
 
 	// Test /mana/percentile
 	t.Run("mana/percentile", func(t *testing.T) {
-		resp, err := faucet.GetManaPercentile(fullID(peers[0].ID()))
+		resp, err := faucet.GetManaPercentile(ctx, fullID(peers[0].ID()))
 		require.NoError(t, err)
 		t.Logf("/mana/percentile %+v", resp)
 		require.Equal(t, fullID(peers[0].ID()), resp.NodeID)
 		require.InDelta(t, 75.0, resp.Access, 0.01)
 
-		resp, err = faucet.GetManaPercentile(fullID(emptyNodeID))
+		resp, err = faucet.GetManaPercentile(ctx, fullID(emptyNodeID))
 		require.NoError(t, err)
 		t.Logf("/mana/percentile %+v", resp)
 		require.Equal(t, fullID(emptyNodeID), resp.NodeID)
@@ -245,7 +245,7 @@ func TestManaApis(t *testing.T) {
 	t.Run("mana/*/online", func(t *testing.T) {
 		// genesis node is not online
 		expectedOnlineAccessOrder := []string{peers[0].ID().String(), peers[1].ID().String(), peers[2].ID().String(), peers[3].ID().String()}
-		aResp, err := faucet.GetOnlineAccessMana()
+		aResp, err := faucet.GetOnlineAccessMana(ctx)
 		require.NoError(t, err)
 		t.Logf("/mana/access/online %+v", aResp)
 		require.Len(t, aResp.Online, len(expectedOnlineAccessOrder))
@@ -256,7 +256,7 @@ func TestManaApis(t *testing.T) {
 		}
 		// empty node is not online
 		expectedOnlineConsensusOrder := []identity.ID{peers[1].ID(), peers[2].ID(), peers[3].ID()}
-		cResp, err := peers[0].GoShimmerAPI.GetOnlineConsensusMana()
+		cResp, err := peers[0].GoShimmerAPI.GetOnlineConsensusMana(ctx)
 		require.NoError(t, err)
 		t.Logf("/mana/consensus/online %+v", cResp)
 		require.Len(t, cResp.Online, len(expectedOnlineConsensusOrder))
@@ -267,10 +267,10 @@ func TestManaApis(t *testing.T) {
 
 	// Test /mana/pending
 	t.Run("mana/pending", func(t *testing.T) {
-		unspentOutputs, err := peers[1].PostAddressUnspentOutputs([]string{peers[1].Address(0).Base58()})
+		unspentOutputs, err := peers[1].PostAddressUnspentOutputs(ctx, []string{peers[1].Address(0).Base58()})
 		require.NoError(t, err)
 		outputID := unspentOutputs.UnspentOutputs[0].Outputs[0].Output.OutputID.Base58
-		resp, err := peers[1].GetPending(outputID)
+		resp, err := peers[1].GetPending(ctx, outputID)
 		require.NoError(t, err)
 		t.Logf("/mana/pending %+v", resp)
 		require.Equal(t, outputID, resp.OutputID)
@@ -279,7 +279,7 @@ func TestManaApis(t *testing.T) {
 
 	// Test /mana/allowedManaPledge
 	t.Run("mana/allowedManaPledge", func(t *testing.T) {
-		resp, err := faucet.GetAllowedManaPledgeNodeIDs()
+		resp, err := faucet.GetAllowedManaPledgeNodeIDs(ctx)
 		require.NoError(t, err)
 		t.Logf("/mana/allowedManaPledge %+v", resp)
 		require.Equal(t, false, resp.Access.IsFilterEnabled)
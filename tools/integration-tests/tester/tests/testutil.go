@@ -141,14 +141,14 @@ func Context(ctx context.Context, t *testing.T) (context.Context, context.Cancel
 
 // Synced returns whether node is synchronized.
 func Synced(t *testing.T, node *framework.Node) bool {
-	info, err := node.Info()
+	info, err := node.Info(context.Background())
 	require.NoError(t, err)
 	return info.TangleTime.Synced
 }
 
 // Mana returns the mana reported by node.
 func Mana(t *testing.T, node *framework.Node) jsonmodels.Mana {
-	info, err := node.Info()
+	info, err := node.Info(context.Background())
 	require.NoError(t, err)
 	return info.Mana
 }
@@ -168,7 +168,7 @@ func AwaitInitialFaucetOutputsPrepared(t *testing.T, faucet *framework.Node, pee
 		// wait for confirmation of each fundingOutput
 		for fundingIndex := FaucetFundingOutputsAddrStart; fundingIndex <= lastFundingOutputAddress; fundingIndex++ {
 			if _, ok := confirmed[fundingIndex]; !ok {
-				resp, err := faucet.PostAddressUnspentOutputs([]string{addrToCheck})
+				resp, err := faucet.PostAddressUnspentOutputs(context.Background(), []string{addrToCheck})
 				require.NoError(t, err)
 				if len(resp.UnspentOutputs[0].Outputs) != 0 {
 					if resp.UnspentOutputs[0].Outputs[0].GradeOfFinality == gof.High {
@@ -185,7 +185,7 @@ func AwaitInitialFaucetOutputsPrepared(t *testing.T, faucet *framework.Node, pee
 
 // AddressUnspentOutputs returns the unspent outputs on address.
 func AddressUnspentOutputs(t *testing.T, node *framework.Node, address ledgerstate.Address) []jsonmodels.WalletOutput {
-	resp, err := node.PostAddressUnspentOutputs([]string{address.Base58()})
+	resp, err := node.PostAddressUnspentOutputs(context.Background(), []string{address.Base58()})
 	require.NoErrorf(t, err, "node=%s, address=%s, PostAddressUnspentOutputs failed", node, address.Base58())
 	require.Lenf(t, resp.UnspentOutputs, 1, "invalid response")
 	require.Equalf(t, address.Base58(), resp.UnspentOutputs[0].Address.Base58, "invalid response")
@@ -216,7 +216,7 @@ func SendFaucetRequest(t *testing.T, node *framework.Node, addr ledgerstate.Addr
 		aManaPledgeID, cManaPledgeID = manaPledgeIDs[0], manaPledgeIDs[1]
 	}
 
-	resp, err := node.SendFaucetRequest(addr.Base58(), faucetPoWDifficulty, aManaPledgeID, cManaPledgeID)
+	resp, err := node.SendFaucetRequest(context.Background(), addr.Base58(), faucetPoWDifficulty, aManaPledgeID, cManaPledgeID)
 	require.NoErrorf(t, err, "node=%s, address=%s, SendFaucetRequest failed", node, addr.Base58())
 
 	sent := DataMessageSent{
@@ -233,7 +233,7 @@ func SendFaucetRequest(t *testing.T, node *framework.Node, addr ledgerstate.Addr
 
 // SendDataMessage sends a data message on a given peer and returns the id and a DataMessageSent struct.
 func SendDataMessage(t *testing.T, node *framework.Node, data []byte, number int) (string, DataMessageSent) {
-	id, err := node.Data(data)
+	id, err := node.Data(context.Background(), data)
 	require.NoErrorf(t, err, "node=%s, 'Data' failed", node)
 
 	sent := DataMessageSent{
@@ -312,7 +312,7 @@ func SendTransaction(t *testing.T, from *framework.Node, to *framework.Node, col
 	}
 
 	// send transaction
-	resp, err := from.PostTransaction(txn.Bytes())
+	resp, err := from.PostTransaction(context.Background(), txn.Bytes())
 	if err != nil {
 		return "", err
 	}
@@ -345,7 +345,7 @@ func RequireMessagesAvailable(t *testing.T, nodes []*framework.Node, messageIDs
 		for _, node := range nodes {
 			nodeMissing := missing[node.ID()]
 			for messageID := range nodeMissing {
-				msg, err := node.GetMessageMetadata(messageID)
+				msg, err := node.GetMessageMetadata(context.Background(), messageID)
 				// retry, when the message could not be found
 				if errors.Is(err, client.ErrNotFound) {
 					log.Printf("node=%s, messageID=%s; message not found", node, messageID)
@@ -380,11 +380,11 @@ func RequireMessagesAvailable(t *testing.T, nodes []*framework.Node, messageIDs
 func RequireMessagesEqual(t *testing.T, nodes []*framework.Node, messagesByID map[string]DataMessageSent) {
 	for _, node := range nodes {
 		for messageID := range messagesByID {
-			resp, err := node.GetMessage(messageID)
+			resp, err := node.GetMessage(context.Background(), messageID)
 			require.NoErrorf(t, err, "node=%s, messageID=%s, 'GetMessage' failed", node, messageID)
 			require.Equal(t, resp.ID, messageID)
 
-			respMetadata, err := node.GetMessageMetadata(messageID)
+			respMetadata, err := node.GetMessageMetadata(context.Background(), messageID)
 			require.NoErrorf(t, err, "node=%s, messageID=%s, 'GetMessageMetadata' failed", node, messageID)
 			require.Equal(t, respMetadata.ID, messageID)
 
@@ -467,7 +467,7 @@ type ExpectedTransaction struct {
 func RequireTransactionsEqual(t *testing.T, nodes []*framework.Node, transactionsByID map[string]*ExpectedTransaction) {
 	for _, node := range nodes {
 		for txID, expTransaction := range transactionsByID {
-			transaction, err := node.GetTransaction(txID)
+			transaction, err := node.GetTransaction(context.Background(), txID)
 			require.NoErrorf(t, err, "node%s, txID=%s, 'GetTransaction' failed", node, txID)
 
 			if expTransaction != nil {
@@ -494,7 +494,7 @@ func RequireGradeOfFinalityEqual(t *testing.T, nodes framework.Nodes, expectedSt
 	condition := func() bool {
 		for _, node := range nodes {
 			for txID, expInclState := range expectedStates {
-				_, err := node.GetTransaction(txID)
+				_, err := node.GetTransaction(context.Background(), txID)
 				// retry, when the transaction could not be found
 				if errors.Is(err, client.ErrNotFound) {
 					continue
@@ -537,7 +537,7 @@ func OutputIndex(transaction *ledgerstate.Transaction, address ledgerstate.Addre
 }
 
 func txMetadataStateEqual(t *testing.T, node *framework.Node, txID string, expInclState ExpectedState) (bool, gof.GradeOfFinality) {
-	metadata, err := node.GetTransactionMetadata(txID)
+	metadata, err := node.GetTransactionMetadata(context.Background(), txID)
 	require.NoErrorf(t, err, "node=%s, txID=%, 'GetTransactionMetadata' failed")
 
 	if (expInclState.GradeOfFinality != nil && *expInclState.GradeOfFinality != metadata.GradeOfFinality) ||
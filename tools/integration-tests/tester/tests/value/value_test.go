@@ -38,7 +38,7 @@ func TestValueTransactionPersistence(t *testing.T) {
 	defer tests.ShutdownNetwork(ctx, t, n)
 
 	for i, p := range n.Peers() {
-		resp, _ := p.Info()
+		resp, _ := p.Info(ctx)
 		t.Logf("node %d mana: %v acc %v\n", i, resp.Mana.Consensus, resp.Mana.Access)
 	}
 	// check consensus mana
@@ -181,12 +181,12 @@ func TestValueAliasPersistence(t *testing.T) {
 
 	// check if all nodes destroyed it
 	for _, peer := range n.Peers() {
-		outputMetadata, err := peer.GetOutputMetadata(aliasOutputID.Base58())
+		outputMetadata, err := peer.GetOutputMetadata(ctx, aliasOutputID.Base58())
 		require.NoError(t, err)
 		// it has been spent
 		require.True(t, outputMetadata.ConsumerCount > 0)
 
-		resp, err := peer.GetAddressUnspentOutputs(aliasID.Base58())
+		resp, err := peer.GetAddressUnspentOutputs(ctx, aliasID.Base58())
 		require.NoError(t, err)
 		// there should be no outputs
 		require.True(t, len(resp.Outputs) == 0)
@@ -241,7 +241,7 @@ func TestValueAliasDelegation(t *testing.T) {
 	delegatedAliasOutputID := ledgerstate.OutputID{}
 	delegatedAliasOutput := &ledgerstate.AliasOutput{}
 	for i, peer := range n.Peers() {
-		resp, err := peer.GetAddressUnspentOutputs(delegationIDs[0].Base58())
+		resp, err := peer.GetAddressUnspentOutputs(ctx, delegationIDs[0].Base58())
 		require.NoError(t, err)
 		// there should be only this output
 		require.True(t, len(resp.Outputs) == 1)
@@ -274,7 +274,7 @@ func TestValueAliasDelegation(t *testing.T) {
 		ledgerstate.NewInputs(ledgerstate.NewUTXOInput(delegatedAliasOutputID)),
 		ledgerstate.NewOutputs(nextOutput))
 	tx := ledgerstate.NewTransaction(essence, dumbWallet.unlockBlocks(essence))
-	_, err = peer.PostTransaction(tx.Bytes())
+	_, err = peer.PostTransaction(ctx, tx.Bytes())
 	require.NoError(t, err)
 
 	tests.RequireGradeOfFinalityEqual(t, n.Peers(), map[string]tests.ExpectedState{
@@ -283,9 +283,9 @@ func TestValueAliasDelegation(t *testing.T) {
 		},
 	}, tests.Timeout, tests.Tick)
 
-	aManaReceiverCurrMana, err := peer.GetManaFullNodeID(base58.Encode(aManaReceiver.Bytes()))
+	aManaReceiverCurrMana, err := peer.GetManaFullNodeID(ctx, base58.Encode(aManaReceiver.Bytes()))
 	require.NoError(t, err)
-	cManaReceiverCurrMana, err := peer.GetManaFullNodeID(base58.Encode(cManaReceiver.Bytes()))
+	cManaReceiverCurrMana, err := peer.GetManaFullNodeID(ctx, base58.Encode(cManaReceiver.Bytes()))
 	require.NoError(t, err)
 
 	// check that the pledge actually worked
@@ -297,7 +297,7 @@ func checkAliasOutputOnAllPeers(t *testing.T, peers []*framework.Node, aliasAddr
 	aliasOutputID := ledgerstate.OutputID{}
 
 	for i, peer := range peers {
-		resp, err := peer.GetAddressUnspentOutputs(aliasAddr.Base58())
+		resp, err := peer.GetAddressUnspentOutputs(context.Background(), aliasAddr.Base58())
 		require.NoError(t, err)
 		// there should be only this output
 		require.True(t, len(resp.Outputs) == 1)
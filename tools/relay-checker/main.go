@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -12,7 +13,7 @@ import (
 )
 
 func testBroadcastData(api *client.GoShimmerAPI) (string, error) {
-	msgID, err := api.Data([]byte(msgData))
+	msgID, err := api.Data(context.Background(), []byte(msgData))
 	if err != nil {
 		return "", fmt.Errorf("broadcast failed: %w", err)
 	}
@@ -21,7 +22,7 @@ func testBroadcastData(api *client.GoShimmerAPI) (string, error) {
 
 func testTargetGetMessages(api *client.GoShimmerAPI, msgID string) error {
 	// query target node for broadcasted data
-	if _, err := api.GetMessage(msgID); err != nil {
+	if _, err := api.GetMessage(context.Background(), msgID); err != nil {
 		return fmt.Errorf("querying the target node failed: %w", err)
 	}
 	return nil
@@ -31,7 +32,7 @@ func testNodesGetMessages(msgID string) error {
 	// query nodes node for broadcasted data
 	for _, n := range nodes {
 		nodesAPI := client.NewGoShimmerAPI(n)
-		if _, err := nodesAPI.GetMessage(msgID); err != nil {
+		if _, err := nodesAPI.GetMessage(context.Background(), msgID); err != nil {
 			return fmt.Errorf("querying node %s failed: %w", n, err)
 		}
 		fmt.Printf("msg found in node %s\n", n)
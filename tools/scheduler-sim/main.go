@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	flag "github.com/spf13/pflag"
+)
+
+const (
+	cfgScenario      = "scenario"
+	cfgDuration      = "duration"
+	cfgRate          = "rate"
+	cfgMaxBufferSize = "max-buffer-size"
+	cfgMaxDeficit    = "max-deficit"
+	cfgMinMana       = "min-mana"
+)
+
+func main() {
+	flag.String(cfgScenario, "", "JSON file describing the scheduler parameters and the nodes (uses a built-in example if empty)")
+	flag.Duration(cfgDuration, 0, "overrides the simulated duration of the scenario")
+	flag.Duration(cfgRate, 0, "overrides the scheduling interval of the scenario")
+	flag.Int(cfgMaxBufferSize, 0, "overrides the maximum scheduler buffer size (in bytes) of the scenario")
+	flag.Float64(cfgMaxDeficit, 0, "overrides the maximum deficit of the scenario")
+	flag.Float64(cfgMinMana, 0, "overrides the minimum mana of the scenario")
+	flag.Parse()
+
+	scenarioPath, _ := flag.CommandLine.GetString(cfgScenario)
+	scenario, err := loadScenario(scenarioPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	applyOverrides(scenario)
+	if err := scenario.validate(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	sim := newSimulation(scenario)
+	if err := sim.run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	printReport(sim)
+}
+
+func applyOverrides(scenario *Scenario) {
+	if duration, _ := flag.CommandLine.GetDuration(cfgDuration); duration > 0 {
+		scenario.Duration = Duration(duration)
+	}
+	if rate, _ := flag.CommandLine.GetDuration(cfgRate); rate > 0 {
+		scenario.Rate = Duration(rate)
+	}
+	if maxBufferSize, _ := flag.CommandLine.GetInt(cfgMaxBufferSize); maxBufferSize > 0 {
+		scenario.MaxBufferSize = maxBufferSize
+	}
+	if maxDeficit, _ := flag.CommandLine.GetFloat64(cfgMaxDeficit); maxDeficit > 0 {
+		scenario.MaxDeficit = maxDeficit
+	}
+	if minMana, _ := flag.CommandLine.GetFloat64(cfgMinMana); minMana > 0 {
+		scenario.MinMana = minMana
+	}
+}
+
+func printReport(sim *simulation) {
+	totalMana, totalScheduled := 0.0, 0
+	for _, node := range sim.nodes {
+		totalMana += node.config.Mana
+		totalScheduled += node.scheduled
+	}
+
+	fmt.Printf("simulated %s with rate %s and max buffer size %d bytes\n\n",
+		time.Duration(sim.scenario.Duration), time.Duration(sim.scenario.Rate), sim.scenario.MaxBufferSize)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "node\tmana share\tissued\tscheduled\tthroughput share\tp50\tp90\tp99\tmax\tdropped\tpending\tblacklisted\t")
+	for _, node := range sim.nodes {
+		sortDurations(node.latencies)
+		fmt.Fprintf(w, "%s\t%.2f%%\t%d\t%d\t%.2f%%\t%s\t%s\t%s\t%s\t%d\t%d\t%d\t\n",
+			node.config.Name,
+			share(node.config.Mana, totalMana),
+			node.issued,
+			node.scheduled,
+			share(float64(node.scheduled), float64(totalScheduled)),
+			percentile(node.latencies, 0.5).Round(time.Millisecond),
+			percentile(node.latencies, 0.9).Round(time.Millisecond),
+			percentile(node.latencies, 0.99).Round(time.Millisecond),
+			percentile(node.latencies, 1).Round(time.Millisecond),
+			node.dropped,
+			node.issued-node.scheduled-node.dropped,
+			node.blacklisted,
+		)
+	}
+	_ = w.Flush()
+}
+
+func share(value, total float64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return 100 * value / total
+}
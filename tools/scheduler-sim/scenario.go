@@ -0,0 +1,158 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// profileConstant issues messages at a constant rate.
+	profileConstant = "constant"
+	// profilePoisson issues messages with exponentially distributed inter-arrival times.
+	profilePoisson = "poisson"
+	// profileBurst issues BurstSize messages at once every BurstInterval.
+	profileBurst = "burst"
+)
+
+// Duration is a time.Duration that is read from a duration string like "5ms" in JSON.
+type Duration time.Duration
+
+// UnmarshalJSON parses a duration string.
+func (d *Duration) UnmarshalJSON(bytes []byte) error {
+	var durationString string
+	if err := json.Unmarshal(bytes, &durationString); err != nil {
+		return errors.Wrap(err, "duration must be a string")
+	}
+	duration, err := time.ParseDuration(durationString)
+	if err != nil {
+		return errors.Wrapf(err, "invalid duration %s", durationString)
+	}
+	*d = Duration(duration)
+	return nil
+}
+
+// Scenario defines the scheduler parameters and the nodes of a simulation.
+type Scenario struct {
+	// Duration is the simulated (virtual) time.
+	Duration Duration `json:"duration"`
+	// Rate is the scheduling interval of the scheduler.
+	Rate Duration `json:"rate"`
+	// MaxBufferSize is the maximum size of the scheduler buffer in bytes.
+	MaxBufferSize int `json:"maxBufferSize"`
+	// MaxDeficit overrides the scheduler's MaxDeficit if set.
+	MaxDeficit float64 `json:"maxDeficit,omitempty"`
+	// MinMana overrides the scheduler's MinMana if set.
+	MinMana float64 `json:"minMana,omitempty"`
+	// Seed initializes the random source of the issuance profiles.
+	Seed int64 `json:"seed"`
+	// Nodes are the simulated nodes.
+	Nodes []*NodeConfig `json:"nodes"`
+}
+
+// NodeConfig defines the access mana and the issuance profile of a simulated node.
+type NodeConfig struct {
+	Name string `json:"name"`
+	// Mana is the access mana of the node.
+	Mana float64 `json:"mana"`
+	// Profile is the issuance profile: constant, poisson or burst.
+	Profile string `json:"profile"`
+	// Rate is the average number of messages per second issued with the constant and poisson profiles.
+	Rate float64 `json:"rate"`
+	// BurstSize is the number of messages issued at once with the burst profile.
+	BurstSize int `json:"burstSize,omitempty"`
+	// BurstInterval is the time between two bursts with the burst profile.
+	BurstInterval Duration `json:"burstInterval,omitempty"`
+	// MessageSize is the size of the issued messages in bytes.
+	MessageSize int `json:"messageSize"`
+	// Start is the time at which the node starts issuing.
+	Start Duration `json:"start,omitempty"`
+	// Stop is the time at which the node stops issuing. If zero, the node issues until the end of the simulation.
+	Stop Duration `json:"stop,omitempty"`
+}
+
+// defaultScenario is used if no scenario file is given: an honest majority, a low mana node and a spammer.
+var defaultScenario = &Scenario{
+	Duration:      Duration(time.Minute),
+	Rate:          Duration(5 * time.Millisecond),
+	MaxBufferSize: 1000000,
+	Seed:          1,
+	Nodes: []*NodeConfig{
+		{Name: "large", Mana: 600000, Profile: profilePoisson, Rate: 80, MessageSize: 500},
+		{Name: "medium", Mana: 300000, Profile: profileConstant, Rate: 40, MessageSize: 500},
+		{Name: "small", Mana: 10000, Profile: profilePoisson, Rate: 5, MessageSize: 500},
+		{Name: "spammer", Mana: 90000, Profile: profileBurst, BurstSize: 500, BurstInterval: Duration(5 * time.Second), MessageSize: 1000},
+	},
+}
+
+func loadScenario(path string) (*Scenario, error) {
+	if path == "" {
+		return defaultScenario, nil
+	}
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read scenario %s", path)
+	}
+	scenario := &Scenario{}
+	if err := json.Unmarshal(bytes, scenario); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse scenario %s", path)
+	}
+	return scenario, scenario.validate()
+}
+
+func (s *Scenario) validate() error {
+	if s.Duration <= 0 || s.Rate <= 0 {
+		return errors.New("duration and rate must be positive")
+	}
+	if len(s.Nodes) == 0 {
+		return errors.New("the scenario does not contain any node")
+	}
+	for _, node := range s.Nodes {
+		if node.MessageSize <= 0 {
+			return errors.Errorf("node %s: messageSize must be positive", node.Name)
+		}
+		switch node.Profile {
+		case profileConstant, profilePoisson:
+			if node.Rate <= 0 {
+				return errors.Errorf("node %s: rate must be positive", node.Name)
+			}
+		case profileBurst:
+			if node.BurstSize <= 0 || node.BurstInterval <= 0 {
+				return errors.Errorf("node %s: burstSize and burstInterval must be positive", node.Name)
+			}
+		default:
+			return errors.Errorf("node %s: unknown profile %s", node.Name, node.Profile)
+		}
+	}
+	return nil
+}
+
+// arrivals returns the virtual times at which the node issues messages, in ascending order.
+func (n *NodeConfig) arrivals(duration time.Duration, random *rand.Rand) (arrivals []time.Duration) {
+	stop := time.Duration(n.Stop)
+	if stop == 0 || stop > duration {
+		stop = duration
+	}
+	for t := time.Duration(n.Start); t < stop; {
+		switch n.Profile {
+		case profileConstant:
+			arrivals = append(arrivals, t)
+			t += time.Duration(float64(time.Second) / n.Rate)
+		case profilePoisson:
+			t += time.Duration(math.Round(random.ExpFloat64() / n.Rate * float64(time.Second)))
+			if t < stop {
+				arrivals = append(arrivals, t)
+			}
+		case profileBurst:
+			for i := 0; i < n.BurstSize; i++ {
+				arrivals = append(arrivals, t)
+			}
+			t += time.Duration(n.BurstInterval)
+		}
+	}
+	return arrivals
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+)
+
+// simNode is a simulated node together with the statistics that are collected for it.
+type simNode struct {
+	config    *NodeConfig
+	publicKey ed25519.PublicKey
+	arrivals  []time.Duration
+	sequence  uint64
+
+	issued      int
+	scheduled   int
+	dropped     int
+	blacklisted int
+	latencies   []time.Duration
+}
+
+// simulation runs a tangle.Scheduler on a virtual clock. Every tick of the scheduler's rate the arrived messages are
+// submitted and the scheduler is stepped once.
+type simulation struct {
+	scenario *Scenario
+	tangle   *tangle.Tangle
+	nodes    []*simNode
+	byID     map[identity.ID]*simNode
+	// origin is the issuing time of the messages at virtual time 0. It lies in the past so that the scheduler never
+	// considers a message to be issued in the future.
+	origin time.Time
+	// issuedAt maps the unscheduled messages to their node and virtual arrival time.
+	issuedAt map[tangle.MessageID]issuedMessage
+}
+
+type issuedMessage struct {
+	node    *simNode
+	arrival time.Duration
+}
+
+func newSimulation(scenario *Scenario) *simulation {
+	random := rand.New(rand.NewSource(scenario.Seed))
+	sim := &simulation{
+		scenario: scenario,
+		byID:     make(map[identity.ID]*simNode),
+		origin:   time.Now().Add(-time.Duration(scenario.Duration) - time.Hour),
+		issuedAt: make(map[tangle.MessageID]issuedMessage),
+	}
+
+	manaMap := make(map[identity.ID]float64)
+	totalMana := 0.0
+	for _, config := range scenario.Nodes {
+		node := &simNode{
+			config:    config,
+			publicKey: ed25519.GenerateKeyPair().PublicKey,
+			arrivals:  config.arrivals(time.Duration(scenario.Duration), random),
+		}
+		nodeID := identity.NewID(node.publicKey)
+		sim.nodes = append(sim.nodes, node)
+		sim.byID[nodeID] = node
+		manaMap[nodeID] = config.Mana
+		totalMana += config.Mana
+	}
+
+	sim.tangle = tangle.New(
+		tangle.Store(mapdb.NewMapDB()),
+		tangle.CacheTimeProvider(database.NewCacheTimeProvider(0)),
+		tangle.SchedulerConfig(tangle.SchedulerParams{
+			MaxBufferSize: scenario.MaxBufferSize,
+			Rate:          time.Duration(scenario.Rate),
+			MaxDeficit:    scenario.MaxDeficit,
+			MinMana:       scenario.MinMana,
+			AccessManaMapRetrieverFunc: func() map[identity.ID]float64 {
+				return manaMap
+			},
+			AccessManaRetrieveFunc: func(nodeID identity.ID) float64 {
+				return manaMap[nodeID]
+			},
+			TotalAccessManaRetrieveFunc: func() float64 {
+				return totalMana
+			},
+		}),
+	)
+	sim.tangle.ConfirmationOracle = &tangle.MockConfirmationOracle{}
+
+	sim.tangle.Scheduler.Events.MessageDiscarded.Attach(events.NewClosure(func(messageID tangle.MessageID) {
+		if issued, ok := sim.issuedAt[messageID]; ok {
+			issued.node.dropped++
+			delete(sim.issuedAt, messageID)
+		}
+	}))
+	sim.tangle.Scheduler.Events.NodeBlacklisted.Attach(events.NewClosure(func(nodeID identity.ID) {
+		if node, ok := sim.byID[nodeID]; ok {
+			node.blacklisted++
+		}
+	}))
+
+	return sim
+}
+
+// run executes the simulation until the virtual duration of the scenario has passed.
+func (s *simulation) run() error {
+	defer s.tangle.Shutdown()
+
+	rate := time.Duration(s.scenario.Rate)
+	for now := time.Duration(0); now < time.Duration(s.scenario.Duration); now += rate {
+		for _, node := range s.nodes {
+			for len(node.arrivals) > 0 && node.arrivals[0] <= now {
+				if err := s.issue(node, node.arrivals[0]); err != nil {
+					return err
+				}
+				node.arrivals = node.arrivals[1:]
+			}
+		}
+
+		msg := s.tangle.Scheduler.Step()
+		if msg == nil {
+			continue
+		}
+		if issued, ok := s.issuedAt[msg.ID()]; ok {
+			issued.node.scheduled++
+			issued.node.latencies = append(issued.node.latencies, now-issued.arrival)
+			delete(s.issuedAt, msg.ID())
+		}
+	}
+	return nil
+}
+
+func (s *simulation) issue(node *simNode, arrival time.Duration) error {
+	msg, err := s.newMessage(node, arrival, node.config.MessageSize)
+	if err != nil {
+		return err
+	}
+	node.issued++
+	s.issuedAt[msg.ID()] = issuedMessage{node: node, arrival: arrival}
+
+	s.tangle.Storage.StoreMessage(msg)
+	if err := s.tangle.Scheduler.SubmitAndReady(msg.ID()); err != nil {
+		return errors.Wrapf(err, "failed to submit message of node %s", node.config.Name)
+	}
+	return nil
+}
+
+// newMessage creates a message of the node with the given size in bytes.
+func (s *simulation) newMessage(node *simNode, arrival time.Duration, size int) (*tangle.Message, error) {
+	create := func(data []byte) (*tangle.Message, error) {
+		return tangle.NewMessage(
+			[]tangle.MessageID{tangle.EmptyMessageID},
+			nil,
+			nil,
+			nil,
+			s.origin.Add(arrival),
+			node.publicKey,
+			node.sequence,
+			payload.NewGenericDataPayload(data),
+			0,
+			ed25519.Signature{},
+		)
+	}
+	node.sequence++
+
+	msg, err := create(nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create message")
+	}
+	if padding := size - msg.Size(); padding > 0 {
+		if msg, err = create(make([]byte, padding)); err != nil {
+			return nil, errors.Wrap(err, "failed to create message")
+		}
+	}
+	return msg, nil
+}
+
+// percentile returns the p-th percentile (0 < p <= 1) of the sorted durations.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	index := int(float64(len(sorted))*p+0.5) - 1
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(sorted) {
+		index = len(sorted) - 1
+	}
+	return sorted[index]
+}
+
+func sortDurations(durations []time.Duration) {
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulation_ManaShare(t *testing.T) {
+	// each node issues one message per tick, so together they issue twice as many messages as the scheduler can
+	// schedule and the throughput is shared according to the mana
+	newScenario := func() *Scenario {
+		return &Scenario{
+			Duration:      Duration(20 * time.Second),
+			Rate:          Duration(5 * time.Millisecond),
+			MaxBufferSize: 1000000,
+			Seed:          1,
+			Nodes: []*NodeConfig{
+				{Name: "large", Mana: 2000, Profile: profileConstant, Rate: 200, MessageSize: 500},
+				{Name: "small", Mana: 1000, Profile: profileConstant, Rate: 200, MessageSize: 500},
+			},
+		}
+	}
+
+	sim := newSimulation(newScenario())
+	require.NoError(t, sim.run())
+	large, small := sim.nodes[0], sim.nodes[1]
+
+	// the scheduler schedules one message per tick
+	assert.Equal(t, 4000, large.scheduled+small.scheduled)
+	assert.InDelta(t, 2.0/3, float64(large.scheduled)/float64(large.scheduled+small.scheduled), 0.01)
+
+	// the buffer is full, so the messages of the node that exceeds its share the most are dropped
+	assert.Equal(t, 4000, large.issued)
+	assert.Equal(t, 4000, small.issued)
+	assert.Greater(t, small.dropped, large.dropped)
+	for _, node := range sim.nodes {
+		assert.LessOrEqual(t, node.issued-node.scheduled-node.dropped, 1000000/500)
+		assert.Zero(t, node.blacklisted)
+	}
+
+	// the simulation runs on a virtual clock and is deterministic
+	rerun := newSimulation(newScenario())
+	require.NoError(t, rerun.run())
+	for i, node := range rerun.nodes {
+		assert.Equal(t, sim.nodes[i].scheduled, node.scheduled)
+		assert.Equal(t, sim.nodes[i].dropped, node.dropped)
+	}
+}
+
+func TestScenario_Validate(t *testing.T) {
+	assert.NoError(t, defaultScenario.validate())
+
+	invalid := *defaultScenario
+	invalid.Nodes = []*NodeConfig{{Name: "node", Mana: 1, Profile: "unknown", Rate: 1, MessageSize: 100}}
+	assert.Error(t, invalid.validate())
+
+	invalid.Nodes = []*NodeConfig{{Name: "node", Mana: 1, Profile: profileBurst, MessageSize: 100}}
+	assert.Error(t, invalid.validate())
+}
+
+func TestNodeConfig_Arrivals(t *testing.T) {
+	constant := &NodeConfig{Profile: profileConstant, Rate: 10, Start: Duration(time.Second), Stop: Duration(2 * time.Second)}
+	arrivals := constant.arrivals(time.Minute, nil)
+	require.Len(t, arrivals, 10)
+	assert.Equal(t, time.Second, arrivals[0])
+	assert.Equal(t, time.Second+900*time.Millisecond, arrivals[9])
+
+	burst := &NodeConfig{Profile: profileBurst, BurstSize: 3, BurstInterval: Duration(time.Second)}
+	assert.Equal(t, []time.Duration{0, 0, 0, time.Second, time.Second, time.Second}, burst.arrivals(2*time.Second, nil))
+}
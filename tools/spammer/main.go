@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 
 	flag "github.com/spf13/pflag"
@@ -47,7 +48,7 @@ func main() {
 	}
 
 	for _, api := range apis {
-		resp, err := api.ToggleSpammer(enableSpammer, rate, unit, imif)
+		resp, err := api.ToggleSpammer(context.Background(), enableSpammer, rate, unit, imif)
 		if err != nil {
 			fmt.Println(err)
 			continue